@@ -1,13 +1,16 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
 	"github.com/spf13/cobra"
 )
 
@@ -18,15 +21,79 @@ var (
 	addTags        []string
 	addUnread      bool
 	addShared      bool
+	addInteractive bool
+
+	addResolveRedirects bool
+	addNoResolve        bool
+	addMaxRedirects     int
+	addResolveTimeout   time.Duration
+	addAllowSchemes     []string
 )
 
 var addCmd = &cobra.Command{
-	Use:   "add <url>",
+	Use:   "add [url]",
 	Short: "Add a new bookmark",
-	Long:  `Add a new bookmark to your LinkDing instance with optional metadata.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Add a new bookmark to your LinkDing instance with optional metadata.
+
+With --resolve-redirects, the URL is requested first and redirects are
+followed so the final URL is stored instead (useful for shortened links).
+
+Only http and https URLs are accepted; use --allow-scheme to permit others
+such as ftp.
+
+With --interactive the URL (when not given), title, description, tags and
+the unread and shared settings are asked for, one line each, with any flag
+values as defaults; press Enter to keep a default. Prompts are written to
+stderr and answers read from stdin, so they can also be piped in.
+
+Examples:
+  linkdingctl add https://example.com --title "Example" --tags "dev,tools"
+  linkdingctl add https://bit.ly/abc123 --resolve-redirects
+  linkdingctl add ftp://ftp.example.com/pub --allow-scheme ftp
+  linkdingctl add --interactive`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if addInteractive {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		url := args[0]
+		url := ""
+		if len(args) > 0 {
+			url = args[0]
+		}
+		if addInteractive {
+			var err error
+			if url, err = promptAddFields(bufio.NewReader(os.Stdin), url); err != nil {
+				return err
+			}
+		}
+
+		if addResolveRedirects && addNoResolve {
+			return fmt.Errorf("--resolve-redirects and --no-resolve cannot be used together")
+		}
+
+		allowed := urlutil.AllowedSchemes(addAllowSchemes)
+		if err := checkURLScheme(url, allowed); err != nil {
+			return err
+		}
+
+		// Resolve shortened/redirecting URLs before saving
+		if addResolveRedirects {
+			resolved, err := resolveRedirects(url, addMaxRedirects, addResolveTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", url, err)
+			}
+			if resolved != url {
+				statusf(os.Stderr, "Resolved %s -> %s\n", url, resolved)
+			} else {
+				statusf(os.Stderr, "No redirect for %s\n", url)
+			}
+			url = resolved
+			if err := checkURLScheme(url, allowed); err != nil {
+				return err
+			}
+		}
 
 		// Load config
 		cfg, err := loadConfig()
@@ -35,7 +102,7 @@ var addCmd = &cobra.Command{
 		}
 
 		// Create API client
-		client := api.NewClient(cfg.URL, cfg.Token)
+		client := newClient(cfg)
 
 		// Create bookmark
 		create := &models.BookmarkCreate{
@@ -48,17 +115,25 @@ var addCmd = &cobra.Command{
 			Shared:      addShared,
 		}
 
+		if isDryRun() {
+			return reportDryRun(plannedRequest{Method: "POST", Path: "/api/bookmarks/", Body: create})
+		}
+
 		bookmark, err := client.CreateBookmark(create)
 		if err != nil {
 			return err
 		}
 
 		// Output
-		if jsonOutput {
-			return json.NewEncoder(os.Stdout).Encode(bookmark)
+		if structuredOutput() {
+			return outputBookmarkJSON(bookmark)
+		}
+		if isQuiet() {
+			fmt.Println(bookmark.ID)
+			return nil
 		}
 
-		fmt.Printf("✓ Bookmark added: %s\n", bookmark.Title)
+		statusf(os.Stdout, "✓ Bookmark added: %s\n", bookmark.Title)
 		fmt.Printf("  ID: %d\n", bookmark.ID)
 		fmt.Printf("  URL: %s\n", bookmark.URL)
 		if len(bookmark.TagNames) > 0 {
@@ -76,6 +151,133 @@ func init() {
 	addCmd.Flags().StringVarP(&addDescription, "description", "d", "", "Description")
 	addCmd.Flags().StringVarP(&addNotes, "notes", "n", "", "Notes")
 	addCmd.Flags().StringSliceVarP(&addTags, "tags", "T", nil, "Comma-separated tags")
-	addCmd.Flags().BoolVarP(&addUnread, "unread", "u", false, "Mark as unread")
-	addCmd.Flags().BoolVarP(&addShared, "shared", "s", false, "Make publicly shared")
+	_ = addCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	addCmd.Flags().BoolVarP(&addUnread, "unread", "u", false, "Mark as unread (default false)")
+	addCmd.Flags().BoolVarP(&addShared, "shared", "s", false, "Make publicly shared (default false)")
+	addCmd.Flags().BoolVarP(&addInteractive, "interactive", "i", false, "Prompt for the URL and bookmark fields")
+	addCmd.Flags().BoolVar(&addResolveRedirects, "resolve-redirects", false, "Follow redirects and store the final URL")
+	addCmd.Flags().BoolVar(&addNoResolve, "no-resolve", false, "Store the URL as given (default)")
+	addCmd.Flags().IntVar(&addMaxRedirects, "max-redirects", 10, "Maximum redirects to follow with --resolve-redirects")
+	addCmd.Flags().StringSliceVar(&addAllowSchemes, "allow-scheme", nil, "Also accept URLs with these schemes (default: http, https)")
+	addCmd.Flags().DurationVar(&addResolveTimeout, "resolve-timeout", 10*time.Second, "Timeout for resolving redirects")
+}
+
+// promptAddFields asks for the URL, unless one was given, and for the
+// bookmark fields, offering the flag values as defaults. It keeps asking
+// until a URL is entered; at the end of input, unanswered fields keep their
+// defaults.
+func promptAddFields(in *bufio.Reader, url string) (string, error) {
+	for url == "" {
+		answer, err := promptLine(in, "URL: ")
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		if answer != "" {
+			url = answer
+		} else if err == io.EOF {
+			return "", fmt.Errorf("a URL is required")
+		} else {
+			fmt.Fprintln(os.Stderr, "A URL is required.")
+		}
+	}
+
+	var err error
+	ask := func(label, current string) string {
+		if err != nil {
+			return current
+		}
+		prompt := label + ": "
+		if current != "" {
+			prompt = fmt.Sprintf("%s [%s]: ", label, current)
+		}
+		var answer string
+		if answer, err = promptLine(in, prompt); answer == "" {
+			return current
+		}
+		return answer
+	}
+	askBool := func(label string, current bool) bool {
+		choices := "y/N"
+		if current {
+			choices = "Y/n"
+		}
+		switch strings.ToLower(ask(fmt.Sprintf("%s? (%s)", label, choices), "")) {
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		return current
+	}
+
+	addTitle = ask("Title (blank to fetch it)", addTitle)
+	addDescription = ask("Description", addDescription)
+	if tags := ask("Tags (comma-separated)", strings.Join(addTags, ",")); tags != "" {
+		addTags = nil
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				addTags = append(addTags, tag)
+			}
+		}
+	}
+	addUnread = askBool("Mark as unread", addUnread)
+	addShared = askBool("Share publicly", addShared)
+
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return url, nil
+}
+
+// promptLine writes prompt to stderr and reads one trimmed line of input.
+func promptLine(in *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), err
+}
+
+// checkURLScheme rejects URLs whose scheme is not in allowed.
+func checkURLScheme(rawURL string, allowed []string) error {
+	if err := urlutil.CheckScheme(rawURL, allowed); err != nil {
+		return fmt.Errorf("%w; use --allow-scheme to accept it", err)
+	}
+	return nil
+}
+
+// resolveRedirects follows redirects from rawURL and returns the final URL.
+// It tries HEAD first and falls back to GET for servers that reject HEAD.
+func resolveRedirects(rawURL string, maxRedirects int, timeout time.Duration) (string, error) {
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		},
+	}
+
+	var lastErr error
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequest(method, rawURL, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+			lastErr = fmt.Errorf("%s not supported (status %d)", method, resp.StatusCode)
+			continue
+		}
+		return resp.Request.URL.String(), nil
+	}
+
+	return "", lastErr
 }
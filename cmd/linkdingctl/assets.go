@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// assetsCmd groups commands for the files LinkDing keeps with bookmarks
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "Work with bookmark assets (archived snapshots and uploads)",
+	Long: `Commands for the files LinkDing stores with a bookmark: web archive
+snapshots and uploaded files.
+
+Examples:
+  linkdingctl assets download 123 --dir ./out`,
+}
+
+// assetsDownloadCmd represents the assets download command
+var assetsDownloadCmd = &cobra.Command{
+	Use:   "download <bookmark-id>",
+	Short: "Save a bookmark's assets to a directory",
+	Long: `Download every complete asset of a bookmark into --dir, keeping the file
+names LinkDing gives them. Existing files with the same name are
+overwritten. Assets that are still pending or failed on the server are
+skipped.
+
+With --favicon the favicon LinkDing cached for the bookmark is saved too,
+when favicons are enabled in the user profile.
+
+Each asset is reported as it is saved; the command exits non-zero if any
+download fails.
+
+Examples:
+  linkdingctl assets download 123
+  linkdingctl assets download 123 --dir ./out --mkdir
+  linkdingctl assets download 123 --favicon --json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runAssetsDownload,
+}
+
+var (
+	assetsDir     string
+	assetsMkdir   bool
+	assetsFavicon bool
+)
+
+func init() {
+	rootCmd.AddCommand(assetsCmd)
+	assetsCmd.AddCommand(assetsDownloadCmd)
+
+	assetsDownloadCmd.Flags().StringVarP(&assetsDir, "dir", "d", ".", "Directory to save the files in")
+	assetsDownloadCmd.Flags().BoolVar(&assetsMkdir, "mkdir", false, "Create --dir (mode 0700) if it does not exist")
+	assetsDownloadCmd.Flags().BoolVar(&assetsFavicon, "favicon", false, "Also save the bookmark's favicon")
+}
+
+// assetDownload is the outcome for one asset or the favicon.
+type assetDownload struct {
+	AssetID int    `json:"asset_id,omitempty"`
+	Name    string `json:"name"`
+	File    string `json:"file,omitempty"`
+	Status  string `json:"status"` // downloaded, skipped or failed
+	Reason  string `json:"reason,omitempty"`
+}
+
+func runAssetsDownload(cmd *cobra.Command, args []string) error {
+	bookmarkID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid bookmark ID: %s (must be a number)", args[0])
+	}
+	if err := ensureOutputDir(assetsDir, assetsMkdir); err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmark, err := client.GetBookmark(bookmarkID)
+	if err != nil {
+		return err
+	}
+	assets, err := client.GetBookmarkAssets(bookmarkID)
+	if err != nil {
+		return fmt.Errorf("failed to list assets: %w", err)
+	}
+
+	used := make(map[string]bool)
+	var results []assetDownload
+	for _, asset := range assets {
+		result := downloadAsset(client, bookmarkID, asset, used)
+		reportAssetDownload(result)
+		results = append(results, result)
+	}
+	if assetsFavicon {
+		result := downloadFavicon(client, bookmark, used)
+		reportAssetDownload(result)
+		results = append(results, result)
+	}
+
+	downloaded, failed := 0, 0
+	for _, r := range results {
+		switch r.Status {
+		case "downloaded":
+			downloaded++
+		case "failed":
+			failed++
+		}
+	}
+
+	if structuredOutput() {
+		if results == nil {
+			results = []assetDownload{}
+		}
+		if err := writeJSON(map[string]interface{}{
+			"bookmark_id": bookmarkID,
+			"dir":         assetsDir,
+			"assets":      results,
+			"downloaded":  downloaded,
+			"failed":      failed,
+		}); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		fmt.Printf("Bookmark %d has no assets\n", bookmarkID)
+	} else {
+		fmt.Printf("\nDownloaded %d of %d file(s) to %s\n", downloaded, len(results), assetsDir)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("some assets failed to download")
+	}
+	return nil
+}
+
+// downloadAsset saves one asset unless it is not complete on the server.
+func downloadAsset(client *api.Client, bookmarkID int, asset models.BookmarkAsset, used map[string]bool) assetDownload {
+	result := assetDownload{AssetID: asset.ID, Name: asset.DisplayName, Status: "skipped"}
+	if result.Name == "" {
+		result.Name = fmt.Sprintf("asset %d", asset.ID)
+	}
+	if asset.Status != "complete" {
+		result.Reason = fmt.Sprintf("status is %s", asset.Status)
+		return result
+	}
+
+	content, filename, err := client.DownloadBookmarkAsset(bookmarkID, asset.ID)
+	if err != nil {
+		result.Status, result.Reason = "failed", err.Error()
+		return result
+	}
+	if filename == "" {
+		filename = asset.DisplayName
+	}
+	return saveAssetFile(result, assetFileName(filename, fmt.Sprintf("asset-%d", asset.ID), used), content)
+}
+
+// downloadFavicon saves the bookmark's favicon, if favicons are enabled and
+// LinkDing has one for it.
+func downloadFavicon(client *api.Client, bookmark *models.Bookmark, used map[string]bool) assetDownload {
+	result := assetDownload{Name: "favicon", Status: "skipped"}
+
+	profile, err := client.GetUserProfile()
+	if err != nil {
+		result.Status, result.Reason = "failed", err.Error()
+		return result
+	}
+	if !profile.EnableFavicons {
+		result.Reason = "favicons are disabled in the user profile"
+		return result
+	}
+	if bookmark.FaviconURL == "" {
+		result.Reason = "LinkDing has no favicon for this bookmark"
+		return result
+	}
+
+	content, err := client.DownloadFavicon(bookmark.FaviconURL)
+	if err != nil {
+		result.Status, result.Reason = "failed", err.Error()
+		return result
+	}
+	name := ""
+	if parsed, err := url.Parse(bookmark.FaviconURL); err == nil {
+		name = path.Base(parsed.Path)
+	}
+	return saveAssetFile(result, assetFileName(name, "favicon", used), content)
+}
+
+// saveAssetFile writes content to file in --dir and records the outcome.
+func saveAssetFile(result assetDownload, file string, content []byte) assetDownload {
+	if err := os.WriteFile(filepath.Join(assetsDir, file), content, 0600); err != nil {
+		result.Status, result.Reason = "failed", err.Error()
+		return result
+	}
+	result.Status, result.File = "downloaded", file
+	return result
+}
+
+// assetFileName makes name safe to use inside --dir, falling back to
+// fallback, and prefixes a counter when an earlier file already took it.
+func assetFileName(name, fallback string, used map[string]bool) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), `\`, "/"))
+	if name == "." || name == "/" || name == ".." || name == "" {
+		name = fallback
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%d-%s", i, name)
+	}
+	used[unique] = true
+	return unique
+}
+
+// reportAssetDownload prints one line per asset to stderr.
+func reportAssetDownload(r assetDownload) {
+	if structuredOutput() {
+		return
+	}
+	switch r.Status {
+	case "downloaded":
+		statusf(os.Stderr, "  ✓ %s → %s\n", r.Name, r.File)
+	case "skipped":
+		statusf(os.Stderr, "  ⊘ %s skipped: %s\n", r.Name, r.Reason)
+	default:
+		failf(os.Stderr, "  ✗ %s: %s\n", r.Name, r.Reason)
+	}
+}
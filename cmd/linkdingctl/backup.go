@@ -1,10 +1,13 @@
 package main
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
@@ -21,19 +24,46 @@ var backupCmd = &cobra.Command{
 The backup file is saved with a timestamp in the filename:
   linkding-backup-2026-01-22T103000.json
 
+The output directory must exist unless --mkdir is given, which creates it
+with owner-only (0700) permissions.
+
+With --gzip the backup is compressed and ".gz" is appended to its name.
+'linkdingctl restore' reads compressed backups directly.
+
+With --since, only bookmarks modified after the given RFC 3339 time are
+saved, and the file is marked as incremental: restoring it leaves bookmarks
+it does not contain alone. --since-file keeps that time in a file instead:
+the first run makes a full backup, and each complete run records its start
+time there for the next one.
+
+With --include-bundles, bundles are saved alongside the bookmarks and
+'linkdingctl restore' recreates them.
+
 This is equivalent to running:
   linkdingctl export -f json -o <timestamped-file>
 
 Examples:
   linkdingctl backup
   linkdingctl backup -o ~/backups/
-  linkdingctl backup --prefix my-backup`,
+  linkdingctl backup -o ~/backups/linkding --mkdir
+  linkdingctl backup --prefix my-backup
+  linkdingctl backup --include-bundles
+  linkdingctl backup --gzip
+  linkdingctl backup --since 2026-01-22T00:00:00Z
+  linkdingctl backup --since-file ~/backups/.linkding-last-backup
+  linkdingctl backup --best-effort`,
 	RunE: runBackup,
 }
 
 var (
-	backupOutput string
-	backupPrefix string
+	backupOutput     string
+	backupPrefix     string
+	backupBestEffort bool
+	backupMkdir      bool
+	backupBundles    bool
+	backupGzip       bool
+	backupSince      string
+	backupSinceFile  string
 )
 
 func init() {
@@ -41,26 +71,46 @@ func init() {
 
 	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", ".", "Output directory (default: current directory)")
 	backupCmd.Flags().StringVar(&backupPrefix, "prefix", "linkding-backup", "Filename prefix")
+	backupCmd.Flags().BoolVar(&backupBestEffort, "best-effort", false, "Keep a partial backup if a page fails to load")
+	backupCmd.Flags().BoolVar(&backupMkdir, "mkdir", false, "Create the output directory (mode 0700) if it does not exist")
+	backupCmd.Flags().BoolVar(&backupBundles, "include-bundles", false, "Also back up bundles, so restore can recreate them")
+	backupCmd.Flags().BoolVar(&backupGzip, "gzip", false, "Compress the backup with gzip (adds .gz to the file name)")
+	backupCmd.Flags().StringVar(&backupSince, "since", "", "Only back up bookmarks modified after this RFC 3339 time (incremental backup)")
+	backupCmd.Flags().StringVar(&backupSinceFile, "since-file", "", "Read --since from this file and record this run's start time in it")
+	backupCmd.MarkFlagsMutuallyExclusive("since", "since-file")
 }
 
 func runBackup(cmd *cobra.Command, args []string) error {
+	// Taken before fetching, so changes made during the backup are in the next one
+	started := time.Now().UTC()
+
+	since, err := backupSinceTime()
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
-	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	// Create API client, counting the bookmarks as they are fetched
+	progress := newProgress("Fetched")
+	clientOpts := clientOptions()
+	clientOpts.Progress = progress.update
+	client := api.NewClientWithOptions(cfg.URL, cfg.Token, clientOpts)
 
 	// Generate timestamped filename
 	timestamp := time.Now().Format("2006-01-02T150405")
 	filename := fmt.Sprintf("%s-%s.json", backupPrefix, timestamp)
+	if backupGzip {
+		filename += ".gz"
+	}
 	fullPath := filepath.Join(backupOutput, filename)
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(backupOutput, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	if err := ensureOutputDir(backupOutput, backupMkdir); err != nil {
+		return err
 	}
 
 	// Create output file
@@ -74,23 +124,105 @@ func runBackup(cmd *cobra.Command, args []string) error {
 	options := export.ExportOptions{
 		Tags:            []string{},
 		IncludeArchived: true,
+		BestEffort:      backupBestEffort,
+		IncludeBundles:  backupBundles,
+		ModifiedSince:   since,
+	}
+	var count int
+	if !since.IsZero() {
+		options.Count = &count
+	}
+
+	var gz *gzip.Writer
+	var writer io.Writer = file
+	if backupGzip {
+		gz = gzip.NewWriter(file)
+		writer = gz
 	}
 
-	if err := export.ExportJSON(client, file, options); err != nil {
+	exportErr := export.ExportJSON(client, writer, options)
+	progress.clear()
+	if exportErr == nil || isPartialFetch(exportErr) {
+		// Flush the compressed stream and the file so write errors are caught
+		if gz != nil {
+			if err := gz.Close(); err != nil {
+				_ = os.Remove(fullPath)
+				return fmt.Errorf("failed to write backup file: %w", err)
+			}
+		}
+		if err := file.Close(); err != nil {
+			_ = os.Remove(fullPath)
+			return fmt.Errorf("failed to write backup file: %w", err)
+		}
+	}
+	if exportErr != nil && !isPartialFetch(exportErr) {
 		// Remove partial file on error
 		_ = os.Remove(fullPath)
-		return fmt.Errorf("failed to export bookmarks: %w", err)
+		return fmt.Errorf("failed to export bookmarks: %w", exportErr)
+	}
+
+	// Only a complete backup moves the recorded time forward
+	if backupSinceFile != "" && exportErr == nil {
+		if err := os.WriteFile(backupSinceFile, []byte(started.Format(time.RFC3339)+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to write --since-file: %w", err)
+		}
 	}
 
 	// Success message
 	if !jsonOutput {
-		fmt.Fprintf(os.Stderr, "Backup created: %s\n", fullPath)
+		if !since.IsZero() {
+			statusf(os.Stderr, "Included %d bookmarks modified since %s\n", count, since.Format(time.RFC3339))
+		}
+		if exportErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", exportErr)
+			statusf(os.Stderr, "Partial backup created: %s\n", fullPath)
+		} else {
+			statusf(os.Stderr, "Backup created: %s\n", fullPath)
+		}
 	} else {
 		// JSON output with proper escaping
-		if err := json.NewEncoder(os.Stdout).Encode(map[string]string{"file": fullPath}); err != nil {
+		output := map[string]interface{}{"file": fullPath}
+		if !since.IsZero() {
+			output["incremental_since"] = since.UTC()
+			output["bookmarks"] = count
+		}
+		if exportErr != nil {
+			output["incomplete"] = true
+			output["error"] = exportErr.Error()
+		}
+		if err := json.NewEncoder(os.Stdout).Encode(output); err != nil {
 			return fmt.Errorf("failed to encode JSON output: %w", err)
 		}
 	}
 
+	if exportErr != nil {
+		return fmt.Errorf("backup incomplete: not all bookmarks could be fetched")
+	}
+
 	return nil
 }
+
+// backupSinceTime returns the time an incremental backup starts from, from
+// --since or --since-file. The zero time means a full backup.
+func backupSinceTime() (time.Time, error) {
+	flag, value := "--since", backupSince
+	if backupSinceFile != "" {
+		content, err := os.ReadFile(backupSinceFile)
+		if os.IsNotExist(err) {
+			// First run: make a full backup
+			return time.Time{}, nil
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read --since-file: %w", err)
+		}
+		flag, value = "time in "+backupSinceFile, strings.TrimSpace(string(content))
+	} else if value == "" {
+		return time.Time{}, nil
+	}
+
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: use an RFC 3339 timestamp such as 2026-01-22T10:30:00Z", flag, value)
+	}
+	return since, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/batch"
+)
+
+// defaultBatchPause is the wait between batches when --batch-size is set.
+const defaultBatchPause = 500 * time.Millisecond
+
+// newPacer builds the pacer for --batch-size and --batch-pause, or returns
+// nil when batching is off. The start of each batch is reported on stderr;
+// with resumable set, the message names the --resume-from value that
+// continues from that batch.
+func newPacer(size int, pause time.Duration, resumable bool) (*batch.Pacer, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("--batch-size must be zero or greater")
+	}
+	if pause < 0 {
+		return nil, fmt.Errorf("--batch-pause must be zero or greater")
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	return &batch.Pacer{
+		Size:  size,
+		Pause: pause,
+		OnBatch: func(index int) {
+			if jsonOutput {
+				return
+			}
+			if resumable {
+				statusf(os.Stderr, "Batch starting at entry %d (--resume-from %d continues from here)\n", index, index)
+			} else {
+				statusf(os.Stderr, "Batch starting at item %d\n", index+1)
+			}
+		},
+	}, nil
+}
@@ -0,0 +1,1155 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
+	"github.com/spf13/cobra"
+)
+
+// bookmarksCmd groups commands that work across the whole bookmark library
+var bookmarksCmd = &cobra.Command{
+	Use:   "bookmarks",
+	Short: "Maintain the bookmark library",
+	Long: `Commands that work across all bookmarks.
+
+Examples:
+  linkdingctl bookmarks dedupe
+  linkdingctl bookmarks check --only-broken
+  linkdingctl bookmarks open 123
+  linkdingctl bookmarks notes 123 --edit
+  linkdingctl bookmarks archive-all --tags old
+  linkdingctl bookmarks move-tags --from-prefix project- --to-prefix proj/`,
+}
+
+// bookmarksDedupeCmd represents the bookmarks dedupe command
+var bookmarksDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find and remove bookmarks with duplicate URLs",
+	Long: `Find bookmarks, including archived ones, that share a URL.
+
+URLs are compared after lowercasing the scheme and host, dropping default
+ports (80 for http, 443 for https) and stripping trailing slashes. In each
+group the oldest bookmark is kept and the others are duplicates.
+
+By default the groups are only listed. With --delete the duplicates are
+deleted after confirmation (skipped with --force or --json); --merge-tags
+first adds their tags to the bookmark that is kept. --dry-run shows the
+requests that --delete would send.
+
+Examples:
+  linkdingctl bookmarks dedupe
+  linkdingctl bookmarks dedupe --delete --merge-tags --dry-run
+  linkdingctl bookmarks dedupe --delete --force`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksDedupe,
+}
+
+// bookmarksCheckCmd represents the bookmarks check command
+var bookmarksCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check bookmarks for dead links",
+	Long: `Request every bookmarked URL, including archived ones, and report its
+HTTP status and where redirects lead.
+
+Each URL is requested with HEAD, falling back to GET when the server rejects
+HEAD or does not answer with a success. A link is broken when it ends in a
+non-2xx status or cannot be reached.
+
+The checks are plain requests to each site: the LinkDing token is never sent.
+--timeout applies to each check; LinkDing API requests keep the default.
+
+Examples:
+  linkdingctl bookmarks check
+  linkdingctl bookmarks check --only-broken --concurrency 16
+  linkdingctl bookmarks check --timeout 5s --json`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksCheck,
+}
+
+// bookmarksOpenCmd represents the bookmarks open command
+var bookmarksOpenCmd = &cobra.Command{
+	Use:   "open [id]",
+	Short: "Open bookmarks in the default browser",
+	Long: `Open a bookmark by ID, or the first matches of --tags and --query, in the
+system's default browser.
+
+Opening more than 5 bookmarks at once asks for confirmation unless --force
+is given. --print prints the URLs instead, for machines without a browser.
+
+Examples:
+  linkdingctl bookmarks open 123
+  linkdingctl bookmarks open --tags reading --limit 5
+  linkdingctl bookmarks open -q kubernetes --unread --print`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runBookmarksOpen,
+}
+
+// bookmarksArchiveAllCmd represents the bookmarks archive-all command
+var bookmarksArchiveAllCmd = &cobra.Command{
+	Use:   "archive-all",
+	Short: "Archive every bookmark with the given tags",
+	Long: `Archive every unarchived bookmark that has all of the given tags.
+
+The matching bookmarks are counted and the archive asks for confirmation
+(skipped with --force or --json). --dry-run shows the requests instead.
+Failures are reported per bookmark and the command exits non-zero if any
+bookmark could not be archived.
+
+Examples:
+  linkdingctl bookmarks archive-all --tags old
+  linkdingctl bookmarks archive-all --tags old,legacy --dry-run
+  linkdingctl bookmarks archive-all --tags old --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulkArchive(true)
+	},
+}
+
+// bookmarksUnarchiveAllCmd represents the bookmarks unarchive-all command
+var bookmarksUnarchiveAllCmd = &cobra.Command{
+	Use:   "unarchive-all",
+	Short: "Unarchive every archived bookmark with the given tags",
+	Long: `Unarchive every archived bookmark that has all of the given tags.
+
+Confirmation, --force and --dry-run work as for archive-all.
+
+Examples:
+  linkdingctl bookmarks unarchive-all --tags reference
+  linkdingctl bookmarks unarchive-all --tags reference --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBulkArchive(false)
+	},
+}
+
+// bookmarksCountCmd represents the bookmarks count command
+var bookmarksCountCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Count bookmarks by state, optionally per tag",
+	Long: `Print how many bookmarks there are in total and how many are unread,
+archived, shared and untagged, without listing them. Archived bookmarks are
+included.
+
+--tags and --query are sent to the server, so the counts cover only the
+matching bookmarks. --by-tag adds the number of bookmarks carrying each tag,
+most used first.
+
+Examples:
+  linkdingctl bookmarks count
+  linkdingctl bookmarks count --tags go
+  linkdingctl bookmarks count --query kubernetes --by-tag
+  linkdingctl bookmarks count --json`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksCount,
+}
+
+// bookmarksTagStatsCmd represents the bookmarks tag-stats command
+var bookmarksTagStatsCmd = &cobra.Command{
+	Use:   "tag-stats <tag>",
+	Short: "Show which tags appear alongside a tag",
+	Long: `Count the other tags on the bookmarks carrying <tag>, most frequent first,
+to see how a tag is used and which tags could be merged into it. Archived
+bookmarks are included, and tags match case-insensitively. For the pairs
+that occur most often across all tags, use 'linkdingctl tags cooccurrence'.
+
+Examples:
+  linkdingctl bookmarks tag-stats go
+  linkdingctl bookmarks tag-stats go --top 10
+  linkdingctl bookmarks tag-stats go --json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTagArgs(1),
+	RunE:              runBookmarksTagStats,
+}
+
+// bookmarksMoveTagsCmd represents the bookmarks move-tags command
+var bookmarksMoveTagsCmd = &cobra.Command{
+	Use:   "move-tags",
+	Short: "Rename a tag prefix on every bookmark",
+	Long: `Replace --from-prefix with --to-prefix at the start of every tag, on all
+bookmarks including archived ones, so project-foo becomes proj/foo.
+
+Prefixes match case-insensitively, as LinkDing compares tags, and the rest
+of each tag is kept as it is. When a rewritten tag is already on the
+bookmark, or two tags rewrite to the same one, the bookmark keeps it once.
+
+The tags to rename and the number of bookmarks affected are shown before
+asking for confirmation (skipped with --force or --json). --dry-run shows
+the requests instead. Failures are reported per bookmark and the command
+exits non-zero if any bookmark could not be updated. The old tags stay as
+unused tags; remove them with 'linkdingctl tags delete'.
+
+Examples:
+  linkdingctl bookmarks move-tags --from-prefix project- --to-prefix proj/
+  linkdingctl bookmarks move-tags --from-prefix project- --to-prefix proj/ --dry-run
+  linkdingctl bookmarks move-tags --from-prefix old/ --to-prefix archive/old/ --force`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksMoveTags,
+}
+
+var (
+	dedupeDelete    bool
+	dedupeForce     bool
+	dedupeMergeTags bool
+
+	checkConcurrency int
+	checkTimeout     time.Duration
+	checkOnlyBroken  bool
+
+	openTags   []string
+	openQuery  string
+	openUnread bool
+	openLimit  int
+	openForce  bool
+	openPrint  bool
+
+	bulkArchiveTags  []string
+	bulkArchiveForce bool
+
+	moveTagsFrom  string
+	moveTagsTo    string
+	moveTagsForce bool
+
+	countTags  []string
+	countQuery string
+	countByTag bool
+
+	tagStatsTop int
+)
+
+// openConfirmThreshold is the number of bookmarks open launches without
+// asking first.
+const openConfirmThreshold = 5
+
+// defaultCheckConcurrency is the number of links checked at the same time.
+const defaultCheckConcurrency = 8
+
+// maxCheckConcurrency caps --concurrency for commands that fetch every
+// bookmark's page, so a large library cannot flood the sites or the server.
+const maxCheckConcurrency = 16
+
+func init() {
+	rootCmd.AddCommand(bookmarksCmd)
+	bookmarksCmd.AddCommand(bookmarksDedupeCmd)
+	bookmarksCmd.AddCommand(bookmarksCheckCmd)
+	bookmarksCmd.AddCommand(bookmarksOpenCmd)
+	bookmarksCmd.AddCommand(bookmarksArchiveAllCmd)
+	bookmarksCmd.AddCommand(bookmarksUnarchiveAllCmd)
+	bookmarksCmd.AddCommand(bookmarksMoveTagsCmd)
+	bookmarksCmd.AddCommand(bookmarksCountCmd)
+	bookmarksCmd.AddCommand(bookmarksTagStatsCmd)
+
+	bookmarksDedupeCmd.Flags().BoolVar(&dedupeDelete, "delete", false, "Delete the duplicates, keeping the oldest bookmark of each group")
+	bookmarksDedupeCmd.Flags().BoolVarP(&dedupeForce, "force", "f", false, "Skip confirmation prompt")
+	bookmarksDedupeCmd.Flags().BoolVar(&dedupeMergeTags, "merge-tags", false, "With --delete, add the duplicates' tags to the bookmark that is kept")
+
+	bookmarksCheckCmd.Flags().IntVar(&checkConcurrency, "concurrency", defaultCheckConcurrency, fmt.Sprintf("Number of links checked in parallel (max %d)", maxCheckConcurrency))
+	bookmarksCheckCmd.Flags().DurationVar(&checkTimeout, "timeout", 10*time.Second, "Time limit for each link check")
+	bookmarksCheckCmd.Flags().BoolVar(&checkOnlyBroken, "only-broken", false, "Show only links that failed or did not return 2xx")
+
+	bookmarksOpenCmd.Flags().StringSliceVarP(&openTags, "tags", "T", []string{}, "Open bookmarks with these tags (AND logic)")
+	_ = bookmarksOpenCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	bookmarksOpenCmd.Flags().StringVarP(&openQuery, "query", "q", "", "Open bookmarks matching this search query")
+	bookmarksOpenCmd.Flags().BoolVarP(&openUnread, "unread", "u", false, "Open only unread bookmarks")
+	bookmarksOpenCmd.Flags().IntVarP(&openLimit, "limit", "l", openConfirmThreshold, "Max bookmarks to open with --tags or --query")
+	bookmarksOpenCmd.Flags().BoolVarP(&openForce, "force", "f", false, fmt.Sprintf("Skip confirmation when opening more than %d bookmarks", openConfirmThreshold))
+	bookmarksOpenCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URLs instead of opening them")
+
+	for _, c := range []*cobra.Command{bookmarksArchiveAllCmd, bookmarksUnarchiveAllCmd} {
+		c.Flags().StringSliceVarP(&bulkArchiveTags, "tags", "T", []string{}, "Only bookmarks with these tags (AND logic, required)")
+		_ = c.RegisterFlagCompletionFunc("tags", completeTagNames)
+		c.Flags().BoolVarP(&bulkArchiveForce, "force", "f", false, "Skip confirmation prompt")
+	}
+
+	bookmarksMoveTagsCmd.Flags().StringVar(&moveTagsFrom, "from-prefix", "", "Tag prefix to replace (required)")
+	bookmarksMoveTagsCmd.Flags().StringVar(&moveTagsTo, "to-prefix", "", "Prefix to put in its place (required)")
+	bookmarksMoveTagsCmd.Flags().BoolVarP(&moveTagsForce, "force", "f", false, "Skip confirmation prompt")
+
+	bookmarksCountCmd.Flags().StringSliceVarP(&countTags, "tags", "T", []string{}, "Count only bookmarks with these tags (AND logic)")
+	_ = bookmarksCountCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	bookmarksCountCmd.Flags().StringVarP(&countQuery, "query", "q", "", "Count only bookmarks matching this search query")
+	bookmarksCountCmd.Flags().BoolVar(&countByTag, "by-tag", false, "Also count the bookmarks carrying each tag")
+
+	bookmarksTagStatsCmd.Flags().IntVar(&tagStatsTop, "top", 0, "Show only the N most frequent tags (0 for all)")
+}
+
+// duplicateGroup is a set of bookmarks sharing a normalized URL. Keep is the
+// oldest; Duplicates are the rest, oldest first.
+type duplicateGroup struct {
+	URL        string
+	Keep       models.Bookmark
+	Duplicates []models.Bookmark
+}
+
+// mergedTags returns the kept bookmark's tags followed by any new tags from
+// the duplicates, and whether that adds anything.
+func (g duplicateGroup) mergedTags() ([]string, bool) {
+	tags := append([]string{}, g.Keep.TagNames...)
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		seen[tag] = true
+	}
+	for _, d := range g.Duplicates {
+		for _, tag := range d.TagNames {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags, len(tags) > len(g.Keep.TagNames)
+}
+
+// findDuplicates groups bookmarks by normalized URL and returns the groups
+// with more than one bookmark, sorted by URL.
+func findDuplicates(bookmarks []models.Bookmark) []duplicateGroup {
+	byURL := make(map[string][]models.Bookmark)
+	for _, b := range bookmarks {
+		key := urlutil.Normalize(b.URL)
+		byURL[key] = append(byURL[key], b)
+	}
+
+	groups := []duplicateGroup{}
+	for url, members := range byURL {
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			if !members[i].DateAdded.Equal(members[j].DateAdded) {
+				return members[i].DateAdded.Before(members[j].DateAdded)
+			}
+			return members[i].ID < members[j].ID
+		})
+		groups = append(groups, duplicateGroup{URL: url, Keep: members[0], Duplicates: members[1:]})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].URL < groups[j].URL })
+	return groups
+}
+
+func runBookmarksDedupe(cmd *cobra.Command, args []string) error {
+	if dedupeMergeTags && !dedupeDelete {
+		return fmt.Errorf("--merge-tags requires --delete")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return err
+	}
+	groups := findDuplicates(bookmarks)
+
+	duplicates := 0
+	for _, g := range groups {
+		duplicates += len(g.Duplicates)
+	}
+
+	if !dedupeDelete || duplicates == 0 {
+		if structuredOutput() {
+			return outputDuplicateGroupsJSON(groups, duplicates)
+		}
+		return outputDuplicateGroupsTable(groups, duplicates)
+	}
+
+	if isDryRun() {
+		if !structuredOutput() {
+			if err := outputDuplicateGroupsTable(groups, duplicates); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+		return reportDryRun(dedupeRequests(groups)...)
+	}
+
+	// Ask for confirmation unless --force or structured output
+	if !dedupeForce && !structuredOutput() {
+		if err := outputDuplicateGroupsTable(groups, duplicates); err != nil {
+			return err
+		}
+		fmt.Printf("\nAbout to delete %d duplicate bookmark(s), keeping the oldest of each group.\n", duplicates)
+		fmt.Printf("Are you sure? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Dedupe cancelled")
+			return nil
+		}
+	}
+
+	deleted, merged, failures := deleteDuplicates(client, groups)
+
+	if structuredOutput() {
+		output := map[string]interface{}{
+			"groups":  len(groups),
+			"deleted": deleted,
+			"merged":  merged,
+		}
+		if len(failures) > 0 {
+			output["errors"] = failures
+		}
+		if err := writeJSON(output); err != nil {
+			return err
+		}
+	} else {
+		statusf(os.Stdout, "✓ %d duplicate bookmark(s) deleted\n", deleted)
+		if dedupeMergeTags {
+			statusf(os.Stdout, "✓ Tags merged into %d bookmark(s)\n", merged)
+		}
+		for _, f := range failures {
+			failf(os.Stderr, "✗ %s\n", f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("some duplicates could not be removed")
+	}
+	return nil
+}
+
+// dedupeRequests lists the writes --delete would send: a tag update for each
+// kept bookmark that gains tags under --merge-tags, then the deletions.
+func dedupeRequests(groups []duplicateGroup) []plannedRequest {
+	var requests []plannedRequest
+	for _, g := range groups {
+		if dedupeMergeTags {
+			if tags, changed := g.mergedTags(); changed {
+				requests = append(requests, plannedRequest{
+					Method: "PATCH",
+					Path:   fmt.Sprintf("/api/bookmarks/%d/", g.Keep.ID),
+					Body:   models.BookmarkUpdate{TagNames: &tags},
+				})
+			}
+		}
+		for _, d := range g.Duplicates {
+			requests = append(requests, plannedRequest{Method: "DELETE", Path: fmt.Sprintf("/api/bookmarks/%d/", d.ID)})
+		}
+	}
+	return requests
+}
+
+// deleteDuplicates merges tags when requested and deletes each group's
+// duplicates. A group whose tags could not be merged is left alone so no
+// tags are lost.
+func deleteDuplicates(client *api.Client, groups []duplicateGroup) (deleted, merged int, failures []string) {
+	for _, g := range groups {
+		if dedupeMergeTags {
+			if tags, changed := g.mergedTags(); changed {
+				if _, err := client.UpdateBookmark(g.Keep.ID, &models.BookmarkUpdate{TagNames: &tags}); err != nil {
+					failures = append(failures, fmt.Sprintf("bookmark %d: failed to merge tags, duplicates kept: %v", g.Keep.ID, err))
+					continue
+				}
+				merged++
+			}
+		}
+		for _, d := range g.Duplicates {
+			if err := client.DeleteBookmark(d.ID); err != nil {
+				failures = append(failures, fmt.Sprintf("bookmark %d: %v", d.ID, err))
+				continue
+			}
+			deleted++
+		}
+	}
+	return deleted, merged, failures
+}
+
+func outputDuplicateGroupsTable(groups []duplicateGroup, duplicates int) error {
+	if len(groups) == 0 {
+		fmt.Println("No duplicate bookmarks found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { _ = w.Flush() }()
+
+	header := []string{"URL", "ID", "TITLE", "ADDED", "ACTION"}
+	_, _ = fmt.Fprintln(w, strings.Join(header, "\t"))
+	_, _ = fmt.Fprintln(w, strings.Join(headerUnderline(header), "\t"))
+
+	row := func(url string, b models.Bookmark, action string) {
+		added := displayTime(b.DateAdded).Format("2006-01-02")
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", url, b.ID, truncate(b.Title, 40), added, action)
+	}
+	for _, g := range groups {
+		row(truncate(g.URL, 60), g.Keep, "keep")
+		for _, d := range g.Duplicates {
+			row("", d, "duplicate")
+		}
+	}
+
+	_ = w.Flush()
+	fmt.Printf("\n%d duplicate bookmark(s) in %d group(s)\n", duplicates, len(groups))
+	return nil
+}
+
+func outputDuplicateGroupsJSON(groups []duplicateGroup, duplicates int) error {
+	output := make([]map[string]interface{}, len(groups))
+	for i, g := range groups {
+		ids := make([]int, len(g.Duplicates))
+		for j, d := range g.Duplicates {
+			ids[j] = d.ID
+		}
+		output[i] = map[string]interface{}{
+			"url":        g.URL,
+			"keep":       g.Keep.ID,
+			"duplicates": ids,
+		}
+	}
+	return writeJSON(map[string]interface{}{
+		"groups":     output,
+		"duplicates": duplicates,
+	})
+}
+
+// linkCheck is the outcome of checking one bookmark's URL. Status is zero
+// when the request failed.
+type linkCheck struct {
+	ID       int    `json:"id"`
+	URL      string `json:"url"`
+	Status   int    `json:"status"`
+	FinalURL string `json:"final_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// broken reports whether the link failed or ended in a non-2xx status.
+func (c linkCheck) broken() bool {
+	return c.Error != "" || c.Status < 200 || c.Status > 299
+}
+
+func runBookmarksCheck(cmd *cobra.Command, args []string) error {
+	if checkConcurrency < 1 || checkConcurrency > maxCheckConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", maxCheckConcurrency)
+	}
+	if checkTimeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return err
+	}
+
+	if !structuredOutput() {
+		statusf(os.Stderr, "Checking %d links...\n", len(bookmarks))
+	}
+	checks := checkLinks(bookmarks, &http.Client{Timeout: checkTimeout}, checkConcurrency)
+
+	broken := 0
+	shown := make([]linkCheck, 0, len(checks))
+	for _, c := range checks {
+		if c.broken() {
+			broken++
+		} else if checkOnlyBroken {
+			continue
+		}
+		shown = append(shown, c)
+	}
+
+	if structuredOutput() {
+		return writeJSON(shown)
+	}
+
+	outputLinkChecks(shown)
+	fmt.Printf("\n%d of %d links broken\n", broken, len(checks))
+	return nil
+}
+
+// checkLinks checks each bookmark's URL using a pool of workers and returns
+// the results in bookmark order. The HTTP client carries no LinkDing
+// credentials.
+func checkLinks(bookmarks []models.Bookmark, httpClient *http.Client, concurrency int) []linkCheck {
+	checks := make([]linkCheck, len(bookmarks))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				checks[i] = checkLink(httpClient, bookmarks[i])
+			}
+		}()
+	}
+	for i := range bookmarks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return checks
+}
+
+// checkLink requests a bookmark's URL with HEAD, then with GET if HEAD
+// failed or did not succeed, since many servers handle HEAD badly.
+func checkLink(httpClient *http.Client, b models.Bookmark) linkCheck {
+	check := linkCheck{ID: b.ID, URL: b.URL}
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequest(method, b.URL, nil)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		req.Header.Set("User-Agent", "linkdingctl/"+version)
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			check.Status, check.FinalURL, check.Error = 0, "", err.Error()
+			continue
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		_ = resp.Body.Close()
+
+		check.Status, check.Error = resp.StatusCode, ""
+		check.FinalURL = ""
+		if final := resp.Request.URL.String(); final != b.URL {
+			check.FinalURL = final
+		}
+		if !check.broken() {
+			break
+		}
+	}
+	return check
+}
+
+func outputLinkChecks(checks []linkCheck) {
+	if len(checks) == 0 {
+		fmt.Println("No links to show")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { _ = w.Flush() }()
+
+	header := []string{"ID", "URL", "STATUS", "REDIRECTED TO"}
+	_, _ = fmt.Fprintln(w, strings.Join(header, "\t"))
+	_, _ = fmt.Fprintln(w, strings.Join(headerUnderline(header), "\t"))
+
+	for _, c := range checks {
+		status := fmt.Sprintf("%d", c.Status)
+		if c.Error != "" {
+			status = "error: " + truncate(c.Error, 60)
+		}
+		final := "-"
+		if c.FinalURL != "" {
+			final = truncate(c.FinalURL, 60)
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", c.ID, truncate(c.URL, 60), status, final)
+	}
+}
+
+// launchBrowser opens a URL in the system's default browser, reaping the
+// launcher in the background once it exits. Tests replace it to avoid
+// starting one.
+var launchBrowser = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		// rundll32 avoids cmd.exe interpreting & and ^ in the URL
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+func runBookmarksOpen(cmd *cobra.Command, args []string) error {
+	filtered := openQuery != "" || len(openTags) > 0 || openUnread
+	if len(args) == 1 && filtered {
+		return fmt.Errorf("give a bookmark ID or --tags/--query/--unread, not both")
+	}
+	if len(args) == 0 && !filtered {
+		return fmt.Errorf("give a bookmark ID, or --tags, --query or --unread to open matches")
+	}
+	if openLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	var bookmarks []models.Bookmark
+	if len(args) == 1 {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid bookmark ID: %s (must be a number)", args[0])
+		}
+		bookmark, err := client.GetBookmark(id)
+		if err != nil {
+			return err
+		}
+		bookmarks = []models.Bookmark{*bookmark}
+	} else {
+		var unreadPtr *bool
+		if openUnread {
+			unreadPtr = &openUnread
+		}
+		list, err := client.GetBookmarks(openQuery, openTags, unreadPtr, nil, openLimit, 0)
+		if err != nil {
+			return err
+		}
+		bookmarks = list.Results
+	}
+
+	if len(bookmarks) == 0 {
+		return fmt.Errorf("no bookmarks found")
+	}
+
+	if openPrint {
+		if structuredOutput() {
+			return outputOpenedJSON(bookmarks)
+		}
+		for _, b := range bookmarks {
+			fmt.Println(b.URL)
+		}
+		return nil
+	}
+
+	// Ask before opening a pile of tabs unless --force
+	if len(bookmarks) > openConfirmThreshold && !openForce {
+		if structuredOutput() {
+			return fmt.Errorf("opening more than %d bookmarks with structured output requires --force", openConfirmThreshold)
+		}
+		fmt.Printf("About to open %d bookmarks in the browser.\n", len(bookmarks))
+		fmt.Print("Continue? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	for _, b := range bookmarks {
+		if err := launchBrowser(b.URL); err != nil {
+			return fmt.Errorf("failed to open %s: %w", b.URL, err)
+		}
+	}
+	if structuredOutput() {
+		return outputOpenedJSON(bookmarks)
+	}
+	statusf(os.Stderr, "✓ Opened %d bookmark(s)\n", len(bookmarks))
+	return nil
+}
+
+// outputOpenedJSON lists the bookmarks open launched or printed.
+func outputOpenedJSON(bookmarks []models.Bookmark) error {
+	output := make([]map[string]interface{}, len(bookmarks))
+	for i, b := range bookmarks {
+		output[i] = map[string]interface{}{"id": b.ID, "url": b.URL}
+	}
+	return writeJSON(output)
+}
+
+// runBulkArchive sets the archived state of every bookmark matching
+// --tags, fetching only those not already in that state.
+func runBulkArchive(archive bool) error {
+	if len(bulkArchiveTags) == 0 {
+		return fmt.Errorf("--tags is required")
+	}
+	verb, done := "archive", "archived"
+	if !archive {
+		verb, done = "unarchive", "unarchived"
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	archived := !archive
+	bookmarks, err := client.BookmarkPages("", bulkArchiveTags, nil, &archived).All()
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	// The search also finds the tag names in titles, URLs and descriptions
+	bookmarks = models.TaggedWithAll(bookmarks, bulkArchiveTags)
+
+	if len(bookmarks) == 0 {
+		if structuredOutput() {
+			return writeJSON(map[string]interface{}{done: 0})
+		}
+		fmt.Printf("No bookmarks to %s.\n", verb)
+		return nil
+	}
+
+	if isDryRun() {
+		requests := make([]plannedRequest, len(bookmarks))
+		for i, b := range bookmarks {
+			requests[i] = plannedRequest{
+				Method: "PATCH",
+				Path:   fmt.Sprintf("/api/bookmarks/%d/", b.ID),
+				Body:   &models.BookmarkUpdate{IsArchived: &archive},
+			}
+		}
+		return reportDryRun(requests...)
+	}
+
+	// Ask for confirmation unless --force or structured output
+	if !bulkArchiveForce && !structuredOutput() {
+		fmt.Printf("About to %s %d bookmark(s).\n", verb, len(bookmarks))
+		fmt.Printf("Are you sure? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	updated := 0
+	var failures []string
+	for _, b := range bookmarks {
+		if _, err := client.UpdateBookmark(b.ID, &models.BookmarkUpdate{IsArchived: &archive}); err != nil {
+			failures = append(failures, fmt.Sprintf("bookmark %d: %v", b.ID, err))
+			continue
+		}
+		updated++
+	}
+
+	if structuredOutput() {
+		output := map[string]interface{}{done: updated}
+		if len(failures) > 0 {
+			output["errors"] = failures
+		}
+		if err := writeJSON(output); err != nil {
+			return err
+		}
+	} else {
+		statusf(os.Stdout, "✓ %d bookmark(s) %s\n", updated, done)
+		for _, f := range failures {
+			failf(os.Stderr, "✗ %s\n", f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("some bookmarks failed to %s", verb)
+	}
+	return nil
+}
+
+// tagMove is the new tag list of a bookmark whose tags move-tags rewrites.
+type tagMove struct {
+	bookmark models.Bookmark
+	tags     []string
+}
+
+func runBookmarksMoveTags(cmd *cobra.Command, args []string) error {
+	if moveTagsFrom == "" || moveTagsTo == "" {
+		return fmt.Errorf("--from-prefix and --to-prefix are required")
+	}
+	if strings.EqualFold(moveTagsFrom, moveTagsTo) {
+		return fmt.Errorf("--from-prefix and --to-prefix are the same")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+
+	renames := make(map[string]string)
+	var moves []tagMove
+	for _, b := range bookmarks {
+		if tags, changed := movePrefixedTags(b.TagNames, moveTagsFrom, moveTagsTo, renames); changed {
+			moves = append(moves, tagMove{bookmark: b, tags: tags})
+		}
+	}
+
+	if len(moves) == 0 {
+		if structuredOutput() {
+			return writeJSON(map[string]interface{}{"tags_rewritten": 0, "bookmarks_updated": 0})
+		}
+		fmt.Printf("No tags start with '%s'.\n", moveTagsFrom)
+		return nil
+	}
+
+	if isDryRun() {
+		requests := make([]plannedRequest, len(moves))
+		for i, m := range moves {
+			tags := m.tags
+			requests[i] = plannedRequest{
+				Method: "PATCH",
+				Path:   fmt.Sprintf("/api/bookmarks/%d/", m.bookmark.ID),
+				Body:   &models.BookmarkUpdate{TagNames: &tags},
+			}
+		}
+		return reportDryRun(requests...)
+	}
+
+	// Ask for confirmation unless --force or structured output
+	if !moveTagsForce && !structuredOutput() {
+		oldTags := make([]string, 0, len(renames))
+		for old := range renames {
+			oldTags = append(oldTags, old)
+		}
+		sort.Strings(oldTags)
+		fmt.Printf("About to rename %d tag(s) on %d bookmark(s):\n", len(renames), len(moves))
+		for _, old := range oldTags {
+			fmt.Printf("  %s → %s\n", old, renames[old])
+		}
+		fmt.Printf("Are you sure? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	updated := 0
+	var failures []string
+	for _, m := range moves {
+		tags := m.tags
+		if _, err := client.UpdateBookmark(m.bookmark.ID, &models.BookmarkUpdate{TagNames: &tags}); err != nil {
+			failures = append(failures, fmt.Sprintf("bookmark %d: %v", m.bookmark.ID, err))
+			continue
+		}
+		updated++
+	}
+
+	if structuredOutput() {
+		output := map[string]interface{}{
+			"tags_rewritten":    len(renames),
+			"bookmarks_updated": updated,
+			"renames":           renames,
+		}
+		if len(failures) > 0 {
+			output["errors"] = failures
+		}
+		if err := writeJSON(output); err != nil {
+			return err
+		}
+	} else {
+		statusf(os.Stdout, "✓ %d tag(s) rewritten on %d bookmark(s)\n", len(renames), updated)
+		for _, f := range failures {
+			failf(os.Stderr, "✗ %s\n", f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("some bookmarks failed to update")
+	}
+	return nil
+}
+
+// movePrefixedTags replaces the prefix from with to on every tag that
+// starts with it, ignoring case, and records each rename in renames. A tag
+// that ends up on the bookmark twice, in any case, is kept once, in its
+// first position. It reports whether any tag was rewritten.
+func movePrefixedTags(tags []string, from, to string, renames map[string]string) ([]string, bool) {
+	moved := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	changed := false
+	for _, tag := range tags {
+		if len(tag) >= len(from) && strings.EqualFold(tag[:len(from)], from) {
+			renamed := to + tag[len(from):]
+			renames[tag] = renamed
+			tag, changed = renamed, true
+		}
+		if key := strings.ToLower(tag); !seen[key] {
+			seen[key] = true
+			moved = append(moved, tag)
+		}
+	}
+	return moved, changed
+}
+
+func runBookmarksCount(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchMatchingBookmarks(countQuery, countTags, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+
+	counts := countBookmarks(bookmarks)
+	if countByTag {
+		counts.ByTag = counts.tagsByCount()
+	}
+	if structuredOutput() {
+		return writeJSON(counts)
+	}
+
+	printBookmarkCounts(counts)
+	if countByTag && len(counts.ByTag) > 0 {
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TAG\tBOOKMARKS")
+		for _, tag := range counts.ByTag {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", tag.Name, tag.Count)
+		}
+		_ = w.Flush()
+	}
+	return nil
+}
+
+// tagStats is the co-occurrence of other tags with one tag.
+type tagStats struct {
+	Tag       string                `json:"tag"`
+	Bookmarks int                   `json:"bookmarks"`
+	Tags      []models.TagWithCount `json:"tags"`
+}
+
+func runBookmarksTagStats(cmd *cobra.Command, args []string) error {
+	tag := args[0]
+	if tagStatsTop < 0 {
+		return fmt.Errorf("--top must be zero or greater")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks([]string{tag}, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks with tag '%s': %w", tag, err)
+	}
+
+	stats := countTagStats(bookmarks, tag)
+	if tagStatsTop > 0 && len(stats.Tags) > tagStatsTop {
+		stats.Tags = stats.Tags[:tagStatsTop]
+	}
+
+	if structuredOutput() {
+		return writeJSON(stats)
+	}
+
+	if stats.Bookmarks == 0 {
+		fmt.Printf("No bookmarks tagged '%s'.\n", tag)
+		return nil
+	}
+	fmt.Printf("%d bookmark(s) tagged '%s'\n", stats.Bookmarks, tag)
+	if len(stats.Tags) == 0 {
+		fmt.Println("No other tags appear on them.")
+		return nil
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TAG\tBOOKMARKS")
+	for _, t := range stats.Tags {
+		_, _ = fmt.Fprintf(w, "%s\t%d\n", t.Name, t.Count)
+	}
+	return w.Flush()
+}
+
+// countTagStats counts the other tags on the bookmarks carrying tag, sorted
+// by count descending and then by name. Bookmarks without the tag are
+// ignored, as the server's tag search may match more loosely, and repeated
+// tags on one bookmark count once.
+func countTagStats(bookmarks []models.Bookmark, tag string) tagStats {
+	stats := tagStats{Tag: tag, Tags: []models.TagWithCount{}}
+	perTag := make(map[string]int)
+	for _, b := range bookmarks {
+		if !b.HasAnyTag([]string{tag}) {
+			continue
+		}
+		stats.Bookmarks++
+		seen := make(map[string]bool, len(b.TagNames))
+		for _, other := range b.TagNames {
+			if !seen[other] && !strings.EqualFold(other, tag) {
+				seen[other] = true
+				perTag[other]++
+			}
+		}
+	}
+
+	for name, count := range perTag {
+		stats.Tags = append(stats.Tags, models.TagWithCount{Name: name, Count: count})
+	}
+	sort.Slice(stats.Tags, func(i, j int) bool {
+		if stats.Tags[i].Count != stats.Tags[j].Count {
+			return stats.Tags[i].Count > stats.Tags[j].Count
+		}
+		return stats.Tags[i].Name < stats.Tags[j].Name
+	})
+	return stats
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
+	"github.com/spf13/cobra"
+)
+
+// bulkCmd represents the bulk command
+var bulkCmd = &cobra.Command{
+	Use:   "bulk <file>",
+	Short: "Run bookmark operations from a JSONL file",
+	Long: `Run the add, update and delete operations listed in a JSONL file, one
+JSON object per line, in file order. Use - to read from stdin. Blank lines
+are ignored.
+
+  {"op": "add", "url": "https://example.com", "title": "Example", "tags": ["go"]}
+  {"op": "update", "id": 12, "title": "New title"}
+  {"op": "update", "id": 13, "tags": []}
+  {"op": "delete", "id": 14}
+
+add needs a url; title and tags are optional. update needs an id and at
+least one of url, title or tags; fields that are left out are not changed,
+and "tags" replaces the bookmark's tags. delete needs an id. Every line is
+checked before anything is sent, and nothing runs if any line is invalid.
+Only http and https URLs are accepted; use --allow-scheme to permit others.
+
+A failed operation is reported with its line number and the rest still run,
+unless --stop-on-error is set. The command exits non-zero if any operation
+failed. --dry-run shows the requests instead.
+
+Examples:
+  linkdingctl bulk ops.jsonl
+  linkdingctl bulk ops.jsonl --stop-on-error
+  linkdingctl bulk ops.jsonl --dry-run
+  linkdingctl bulk ops.jsonl --allow-scheme ftp
+  jq -c '...' bookmarks.json | linkdingctl bulk - --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBulk,
+}
+
+var (
+	bulkStopOnError  bool
+	bulkAllowSchemes []string
+)
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+
+	bulkCmd.Flags().BoolVar(&bulkStopOnError, "stop-on-error", false, "Stop at the first failed operation instead of continuing")
+	bulkCmd.Flags().StringSliceVar(&bulkAllowSchemes, "allow-scheme", nil, "Also accept URLs with these schemes (default: http, https)")
+}
+
+// bulkOp is one line of a bulk file.
+type bulkOp struct {
+	Op    string    `json:"op"`
+	ID    int       `json:"id"`
+	URL   string    `json:"url"`
+	Title *string   `json:"title"`
+	Tags  *[]string `json:"tags"`
+
+	line int
+}
+
+// bulkResult is the outcome of one operation.
+type bulkResult struct {
+	Line   int    `json:"line"`
+	Op     string `json:"op"`
+	ID     int    `json:"id,omitempty"`
+	Status string `json:"status"` // ok, failed or skipped
+	Error  string `json:"error,omitempty"`
+}
+
+func runBulk(cmd *cobra.Command, args []string) error {
+	var in io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		in = file
+	}
+	ops, err := parseBulkOps(in, urlutil.AllowedSchemes(bulkAllowSchemes))
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf("%s contains no operations", args[0])
+	}
+
+	if isDryRun() {
+		requests := make([]plannedRequest, 0, len(ops))
+		for _, op := range ops {
+			requests = append(requests, op.planned())
+		}
+		return reportDryRun(requests...)
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	results := make([]bulkResult, 0, len(ops))
+	succeeded, failed := 0, 0
+	for i, op := range ops {
+		result := op.run(client)
+		results = append(results, result)
+		if result.Status == "ok" {
+			succeeded++
+			if !structuredOutput() {
+				statusf(os.Stdout, "✓ line %d: %s bookmark %d\n", result.Line, bulkPastTense[op.Op], result.ID)
+			}
+			continue
+		}
+		failed++
+		if !structuredOutput() {
+			failf(os.Stderr, "✗ line %d: %s\n", result.Line, result.Error)
+		}
+		if bulkStopOnError {
+			for _, rest := range ops[i+1:] {
+				results = append(results, bulkResult{Line: rest.line, Op: rest.Op, ID: rest.ID, Status: "skipped"})
+			}
+			break
+		}
+	}
+	skipped := len(ops) - succeeded - failed
+
+	if structuredOutput() {
+		if err := writeJSON(map[string]interface{}{
+			"succeeded": succeeded,
+			"failed":    failed,
+			"skipped":   skipped,
+			"results":   results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		statusf(os.Stdout, "\nCompleted: %d succeeded, %d failed", succeeded, failed)
+		if skipped > 0 {
+			statusf(os.Stdout, ", %d not run (--stop-on-error)", skipped)
+		}
+		statusf(os.Stdout, "\n")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("some operations failed")
+	}
+	return nil
+}
+
+var bulkPastTense = map[string]string{"add": "added", "update": "updated", "delete": "deleted"}
+
+// parseBulkOps reads and validates every line, reporting all invalid lines
+// at once. URLs must use one of the allowed schemes.
+func parseBulkOps(in io.Reader, allowed []string) ([]bulkOp, error) {
+	var ops []bulkOp
+	var problems []string
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var op bulkOp
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&op); err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: invalid JSON: %v", line, err))
+			continue
+		}
+		op.line = line
+		if err := op.validate(allowed); err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid operations, nothing was run:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return ops, nil
+}
+
+// validate checks that the operation has the fields it needs and that its
+// URL, if any, uses an allowed scheme.
+func (op *bulkOp) validate(allowed []string) error {
+	if op.URL != "" {
+		if err := urlutil.CheckScheme(op.URL, allowed); err != nil {
+			return err
+		}
+	}
+	switch op.Op {
+	case "add":
+		if op.URL == "" {
+			return fmt.Errorf("add needs a url")
+		}
+		if op.ID != 0 {
+			return fmt.Errorf("add does not take an id")
+		}
+	case "update":
+		if op.ID <= 0 {
+			return fmt.Errorf("update needs a positive id")
+		}
+		if op.URL == "" && op.Title == nil && op.Tags == nil {
+			return fmt.Errorf("update needs at least one of url, title or tags")
+		}
+	case "delete":
+		if op.ID <= 0 {
+			return fmt.Errorf("delete needs a positive id")
+		}
+		if op.URL != "" || op.Title != nil || op.Tags != nil {
+			return fmt.Errorf("delete takes only an id")
+		}
+	case "":
+		return fmt.Errorf("missing op (add, update or delete)")
+	default:
+		return fmt.Errorf("unknown op %q (use add, update or delete)", op.Op)
+	}
+	return nil
+}
+
+func (op *bulkOp) create() *models.BookmarkCreate {
+	create := &models.BookmarkCreate{URL: op.URL}
+	if op.Title != nil {
+		create.Title = *op.Title
+	}
+	if op.Tags != nil {
+		create.TagNames = *op.Tags
+	}
+	return create
+}
+
+func (op *bulkOp) update() *models.BookmarkUpdate {
+	update := &models.BookmarkUpdate{Title: op.Title, TagNames: op.Tags}
+	if op.URL != "" {
+		update.URL = &op.URL
+	}
+	return update
+}
+
+// planned is the request the operation sends.
+func (op *bulkOp) planned() plannedRequest {
+	switch op.Op {
+	case "add":
+		return plannedRequest{Method: "POST", Path: "/api/bookmarks/", Body: op.create()}
+	case "update":
+		return plannedRequest{Method: "PATCH", Path: fmt.Sprintf("/api/bookmarks/%d/", op.ID), Body: op.update()}
+	default:
+		return plannedRequest{Method: "DELETE", Path: fmt.Sprintf("/api/bookmarks/%d/", op.ID)}
+	}
+}
+
+// run sends the operation and records its outcome.
+func (op *bulkOp) run(client *api.Client) bulkResult {
+	result := bulkResult{Line: op.line, Op: op.Op, ID: op.ID, Status: "ok"}
+	var err error
+	switch op.Op {
+	case "add":
+		var bookmark *models.Bookmark
+		if bookmark, err = client.CreateBookmark(op.create()); err == nil {
+			result.ID = bookmark.ID
+		}
+	case "update":
+		_, err = client.UpdateBookmark(op.ID, op.update())
+	case "delete":
+		err = client.DeleteBookmark(op.ID)
+	}
+	if err != nil {
+		result.Status, result.Error = "failed", err.Error()
+	}
+	return result
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"unicode"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/models"
@@ -21,6 +22,7 @@ that allow you to quickly filter bookmarks by specific criteria.
 Examples:
   linkdingctl bundles list
   linkdingctl bundles get 1
+  linkdingctl bundles apply 1
   linkdingctl bundles create "Work" --search "work" --any-tags "project,task"`,
 }
 
@@ -31,15 +33,20 @@ var (
 	bundleAllTags      string
 	bundleExcludedTags string
 	bundleOrder        int
+
+	bundleApplyLimit int
+	bundleApplyAll   bool
 )
 
 func init() {
 	rootCmd.AddCommand(bundlesCmd)
 	bundlesCmd.AddCommand(bundlesListCmd)
 	bundlesCmd.AddCommand(bundlesGetCmd)
+	bundlesCmd.AddCommand(bundlesApplyCmd)
 	bundlesCmd.AddCommand(bundlesCreateCmd)
 	bundlesCmd.AddCommand(bundlesUpdateCmd)
 	bundlesCmd.AddCommand(bundlesDeleteCmd)
+	bundlesCmd.AddCommand(bundlesDuplicateCmd)
 
 	// Create command flags
 	bundlesCreateCmd.Flags().StringVar(&bundleSearch, "search", "", "Search query for the bundle")
@@ -55,6 +62,19 @@ func init() {
 	bundlesUpdateCmd.Flags().StringVar(&bundleAllTags, "all-tags", "", "Comma-separated list of tags (all required)")
 	bundlesUpdateCmd.Flags().StringVar(&bundleExcludedTags, "excluded-tags", "", "Comma-separated list of tags to exclude")
 	bundlesUpdateCmd.Flags().IntVar(&bundleOrder, "order", -1, "Display order")
+
+	// Duplicate command flags
+	bundlesDuplicateCmd.Flags().StringVar(&bundleName, "name", "", "Name for the new bundle (required)")
+	bundlesDuplicateCmd.Flags().StringVar(&bundleSearch, "search", "", "Override the search query")
+	bundlesDuplicateCmd.Flags().StringVar(&bundleAnyTags, "any-tags", "", "Override the any-tags list")
+	bundlesDuplicateCmd.Flags().StringVar(&bundleAllTags, "all-tags", "", "Override the all-tags list")
+	bundlesDuplicateCmd.Flags().StringVar(&bundleExcludedTags, "excluded-tags", "", "Override the excluded-tags list")
+	bundlesDuplicateCmd.Flags().IntVar(&bundleOrder, "order", 0, "Override the display order (default: same as source)")
+	_ = bundlesDuplicateCmd.MarkFlagRequired("name")
+
+	// Apply command flags
+	bundlesApplyCmd.Flags().IntVarP(&bundleApplyLimit, "limit", "l", 0, fmt.Sprintf("Max results (default: %d)", defaultResultCap))
+	bundlesApplyCmd.Flags().BoolVar(&bundleApplyAll, "all", false, "Show every matching bookmark")
 }
 
 // bundlesListCmd represents the bundles list command
@@ -77,7 +97,7 @@ func runBundlesList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Fetch all bundles
 	bundles, err := client.FetchAllBundles()
@@ -86,10 +106,8 @@ func runBundlesList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(bundles)
+	if structuredOutput() {
+		return writeJSON(bundles)
 	}
 
 	return outputBundlesTable(bundles)
@@ -153,7 +171,7 @@ func runBundlesGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Get the bundle
 	bundle, err := client.GetBundle(bundleID)
@@ -162,10 +180,8 @@ func runBundlesGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(bundle)
+	if structuredOutput() {
+		return writeJSON(bundle)
 	}
 
 	fmt.Printf("Bundle: %s\n", bundle.Name)
@@ -175,12 +191,142 @@ func runBundlesGet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  All Tags: %s\n", bundle.AllTags)
 	fmt.Printf("  Excluded Tags: %s\n", bundle.ExcludedTags)
 	fmt.Printf("  Order: %d\n", bundle.Order)
-	fmt.Printf("  Date Created: %s\n", bundle.DateCreated.Format("2006-01-02 15:04:05"))
-	fmt.Printf("  Date Modified: %s\n", bundle.DateModified.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Date Created: %s\n", displayTime(bundle.DateCreated).Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Date Modified: %s\n", displayTime(bundle.DateModified).Format("2006-01-02 15:04:05"))
 
 	return nil
 }
 
+// bundlesApplyCmd represents the bundles apply command
+var bundlesApplyCmd = &cobra.Command{
+	Use:   "apply <id>",
+	Short: "List the bookmarks a bundle matches",
+	Long: `List the bookmarks matched by a bundle's search and tag filters.
+
+The filters map to the bookmarks API as follows:
+  search         sent as the search query
+  all-tags       sent with the query, so every tag must be present
+  any-tags       applied locally: the API cannot express "any of", so
+                 bookmarks without at least one of these tags are dropped
+  excluded-tags  applied locally: bookmarks with any of these are dropped
+
+Because some filters are local, bookmarks matching the search and
+all-tags are fetched page by page and filtered here. Without --all, paging
+stops as soon as more than the limit (50 unless --limit is given) have
+matched. The total is then unknown, so the output says that more than the
+limit match, and JSON has "truncated": true instead of a count. Without
+--limit a warning is also printed.
+
+Examples:
+  linkdingctl bundles apply 1
+  linkdingctl bundles apply 1 --all --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundlesApply,
+}
+
+func runBundlesApply(cmd *cobra.Command, args []string) error {
+	// Parse bundle ID
+	var bundleID int
+	if _, err := fmt.Sscanf(args[0], "%d", &bundleID); err != nil {
+		return fmt.Errorf("invalid bundle ID: %s (must be a number)", args[0])
+	}
+	if bundleApplyAll && bundleApplyLimit > 0 {
+		return fmt.Errorf("cannot use --limit with --all")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bundle, err := client.GetBundle(bundleID)
+	if err != nil {
+		return err
+	}
+
+	limit := 0
+	if !bundleApplyAll {
+		limit = bundleApplyLimit
+		if limit <= 0 {
+			limit = defaultResultCap
+		}
+	}
+	matches, err := fetchBundleMatches(client, bundle, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+
+	if limit == 0 || len(matches) <= limit {
+		bookmarkList := &models.BookmarkList{Count: len(matches), Results: matches}
+		if structuredOutput() {
+			return outputJSON(os.Stdout, bookmarkList)
+		}
+		return outputTable(os.Stdout, bookmarkList)
+	}
+
+	// Paging stopped early, so the total is unknown: only that it is above limit
+	shown := matches[:limit]
+	if bundleApplyLimit <= 0 {
+		fmt.Fprintf(os.Stderr, "Warning: more than %d bookmarks match; showing the first %d. Use --all to show everything or --limit to change the cap.\n", limit, limit)
+	}
+	if structuredOutput() {
+		return writeJSON(map[string]interface{}{"results": shown, "truncated": true})
+	}
+	if outputTableRows(os.Stdout, shown) && !listNoHeader {
+		fmt.Printf("\nShowing the first %d of more than %d matching bookmarks\n", limit, limit)
+	}
+	return nil
+}
+
+// fetchBundleMatches pages through the bookmarks matching the bundle's
+// search and all-tags and keeps those that pass its local filters. With a
+// limit above 0 it stops reading pages once more than limit have matched,
+// which is enough to know some will not be shown.
+func fetchBundleMatches(client *api.Client, bundle *models.Bundle, limit int) ([]models.Bookmark, error) {
+	anyTags, excludedTags := bundleTags(bundle.AnyTags), bundleTags(bundle.ExcludedTags)
+	matches := []models.Bookmark{}
+	pages := client.BookmarkPages(bundle.Search, bundleTags(bundle.AllTags), nil, nil)
+	for limit == 0 || len(matches) <= limit {
+		bookmarks, ok, err := pages.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		matches = append(matches, filterBundleMatches(bookmarks, anyTags, excludedTags)...)
+	}
+	return matches, nil
+}
+
+// bundleTags splits a bundle tag field. LinkDing separates tags with spaces;
+// commas, as accepted by --any-tags and friends, work too.
+func bundleTags(field string) []string {
+	return strings.FieldsFunc(field, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// filterBundleMatches applies the bundle filters the API cannot: bookmarks
+// must carry one of anyTags, when given, and none of excludedTags.
+func filterBundleMatches(bookmarks []models.Bookmark, anyTags, excludedTags []string) []models.Bookmark {
+	matches := []models.Bookmark{}
+	for _, b := range bookmarks {
+		if len(anyTags) > 0 && !b.HasAnyTag(anyTags) {
+			continue
+		}
+		if b.HasAnyTag(excludedTags) {
+			continue
+		}
+		matches = append(matches, b)
+	}
+	return matches
+}
+
 // bundlesCreateCmd represents the bundles create command
 var bundlesCreateCmd = &cobra.Command{
 	Use:   "create <name>",
@@ -210,7 +356,7 @@ func runBundlesCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Build bundle create request
 	bundleCreate := &models.BundleCreate{
@@ -222,6 +368,10 @@ func runBundlesCreate(cmd *cobra.Command, args []string) error {
 		Order:        bundleOrder,
 	}
 
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "POST", Path: "/api/bundles/", Body: bundleCreate})
+	}
+
 	// Create the bundle
 	bundle, err := client.CreateBundle(bundleCreate)
 	if err != nil {
@@ -229,13 +379,15 @@ func runBundlesCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(bundle)
+	if structuredOutput() {
+		return writeJSON(bundle)
+	}
+	if isQuiet() {
+		fmt.Println(bundle.ID)
+		return nil
 	}
 
-	fmt.Printf("✓ Bundle created: %s\n", bundle.Name)
+	statusf(os.Stdout, "✓ Bundle created: %s\n", bundle.Name)
 	fmt.Printf("  ID: %d\n", bundle.ID)
 
 	return nil
@@ -270,7 +422,7 @@ func runBundlesUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Build update request with only specified fields (PATCH semantics)
 	update := &models.BundleUpdate{}
@@ -306,6 +458,10 @@ func runBundlesUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no fields to update (use --name, --search, --any-tags, --all-tags, --excluded-tags, or --order)")
 	}
 
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "PATCH", Path: fmt.Sprintf("/api/bundles/%d/", bundleID), Body: update})
+	}
+
 	// Update the bundle
 	bundle, err := client.UpdateBundle(bundleID, update)
 	if err != nil {
@@ -313,13 +469,14 @@ func runBundlesUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(bundle)
+	if structuredOutput() {
+		return writeJSON(bundle)
+	}
+	if isQuiet() {
+		return nil
 	}
 
-	fmt.Printf("✓ Bundle updated: %s\n", bundle.Name)
+	statusf(os.Stdout, "✓ Bundle updated: %s\n", bundle.Name)
 	fmt.Printf("  ID: %d\n", bundle.ID)
 
 	return nil
@@ -351,7 +508,11 @@ func runBundlesDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
+
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "DELETE", Path: fmt.Sprintf("/api/bundles/%d/", bundleID)})
+	}
 
 	// Delete the bundle
 	err = client.DeleteBundle(bundleID)
@@ -359,11 +520,106 @@ func runBundlesDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if jsonOutput {
-		fmt.Printf("{\"deleted\": true, \"id\": %d}\n", bundleID)
-	} else {
-		fmt.Printf("✓ Bundle %d deleted\n", bundleID)
+	if structuredOutput() {
+		return writeJSON(map[string]interface{}{"deleted": true, "id": bundleID})
+	}
+	statusf(os.Stdout, "✓ Bundle %d deleted\n", bundleID)
+
+	return nil
+}
+
+// bundlesDuplicateCmd represents the bundles duplicate command
+var bundlesDuplicateCmd = &cobra.Command{
+	Use:   "duplicate <id>",
+	Short: "Create a copy of a bundle under a new name",
+	Long: `Create a new bundle by copying an existing one. A new name is required;
+any of the create flags can be used to override fields of the copy.
+The display order is preserved unless --order is given.
+
+Examples:
+  linkdingctl bundles duplicate 1 --name "Work (archive)"
+  linkdingctl bundles duplicate 1 --name "Work Go" --all-tags "go"
+  linkdingctl bundles duplicate 1 --name "Copy" --order 10 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundlesDuplicate,
+}
+
+func runBundlesDuplicate(cmd *cobra.Command, args []string) error {
+	// Parse bundle ID
+	var bundleID int
+	if _, err := fmt.Sscanf(args[0], "%d", &bundleID); err != nil {
+		return fmt.Errorf("invalid bundle ID: %s (must be a number)", args[0])
 	}
 
+	if bundleName == "" {
+		return fmt.Errorf("bundle name cannot be empty")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	// Fetch the source bundle
+	source, err := client.GetBundle(bundleID)
+	if err != nil {
+		return err
+	}
+
+	if source.Name == bundleName {
+		return fmt.Errorf("new bundle name must differ from the source bundle name '%s'", source.Name)
+	}
+
+	// Copy the source, applying any overrides
+	bundleCreate := &models.BundleCreate{
+		Name:         bundleName,
+		Search:       source.Search,
+		AnyTags:      source.AnyTags,
+		AllTags:      source.AllTags,
+		ExcludedTags: source.ExcludedTags,
+		Order:        source.Order,
+	}
+	if cmd.Flags().Changed("search") {
+		bundleCreate.Search = bundleSearch
+	}
+	if cmd.Flags().Changed("any-tags") {
+		bundleCreate.AnyTags = bundleAnyTags
+	}
+	if cmd.Flags().Changed("all-tags") {
+		bundleCreate.AllTags = bundleAllTags
+	}
+	if cmd.Flags().Changed("excluded-tags") {
+		bundleCreate.ExcludedTags = bundleExcludedTags
+	}
+	if cmd.Flags().Changed("order") {
+		bundleCreate.Order = bundleOrder
+	}
+
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "POST", Path: "/api/bundles/", Body: bundleCreate})
+	}
+
+	// Create the copy
+	bundle, err := client.CreateBundle(bundleCreate)
+	if err != nil {
+		return err
+	}
+
+	// Output based on format
+	if structuredOutput() {
+		return writeJSON(bundle)
+	}
+	if isQuiet() {
+		fmt.Println(bundle.ID)
+		return nil
+	}
+
+	statusf(os.Stdout, "✓ Bundle duplicated: %s\n", bundle.Name)
+	fmt.Printf("  ID: %d (copied from %d)\n", bundle.ID, source.ID)
+
 	return nil
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rodstewart/linkding-cli/internal/export"
+)
+
+// openCheckpoint opens the checkpoint for importing filename when one was
+// asked for with --checkpoint or --resume, and returns nil otherwise. Its
+// path defaults to one in the user cache directory named after the file.
+// Without resume, a checkpoint left by an earlier run is replaced. Dry runs
+// write nothing, so they get no checkpoint.
+func openCheckpoint(filename, path string, resume, dry bool) (*export.Checkpoint, error) {
+	if dry || (path == "" && !resume) {
+		return nil, nil
+	}
+	if path == "" {
+		var err error
+		if path, err = defaultCheckpointPath(filename); err != nil {
+			return nil, err
+		}
+	}
+
+	cp, err := export.OpenCheckpoint(path, filename, resume)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Len() > 0 && !jsonOutput {
+		statusf(os.Stderr, "Resuming: %d entries already processed (checkpoint %s)\n", cp.Len(), path)
+	}
+	return cp, nil
+}
+
+// defaultCheckpointPath returns the checkpoint path for filename in the
+// user cache directory, keyed by the file's absolute path, and creates its
+// directory.
+func defaultCheckpointPath(filename string) (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find a cache directory for the checkpoint; set --checkpoint: %w", err)
+	}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	dir := filepath.Join(cache, "linkdingctl", "checkpoints")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return filepath.Join(dir, filepath.Base(filename)+"-"+hex.EncodeToString(sum[:8])+".checkpoint"), nil
+}
+
+// finishCheckpoint deletes the checkpoint once every entry has succeeded.
+// Otherwise it is kept, so a rerun with --resume retries only the entries
+// that failed or were never reached.
+func finishCheckpoint(cp *export.Checkpoint, result *export.ImportResult, err error) {
+	if cp == nil {
+		return
+	}
+	if (err == nil && result != nil && result.Failed == 0) || cp.Len() == 0 {
+		_ = cp.Remove()
+		return
+	}
+	_ = cp.Close()
+	if !jsonOutput {
+		fmt.Fprintf(os.Stderr, "\nProgress saved to %s; rerun with --resume to continue\n", cp.Path())
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ANSI colors for the status marks that start human-readable lines.
+const (
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiRed    = "\x1b[31m"
+	ansiReset  = "\x1b[0m"
+)
+
+var markColors = []struct {
+	mark, color string
+}{
+	{"✓", ansiGreen},
+	{"⊘", ansiYellow},
+	{"↻", ansiYellow},
+	{"✗", ansiRed},
+}
+
+// isTerminal reports whether f is a terminal. Tests replace it.
+var isTerminal = func(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// useColor reports whether output written to w may be colored: w must be a
+// terminal, neither --no-color nor NO_COLOR (https://no-color.org) may be
+// set, and the output must not be JSON or YAML.
+func useColor(w io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || structuredOutput() {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f)
+}
+
+// colorMark colors the status mark at the start of text, after any
+// indentation, when output to w may be colored.
+func colorMark(w io.Writer, text string) string {
+	if !useColor(w) {
+		return text
+	}
+	rest := strings.TrimLeft(text, " \n")
+	indent := text[:len(text)-len(rest)]
+	for _, m := range markColors {
+		if strings.HasPrefix(rest, m.mark) {
+			return indent + m.color + m.mark + ansiReset + rest[len(m.mark):]
+		}
+	}
+	return text
+}
+
+// failf prints a failure line to w. Unlike statusf it is shown under
+// --quiet.
+func failf(w io.Writer, format string, a ...interface{}) {
+	fmt.Fprint(w, colorMark(w, fmt.Sprintf(format, a...)))
+}
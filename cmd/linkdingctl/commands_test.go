@@ -2,25 +2,50 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/config"
+	"github.com/rodstewart/linkding-cli/internal/export"
+	"github.com/rodstewart/linkding-cli/internal/migrate"
 	"github.com/rodstewart/linkding-cli/internal/models"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	gokeyring "github.com/zalando/go-keyring"
+	"gopkg.in/yaml.v3"
 )
 
 // executeCommand executes a command with the given arguments and returns the output
 func executeCommand(t *testing.T, args ...string) (string, error) {
 	t.Helper()
+	stdout, stderr, err := executeCommandStreams(t, args...)
+
+	// Combine stdout and stderr
+	return stdout + stderr, err
+}
+
+// executeCommandStreams is executeCommand with stdout and stderr returned
+// separately.
+func executeCommandStreams(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
 
 	// Create pipes to capture stdout and stderr
 	oldStdout := os.Stdout
@@ -66,16 +91,17 @@ func executeCommand(t *testing.T, args ...string) (string, error) {
 	stdout := <-outC
 	stderr := <-errC
 
-	// Combine stdout and stderr
-	output := stdout + stderr
-
 	// Reset args and global flags for next test (but keep commands registered)
 	rootCmd.SetArgs(nil)
 	jsonOutput = false
+	yamlOutput = false
+	outputFmt = formatTable
 	debugMode = false
 	flagURL = ""
 	flagToken = ""
+	tokenFile, flagTokenSource = "", ""
 	forceDelete = false
+	deleteTags, deleteQuery = []string{}, ""
 	updateArchive = false
 	updateUnarchive = false
 	updateTags = nil
@@ -90,13 +116,164 @@ func executeCommand(t *testing.T, args ...string) (string, error) {
 	backupOutput = "."
 	backupPrefix = "linkding-backup"
 	tagsRenameForce = false
+	tagsMergeForce = false
 	tagsDeleteForce = false
+	tagsDeleteKeep = false
 	bundleName = ""
 	bundleSearch = ""
 	bundleAnyTags = ""
 	bundleAllTags = ""
 	bundleExcludedTags = ""
+	bundleApplyLimit, bundleApplyAll = 0, false
 	bundleOrder = 0
+	listShowAdded = false
+	listShowModified = false
+	listAbsoluteDates = false
+	selectExpr = ""
+	exportFormat = "json"
+	exportOutput = ""
+	exportOutputDir = ""
+	exportTags = []string{}
+	exportQuery = ""
+	exportArchived = true
+	exportBestEffort = false
+	exportGroupBy = ""
+	exportFolders = ""
+	tagsShowLimit = 0
+	tagsShowAll = false
+	backupBestEffort = false
+	exportSchema = false
+	importValidateOnly = false
+	importStrict = false
+	importLimit = 0
+	importOffset = 0
+	restoreLimit = 0
+	restoreOffset = 0
+	importStopOnError = false
+	importContinue = false
+	restoreStopOnError = false
+	restoreContinue = false
+	addAllowSchemes = nil
+	importAllowSchemes = nil
+	restoreAllowSchemes = nil
+	importBatchSize = 0
+	importBatchPause = defaultBatchPause
+	importResumeFrom = 0
+	restoreBatchSize = 0
+	restoreBatchPause = defaultBatchPause
+	restoreResumeFrom = 0
+	tagsRenameBatchSize = 0
+	tagsRenameBatchPause = defaultBatchPause
+	importResume = false
+	importCheckpoint = ""
+	restoreResume = false
+	restoreCheckpoint = ""
+	importSummaryOnly = false
+	importConcurrency = 1
+	importCSVDialect = "default"
+	importFoldersAsTags = false
+	importNormalizeURLs = false
+	importRetryFailed = 1
+	restoreSummaryOnly = false
+	importFailOnError = false
+	restoreFailOnError = false
+	restoreUpdate = true
+	getFields = nil
+	getMarkdownLink = false
+	getWithContent, getFullContent = false, false
+	assetsDir, assetsMkdir, assetsFavicon = ".", false, false
+	listMarkdownLink = false
+	listOutput, listMkdir = "", false
+	listFields, listNoHeader, listColumns = nil, false, nil
+	listFilter, listFilterExpr = "", nil
+	listTemplate, listTemplateFile, listTmpl = "", "", nil
+	listAfterID, listBeforeID = 0, 0
+	listSort, listSortCmp = "", nil
+	randomCount, randomTags, randomUnread, randomSeed, randomOpen = 1, []string{}, false, 0, false
+	reparseTags, reparseConcurrency, reparseTimeout = []string{}, defaultCheckConcurrency, 10*time.Second
+	watchInterval, watchExec = 30*time.Second, ""
+	notesEdit = false
+	tagsExportOutput = ""
+	getTemplate, getTemplateFile = "", ""
+	searchQuery, searchTags, searchArchived = "", []string{}, false
+	searchAddedBefore, searchAddedAfter = "", ""
+	searchTitleRegex, searchURLRegex, searchDescriptionContains = "", "", ""
+	dedupeDelete, dedupeForce, dedupeMergeTags = false, false, false
+	checkConcurrency, checkTimeout, checkOnlyBroken = defaultCheckConcurrency, 10*time.Second, false
+	openTags, openQuery, openUnread = []string{}, "", false
+	bulkArchiveTags, bulkArchiveForce = []string{}, false
+	moveTagsFrom, moveTagsTo, moveTagsForce = "", "", false
+	quiet, noColor = false, false
+	countTags, countQuery, countByTag = []string{}, "", false
+	tagStatsTop = 0
+	openLimit, openForce, openPrint = openConfirmThreshold, false, false
+	migrateFromConfig, migrateFromURL, migrateFromToken = "", "", ""
+	migrateToConfig, migrateToURL, migrateToToken = "", "", ""
+	migrateFromProfile, migrateToProfile = "", ""
+	migrateSkipDuplicates, migrateUnusedTags, migrateBundles = false, false, false
+	migrateConcurrency = migrate.DefaultConcurrency
+	tagsCooccurMinCount = 2
+	tagsCooccurTop = 20
+	backupMkdir = false
+	exportMkdir = false
+	backupBundles = false
+	backupGzip = false
+	backupSince, backupSinceFile = "", ""
+	exportBundles = false
+	cfgFile = ""
+	profileName = ""
+	listLimit = 100
+	headers = nil
+	forceHeader = false
+	statsByDay = false
+	statsByMonth = false
+	statsByYear = false
+	profiling = false
+	timezone = ""
+	displayZone = nil
+	listExclude = []string{}
+	listSample = 0
+	listSampleAll = false
+	listSeed = 0
+	exportExclude = []string{}
+	exportAnonymize = false
+	listTags = []string{}
+	listQuery, listOffset = "", 0
+	listUnread, listArchived = false, false
+	addTitle, addDescription, addNotes, addTags = "", "", "", nil
+	addInteractive = false
+	addUnread = false
+	addShared = false
+	addResolveRedirects = false
+	addNoResolve = false
+	addMaxRedirects = 10
+	addResolveTimeout = 10 * time.Second
+	retryCount = 0
+	http2 = true
+	insecure, caCertFile = false, ""
+	proxyURL = ""
+	maxIdleConns = 0
+	idleTimeout = 0
+	rateLimit = 0
+	parallel = 1
+	cacheTTL, noCache = 0, false
+	bulkStopOnError = false
+	bulkAllowSchemes = nil
+	tagsListForInline = false
+	configToKeyring, configToFile = false, false
+	configTestVerbose = false
+	retryOn = "5xx,conn"
+	retryWait = defaultRetryWait
+	timeout = api.DefaultTimeout
+	dryRun = false
+	importDryRun = false
+	restoreDryRun = false
+	syncDryRun, syncPrune, syncForce = false, false, false
+	restoreWipe = false
+	profileTheme, profileBookmarkDateDisplay, profileBookmarkLinkTarget = "", "", ""
+	profileWebArchiveIntegration, profileTagSearch = "", ""
+	profileEnableSharing, profileEnablePublicSharing, profileEnableFavicons = false, false, false
+	profileDisplayURL, profilePermanentNotes = false, false
 
 	// Reset all command flags' "Changed" state
 	rootCmd.Flags().VisitAll(func(f *pflag.Flag) {
@@ -116,7 +293,7 @@ func executeCommand(t *testing.T, args ...string) (string, error) {
 		resetFlags(cmd)
 	}
 
-	return output, cmdErr
+	return stdout, stderr, cmdErr
 }
 
 // setupMockServer creates a mock LinkDing API server for testing
@@ -406,6 +583,225 @@ func TestDeleteCommand(t *testing.T) {
 	})
 }
 
+func TestDeleteDryRun(t *testing.T) {
+	var deletes int
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.URL.Path == "/api/bookmarks/1/" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+			return
+		}
+		http.NotFound(w, r)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "delete", "1", "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Would delete bookmark 1: Example") {
+		t.Errorf("Expected the bookmark to be previewed, got: %s", output)
+	}
+
+	if _, err := executeCommand(t, "delete", "2", "--dry-run"); err == nil {
+		t.Error("Expected an error for a bookmark that does not exist")
+	}
+	if deletes != 0 {
+		t.Errorf("Expected no DELETE request under --dry-run, got %d", deletes)
+	}
+}
+
+// setupDeleteMatchingServer serves three bookmarks tagged temp, failing the
+// deletion of failID. It returns the IDs of the DELETE requests and the
+// query strings of the fetches.
+func setupDeleteMatchingServer(t *testing.T, failID int) (*[]int, *[]string) {
+	t.Helper()
+	deleted := &[]int{}
+	queries := &[]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			id, _ := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bookmarks/"), "/"))
+			*deleted = append(*deleted, id)
+			if id == failID {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		*queries = append(*queries, r.URL.RawQuery)
+		var results []models.Bookmark
+		for id := 1; id <= 3; id++ {
+			results = append(results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), fmt.Sprintf("Temp %d", id), []string{"temp"}))
+		}
+		// The search also matches "temp" in this untagged bookmark's title
+		results = append(results, mockBookmark(4, "https://example.com/4", "Temp files explained", nil))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return deleted, queries
+}
+
+func TestDeleteMatching(t *testing.T) {
+	t.Run("requires an ID or a filter", func(t *testing.T) {
+		deleted, queries := setupDeleteMatchingServer(t, 0)
+		_, err := executeCommand(t, "delete", "--force")
+		if err == nil || !strings.Contains(err.Error(), "give a bookmark ID, or --tags or --query") {
+			t.Errorf("Expected the no-filter guard, got: %v", err)
+		}
+		if len(*deleted) > 0 || len(*queries) > 0 {
+			t.Errorf("Expected no requests, got deletes %v and queries %v", *deleted, *queries)
+		}
+	})
+
+	t.Run("blank filters are refused", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"delete", "--query", " ", "--force"},
+			{"delete", "--tags", ",", "--force"},
+			{"delete", "--tags", " , ", "--query", "\t", "--force"},
+		} {
+			deleted, queries := setupDeleteMatchingServer(t, 0)
+			_, err := executeCommand(t, args...)
+			if err == nil || !strings.Contains(err.Error(), "give a bookmark ID, or --tags or --query") {
+				t.Errorf("%q: expected the no-filter guard, got: %v", args, err)
+			}
+			if len(*deleted) > 0 || len(*queries) > 0 {
+				t.Errorf("%q: expected no requests, got deletes %v and queries %v", args, *deleted, *queries)
+			}
+		}
+	})
+
+	t.Run("blank tags are dropped", func(t *testing.T) {
+		deleted, queries := setupDeleteMatchingServer(t, 0)
+		if _, err := executeCommand(t, "delete", "--tags", "temp,, ", "--force"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(*deleted) != 3 || len(*queries) == 0 || (*queries)[0] != "limit=100&q=+temp" {
+			t.Errorf("Expected only the temp tag in the query, got %v (deleted %v)", *queries, *deleted)
+		}
+	})
+
+	t.Run("not with an ID", func(t *testing.T) {
+		setupDeleteMatchingServer(t, 0)
+		_, err := executeCommand(t, "delete", "1", "--tags", "temp")
+		if err == nil || !strings.Contains(err.Error(), "not both") {
+			t.Errorf("Expected an error for an ID with a filter, got: %v", err)
+		}
+	})
+
+	t.Run("force", func(t *testing.T) {
+		deleted, queries := setupDeleteMatchingServer(t, 0)
+		output, err := executeCommand(t, "delete", "--tags", "temp", "--query", "junk", "--force")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !slices.Equal(*deleted, []int{1, 2, 3}) {
+			t.Errorf("Expected bookmarks 1-3 to be deleted, got %v", *deleted)
+		}
+		if len(*queries) == 0 || !strings.Contains((*queries)[0], "temp") || !strings.Contains((*queries)[0], "junk") {
+			t.Errorf("Expected the filters in the request, got %v", *queries)
+		}
+		if !strings.Contains(output, "✓ 3 bookmark(s) deleted") {
+			t.Errorf("Expected a summary, got: %s", output)
+		}
+	})
+
+	t.Run("confirm with the count", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 0)
+		feedStdin(t, "3\n")
+		output, err := executeCommand(t, "delete", "--tags", "temp")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Type 3 to confirm") || len(*deleted) != 3 {
+			t.Errorf("Expected 3 deletions after confirming, got %v\n%s", *deleted, output)
+		}
+		if !strings.Contains(output, "  2: Temp 2\n") {
+			t.Errorf("Expected the matches listed in the prompt, got:\n%s", output)
+		}
+		if strings.Contains(output, "Temp files explained") {
+			t.Errorf("Expected the untagged bookmark not listed, got:\n%s", output)
+		}
+	})
+
+	t.Run("tags must be on the bookmark", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 0)
+		if _, err := executeCommand(t, "delete", "--tags", "temp", "--force"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if slices.Contains(*deleted, 4) {
+			t.Errorf("Expected the untagged bookmark that mentions temp to be kept, got %v", *deleted)
+		}
+	})
+
+	t.Run("wrong confirmation", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 0)
+		feedStdin(t, "y\n")
+		output, err := executeCommand(t, "delete", "--tags", "temp")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Delete cancelled") || len(*deleted) != 0 {
+			t.Errorf("Expected the delete to be cancelled, got %v\n%s", *deleted, output)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 0)
+		output, err := executeCommand(t, "delete", "--tags", "temp", "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(*deleted) != 0 {
+			t.Errorf("Expected no DELETE request under --dry-run, got %v", *deleted)
+		}
+		if !strings.Contains(output, "Would delete bookmark 2: Temp 2") {
+			t.Errorf("Expected each bookmark to be previewed, got: %s", output)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 2)
+		output, err := executeCommand(t, "delete", "--tags", "temp", "--force")
+		if err == nil || !strings.Contains(err.Error(), "1 of 3 bookmarks could not be deleted") {
+			t.Errorf("Expected a failure error, got: %v", err)
+		}
+		if len(*deleted) != 3 || !strings.Contains(output, "✗ bookmark 2") {
+			t.Errorf("Expected every bookmark attempted and 2 reported, got %v\n%s", *deleted, output)
+		}
+	})
+
+	t.Run("json requires force", func(t *testing.T) {
+		deleted, _ := setupDeleteMatchingServer(t, 0)
+		_, err := executeCommand(t, "delete", "--tags", "temp", "--json")
+		if err == nil || !strings.Contains(err.Error(), "requires --force") {
+			t.Errorf("Expected --json to require --force, got: %v", err)
+		}
+		if len(*deleted) != 0 {
+			t.Errorf("Expected no deletions, got %v", *deleted)
+		}
+	})
+
+	t.Run("yaml and select require force", func(t *testing.T) {
+		for _, flags := range [][]string{{"-O", "yaml"}, {"--select", ".deleted"}} {
+			deleted, _ := setupDeleteMatchingServer(t, 0)
+			_, err := executeCommand(t, append([]string{"delete", "--tags", "temp"}, flags...)...)
+			if err == nil || !strings.Contains(err.Error(), "requires --force") {
+				t.Errorf("%v: expected --force to be required, got: %v", flags, err)
+			}
+			if len(*deleted) != 0 {
+				t.Errorf("%v: expected no deletions, got %v", flags, *deleted)
+			}
+		}
+	})
+}
+
 // TestExportCommand tests the 'linkdingctl export' command
 func TestExportCommand(t *testing.T) {
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -892,6 +1288,83 @@ func TestExportFormatsExtended(t *testing.T) {
 		}
 	})
 
+	t.Run("export org format", func(t *testing.T) {
+		output, err := executeCommand(t, "export", "-f", "org")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "* test\n** [[https://example.com][Example]] :test:") {
+			t.Errorf("Expected org output grouped by tag, got: %s", output)
+		}
+	})
+
+	t.Run("export org format without grouping", func(t *testing.T) {
+		output, err := executeCommand(t, "export", "-f", "org", "--group-by", "none")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "* test\n") || !strings.Contains(output, "* [[https://example.com][Example]] :test:") {
+			t.Errorf("Expected a flat org outline, got: %s", output)
+		}
+	})
+
+	t.Run("export markdown format", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "bookmarks.md")
+		if _, err := executeCommand(t, "export", "-f", "markdown", "-o", outputFile); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		content, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("Expected the Markdown file to exist: %v", err)
+		}
+		if !strings.Contains(string(content), "## test\n\n- [Example](https://example.com) — Description for Example") {
+			t.Errorf("Expected Markdown grouped by tag, got: %s", content)
+		}
+	})
+
+	t.Run("export jsonl format", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "bookmarks.jsonl")
+		if _, err := executeCommand(t, "export", "-f", "jsonl", "-o", outputFile); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		content, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("Expected the JSON Lines file to exist: %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		if len(lines) != 1 {
+			t.Fatalf("Expected 1 line, got %d: %s", len(lines), content)
+		}
+		var bookmark export.ExportBookmark
+		if err := json.Unmarshal([]byte(lines[0]), &bookmark); err != nil || bookmark.URL != "https://example.com" {
+			t.Errorf("Expected a bookmark object on the line, got %v: %s", err, lines[0])
+		}
+	})
+
+	t.Run("group-by requires org format", func(t *testing.T) {
+		_, err := executeCommand(t, "export", "-f", "json", "--group-by", "tag")
+		if err == nil {
+			t.Error("Expected error using --group-by with json")
+		}
+	})
+
+	t.Run("export html with folder prefix", func(t *testing.T) {
+		output, err := executeCommand(t, "export", "-f", "html", "--folder-prefix", "test")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, `<DT><A HREF="https://example.com"`) {
+			t.Errorf("Expected the bookmark at the top level, got: %s", output)
+		}
+	})
+
+	t.Run("folder-prefix requires html format", func(t *testing.T) {
+		_, err := executeCommand(t, "export", "-f", "csv", "--folder-prefix", "browser")
+		if err == nil {
+			t.Error("Expected error using --folder-prefix with csv")
+		}
+	})
+
 	t.Run("export invalid format error", func(t *testing.T) {
 		_, err := executeCommand(t, "export", "-f", "invalid")
 		if err == nil {
@@ -1103,6 +1576,15 @@ func TestDeleteJSONOutput(t *testing.T) {
 	if err := json.Unmarshal([]byte(output), &result); err != nil {
 		t.Errorf("Expected valid JSON output")
 	}
+
+	// YAML skips the prompt like --json and prints the same fields
+	output, err = executeCommand(t, "delete", "1", "-O", "yaml")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if strings.Contains(output, "Are you sure") || !strings.Contains(output, "deleted: true") || !strings.Contains(output, "id: 1") {
+		t.Errorf("Expected YAML output without a prompt, got:\n%s", output)
+	}
 }
 
 // TestConfigTestCommand tests config test
@@ -1143,6 +1625,83 @@ func TestConfigTestCommand(t *testing.T) {
 	})
 }
 
+func TestConfigTestVerbose(t *testing.T) {
+	handler := func(withHealth bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/health" && withHealth:
+				_, _ = w.Write([]byte(`{"version": "1.39.1", "status": "healthy"}`))
+			case r.URL.Path == "/api/bookmarks/" && r.Method == "GET":
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 42, Results: []models.Bookmark{}})
+			case r.URL.Path == "/api/bookmarks/" && r.Method == "POST":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"url": ["This field may not be blank."]}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}
+	}
+
+	t.Run("default output unchanged", func(t *testing.T) {
+		server := setupMockServer(t, handler(true))
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "version") || strings.Contains(output, "Bookmarks:") {
+			t.Errorf("Expected no server details without --verbose, got: %s", output)
+		}
+	})
+
+	t.Run("verbose", func(t *testing.T) {
+		server := setupMockServer(t, handler(true))
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test", "--verbose")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		for _, want := range []string{"LinkDing version: 1.39.1", "Bookmarks: 42", "Token access: read-write"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got: %s", want, output)
+			}
+		}
+	})
+
+	t.Run("verbose json", func(t *testing.T) {
+		server := setupMockServer(t, handler(true))
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test", "-v", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Expected JSON output, got %v: %s", err, output)
+		}
+		if result["version"] != "1.39.1" || result["bookmark_count"] != float64(42) || result["token_scope"] != "read-write" {
+			t.Errorf("Unexpected JSON output: %v", result)
+		}
+	})
+
+	t.Run("no health endpoint", func(t *testing.T) {
+		server := setupMockServer(t, handler(false))
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test", "--verbose")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "LinkDing version: unknown") || !strings.Contains(output, "Bookmarks: 42") {
+			t.Errorf("Expected an unknown version and the count, got: %s", output)
+		}
+	})
+}
+
 // TestTagsShowCommand tests tags show
 func TestTagsShowCommand(t *testing.T) {
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -1372,6 +1931,14 @@ func TestUpdateJSONOutput(t *testing.T) {
 	if err := json.Unmarshal([]byte(output), &bookmark); err != nil {
 		t.Errorf("Expected valid JSON output")
 	}
+
+	output, err = executeCommand(t, "update", "1", "--title", "Updated", "-O", "yaml")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "title: Updated") || strings.Contains(output, "✓") {
+		t.Errorf("Expected the bookmark as YAML, got:\n%s", output)
+	}
 }
 
 // TestTagsWithUnusedFilter tests tags command with unused filter
@@ -1410,6 +1977,63 @@ func TestTagsWithUnusedFilter(t *testing.T) {
 	}
 }
 
+// TestTagsMultiPage tests that tags and --unused include tags beyond the first page
+func TestTagsMultiPage(t *testing.T) {
+	tagPages := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/tags/" && r.Method == "GET" {
+			tagPages++
+			nextURL := "/api/tags/?offset=100&limit=100"
+			var response models.TagList
+			if r.URL.Query().Get("offset") == "100" {
+				response = models.TagList{Count: 101, Results: []models.Tag{{ID: 101, Name: "last-page-tag"}}}
+			} else {
+				response = models.TagList{Count: 101, Next: &nextURL, Results: make([]models.Tag, 100)}
+				for i := range response.Results {
+					response.Results[i] = models.Tag{ID: i + 1, Name: fmt.Sprintf("tag%03d", i+1)}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			bookmark := mockBookmark(1, "https://example.com", "Example", []string{"tag001"})
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "tags", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var tags []models.TagWithCount
+	if err := json.Unmarshal([]byte(output), &tags); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if len(tags) != 101 {
+		t.Errorf("Expected all 101 tags across both pages, got %d", len(tags))
+	}
+	if tagPages != 2 {
+		t.Errorf("Expected 2 tag page requests, got %d", tagPages)
+	}
+
+	output, err = executeCommand(t, "tags", "--unused")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "last-page-tag") {
+		t.Errorf("Expected unused tag from the second page, got: %s", output)
+	}
+	if strings.Contains(output, "tag001") {
+		t.Errorf("Expected used tag to be filtered out, got: %s", output)
+	}
+}
+
 // TestTagsSortByCount tests tags sorted by count
 func TestTagsSortByCount(t *testing.T) {
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -1482,26 +2106,79 @@ func TestExportWithOutputFile(t *testing.T) {
 	}
 }
 
-// TestLoadConfigError tests config loading error
-func TestLoadConfigError(t *testing.T) {
-	// Clear environment variables
-	_ = os.Unsetenv("LINKDING_URL")
-	_ = os.Unsetenv("LINKDING_TOKEN")
-
-	tmpDir := t.TempDir()
-	badConfigPath := filepath.Join(tmpDir, "nonexistent", "config.yaml")
+func TestExportOutputDir(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		bookmarks := []models.Bookmark{mockBookmark(1, "https://example.com", "Example", []string{"test"})}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: bookmarks})
+	})
+	setTestEnv(t, server.URL, "test-token")
 
-	_, err := executeCommand(t, "--config", badConfigPath, "list")
-	if err == nil {
-		t.Error("Expected error with missing config")
-	}
-}
+	name := regexp.MustCompile(`^linkding-export-\d{4}-\d{2}-\d{2}T\d{6}\.md$`)
 
-// ================= IMPORT COMMAND TESTS =================
+	t.Run("names the file", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := executeCommand(t, "export", "-f", "markdown", "--output-dir", dir); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || !name.MatchString(entries[0].Name()) {
+			t.Fatalf("Expected one timestamped .md file, got %v", entries)
+		}
+	})
 
-// TestImportCommandJSON tests importing from JSON format
-func TestImportCommandJSON(t *testing.T) {
-	createdCount := 0
+	t.Run("json reports the path", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "exports")
+		output, err := executeCommand(t, "export", "-f", "markdown", "--output-dir", dir, "--mkdir", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result struct {
+			File string `json:"file"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		if filepath.Dir(result.File) != dir || !name.MatchString(filepath.Base(result.File)) {
+			t.Errorf("Unexpected file %q", result.File)
+		}
+		content, err := os.ReadFile(result.File)
+		if err != nil || !strings.Contains(string(content), "https://example.com") {
+			t.Errorf("Expected the export in %s, got %q (%v)", result.File, content, err)
+		}
+	})
+
+	t.Run("not with --output", func(t *testing.T) {
+		_, err := executeCommand(t, "export", "-o", "x.json", "--output-dir", t.TempDir())
+		if err == nil {
+			t.Error("Expected --output and --output-dir to conflict")
+		}
+	})
+}
+
+// TestLoadConfigError tests config loading error
+func TestLoadConfigError(t *testing.T) {
+	// Clear environment variables
+	_ = os.Unsetenv("LINKDING_URL")
+	_ = os.Unsetenv("LINKDING_TOKEN")
+
+	tmpDir := t.TempDir()
+	badConfigPath := filepath.Join(tmpDir, "nonexistent", "config.yaml")
+
+	_, err := executeCommand(t, "--config", badConfigPath, "list")
+	if err == nil {
+		t.Error("Expected error with missing config")
+	}
+}
+
+// ================= IMPORT COMMAND TESTS =================
+
+// TestImportCommandJSON tests importing from JSON format
+func TestImportCommandJSON(t *testing.T) {
+	createdCount := 0
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/api/bookmarks/" && r.Method == "POST" {
 			var create models.BookmarkCreate
@@ -2265,6 +2942,7 @@ func TestTagsRenameNoBookmarks(t *testing.T) {
 // TestTagsDeleteWithForce tests tags delete with force flag
 func TestTagsDeleteWithForce(t *testing.T) {
 	updateCallCount := 0
+	tagDeleted := false
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
 			bookmarks := []models.Bookmark{
@@ -2283,6 +2961,17 @@ func TestTagsDeleteWithForce(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(bookmark)
 			return
 		}
+		if r.URL.Path == "/api/tags/" && r.Method == "GET" {
+			response := models.TagList{Count: 1, Results: []models.Tag{{ID: 7, Name: "removeme"}}}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		if r.URL.Path == "/api/tags/7/" && r.Method == "DELETE" {
+			tagDeleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		http.NotFound(w, r)
 	})
 
@@ -2312,6 +3001,12 @@ func TestTagsDeleteWithForce(t *testing.T) {
 	if updateCallCount != 2 {
 		t.Errorf("Expected 2 update calls, got %d", updateCallCount)
 	}
+	if !tagDeleted {
+		t.Error("Expected the tag itself to be deleted")
+	}
+	if !strings.Contains(output, "✓ Tag 'removeme' deleted") {
+		t.Errorf("Expected tag deletion message, got: %s", output)
+	}
 }
 
 // TestTagsDeleteWithUpdateError tests tags delete with partial failures
@@ -2373,6 +3068,16 @@ func TestTagsDeleteNoBookmarks(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(response)
 			return
 		}
+		if r.URL.Path == "/api/tags/" && r.Method == "GET" {
+			response := models.TagList{Count: 1, Results: []models.Tag{{ID: 4, Name: "unused"}}}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		if r.URL.Path == "/api/tags/4/" && r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		http.NotFound(w, r)
 	})
 
@@ -2387,6 +3092,81 @@ func TestTagsDeleteNoBookmarks(t *testing.T) {
 	}
 }
 
+// TestTagsDeleteUnsupportedByServer tests that a server rejecting tag deletion
+// leaves the tag as unused instead of failing
+func TestTagsDeleteUnsupportedByServer(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/bookmarks/" && r.Method == "GET":
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 0, Results: []models.Bookmark{}})
+		case r.URL.Path == "/api/tags/" && r.Method == "GET":
+			_ = json.NewEncoder(w).Encode(models.TagList{Count: 1, Results: []models.Tag{{ID: 4, Name: "Unused"}}})
+		case r.URL.Path == "/api/tags/4/" && r.Method == "DELETE":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "tags", "delete", "unused")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "does not support deleting tags") || !strings.Contains(output, "remains as an unused tag") {
+		t.Errorf("Expected unsupported-deletion note, got: %s", output)
+	}
+}
+
+// TestTagsDeleteKeepTag tests that --keep-tag only strips the tag from bookmarks
+func TestTagsDeleteKeepTag(t *testing.T) {
+	tagRequests := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/bookmarks/" && r.Method == "GET":
+			bookmark := mockBookmark(1, "https://example.com/1", "Test 1", []string{"removeme", "keep"})
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}})
+		case strings.HasPrefix(r.URL.Path, "/api/bookmarks/") && r.Method == "PATCH":
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com/1", "Test 1", []string{"keep"}))
+		case strings.HasPrefix(r.URL.Path, "/api/tags/"):
+			tagRequests++
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdin = r
+
+	go func() {
+		_, _ = w.WriteString("y\n")
+		_ = w.Close()
+	}()
+
+	output, err := executeCommand(t, "tags", "delete", "removeme", "--force", "--keep-tag")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if tagRequests != 0 {
+		t.Errorf("Expected no tag requests with --keep-tag, got %d", tagRequests)
+	}
+	if !strings.Contains(output, "kept as an unused tag") {
+		t.Errorf("Expected kept-tag message, got: %s", output)
+	}
+}
+
 // TestTagsDeleteWithoutForce tests tags delete without force when tag has bookmarks
 func TestTagsDeleteWithoutForce(t *testing.T) {
 	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -4290,3 +5070,6479 @@ func TestVersionCommandJSON(t *testing.T) {
 		t.Errorf("Expected version to be 'dev', got: %s", result["version"])
 	}
 }
+
+// ================= LIST DATE COLUMN TESTS =================
+
+// TestRelativeTime tests human-friendly relative time formatting
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected string
+	}{
+		{"seconds ago", now.Add(-30 * time.Second), "just now"},
+		{"one minute", now.Add(-1 * time.Minute), "1 minute ago"},
+		{"minutes", now.Add(-45 * time.Minute), "45 minutes ago"},
+		{"hours", now.Add(-5 * time.Hour), "5 hours ago"},
+		{"one day", now.Add(-24 * time.Hour), "1 day ago"},
+		{"days", now.Add(-3 * 24 * time.Hour), "3 days ago"},
+		{"months", now.Add(-65 * 24 * time.Hour), "2 months ago"},
+		{"years", now.Add(-800 * 24 * time.Hour), "2 years ago"},
+		{"future", now.Add(2 * time.Hour), "2 hours from now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeTime(tt.t, now); got != tt.expected {
+				t.Errorf("relativeTime() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestListDateColumns tests the --added, --modified and --absolute-dates flags
+func TestListDateColumns(t *testing.T) {
+	added := time.Now().Add(-3 * 24 * time.Hour)
+	modified := time.Date(2025, 6, 1, 9, 30, 0, 0, time.Local)
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			bookmark := mockBookmark(1, "https://example.com", "Example", []string{"test"})
+			bookmark.DateAdded = added
+			bookmark.DateModified = modified
+			response := models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("default keeps DATE column", func(t *testing.T) {
+		output, err := executeCommand(t, "list")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "DATE") || strings.Contains(output, "ADDED") {
+			t.Errorf("Expected only the DATE column, got: %s", output)
+		}
+		if !strings.Contains(output, added.Format("2006-01-02")) {
+			t.Errorf("Expected added date in output, got: %s", output)
+		}
+	})
+
+	t.Run("added shows relative time", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--added")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "ADDED") {
+			t.Errorf("Expected ADDED column, got: %s", output)
+		}
+		if !strings.Contains(output, "3 days ago") {
+			t.Errorf("Expected relative date '3 days ago', got: %s", output)
+		}
+	})
+
+	t.Run("modified with absolute dates", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--added", "--modified", "--absolute-dates")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "ADDED") || !strings.Contains(output, "MODIFIED") {
+			t.Errorf("Expected ADDED and MODIFIED columns, got: %s", output)
+		}
+		if !strings.Contains(output, "2025-06-01 09:30:00") {
+			t.Errorf("Expected absolute modified timestamp, got: %s", output)
+		}
+		if strings.Contains(output, "ago") {
+			t.Errorf("Expected no relative times with --absolute-dates, got: %s", output)
+		}
+	})
+
+	t.Run("json output keeps RFC3339 dates", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--added", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "ago") {
+			t.Errorf("Expected raw timestamps in JSON output, got: %s", output)
+		}
+	})
+}
+
+// ================= SELECT TESTS =================
+
+// TestParseSelector tests parsing of --select expressions
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		expr    string
+		steps   int
+		wantErr bool
+	}{
+		{".", 0, false},
+		{".count", 1, false},
+		{".results[].url", 3, false},
+		{".results[0].tag_names[]", 4, false},
+		{"results", 0, true},
+		{"", 0, true},
+		{".results[", 0, true},
+		{".results[x]", 0, true},
+		{".a..b", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			steps, err := parseSelector(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(steps) != tt.steps {
+				t.Errorf("Expected %d steps, got %d", tt.steps, len(steps))
+			}
+		})
+	}
+}
+
+// TestWriteSelected tests value extraction from JSON structures
+func TestWriteSelected(t *testing.T) {
+	data := models.BookmarkList{
+		Count: 2,
+		Results: []models.Bookmark{
+			{ID: 1, URL: "https://a.com", TagNames: []string{"x", "y"}},
+			{ID: 2, URL: "https://b.com", TagNames: []string{"z"}},
+		},
+	}
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{".count", "2\n"},
+		{".results[].url", "https://a.com\nhttps://b.com\n"},
+		{".results[1].id", "2\n"},
+		{".results[].tag_names[]", "x\ny\nz\n"},
+		{".results[0].tag_names", "[\"x\",\"y\"]\n"},
+		{".results[5].url", ""},
+		{".missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeSelected(&buf, data, tt.expr); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("field on array errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeSelected(&buf, data, ".results.url"); err == nil {
+			t.Error("Expected error selecting a field from an array")
+		}
+	})
+}
+
+// TestSelectFlag tests --select on read commands
+func TestSelectFlag(t *testing.T) {
+	requests := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			bookmarks := []models.Bookmark{
+				mockBookmark(1, "https://example.com", "Example", []string{"go"}),
+				mockBookmark(2, "https://test.com", "Test", []string{"rust"}),
+			}
+			response := models.BookmarkList{Count: 2, Results: bookmarks}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		if r.URL.Path == "/api/bookmarks/1/" && r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", []string{"go"}))
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("list select urls", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--select", ".results[].url")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if output != "https://example.com\nhttps://test.com\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+
+	t.Run("get select title", func(t *testing.T) {
+		output, err := executeCommand(t, "get", "1", "--select", ".title")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if output != "Example\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		requests = 0
+		_, err := executeCommand(t, "list", "--select", "results")
+		if err == nil {
+			t.Fatal("Expected error for invalid selector")
+		}
+		if !strings.Contains(err.Error(), "invalid selector") {
+			t.Errorf("Expected invalid selector error, got: %v", err)
+		}
+		if requests != 0 {
+			t.Errorf("Expected the selector to be rejected before any request, got %d", requests)
+		}
+	})
+}
+
+// ================= CONFIG TEST TOKEN SCOPE TESTS =================
+
+// TestConfigTestTokenScope tests read-only/read-write token reporting
+func TestConfigTestTokenScope(t *testing.T) {
+	newServer := func(postStatus int) *httptest.Server {
+		return setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+				return
+			}
+			if r.URL.Path == "/api/bookmarks/" && r.Method == "POST" {
+				w.WriteHeader(postStatus)
+				_, _ = w.Write([]byte(`{"url":["This field may not be blank."]}`))
+				return
+			}
+			http.NotFound(w, r)
+		})
+	}
+
+	t.Run("read-write token", func(t *testing.T) {
+		server := newServer(http.StatusBadRequest)
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Token access: read-write") {
+			t.Errorf("Expected read-write token access, got: %s", output)
+		}
+	})
+
+	t.Run("read-only token warns", func(t *testing.T) {
+		server := newServer(http.StatusForbidden)
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Token access: read-only") || !strings.Contains(output, "Warning") {
+			t.Errorf("Expected read-only warning, got: %s", output)
+		}
+	})
+
+	t.Run("json includes token scope", func(t *testing.T) {
+		server := newServer(http.StatusForbidden)
+		setTestEnv(t, server.URL, "test-token")
+
+		output, err := executeCommand(t, "config", "test", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result map[string]string
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Expected valid JSON, got: %s", output)
+		}
+		if result["token_scope"] != "read-only" {
+			t.Errorf("Expected token_scope read-only, got: %s", result["token_scope"])
+		}
+	})
+}
+
+// ================= BEST-EFFORT PAGINATION TESTS =================
+
+// TestBackupBestEffort tests backup behavior when a page fails mid-pagination
+func TestBackupBestEffort(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			if r.URL.Query().Get("offset") == "100" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			nextURL := "/api/bookmarks/?offset=100&limit=100"
+			response := models.BookmarkList{
+				Count:   2,
+				Next:    &nextURL,
+				Results: []models.Bookmark{mockBookmark(1, "https://example.com", "Example", []string{})},
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("default removes the partial file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		_, err := executeCommand(t, "backup", "--output", tmpDir)
+		if err == nil {
+			t.Fatal("Expected error on mid-pagination failure")
+		}
+		files, _ := os.ReadDir(tmpDir)
+		if len(files) != 0 {
+			t.Errorf("Expected no backup file, found %d", len(files))
+		}
+	})
+
+	t.Run("best-effort keeps the partial file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		output, err := executeCommand(t, "backup", "--output", tmpDir, "--best-effort")
+		if err == nil || !strings.Contains(err.Error(), "backup incomplete") {
+			t.Fatalf("Expected backup incomplete error, got: %v", err)
+		}
+		if !strings.Contains(output, "Warning:") || !strings.Contains(output, "Partial backup created") {
+			t.Errorf("Expected partial backup warning, got: %s", output)
+		}
+
+		files, _ := os.ReadDir(tmpDir)
+		if len(files) != 1 {
+			t.Fatalf("Expected 1 backup file, found %d", len(files))
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, files[0].Name()))
+		if !strings.Contains(string(content), "https://example.com") {
+			t.Errorf("Expected fetched bookmark in partial backup, got: %s", string(content))
+		}
+	})
+
+	t.Run("export best-effort writes to stdout", func(t *testing.T) {
+		output, err := executeCommand(t, "export", "-f", "csv", "--best-effort")
+		if err == nil || !strings.Contains(err.Error(), "export incomplete") {
+			t.Fatalf("Expected export incomplete error, got: %v", err)
+		}
+		if !strings.Contains(output, "https://example.com") {
+			t.Errorf("Expected fetched bookmark in output, got: %s", output)
+		}
+	})
+}
+
+// ================= BUNDLES DUPLICATE TESTS =================
+
+// TestBundlesDuplicateCommand tests the 'linkdingctl bundles duplicate' command
+func TestBundlesDuplicateCommand(t *testing.T) {
+	var created models.BundleCreate
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bundles/3/" && r.Method == "GET" {
+			source := models.Bundle{
+				ID:           3,
+				Name:         "Work",
+				Search:       "project",
+				AnyTags:      "go,rust",
+				AllTags:      "work",
+				ExcludedTags: "spam",
+				Order:        4,
+			}
+			_ = json.NewEncoder(w).Encode(source)
+			return
+		}
+		if r.URL.Path == "/api/bundles/" && r.Method == "POST" {
+			created = models.BundleCreate{}
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			bundle := models.Bundle{
+				ID:           9,
+				Name:         created.Name,
+				Search:       created.Search,
+				AnyTags:      created.AnyTags,
+				AllTags:      created.AllTags,
+				ExcludedTags: created.ExcludedTags,
+				Order:        created.Order,
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(bundle)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("duplicate copies all fields", func(t *testing.T) {
+		output, err := executeCommand(t, "bundles", "duplicate", "3", "--name", "Work Copy")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "✓ Bundle duplicated: Work Copy") {
+			t.Errorf("Expected success message, got: %s", output)
+		}
+		if created.Name != "Work Copy" || created.Search != "project" || created.AnyTags != "go,rust" ||
+			created.AllTags != "work" || created.ExcludedTags != "spam" || created.Order != 4 {
+			t.Errorf("Expected fields copied from source, got: %+v", created)
+		}
+	})
+
+	t.Run("duplicate with overrides", func(t *testing.T) {
+		output, err := executeCommand(t, "bundles", "duplicate", "3", "--name", "Go Only",
+			"--any-tags", "go", "--order", "7", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var bundle models.Bundle
+		if err := json.Unmarshal([]byte(output), &bundle); err != nil {
+			t.Fatalf("Expected valid JSON, got: %s", output)
+		}
+		if bundle.ID != 9 || bundle.AnyTags != "go" || bundle.Order != 7 {
+			t.Errorf("Expected overridden fields, got: %+v", bundle)
+		}
+		if created.Search != "project" {
+			t.Errorf("Expected non-overridden search to be copied, got: %s", created.Search)
+		}
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		_, err := executeCommand(t, "bundles", "duplicate", "3")
+		if err == nil || !strings.Contains(err.Error(), "name") {
+			t.Errorf("Expected required name error, got: %v", err)
+		}
+	})
+
+	t.Run("same name is rejected", func(t *testing.T) {
+		_, err := executeCommand(t, "bundles", "duplicate", "3", "--name", "Work")
+		if err == nil || !strings.Contains(err.Error(), "must differ") {
+			t.Errorf("Expected name collision error, got: %v", err)
+		}
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := executeCommand(t, "bundles", "duplicate", "abc", "--name", "X")
+		if err == nil || !strings.Contains(err.Error(), "invalid bundle ID") {
+			t.Errorf("Expected invalid bundle ID error, got: %v", err)
+		}
+	})
+}
+
+// ================= RESULT CAP TESTS =================
+
+// TestTagsShowResultCap tests the default cap, --limit and --all on tags show
+func TestTagsShowResultCap(t *testing.T) {
+	const total = 120
+	var requestedLimits []string
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			query := r.URL.Query()
+			requestedLimits = append(requestedLimits, query.Get("limit"))
+
+			limit := total
+			if l := query.Get("limit"); l != "" {
+				_, _ = fmt.Sscanf(l, "%d", &limit)
+			}
+			offset := 0
+			if o := query.Get("offset"); o != "" {
+				_, _ = fmt.Sscanf(o, "%d", &offset)
+			}
+
+			var results []models.Bookmark
+			for i := offset; i < total && i < offset+limit; i++ {
+				results = append(results, mockBookmark(i+1, fmt.Sprintf("https://example.com/%d", i+1), fmt.Sprintf("Bookmark %d", i+1), []string{"big"}))
+			}
+			response := models.BookmarkList{Count: total, Results: results}
+			if offset+limit < total {
+				next := "next"
+				response.Next = &next
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("default caps and warns", func(t *testing.T) {
+		requestedLimits = nil
+		output, err := executeCommand(t, "tags", "show", "big", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(requestedLimits) != 1 || requestedLimits[0] != "50" {
+			t.Errorf("Expected a single request with limit 50, got: %v", requestedLimits)
+		}
+		if !strings.Contains(output, "Warning: showing first 50 of 120") {
+			t.Errorf("Expected cap warning, got: %s", output)
+		}
+		if !strings.Contains(output, "--all") {
+			t.Errorf("Expected --all hint, got: %s", output)
+		}
+	})
+
+	t.Run("explicit limit does not warn", func(t *testing.T) {
+		output, err := executeCommand(t, "tags", "show", "big", "--limit", "10")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "Warning") {
+			t.Errorf("Expected no warning with explicit --limit, got: %s", output)
+		}
+		if !strings.Contains(output, "Showing 10 of 120") {
+			t.Errorf("Expected 10 results shown, got: %s", output)
+		}
+	})
+
+	t.Run("all fetches everything", func(t *testing.T) {
+		output, err := executeCommand(t, "tags", "show", "big", "--all")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "Warning") {
+			t.Errorf("Expected no warning with --all, got: %s", output)
+		}
+		if !strings.Contains(output, "Showing 120 of 120") {
+			t.Errorf("Expected all 120 results, got: %s", output)
+		}
+	})
+
+	t.Run("limit and all conflict", func(t *testing.T) {
+		_, err := executeCommand(t, "tags", "show", "big", "--all", "--limit", "5")
+		if err == nil {
+			t.Error("Expected error when combining --all and --limit")
+		}
+	})
+}
+
+// ================= EXPORT SCHEMA TESTS =================
+
+func TestExportSchemaCommand(t *testing.T) {
+	// No configuration is needed to print the schema
+	setTestEnv(t, "", "")
+
+	output, err := executeCommand(t, "export", "--schema")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &schema); err != nil {
+		t.Fatalf("Expected JSON schema output, got: %s", output)
+	}
+	if schema["$schema"] == nil || schema["properties"] == nil {
+		t.Errorf("Expected a JSON Schema document, got: %v", schema)
+	}
+}
+
+func TestImportValidateOnly(t *testing.T) {
+	// The server must never be contacted
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request during --validate-only: %s %s", r.Method, r.URL.Path)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.json")
+	invalidFile := filepath.Join(dir, "invalid.json")
+	csvFile := filepath.Join(dir, "bookmarks.csv")
+	if err := os.WriteFile(validFile, []byte(`{"version":"1","bookmarks":[{"url":"https://example.com","tags":["a"]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalidFile, []byte(`{"bookmarks":[{"title":"missing url"},{"url":"https://b.com","shared":"no"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(csvFile, []byte("url\nhttps://example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid file", func(t *testing.T) {
+		output, err := executeCommand(t, "import", validFile, "--validate-only")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "is valid") {
+			t.Errorf("Expected valid message, got: %s", output)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		output, err := executeCommand(t, "import", invalidFile, "--validate-only")
+		if err == nil {
+			t.Fatal("Expected validation failure")
+		}
+		if !strings.Contains(output, "2 schema error(s)") {
+			t.Errorf("Expected error count, got: %s", output)
+		}
+		if !strings.Contains(output, "$.bookmarks[0]") || !strings.Contains(output, "$.bookmarks[1].shared") {
+			t.Errorf("Expected error paths, got: %s", output)
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		output, err := executeCommand(t, "import", invalidFile, "--validate-only", "--json")
+		if err == nil {
+			t.Fatal("Expected validation failure")
+		}
+		var result struct {
+			Valid  bool `json:"valid"`
+			Errors []struct {
+				Path string `json:"path"`
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, output)
+		}
+		if result.Valid || len(result.Errors) != 2 {
+			t.Errorf("Expected invalid result with 2 errors, got: %+v", result)
+		}
+	})
+
+	t.Run("non-json format", func(t *testing.T) {
+		_, err := executeCommand(t, "import", csvFile, "--validate-only")
+		if err == nil || !strings.Contains(err.Error(), "JSON files only") {
+			t.Errorf("Expected JSON-only error, got: %v", err)
+		}
+	})
+}
+
+// ================= UNREAD / SHARED FLAG TESTS =================
+
+// TestUnreadSharedExplicitFlags verifies that unread/shared are only sent
+// when the flag was explicitly provided, including explicit false values.
+func TestUnreadSharedExplicitFlags(t *testing.T) {
+	var lastBody map[string]interface{}
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" || r.Method == "PATCH" {
+			lastBody = nil
+			if err := json.NewDecoder(r.Body).Decode(&lastBody); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+		}
+		bookmark := mockBookmark(1, "https://example.com", "Example", []string{"test"})
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(bookmark)
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantFields map[string]bool // field -> expected value
+		absent     []string
+	}{
+		{
+			name:       "update unread=false",
+			args:       []string{"update", "1", "--unread=false"},
+			wantFields: map[string]bool{"unread": false},
+			absent:     []string{"shared"},
+		},
+		{
+			name:       "update shared=false",
+			args:       []string{"update", "1", "--shared=false"},
+			wantFields: map[string]bool{"shared": false},
+			absent:     []string{"unread"},
+		},
+		{
+			name:       "update both true",
+			args:       []string{"update", "1", "--unread", "--shared"},
+			wantFields: map[string]bool{"unread": true, "shared": true},
+		},
+		{
+			name:   "update without flags omits fields",
+			args:   []string{"update", "1", "--title", "New"},
+			absent: []string{"unread", "shared"},
+		},
+		{
+			name:       "add unread",
+			args:       []string{"add", "https://example.com/a", "--unread"},
+			wantFields: map[string]bool{"unread": true},
+			absent:     []string{"shared"},
+		},
+		{
+			name:   "add defaults to false",
+			args:   []string{"add", "https://example.com/b", "--unread=false", "--shared=false"},
+			absent: []string{"unread", "shared"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastBody = nil
+			if _, err := executeCommand(t, tt.args...); err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if lastBody == nil {
+				t.Fatal("Expected a request body")
+			}
+			for field, want := range tt.wantFields {
+				got, ok := lastBody[field]
+				if !ok {
+					t.Errorf("Expected %q in request body, got: %v", field, lastBody)
+					continue
+				}
+				if got != want {
+					t.Errorf("Expected %q=%v, got %v", field, want, got)
+				}
+			}
+			for _, field := range tt.absent {
+				if _, ok := lastBody[field]; ok {
+					t.Errorf("Expected %q to be omitted from request body, got: %v", field, lastBody)
+				}
+			}
+		})
+	}
+}
+
+// ================= RETRY FLAG TESTS =================
+
+func TestRetryOnFlagValidation(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	if _, err := executeCommand(t, "get", "1", "--retries", "2", "--retry-on", "429,502,conn"); err != nil {
+		t.Errorf("Expected valid --retry-on to be accepted, got: %v", err)
+	}
+
+	_, err := executeCommand(t, "get", "1", "--retry-on", "200")
+	if err == nil || !strings.Contains(err.Error(), "between 400 and 599") {
+		t.Errorf("Expected range error for --retry-on 200, got: %v", err)
+	}
+
+	_, err = executeCommand(t, "get", "1", "--retries", "-1")
+	if err == nil {
+		t.Error("Expected error for negative --retries")
+	}
+}
+
+// ================= GLOBAL DRY-RUN TESTS =================
+
+// TestGlobalDryRunSendsNoWrites runs every mutating command under the global
+// --dry-run flag and asserts that only read requests reach the server.
+func TestGlobalDryRunSendsNoWrites(t *testing.T) {
+	var writes []string
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/bookmarks/" || r.URL.Path == "/api/bookmarks/archived/":
+			bookmark := mockBookmark(1, "https://example.com", "Example", []string{"old", "keep"})
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}})
+		case strings.HasPrefix(r.URL.Path, "/api/bookmarks/"):
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", []string{"old"}))
+		case strings.HasPrefix(r.URL.Path, "/api/bundles/"):
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 1, Name: "Source", Search: "go"})
+		case r.URL.Path == "/api/tags/":
+			_ = json.NewEncoder(w).Encode(models.TagList{Count: 1, Results: []models.Tag{{ID: 3, Name: "old"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	importFile := filepath.Join(dir, "import.json")
+	if err := os.WriteFile(importFile, []byte(`{"version":"1","bookmarks":[{"url":"https://new.example.com","tags":["a"]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	commands := [][]string{
+		{"add", "https://new.example.com", "--title", "New"},
+		{"update", "1", "--title", "Changed"},
+		{"update", "1", "--add-tags", "extra"},
+		{"delete", "1"},
+		{"tags", "create", "fresh"},
+		{"tags", "rename", "old", "new", "--force"},
+		{"tags", "delete", "old", "--force"},
+		{"bundles", "create", "Bundle"},
+		{"bundles", "update", "1", "--name", "Renamed"},
+		{"bundles", "delete", "1"},
+		{"bundles", "duplicate", "1", "--name", "Copy"},
+		{"import", importFile},
+		{"restore", importFile},
+		{"restore", importFile, "--wipe"},
+		{"config", "test"},
+	}
+
+	for _, args := range commands {
+		name := strings.Join(args[:2], " ")
+		t.Run(name, func(t *testing.T) {
+			writes = nil
+			// The global flag goes before the subcommand
+			output, err := executeCommand(t, append([]string{"--dry-run"}, args...)...)
+			if err != nil {
+				t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+			}
+			if len(writes) > 0 {
+				t.Errorf("Expected no write requests under --dry-run, got: %v", writes)
+			}
+		})
+	}
+
+	t.Run("prints intended call", func(t *testing.T) {
+		output, err := executeCommand(t, "--dry-run", "update", "1", "--title", "Changed")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Would PATCH /api/bookmarks/1/") || !strings.Contains(output, `"title":"Changed"`) {
+			t.Errorf("Expected planned request in output, got: %s", output)
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		output, err := executeCommand(t, "--dry-run", "--json", "bundles", "delete", "1")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result struct {
+			DryRun   bool             `json:"dry_run"`
+			Requests []plannedRequest `json:"requests"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, output)
+		}
+		if !result.DryRun || len(result.Requests) != 1 || result.Requests[0].Method != "DELETE" {
+			t.Errorf("Unexpected dry-run JSON: %+v", result)
+		}
+	})
+}
+
+// ================= ADD RESOLVE REDIRECTS TESTS =================
+
+func TestAddResolveRedirects(t *testing.T) {
+	var createdURL string
+	var server *httptest.Server
+	server = setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, server.URL+"/hop", http.StatusMovedPermanently)
+		case "/hop":
+			http.Redirect(w, r, server.URL+"/final", http.StatusFound)
+		case "/final":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == "HEAD" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			http.Redirect(w, r, server.URL+"/final", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, server.URL+"/loop", http.StatusFound)
+		case "/api/bookmarks/":
+			var create models.BookmarkCreate
+			_ = json.NewDecoder(r.Body).Decode(&create)
+			createdURL = create.URL
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, create.URL, "Final", nil))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("stores final URL", func(t *testing.T) {
+		createdURL = ""
+		output, err := executeCommand(t, "add", server.URL+"/short", "--resolve-redirects")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if createdURL != server.URL+"/final" {
+			t.Errorf("Expected final URL to be saved, got: %s", createdURL)
+		}
+		if !strings.Contains(output, "Resolved "+server.URL+"/short -> "+server.URL+"/final") {
+			t.Errorf("Expected original and resolved URL in output, got: %s", output)
+		}
+	})
+
+	t.Run("falls back to GET when HEAD is rejected", func(t *testing.T) {
+		createdURL = ""
+		if _, err := executeCommand(t, "add", server.URL+"/no-head", "--resolve-redirects"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if createdURL != server.URL+"/final" {
+			t.Errorf("Expected final URL via GET fallback, got: %s", createdURL)
+		}
+	})
+
+	t.Run("max redirects", func(t *testing.T) {
+		createdURL = ""
+		_, err := executeCommand(t, "add", server.URL+"/loop", "--resolve-redirects", "--max-redirects", "3")
+		if err == nil || !strings.Contains(err.Error(), "stopped after 3 redirects") {
+			t.Errorf("Expected redirect limit error, got: %v", err)
+		}
+		if createdURL != "" {
+			t.Errorf("Expected no bookmark to be created, got: %s", createdURL)
+		}
+	})
+
+	t.Run("default keeps URL as given", func(t *testing.T) {
+		createdURL = ""
+		if _, err := executeCommand(t, "add", server.URL+"/short"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if createdURL != server.URL+"/short" {
+			t.Errorf("Expected original URL without --resolve-redirects, got: %s", createdURL)
+		}
+	})
+
+	t.Run("conflicting flags", func(t *testing.T) {
+		_, err := executeCommand(t, "add", server.URL+"/short", "--resolve-redirects", "--no-resolve")
+		if err == nil {
+			t.Error("Expected error for --resolve-redirects with --no-resolve")
+		}
+	})
+}
+
+// ================= CONFIG DEFAULTS TESTS =================
+
+func TestConfigDefaults(t *testing.T) {
+	var requestedLimits, requestedQueries []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" {
+			requestedLimits = append(requestedLimits, r.URL.Query().Get("limit"))
+			requestedQueries = append(requestedQueries, r.URL.Query().Get("q"))
+			bookmarks := []models.Bookmark{mockBookmark(1, "https://example.com", "Example", []string{"test"})}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: bookmarks})
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "url: " + server.URL + "\ntoken: test-token\ndefaults:\n  json: true\n  limit: 25\n  list:\n    limit: 7\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("defaults apply", func(t *testing.T) {
+		requestedLimits = nil
+		output, err := executeCommand(t, "--config", configPath, "list")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !json.Valid([]byte(output)) {
+			t.Errorf("Expected JSON output from config default, got: %s", output)
+		}
+		// The command-specific default wins over the global one
+		if len(requestedLimits) != 1 || requestedLimits[0] != "7" {
+			t.Errorf("Expected limit 7 from list defaults, got: %v", requestedLimits)
+		}
+	})
+
+	t.Run("explicit flags override", func(t *testing.T) {
+		requestedLimits = nil
+		output, err := executeCommand(t, "--config", configPath, "list", "--limit", "3", "--json=false")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if json.Valid([]byte(output)) {
+			t.Errorf("Expected table output with --json=false, got: %s", output)
+		}
+		if len(requestedLimits) != 1 || requestedLimits[0] != "3" {
+			t.Errorf("Expected explicit limit 3, got: %v", requestedLimits)
+		}
+	})
+
+	t.Run("top-level defaults skip command flags", func(t *testing.T) {
+		requestedLimits = nil
+		output, err := executeCommand(t, "--config", configPath, "tags", "show", "test")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(requestedLimits) != 1 || requestedLimits[0] == "25" {
+			t.Errorf("Expected the top-level limit not to reach tags show, got: %v (output: %s)", requestedLimits, output)
+		}
+
+		// A top-level limit must not cut an import or block a wipe
+		file := filepath.Join(t.TempDir(), "import.json")
+		if err := os.WriteFile(file, []byte(`{"bookmarks":[{"url":"https://a.com"},{"url":"https://b.com"},{"url":"https://c.com"}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		limitPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(limitPath, []byte("url: "+server.URL+"\ntoken: t\ndefaults:\n  limit: 2\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		output, err = executeCommand(t, "--config", limitPath, "import", file, "--dry-run", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse output: %v\n%s", err, output)
+		}
+		if result["added"] != float64(3) {
+			t.Errorf("Expected all 3 entries imported, got %v", result["added"])
+		}
+		if _, err := executeCommand(t, "--config", limitPath, "restore", file, "--wipe", "--dry-run"); err != nil && strings.Contains(err.Error(), "cannot be combined") {
+			t.Errorf("Expected the top-level limit not to reach restore, got: %v", err)
+		}
+	})
+
+	t.Run("invalid default value", func(t *testing.T) {
+		badPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(badPath, []byte("url: "+server.URL+"\ntoken: t\ndefaults:\n  list:\n    limit: lots\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := executeCommand(t, "--config", badPath, "list")
+		if err == nil || !strings.Contains(err.Error(), "invalid config default for --limit") {
+			t.Errorf("Expected invalid default error, got: %v", err)
+		}
+	})
+
+	t.Run("list value", func(t *testing.T) {
+		listPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(listPath, []byte("url: "+server.URL+"\ntoken: t\ndefaults:\n  list:\n    tags: [go, cli]\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		requestedQueries = nil
+		if _, err := executeCommand(t, "--config", listPath, "list"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(requestedQueries) != 1 || strings.TrimSpace(requestedQueries[0]) != "go cli" {
+			t.Errorf("Expected tags go and cli from the list default, got: %q", requestedQueries)
+		}
+	})
+}
+
+// ================= CUSTOM HEADER TESTS =================
+
+func TestHeaderFlag(t *testing.T) {
+	var lastHeaders http.Header
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("custom headers are sent", func(t *testing.T) {
+		_, err := executeCommand(t, "get", "1",
+			"--header", "X-Auth-Request-Email: me@example.com",
+			"--header", "cf-access-token: abc")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if got := lastHeaders.Get("X-Auth-Request-Email"); got != "me@example.com" {
+			t.Errorf("Expected X-Auth-Request-Email header, got %q", got)
+		}
+		if got := lastHeaders.Get("Cf-Access-Token"); got != "abc" {
+			t.Errorf("Expected Cf-Access-Token header, got %q", got)
+		}
+		if got := lastHeaders.Get("Authorization"); got != "Token test-token" {
+			t.Errorf("Expected token Authorization header to be kept, got %q", got)
+		}
+	})
+
+	t.Run("invalid header syntax", func(t *testing.T) {
+		_, err := executeCommand(t, "get", "1", "--header", "NoColon")
+		if err == nil || !strings.Contains(err.Error(), "Name: Value") {
+			t.Errorf("Expected syntax error, got: %v", err)
+		}
+	})
+
+	t.Run("authorization requires force-header", func(t *testing.T) {
+		_, err := executeCommand(t, "get", "1", "--header", "Authorization: Bearer xyz")
+		if err == nil || !strings.Contains(err.Error(), "--force-header") {
+			t.Errorf("Expected --force-header error, got: %v", err)
+		}
+	})
+
+	t.Run("a command's own force does not unlock authorization", func(t *testing.T) {
+		_, err := executeCommand(t, "delete", "1", "-f", "--header", "Authorization: Bearer xyz")
+		if err == nil || !strings.Contains(err.Error(), "--force-header") {
+			t.Errorf("Expected --force-header error, got: %v", err)
+		}
+	})
+
+	t.Run("authorization override with force-header", func(t *testing.T) {
+		_, err := executeCommand(t, "get", "1", "--header", "Authorization: Bearer xyz", "--force-header")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if got := lastHeaders.Get("Authorization"); got != "Bearer xyz" {
+			t.Errorf("Expected overridden Authorization header, got %q", got)
+		}
+	})
+}
+
+// ================= STATS TESTS =================
+
+func TestAddedHistogram(t *testing.T) {
+	at := func(y int, m time.Month, d int) models.Bookmark {
+		return models.Bookmark{DateAdded: time.Date(y, m, d, 12, 0, 0, 0, time.Local)}
+	}
+	bookmarks := []models.Bookmark{
+		at(2024, time.January, 5),
+		at(2024, time.January, 5),
+		at(2024, time.March, 20),
+		at(2025, time.February, 1),
+		{}, // no date
+	}
+
+	tests := []struct {
+		granularity string
+		want        []histogramBucket
+	}{
+		{
+			granularity: "day",
+			want: []histogramBucket{
+				{Period: "2024-01-05", Count: 2},
+				{Period: "2024-03-20", Count: 1},
+				{Period: "2025-02-01", Count: 1},
+			},
+		},
+		{
+			granularity: "year",
+			want: []histogramBucket{
+				{Period: "2024", Count: 3},
+				{Period: "2025", Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := addedHistogram(bookmarks, tt.granularity)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("addedHistogram(%s) = %v, want %v", tt.granularity, got, tt.want)
+		}
+	}
+
+	// Months fill the gaps between the first and last bookmark
+	months := addedHistogram(bookmarks, "month")
+	if len(months) != 14 {
+		t.Fatalf("Expected 14 months from 2024-01 to 2025-02, got %d: %v", len(months), months)
+	}
+	if months[0] != (histogramBucket{Period: "2024-01", Count: 2}) || months[1] != (histogramBucket{Period: "2024-02", Count: 0}) {
+		t.Errorf("Unexpected first months: %v", months[:2])
+	}
+	if months[13] != (histogramBucket{Period: "2025-02", Count: 1}) {
+		t.Errorf("Unexpected last month: %v", months[13])
+	}
+
+	if got := addedHistogram(nil, "month"); len(got) != 0 {
+		t.Errorf("Expected empty series, got %v", got)
+	}
+}
+
+func TestStatsCommand(t *testing.T) {
+	var requests int
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" {
+			requests++
+			b1 := mockBookmark(1, "https://a.com", "A", []string{"go"})
+			b1.DateAdded = time.Date(2024, time.January, 10, 12, 0, 0, 0, time.Local)
+			b1.Unread = true
+			b2 := mockBookmark(2, "https://b.com", "B", nil)
+			b2.DateAdded = time.Date(2024, time.March, 10, 12, 0, 0, 0, time.Local)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{b1, b2}})
+			return
+		}
+		http.NotFound(w, r)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("summary", func(t *testing.T) {
+		output, err := executeCommand(t, "stats")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Bookmarks:  2") || !strings.Contains(output, "Unread:     1") || !strings.Contains(output, "Untagged:   1") {
+			t.Errorf("Unexpected summary: %s", output)
+		}
+	})
+
+	t.Run("by month text", func(t *testing.T) {
+		requests = 0
+		output, err := executeCommand(t, "stats", "--by-month")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if requests != 1 {
+			t.Errorf("Expected a single fetch, got %d requests", requests)
+		}
+		for _, want := range []string{"2024-01", "2024-02", "2024-03", "█", "Total: 2 bookmarks"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got: %s", want, output)
+			}
+		}
+	})
+
+	t.Run("by month json", func(t *testing.T) {
+		output, err := executeCommand(t, "stats", "--by-month", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result struct {
+			Granularity string            `json:"granularity"`
+			Total       int               `json:"total"`
+			Series      []histogramBucket `json:"series"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, output)
+		}
+		if result.Granularity != "month" || result.Total != 2 || len(result.Series) != 3 {
+			t.Errorf("Unexpected JSON result: %+v", result)
+		}
+	})
+
+	t.Run("granularities are exclusive", func(t *testing.T) {
+		if _, err := executeCommand(t, "stats", "--by-day", "--by-year"); err == nil {
+			t.Error("Expected error combining --by-day and --by-year")
+		}
+	})
+}
+
+// ================= EXCLUDE TAGS TESTS =================
+
+func TestListExcludeTags(t *testing.T) {
+	var lastQuery string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/" {
+			lastQuery = r.URL.Query().Get("q")
+			bookmarks := []models.Bookmark{
+				mockBookmark(1, "https://keep.com", "Keep", []string{"k8s"}),
+				mockBookmark(2, "https://drop.com", "Drop", []string{"k8s", "deprecated"}),
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: bookmarks})
+			return
+		}
+		http.NotFound(w, r)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "list", "--tags", "k8s", "--exclude-tags", "deprecated", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(lastQuery, "k8s") {
+		t.Errorf("Expected include tag in query, got %q", lastQuery)
+	}
+
+	var result models.BookmarkList
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if len(result.Results) != 1 || result.Results[0].ID != 1 {
+		t.Errorf("Expected bookmark with excluded tag to be dropped, got %+v", result.Results)
+	}
+}
+
+// ================= PROFILE TIMING TESTS =================
+
+func TestProfileTiming(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		bookmark := mockBookmark(1, "https://example.com", "Example", nil)
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}})
+	})
+
+	setTestEnv(t, server.URL, "test-token")
+
+	if _, err := executeCommand(t, "list", "--profile-timing"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if requestTimer == nil {
+		t.Fatal("Expected --profile-timing to install a request timer")
+	}
+	if requestTimer.Requests() != 1 {
+		t.Errorf("Expected 1 timed request, got %d", requestTimer.Requests())
+	}
+
+	var buf bytes.Buffer
+	printTimingSummary(&buf, requestTimer, time.Second)
+	for _, want := range []string{"API calls:", "Processing:", "Requests:     1", "Avg latency:"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	// Without the flag no timer is installed
+	if _, err := executeCommand(t, "list"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if requestTimer != nil {
+		t.Error("Expected no request timer without --profile-timing")
+	}
+}
+
+// ================= RANDOM SAMPLE TESTS =================
+
+// setupSampleServer serves count bookmarks, honoring limit and offset.
+func setupSampleServer(t *testing.T, count int) *httptest.Server {
+	t.Helper()
+	all := make([]models.Bookmark, count)
+	for i := range all {
+		tags := []string{"keep"}
+		if i%2 == 1 {
+			tags = []string{"skip"}
+		}
+		all[i] = mockBookmark(i+1, fmt.Sprintf("https://example.com/%d", i+1), fmt.Sprintf("Bookmark %d", i+1), tags)
+	}
+	return setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		var next *string
+		if end < len(all) {
+			n := "next"
+			next = &n
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(all), Results: all[offset:end], Next: next})
+	})
+}
+
+func sampleIDs(t *testing.T, output string) []int {
+	t.Helper()
+	var list models.BookmarkList
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, output)
+	}
+	ids := make([]int, 0, len(list.Results))
+	for _, b := range list.Results {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func TestListRandomSample(t *testing.T) {
+	server := setupSampleServer(t, 20)
+	setTestEnv(t, server.URL, "test-token")
+
+	for _, mode := range [][]string{nil, {"--all"}} {
+		name := "offsets"
+		if mode != nil {
+			name = "all"
+		}
+		t.Run(name, func(t *testing.T) {
+			args := append([]string{"list", "--json", "--random-sample", "5", "--seed", "42"}, mode...)
+			output, err := executeCommand(t, args...)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			ids := sampleIDs(t, output)
+			if len(ids) != 5 {
+				t.Fatalf("Expected 5 bookmarks, got %d", len(ids))
+			}
+			seen := map[int]bool{}
+			for _, id := range ids {
+				if seen[id] {
+					t.Errorf("Duplicate bookmark %d in sample %v", id, ids)
+				}
+				seen[id] = true
+			}
+
+			// The same seed repeats the sample
+			output, err = executeCommand(t, args...)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if again := sampleIDs(t, output); !reflect.DeepEqual(ids, again) {
+				t.Errorf("Expected same sample for the same seed, got %v and %v", ids, again)
+			}
+		})
+	}
+
+	t.Run("larger than library", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--json", "--random-sample", "50", "--seed", "1")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if ids := sampleIDs(t, output); len(ids) != 20 {
+			t.Errorf("Expected all 20 bookmarks, got %d", len(ids))
+		}
+	})
+
+	t.Run("exclude tags", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--json", "--random-sample", "4", "--seed", "7", "--exclude-tags", "skip")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		ids := sampleIDs(t, output)
+		if len(ids) != 4 {
+			t.Fatalf("Expected 4 bookmarks, got %d", len(ids))
+		}
+		for _, id := range ids {
+			if id%2 == 0 {
+				t.Errorf("Expected excluded bookmark %d to be skipped", id)
+			}
+		}
+	})
+}
+
+func TestListRandomSampleValidation(t *testing.T) {
+	server := setupSampleServer(t, 3)
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := [][]string{
+		{"list", "--random-sample", "0"},
+		{"list", "--random-sample", "2", "--limit", "5"},
+		{"list", "--seed", "3"},
+		{"list", "--all", "--offset", "10"},
+	}
+	for _, args := range tests {
+		if _, err := executeCommand(t, args...); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
+
+// ================= IMPORT WINDOW TESTS =================
+
+func TestImportLimitOffsetValidation(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "import.json")
+	if err := os.WriteFile(file, []byte(`{"bookmarks":[{"url":"https://a.com"},{"url":"https://b.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"import", file, "--limit", "-1"},
+		{"import", file, "--offset", "-2"},
+		{"restore", file, "--offset", "-1"},
+		{"restore", file, "--wipe", "--limit", "1"},
+		{"import", file, "--csv-dialect", "excel"},
+	} {
+		if _, err := executeCommand(t, args...); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+
+	output, err := executeCommand(t, "import", file, "--dry-run", "--offset", "1", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if result["added"] != float64(1) {
+		t.Errorf("Expected 1 added with --offset 1, got %v", result["added"])
+	}
+}
+
+func TestImportStopOnError(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "import.json")
+	if err := os.WriteFile(file, []byte(`{"bookmarks":[{"url":"https://a.com"},{"url":"https://b.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(t, "import", file, "--stop-on-error")
+	if err == nil || !strings.Contains(err.Error(), "import stopped at line 1") {
+		t.Fatalf("Expected import to stop at line 1, got: %v", err)
+	}
+	if !strings.Contains(output, "1 failed") {
+		t.Errorf("Expected the partial result to be shown, got: %s", output)
+	}
+
+	if _, err := executeCommand(t, "import", file, "--stop-on-error", "--continue-on-error"); err == nil {
+		t.Error("Expected error combining --stop-on-error and --continue-on-error")
+	}
+}
+
+// ================= URL SCHEME TESTS =================
+
+func TestAddSchemeAllowlist(t *testing.T) {
+	posts := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "ftp://ftp.example.com/pub", "FTP", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	for _, url := range []string{"javascript:alert(1)", "file:///etc/passwd", "ftp://ftp.example.com/pub"} {
+		_, err := executeCommand(t, "add", url)
+		if err == nil || !strings.Contains(err.Error(), "--allow-scheme") {
+			t.Errorf("Expected %s to be rejected with a hint, got: %v", url, err)
+		}
+	}
+	if posts != 0 {
+		t.Errorf("Expected no requests for rejected URLs, got %d", posts)
+	}
+
+	if _, err := executeCommand(t, "add", "ftp://ftp.example.com/pub", "--allow-scheme", "ftp"); err != nil {
+		t.Fatalf("Expected ftp to be accepted with --allow-scheme: %v", err)
+	}
+	if posts != 1 {
+		t.Errorf("Expected 1 create request, got %d", posts)
+	}
+}
+
+// ================= BATCH TESTS =================
+
+func TestImportBatchSizeAndResume(t *testing.T) {
+	var created []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		created = append(created, body.URL)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(len(created), body.URL, "", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "import.json")
+	content := `{"bookmarks":[{"url":"https://e.com/0"},{"url":"https://e.com/1"},{"url":"https://e.com/2"},{"url":"https://e.com/3"},{"url":"https://e.com/4"}]}`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(t, "import", file, "--batch-size", "2", "--batch-pause", "0s", "--resume-from", "1")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if strings.Join(created, " ") != "https://e.com/1 https://e.com/2 https://e.com/3 https://e.com/4" {
+		t.Errorf("Expected entries 1-4 to be imported, got %v", created)
+	}
+	for _, want := range []string{"Batch starting at entry 1", "Batch starting at entry 3", "--resume-from 3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"import", file, "--batch-size", "-1"},
+		{"import", file, "--resume-from", "-1"},
+		{"import", file, "--resume-from", "1", "--offset", "1"},
+		{"tags", "rename", "a", "b", "--batch-size", "-2"},
+	} {
+		if _, err := executeCommand(t, args...); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
+
+func TestTagsRenameBatches(t *testing.T) {
+	updates := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			var bookmarks []models.Bookmark
+			for i := 1; i <= 5; i++ {
+				bookmarks = append(bookmarks, mockBookmark(i, fmt.Sprintf("https://e.com/%d", i), "", []string{"old"}))
+			}
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 5, Results: bookmarks})
+			return
+		}
+		updates++
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://e.com/1", "", []string{"new"}))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "tags", "rename", "old", "new", "--force", "--batch-size", "2", "--batch-pause", "0s")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if updates != 5 {
+		t.Errorf("Expected 5 updates, got %d", updates)
+	}
+	if strings.Count(output, "Batch starting at item") != 3 {
+		t.Errorf("Expected 3 batches of at most 2, got: %s", output)
+	}
+}
+
+// ================= CHECKPOINT TESTS =================
+
+func TestImportResumeFromCheckpoint(t *testing.T) {
+	var created []string
+	failing := true
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if failing && strings.HasSuffix(body.URL, "/2") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		created = append(created, body.URL)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(len(created), body.URL, "", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "import.json")
+	content := `{"bookmarks":[{"url":"https://e.com/0"},{"url":"https://e.com/1"},{"url":"https://e.com/2"},{"url":"https://e.com/3"}]}`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	checkpoints := func() []string {
+		matches, _ := filepath.Glob(filepath.Join(cache, "linkdingctl", "checkpoints", "*"))
+		return matches
+	}
+
+	// Without --resume or --checkpoint nothing is recorded, and a rerun works
+	if _, err := executeCommand(t, "import", file); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if _, err := os.Stat(file + ".checkpoint"); !os.IsNotExist(err) {
+		t.Errorf("Expected no checkpoint beside the file, got %v", err)
+	}
+	if got := checkpoints(); len(got) != 0 {
+		t.Errorf("Expected no checkpoint without --resume, got %v", got)
+	}
+	created = nil
+
+	// First --resume run: entry 2 fails, so the checkpoint is kept in the cache
+	output, err := executeCommand(t, "import", file, "--resume")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "rerun with --resume") {
+		t.Errorf("Expected a hint to resume, got: %s", output)
+	}
+	if got := checkpoints(); len(got) != 1 || !strings.HasPrefix(filepath.Base(got[0]), "import.json-") {
+		t.Fatalf("Expected one checkpoint in the cache directory, got %v", got)
+	}
+
+	// Resumed run retries only the failed entry
+	failing = false
+	output, err = executeCommand(t, "import", file, "--resume")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if strings.Join(created, " ") != "https://e.com/0 https://e.com/1 https://e.com/3 https://e.com/2" {
+		t.Errorf("Expected only entry 2 to be retried, got %v", created)
+	}
+	if !strings.Contains(output, "3 already processed") {
+		t.Errorf("Expected resumed count in output, got: %s", output)
+	}
+	if got := checkpoints(); len(got) != 0 {
+		t.Errorf("Expected checkpoint to be deleted after a clean run, got %v", got)
+	}
+
+	// A different file cannot resume another file's checkpoint
+	other := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(other, []byte(`{"bookmarks":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint := filepath.Join(dir, "import.checkpoint")
+	if err := os.WriteFile(checkpoint, []byte(`{"source":"import.json","sha256":"abc"}`+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeCommand(t, "import", other, "--resume", "--checkpoint", checkpoint); err == nil || !strings.Contains(err.Error(), "different file") {
+		t.Errorf("Expected a wrong-file error, got %v", err)
+	}
+}
+
+func TestRestoreResumeRejectsWipe(t *testing.T) {
+	setTestEnv(t, "https://linkding.example.com", "test-token")
+	file := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(file, []byte(`{"bookmarks":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeCommand(t, "restore", file, "--wipe", "--resume"); err == nil {
+		t.Error("Expected --wipe with --resume to fail")
+	}
+}
+
+// ================= GET FIELDS TESTS =================
+
+func TestGetFields(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example Site", []string{"a", "b"}))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("json keeps only requested keys in order", func(t *testing.T) {
+		output, err := executeCommand(t, "get", "1", "--fields", "url,tags", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &got); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		if len(got) != 2 || got["url"] != "https://example.com" || got["tag_names"] == nil {
+			t.Errorf("Expected only url and tag_names, got %v", got)
+		}
+		if strings.Index(output, `"url"`) > strings.Index(output, `"tag_names"`) {
+			t.Errorf("Expected keys in requested order, got: %s", output)
+		}
+	})
+
+	t.Run("human prints only requested fields", func(t *testing.T) {
+		output, err := executeCommand(t, "get", "1", "--fields", "title,archived")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Title:    Example Site") || !strings.Contains(output, "Archived: false") {
+			t.Errorf("Expected title and archived lines, got: %s", output)
+		}
+		if strings.Contains(output, "URL:") || strings.Contains(output, "Tags:") {
+			t.Errorf("Expected other fields to be omitted, got: %s", output)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := executeCommand(t, "get", "1", "--fields", "url,colour")
+		if err == nil || !strings.Contains(err.Error(), `unknown field "colour"`) {
+			t.Errorf("Expected unknown field error, got %v", err)
+		}
+	})
+}
+
+// TestBookmarkFieldsMatchModel keeps --fields in sync with the JSON keys of
+// models.Bookmark.
+func TestBookmarkFieldsMatchModel(t *testing.T) {
+	var keys []string
+	typ := reflect.TypeOf(models.Bookmark{})
+	for i := 0; i < typ.NumField(); i++ {
+		if tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+			keys = append(keys, tag)
+		}
+	}
+	for _, key := range keys {
+		if _, err := parseFields([]string{key}); err != nil {
+			t.Errorf("Bookmark field %q is not selectable: %v", key, err)
+		}
+	}
+	if len(bookmarkFields) != len(keys) {
+		t.Errorf("Expected %d selectable fields, got %d", len(keys), len(bookmarkFields))
+	}
+}
+
+// ================= TIMEZONE TESTS =================
+
+func TestTimezoneFlag(t *testing.T) {
+	added := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		b := mockBookmark(1, "https://example.com", "Example", nil)
+		b.DateAdded, b.DateModified = added, added
+		if r.URL.Path == "/api/bookmarks/" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{b}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(b)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{"Asia/Tokyo", "2026-03-02 08:30:00"},
+		{"America/New_York", "2026-03-01 18:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			output, err := executeCommand(t, "get", "1", "--timezone", tt.zone)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if !strings.Contains(output, "Added:       "+tt.want) {
+				t.Errorf("Expected added date %s, got: %s", tt.want, output)
+			}
+
+			output, err = executeCommand(t, "list", "--added", "--absolute-dates", "--timezone", tt.zone)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("Expected list date %s, got: %s", tt.want, output)
+			}
+		})
+	}
+
+	t.Run("json is unchanged", func(t *testing.T) {
+		output, err := executeCommand(t, "get", "1", "--json", "--timezone", "Asia/Tokyo")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, `"date_added": "2026-03-01T23:30:00Z"`) {
+			t.Errorf("Expected UTC timestamp in JSON, got: %s", output)
+		}
+	})
+
+	t.Run("invalid zone", func(t *testing.T) {
+		if _, err := executeCommand(t, "get", "1", "--timezone", "Mars/Olympus"); err == nil {
+			t.Error("Expected an error for an unknown time zone")
+		}
+	})
+}
+
+// ================= MIGRATE TESTS =================
+
+func TestMigrateCommand(t *testing.T) {
+	source := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "GET" {
+			t.Errorf("Unexpected write to the source: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{
+			mockBookmark(1, "https://one.example.com", "One", []string{"a"}),
+			mockBookmark(2, "https://two.example.com", "Two", nil),
+		}})
+	})
+
+	var created []string
+	dest := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		created = append(created, body.URL)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(10, body.URL, body.Title, body.TagNames))
+	})
+	setTestEnv(t, dest.URL, "dest-token")
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := executeCommand(t, "migrate", "--from-url", source.URL, "--from-token", "src", "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(created) != 0 {
+			t.Errorf("Expected no writes under --dry-run, got %v", created)
+		}
+		if !strings.Contains(output, "2 new bookmarks added") {
+			t.Errorf("Expected dry run counts, got: %s", output)
+		}
+	})
+
+	t.Run("to current config", func(t *testing.T) {
+		output, err := executeCommand(t, "migrate", "--from-url", source.URL, "--from-token", "src", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		if result["added"] != float64(2) || len(created) != 2 {
+			t.Errorf("Expected 2 bookmarks created, got %v and %v", result, created)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		output, err := executeCommand(t, "migrate", "--from-url", source.URL, "--from-token", "src", "--dry-run", "-O", "yaml")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "added: 2") || strings.Contains(output, "Migrating") {
+			t.Errorf("Expected YAML result only, got: %s", output)
+		}
+	})
+
+	t.Run("from config file", func(t *testing.T) {
+		cfgPath := filepath.Join(t.TempDir(), "old.yaml")
+		if err := os.WriteFile(cfgPath, []byte("url: "+source.URL+"\ntoken: src\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		// LINKDING_URL points at the destination; the source file must win
+		if _, err := executeCommand(t, "migrate", "--from-config", cfgPath, "--skip-duplicates"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"migrate"},
+			{"migrate", "--from-url", dest.URL, "--from-token", "x"},
+			{"migrate", "--from-url", source.URL, "--from-token", "x", "--concurrency", "0"},
+			{"migrate", "--from-url", source.URL, "--from-token", "x", "--concurrency", "17"},
+		} {
+			if _, err := executeCommand(t, args...); err == nil {
+				t.Errorf("Expected error for %v", args)
+			}
+		}
+	})
+}
+
+func TestMigrateProfiles(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string][]string{}
+	instance := func(name string) *httptest.Server {
+		return setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			seen[name] = append(seen[name], r.Method+" "+r.Header.Get("Authorization"))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == "GET" {
+				var results []models.Bookmark
+				if name == "personal" {
+					results = []models.Bookmark{mockBookmark(1, "https://one.example.com", "One", nil)}
+				}
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(10, "https://one.example.com", "One", nil))
+		})
+	}
+	personal, work, env := instance("personal"), instance("work"), instance("env")
+	// Named profiles ignore LINKDING_URL/LINKDING_TOKEN
+	setTestEnv(t, env.URL, "env-token")
+
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "url: " + env.URL + "\ntoken: default-token\nprofiles:\n" +
+		"  personal:\n    url: " + personal.URL + "\n    token: personal-token\n" +
+		"  work:\n    url: " + work.URL + "\n    token: work-token\n"
+	if err := os.WriteFile(cfgPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(t, "migrate", "--config", cfgPath, "--from-profile", "personal", "--to-profile", "work", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, `"added": 1`) {
+		t.Errorf("Expected 1 bookmark added, got: %s", output)
+	}
+	for _, r := range seen["personal"] {
+		if r != "GET Token personal-token" {
+			t.Errorf("Expected only reads with the personal token on the source, got %q", r)
+		}
+	}
+	if !slices.Contains(seen["work"], "POST Token work-token") {
+		t.Errorf("Expected a create with the work token on the destination, got %v", seen["work"])
+	}
+	if len(seen["env"]) > 0 {
+		t.Errorf("Expected no requests to the LINKDING_URL instance, got %v", seen["env"])
+	}
+
+	_, err = executeCommand(t, "migrate", "--config", cfgPath, "--from-profile", "missing", "--to-profile", "work")
+	if err == nil || !strings.Contains(err.Error(), "source configuration error: profile 'missing' not found") {
+		t.Errorf("Expected a missing profile error, got %v", err)
+	}
+}
+
+// ================= TAG COOCCURRENCE TESTS =================
+
+func TestTagCooccurrence(t *testing.T) {
+	bookmarks := []models.Bookmark{
+		{TagNames: []string{"go", "golang", "dev"}},
+		{TagNames: []string{"golang", "go"}},
+		{TagNames: []string{"go", "go", "golang"}},
+		{TagNames: []string{"go", "dev"}},
+		{TagNames: []string{"news"}},
+	}
+
+	pairs := tagCooccurrence(bookmarks, 1)
+	want := []tagPair{
+		{TagA: "go", TagB: "golang", Count: 3, CountA: 4, CountB: 3, Overlap: 1},
+		{TagA: "dev", TagB: "go", Count: 2, CountA: 2, CountB: 4, Overlap: 1},
+		{TagA: "dev", TagB: "golang", Count: 1, CountA: 2, CountB: 3, Overlap: 0.5},
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("tagCooccurrence() =\n%+v\nwant\n%+v", pairs, want)
+	}
+
+	if pairs := tagCooccurrence(bookmarks, 3); len(pairs) != 1 {
+		t.Errorf("Expected one pair with --min-count 3, got %+v", pairs)
+	}
+}
+
+func TestTagsCooccurrenceCommand(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 3, Results: []models.Bookmark{
+			mockBookmark(1, "https://a.example.com", "A", []string{"k8s", "kubernetes", "cloud"}),
+			mockBookmark(2, "https://b.example.com", "B", []string{"k8s", "kubernetes"}),
+			mockBookmark(3, "https://c.example.com", "C", []string{"cloud", "k8s"}),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "tags", "cooccurrence", "--top", "1")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "k8s") || !strings.Contains(output, "100%") || !strings.Contains(output, "Showing top 1 of 2 pairs") {
+		t.Errorf("Unexpected output: %s", output)
+	}
+
+	output, err = executeCommand(t, "tags", "cooccurrence", "--min-count", "1", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var pairs []tagPair
+	if err := json.Unmarshal([]byte(output), &pairs); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, output)
+	}
+	if len(pairs) != 3 {
+		t.Errorf("Expected 3 pairs, got %+v", pairs)
+	}
+
+	if _, err := executeCommand(t, "tags", "cooccurrence", "--min-count", "0"); err == nil {
+		t.Error("Expected error for --min-count 0")
+	}
+}
+
+// ================= OUTPUT DIRECTORY TESTS =================
+
+func TestOutputDirMkdir(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+			mockBookmark(1, "https://example.com", "Example", nil),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	base := t.TempDir()
+
+	t.Run("backup without --mkdir fails", func(t *testing.T) {
+		_, err := executeCommand(t, "backup", "-o", filepath.Join(base, "missing"))
+		if err == nil || !strings.Contains(err.Error(), "--mkdir") {
+			t.Errorf("Expected a missing directory error suggesting --mkdir, got %v", err)
+		}
+	})
+
+	t.Run("backup with --mkdir creates the directory", func(t *testing.T) {
+		dir := filepath.Join(base, "backups", "nested")
+		if _, err := executeCommand(t, "backup", "-o", dir, "--mkdir"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			t.Fatalf("Expected directory to be created: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0700 {
+			t.Errorf("Expected mode 0700, got %o", perm)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Errorf("Expected one backup file, got %d", len(entries))
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		file := filepath.Join(base, "exports", "bookmarks.json")
+		if _, err := executeCommand(t, "export", "-o", file); err == nil {
+			t.Error("Expected export into a missing directory to fail")
+		}
+		if _, err := executeCommand(t, "export", "-o", file, "--mkdir"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("Expected export file to be written: %v", err)
+		}
+	})
+
+	t.Run("output path is a file", func(t *testing.T) {
+		file := filepath.Join(base, "plain-file")
+		if err := os.WriteFile(file, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := executeCommand(t, "backup", "-o", file); err == nil {
+			t.Error("Expected an error when --output is a file")
+		}
+	})
+}
+
+// ================= MARKDOWN LINK TESTS =================
+
+func TestMarkdownLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		bookmark models.Bookmark
+		want     string
+	}{
+		{"plain", models.Bookmark{Title: "Example", URL: "https://example.com"}, "[Example](https://example.com)"},
+		{"brackets in title", models.Bookmark{Title: `Go [beta] \ notes`, URL: "https://go.dev"}, `[Go \[beta\] \\ notes](https://go.dev)`},
+		{"parens and spaces in URL", models.Bookmark{Title: "Wiki", URL: "https://en.wikipedia.org/wiki/Go_(language) x"}, `[Wiki](https://en.wikipedia.org/wiki/Go_\(language\)%20x)`},
+		{"website title fallback", models.Bookmark{WebsiteTitle: "Site", URL: "https://example.com"}, "[Site](https://example.com)"},
+		{"url fallback", models.Bookmark{URL: "https://example.com"}, "[https://example.com](https://example.com)"},
+		{"newlines collapsed", models.Bookmark{Title: "Two\nlines", URL: "https://example.com"}, "[Two lines](https://example.com)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownLink(&tt.bookmark); got != tt.want {
+				t.Errorf("markdownLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownLinkFlag(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bookmarks/1/" {
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example (docs)", nil))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{
+			Count: 2,
+			Results: []models.Bookmark{
+				mockBookmark(1, "https://example.com", "Example (docs)", nil),
+				mockBookmark(2, "https://example.org", "[Draft] Org", nil),
+			},
+		})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("get", func(t *testing.T) {
+		output, err := executeCommand(t, "get", "1", "--markdown-link")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if output != "[Example (docs)](https://example.com)\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+
+	t.Run("list prints one link per row", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--markdown-link")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		want := "[Example (docs)](https://example.com)\n[\\[Draft\\] Org](https://example.org)\n"
+		if output != want {
+			t.Errorf("Expected %q, got %q", want, output)
+		}
+	})
+
+	t.Run("rejects json", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--markdown-link", "--json")
+		if err == nil || !strings.Contains(err.Error(), "--markdown-link cannot be combined") {
+			t.Errorf("Expected combination error, got %v", err)
+		}
+	})
+}
+
+// ================= TRANSPORT FLAG TESTS =================
+
+func TestTransportFlags(t *testing.T) {
+	var protos []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		protos = append(protos, r.Proto)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	if _, err := executeCommand(t, "get", "1", "--http2=false", "--max-idle-conns", "8", "--idle-timeout", "30s"); err != nil {
+		t.Fatalf("Expected transport flags to be accepted, got: %v", err)
+	}
+	if transportOptions != (api.TransportOptions{DisableHTTP2: true, MaxIdleConns: 8, IdleConnTimeout: 30 * time.Second}) {
+		t.Errorf("Unexpected transport options: %+v", transportOptions)
+	}
+	if len(protos) != 1 || protos[0] != "HTTP/1.1" {
+		t.Errorf("Expected one HTTP/1.1 request, got %v", protos)
+	}
+
+	_, err := executeCommand(t, "get", "1", "--max-idle-conns", "-1")
+	if err == nil || !strings.Contains(err.Error(), "--max-idle-conns") {
+		t.Errorf("Expected error for negative --max-idle-conns, got: %v", err)
+	}
+	_, err = executeCommand(t, "get", "1", "--idle-timeout", "-5s")
+	if err == nil || !strings.Contains(err.Error(), "--idle-timeout") {
+		t.Errorf("Expected error for negative --idle-timeout, got: %v", err)
+	}
+}
+
+// ================= INCLUDE BUNDLES TESTS =================
+
+func TestBackupIncludeBundlesAndRestore(t *testing.T) {
+	var createdBundles []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/bundles/"):
+			_ = json.NewEncoder(w).Encode(models.BundleList{Count: 1, Results: []models.Bundle{{ID: 1, Name: "Reading", AnyTags: "books"}}})
+		case r.Method == "GET":
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+				mockBookmark(1, "https://example.com", "Example", nil),
+			}})
+		case r.URL.Path == "/api/bundles/":
+			var body models.BundleCreate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			createdBundles = append(createdBundles, body.Name)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 2, Name: body.Name})
+		default:
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	if _, err := executeCommand(t, "backup", "-o", dir, "--include-bundles"); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected one backup file, got %v %v", entries, err)
+	}
+	file := filepath.Join(dir, entries[0].Name())
+	content, _ := os.ReadFile(file)
+	if !strings.Contains(string(content), `"version": "2"`) || !strings.Contains(string(content), `"Reading"`) {
+		t.Errorf("Expected a version 2 backup with bundles, got %s", content)
+	}
+
+	// The server already has "Reading", so restore leaves it alone
+	output, err := executeCommand(t, "restore", file)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if len(createdBundles) != 0 || !strings.Contains(output, "1 bundles already existed") {
+		t.Errorf("Expected the existing bundle to be skipped, created %v, output: %s", createdBundles, output)
+	}
+
+	t.Run("rejects non-json export", func(t *testing.T) {
+		_, err := executeCommand(t, "export", "-f", "csv", "--include-bundles")
+		if err == nil || !strings.Contains(err.Error(), "only supported for the json format") {
+			t.Errorf("Expected format error, got %v", err)
+		}
+	})
+}
+
+func TestBackupGzipAndRestore(t *testing.T) {
+	var writes []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+				mockBookmark(1, "https://example.com", "Example", []string{"test"}),
+			}})
+			return
+		}
+		writes = append(writes, r.Method+" "+r.URL.Path)
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", []string{"test"}))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	output, err := executeCommand(t, "backup", "-o", dir, "--prefix", "nightly", "--gzip", "--json")
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	file, _ := result["file"].(string)
+	if filepath.Dir(file) != dir || !strings.HasPrefix(filepath.Base(file), "nightly-") || !strings.HasSuffix(file, ".json.gz") {
+		t.Fatalf("Expected the compressed file path, got %q", file)
+	}
+
+	compressed, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("Expected the backup file to exist: %v", err)
+	}
+	defer func() { _ = compressed.Close() }()
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		t.Fatalf("Expected a gzip file: %v", err)
+	}
+	var data export.ExportData
+	if err := json.NewDecoder(gz).Decode(&data); err != nil || len(data.Bookmarks) != 1 {
+		t.Fatalf("Expected one bookmark in the decompressed backup, got %+v, err=%v", data, err)
+	}
+
+	output, err = executeCommand(t, "restore", file)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !strings.Contains(output, "1 existing bookmarks updated") || len(writes) != 1 {
+		t.Errorf("Expected the bookmark to be restored, writes %v, output: %s", writes, output)
+	}
+}
+
+func TestBackupSince(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 12, 0, 0, 0, time.UTC) }
+	old := mockBookmark(1, "https://old.example.com", "Old", nil)
+	old.DateModified = day(1)
+	recent := mockBookmark(2, "https://recent.example.com", "Recent", nil)
+	recent.DateModified = day(10)
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{old, recent}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	readBackup := func(t *testing.T, dir string) (string, export.ExportData) {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("Expected one backup file, got %v %v", entries, err)
+		}
+		path := filepath.Join(dir, entries[0].Name())
+		content, _ := os.ReadFile(path)
+		var data export.ExportData
+		if err := json.Unmarshal(content, &data); err != nil {
+			t.Fatalf("Expected a JSON backup: %v", err)
+		}
+		return path, data
+	}
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := executeCommand(t, "backup", "-o", dir, "--since", "2026-01-05")
+		if err == nil || !strings.Contains(err.Error(), `invalid --since "2026-01-05"`) {
+			t.Errorf("Expected a timestamp error, got %v", err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("Expected no backup file, got %v", entries)
+		}
+	})
+
+	t.Run("filters by date modified", func(t *testing.T) {
+		dir := t.TempDir()
+		output, err := executeCommand(t, "backup", "-o", dir, "--since", "2026-01-05T00:00:00Z")
+		if err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+		if !strings.Contains(output, "Included 1 bookmarks modified since 2026-01-05T00:00:00Z") {
+			t.Errorf("Expected an included count, got: %s", output)
+		}
+		path, data := readBackup(t, dir)
+		if len(data.Bookmarks) != 1 || data.Bookmarks[0].URL != "https://recent.example.com" {
+			t.Errorf("Expected only the recent bookmark, got %+v", data.Bookmarks)
+		}
+		if data.IncrementalSince == nil || !data.IncrementalSince.Equal(day(5).Add(-12*time.Hour)) {
+			t.Errorf("Expected the incremental marker, got %v", data.IncrementalSince)
+		}
+
+		_, err = executeCommand(t, "restore", path, "--wipe")
+		if err == nil || !strings.Contains(err.Error(), "--wipe cannot be used with an incremental backup") {
+			t.Errorf("Expected restore --wipe to refuse an incremental backup, got %v", err)
+		}
+	})
+
+	t.Run("since file", func(t *testing.T) {
+		sinceFile := filepath.Join(t.TempDir(), "last-backup")
+
+		// No file yet: a full backup, then the start time is recorded
+		dir := t.TempDir()
+		if _, err := executeCommand(t, "backup", "-o", dir, "--since-file", sinceFile); err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+		if _, data := readBackup(t, dir); len(data.Bookmarks) != 2 || data.IncrementalSince != nil {
+			t.Errorf("Expected a full backup, got %d bookmarks, marker %v", len(data.Bookmarks), data.IncrementalSince)
+		}
+		content, err := os.ReadFile(sinceFile)
+		if err != nil {
+			t.Fatalf("Expected the since file to be written: %v", err)
+		}
+		if _, err := time.Parse(time.RFC3339, strings.TrimSpace(string(content))); err != nil {
+			t.Errorf("Expected an RFC 3339 time in the since file, got %q", content)
+		}
+
+		if err := os.WriteFile(sinceFile, []byte("2026-01-05T00:00:00Z\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		dir = t.TempDir()
+		if _, err := executeCommand(t, "backup", "-o", dir, "--since-file", sinceFile); err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+		if _, data := readBackup(t, dir); len(data.Bookmarks) != 1 {
+			t.Errorf("Expected an incremental backup, got %d bookmarks", len(data.Bookmarks))
+		}
+
+		if err := os.WriteFile(sinceFile, []byte("yesterday"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err = executeCommand(t, "backup", "-o", t.TempDir(), "--since-file", sinceFile)
+		if err == nil || !strings.Contains(err.Error(), "invalid time in "+sinceFile) {
+			t.Errorf("Expected a timestamp error, got %v", err)
+		}
+	})
+}
+
+// ================= SUMMARY ONLY TESTS =================
+
+func TestImportSummaryOnly(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "import.json")
+	if err := os.WriteFile(file, []byte(`{"bookmarks":[{"url":"https://a.com"},{"title":"no url"},{"url":"ftp://b.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"added":1,"updated":0,"skipped":0,"failed":2}` + "\n"
+	for _, args := range [][]string{
+		{"import", file, "--dry-run", "--summary-only"},
+		{"import", file, "--dry-run", "--summary-only", "--json"},
+		{"restore", file, "--dry-run", "--summary-only"},
+	} {
+		output, err := executeCommand(t, args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		if output != want {
+			t.Errorf("%v: expected %q, got %q", args, want, output)
+		}
+	}
+
+	// The full JSON output still lists the errors
+	output, err := executeCommand(t, "import", file, "--dry-run", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, `"errors"`) {
+		t.Errorf("Expected errors in the default JSON output, got %s", output)
+	}
+
+	// Failures that end the import still exit non-zero
+	if _, err := executeCommand(t, "import", file, "--dry-run", "--summary-only", "--stop-on-error"); err == nil {
+		t.Error("Expected --stop-on-error to fail with --summary-only")
+	}
+}
+
+func TestImportFailOnError(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"detail":"forbidden"}`))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "import.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"bookmarks":[{"url":"https://a.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	csvFile := filepath.Join(dir, "import.csv")
+	if err := os.WriteFile(csvFile, []byte("url\nhttps://a.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"import", jsonFile, "--retry-failed", "0"},
+		{"import", jsonFile, "--retry-failed", "0", "--json"},
+		{"import", jsonFile, "--retry-failed", "0", "--summary-only"},
+		{"import", csvFile, "--retry-failed", "0"},
+		{"restore", jsonFile},
+		{"restore", jsonFile, "--summary-only"},
+	} {
+		// Failed entries are only reported by default
+		if _, err := executeCommand(t, args...); err != nil {
+			t.Errorf("%v: expected no error without --fail-on-error, got %v", args, err)
+		}
+
+		output, err := executeCommand(t, append(args, "--fail-on-error")...)
+		if err == nil || !strings.Contains(err.Error(), "1 entries failed") {
+			t.Errorf("%v: expected --fail-on-error to exit non-zero, got %v", args, err)
+		}
+		if slices.Contains(args, "--summary-only") {
+			if want := `{"added":0,"updated":0,"skipped":0,"failed":1}` + "\n"; output != want {
+				t.Errorf("%v: expected %q, got %q", args, want, output)
+			}
+		}
+	}
+}
+
+// ================= IMPORT CONCURRENCY TESTS =================
+
+func TestImportConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var created []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		created = append(created, body.URL)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, body.URL, "", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "import.json")
+	if err := os.WriteFile(file, []byte(`{"bookmarks":[{"url":"https://a.com"},{"url":"https://b.com"},{"url":"https://c.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(t, "import", file, "--concurrency", "3", "--summary-only")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, `"added":3`) || len(created) != 3 {
+		t.Errorf("Expected 3 bookmarks added, got %v: %s", created, output)
+	}
+
+	for _, value := range []string{"0", "17"} {
+		_, err := executeCommand(t, "import", file, "--concurrency", value)
+		if err == nil || !strings.Contains(err.Error(), "--concurrency must be between 1 and 16") {
+			t.Errorf("Expected range error for --concurrency %s, got %v", value, err)
+		}
+	}
+}
+
+// ================= RETRY BACKOFF TESTS =================
+
+func TestRetryWaitFlag(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	if _, err := executeCommand(t, "get", "1", "--retries", "4", "--retry-wait", "250ms"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if retryPolicy.MaxRetries != 4 || retryPolicy.Wait != 250*time.Millisecond || !retryPolicy.Jitter || retryPolicy.Deadline != retryDeadline {
+		t.Errorf("Unexpected retry policy: %+v", retryPolicy)
+	}
+
+	_, err := executeCommand(t, "get", "1", "--retry-wait", "-1s")
+	if err == nil || !strings.Contains(err.Error(), "--retry-wait") {
+		t.Errorf("Expected error for negative --retry-wait, got %v", err)
+	}
+
+	if flag := rootCmd.PersistentFlags().Lookup("retries"); flag.DefValue != strconv.Itoa(defaultRetries) {
+		t.Errorf("Expected --retries to default to %d, got %s", defaultRetries, flag.DefValue)
+	}
+}
+
+// ================= TIMEOUT TESTS =================
+
+func TestTimeoutFlag(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	_, err := executeCommand(t, "get", "1", "--timeout", "50ms", "--retries", "0")
+	if err == nil || !strings.Contains(err.Error(), "cannot connect") {
+		t.Errorf("Expected a connection error after the timeout, got: %v", err)
+	}
+
+	_, err = executeCommand(t, "get", "1", "--timeout", "0s")
+	if err == nil || !strings.Contains(err.Error(), "--timeout") {
+		t.Errorf("Expected error for zero --timeout, got: %v", err)
+	}
+
+	if flag := rootCmd.PersistentFlags().Lookup("timeout"); flag.DefValue != api.DefaultTimeout.String() {
+		t.Errorf("Expected --timeout to default to %s, got %s", api.DefaultTimeout, flag.DefValue)
+	}
+}
+
+// ================= SEARCH TESTS =================
+
+func TestSearchCommand(t *testing.T) {
+	older := mockBookmark(1, "https://github.com/golang/go", "Go repository", []string{"go"})
+	older.DateAdded = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	older.Description = "The Go programming language"
+	newer := mockBookmark(2, "https://go.dev/blog/generics", "Generics in Go", []string{"go"})
+	newer.DateAdded = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	newer.Description = "An introduction to GENERICS"
+	other := mockBookmark(3, "https://example.com", "Example", nil)
+	other.DateAdded = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var queries []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 3, Results: []models.Bookmark{older, newer, other}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		name string
+		args []string
+		want []int
+	}{
+		{"no filters", nil, []int{1, 2, 3}},
+		{"added before", []string{"--added-before", "2023-01-01", "--timezone", "UTC"}, []int{1}},
+		{"added after", []string{"--added-after", "2023-01-01T00:00:00Z"}, []int{2, 3}},
+		{"title regex", []string{"--title-regex", "(?i)^generics"}, []int{2}},
+		{"url regex", []string{"--url-regex", `github\.com/[^/]+/[^/]+$`}, []int{1}},
+		{"description contains", []string{"--description-contains", "generics"}, []int{2}},
+		{"combined", []string{"--url-regex", "go", "--added-after", "2023-06-01"}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := executeCommand(t, append([]string{"search", "--json"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			var list models.BookmarkList
+			if err := json.Unmarshal([]byte(output), &list); err != nil {
+				t.Fatalf("Failed to parse JSON: %v\n%s", err, output)
+			}
+			var ids []int
+			for _, b := range list.Results {
+				ids = append(ids, b.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) || list.Count != len(tt.want) {
+				t.Errorf("Expected IDs %v, got %v (count %d)", tt.want, ids, list.Count)
+			}
+		})
+	}
+
+	queries = nil
+	output, err := executeCommand(t, "search", "-q", "golang", "--title-regex", "repository")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != "golang" {
+		t.Errorf("Expected the query to be sent to the server, got %v", queries)
+	}
+	if !strings.Contains(output, "Go repository") || strings.Contains(output, "Generics in Go") {
+		t.Errorf("Expected only the matching bookmark in the table, got:\n%s", output)
+	}
+}
+
+func TestSearchCommandInvalidFilters(t *testing.T) {
+	requests := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--added-before", "01/02/2023"}, `invalid --added-before "01/02/2023"`},
+		{[]string{"--added-after", "2023-13-01"}, `invalid --added-after "2023-13-01"`},
+		{[]string{"--added-after", "2024-01-01", "--added-before", "2023-01-01"}, "--added-after must be earlier than --added-before"},
+		{[]string{"--title-regex", "(unclosed"}, "invalid --title-regex: error parsing regexp"},
+		{[]string{"--url-regex", "[a-"}, "invalid --url-regex"},
+	}
+	for _, tt := range tests {
+		_, err := executeCommand(t, append([]string{"search"}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("search %v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Expected invalid filters to fail before any request, got %d requests", requests)
+	}
+}
+
+// ================= BOOKMARKS DEDUPE TESTS =================
+
+func setupDedupeServer(t *testing.T) *[]string {
+	t.Helper()
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	newer := mockBookmark(1, "https://Example.com:443/docs/", "Newer copy", []string{"b", "c"})
+	newer.DateAdded = day(5)
+	oldest := mockBookmark(2, "https://example.com/docs", "Original", []string{"a", "b"})
+	oldest.DateAdded = day(1)
+	middle := mockBookmark(3, "https://example.com/docs/", "Middle copy", nil)
+	middle.DateAdded = day(3)
+	unique := mockBookmark(4, "https://example.com/other", "Unique", nil)
+	bookmarks := []models.Bookmark{newer, oldest, middle, unique}
+
+	writes := &[]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			results := bookmarks
+			if strings.Contains(r.URL.Path, "/archived/") {
+				results = []models.Bookmark{}
+			}
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+		case "PATCH":
+			var body models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*writes = append(*writes, fmt.Sprintf("PATCH %s %v", r.URL.Path, *body.TagNames))
+			_ = json.NewEncoder(w).Encode(oldest)
+		case "DELETE":
+			*writes = append(*writes, "DELETE "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return writes
+}
+
+func TestBookmarksDedupeList(t *testing.T) {
+	writes := setupDedupeServer(t)
+
+	output, err := executeCommand(t, "bookmarks", "dedupe", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result struct {
+		Groups []struct {
+			URL        string `json:"url"`
+			Keep       int    `json:"keep"`
+			Duplicates []int  `json:"duplicates"`
+		} `json:"groups"`
+		Duplicates int `json:"duplicates"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\n%s", err, output)
+	}
+	if len(result.Groups) != 1 || result.Duplicates != 2 {
+		t.Fatalf("Expected one group with two duplicates, got %+v", result)
+	}
+	g := result.Groups[0]
+	if g.URL != "https://example.com/docs" || g.Keep != 2 || fmt.Sprint(g.Duplicates) != "[3 1]" {
+		t.Errorf("Expected to keep the oldest (2) and list 3 then 1, got %+v", g)
+	}
+
+	output, err = executeCommand(t, "bookmarks", "dedupe")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "2 duplicate bookmark(s) in 1 group(s)") || !strings.Contains(output, "keep") {
+		t.Errorf("Expected a duplicate table, got:\n%s", output)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("Expected no writes without --delete, got %v", *writes)
+	}
+
+	_, err = executeCommand(t, "bookmarks", "dedupe", "--merge-tags")
+	if err == nil || !strings.Contains(err.Error(), "--merge-tags requires --delete") {
+		t.Errorf("Expected --merge-tags to require --delete, got %v", err)
+	}
+}
+
+func TestBookmarksDedupeDelete(t *testing.T) {
+	writes := setupDedupeServer(t)
+
+	output, err := executeCommand(t, "bookmarks", "dedupe", "--delete", "--merge-tags", "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	for _, want := range []string{"Would PATCH /api/bookmarks/2/", `["a","b","c"]`, "Would DELETE /api/bookmarks/3/", "Would DELETE /api/bookmarks/1/"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected dry run output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("Expected no writes under --dry-run, got %v", *writes)
+	}
+
+	output, err = executeCommand(t, "bookmarks", "dedupe", "--delete", "--merge-tags", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	want := []string{"PATCH /api/bookmarks/2/ [a b c]", "DELETE /api/bookmarks/3/", "DELETE /api/bookmarks/1/"}
+	if fmt.Sprint(*writes) != fmt.Sprint(want) {
+		t.Errorf("Expected writes %v, got %v", want, *writes)
+	}
+	if !strings.Contains(output, "2 duplicate bookmark(s) deleted") {
+		t.Errorf("Expected a deletion summary, got:\n%s", output)
+	}
+}
+
+// ================= TAGS MERGE COMMAND TESTS =================
+
+// setupTagsMergeServer serves bookmarks matching the word in the query by tag
+// or title, ignoring case as LinkDing does, and records each PATCH as
+// "<id> <tags>". Updates to failID return 500.
+func setupTagsMergeServer(t *testing.T, failID int) *[]string {
+	t.Helper()
+	bookmarks := []models.Bookmark{
+		mockBookmark(1, "https://example.com/1", "Test 1", []string{"js", "web"}),
+		mockBookmark(2, "https://example.com/2", "Test 2", []string{"java-script", "javascript", "js"}),
+		mockBookmark(3, "https://example.com/3", "Test 3", []string{"java-script"}),
+		mockBookmark(4, "https://example.com/4", "Learning JS", []string{"web"}),
+	}
+	patches := &[]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bookmarks/" && r.Method == "GET" {
+			word := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+			matches := []models.Bookmark{}
+			for _, b := range bookmarks {
+				if b.HasAnyTag([]string{word}) || slices.Contains(strings.Fields(strings.ToLower(b.Title)), word) {
+					matches = append(matches, b)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(matches), Results: matches})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/bookmarks/") && r.Method == "PATCH" {
+			var id int
+			_, _ = fmt.Sscanf(r.URL.Path, "/api/bookmarks/%d/", &id)
+			if id == failID {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var body models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*patches = append(*patches, fmt.Sprintf("%d %v", id, *body.TagNames))
+			_ = json.NewEncoder(w).Encode(mockBookmark(id, "https://example.com", "Updated", *body.TagNames))
+			return
+		}
+		http.NotFound(w, r)
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return patches
+}
+
+func TestTagsMergeWithForce(t *testing.T) {
+	patches := setupTagsMergeServer(t, 0)
+
+	output, err := executeCommand(t, "tags", "merge", "javascript", "js", "java-script", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	want := []string{"1 [javascript web]", "2 [javascript]", "3 [javascript]"}
+	if fmt.Sprint(*patches) != fmt.Sprint(want) {
+		t.Errorf("Expected updates %v, got %v", want, *patches)
+	}
+	for _, line := range []string{"Completed: 3 successful, 0 errors", "js → javascript: 2 bookmark(s) updated", "java-script → javascript: 2 bookmark(s) updated"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestTagsMergeIgnoresCase(t *testing.T) {
+	patches := setupTagsMergeServer(t, 0)
+
+	output, err := executeCommand(t, "tags", "merge", "JavaScript", "JS", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	// Bookmark 4 only mentions JS in its title and is left alone
+	want := []string{"1 [JavaScript web]", "2 [java-script JavaScript]"}
+	if fmt.Sprint(*patches) != fmt.Sprint(want) {
+		t.Errorf("Expected updates %v, got %v", want, *patches)
+	}
+	if !strings.Contains(output, "JS → JavaScript: 2 bookmark(s) updated") {
+		t.Errorf("Expected the per-source count, got:\n%s", output)
+	}
+
+	_, err = executeCommand(t, "tags", "merge", "js", "JS")
+	if err == nil || !strings.Contains(err.Error(), "same as the target") {
+		t.Errorf("Expected a source differing only in case to be the target, got: %v", err)
+	}
+}
+
+func TestTagsMergeDryRun(t *testing.T) {
+	patches := setupTagsMergeServer(t, 0)
+
+	output, err := executeCommand(t, "tags", "merge", "javascript", "js", "java-script", "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*patches) != 0 {
+		t.Errorf("Expected no updates under --dry-run, got %v", *patches)
+	}
+	if !strings.Contains(output, "Would PATCH /api/bookmarks/1/") || !strings.Contains(output, `"tag_names":["javascript","web"]`) {
+		t.Errorf("Expected planned rewrites, got:\n%s", output)
+	}
+}
+
+func TestTagsMergeConfirmationNo(t *testing.T) {
+	patches := setupTagsMergeServer(t, 0)
+
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdin = r
+
+	go func() {
+		_, _ = w.WriteString("n\n")
+		_ = w.Close()
+	}()
+
+	output, err := executeCommand(t, "tags", "merge", "javascript", "js")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Aborted") || len(*patches) != 0 {
+		t.Errorf("Expected the merge to be aborted, got %v and:\n%s", *patches, output)
+	}
+}
+
+func TestTagsMergeErrors(t *testing.T) {
+	patches := setupTagsMergeServer(t, 2)
+
+	output, err := executeCommand(t, "tags", "merge", "javascript", "js", "--force")
+	if err == nil || !strings.Contains(err.Error(), "some bookmarks failed to update") {
+		t.Errorf("Expected a partial failure error, got %v", err)
+	}
+	if len(*patches) != 1 || !strings.Contains(output, "js → javascript: 1 bookmark(s) updated") {
+		t.Errorf("Expected one successful update, got %v and:\n%s", *patches, output)
+	}
+
+	_, err = executeCommand(t, "tags", "merge", "javascript", "nonexistent", "--force")
+	if err == nil || !strings.Contains(err.Error(), "no bookmarks found") {
+		t.Errorf("Expected 'no bookmarks found' error, got: %v", err)
+	}
+
+	_, err = executeCommand(t, "tags", "merge", "javascript", "js", "javascript")
+	if err == nil || !strings.Contains(err.Error(), "same as the target") {
+		t.Errorf("Expected an error for a source equal to the target, got: %v", err)
+	}
+}
+
+// ================= LIST OUTPUT FILE TESTS =================
+
+func TestListOutputFile(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bookmarks := []models.Bookmark{mockBookmark(1, "https://example.com", "Example Site", []string{"test"})}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: bookmarks})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	dir := t.TempDir()
+
+	tablePath := filepath.Join(dir, "list.txt")
+	output, err := executeCommand(t, "list", "--output", tablePath)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Wrote 1 bookmarks to "+tablePath) || strings.Contains(output, "Example Site") {
+		t.Errorf("Expected only a confirmation on the terminal, got:\n%s", output)
+	}
+	content, err := os.ReadFile(tablePath)
+	if err != nil {
+		t.Fatalf("Expected the table file to exist: %v", err)
+	}
+	if !strings.Contains(string(content), "ID") || !strings.Contains(string(content), "Example Site") {
+		t.Errorf("Expected the table in the file, got:\n%s", content)
+	}
+
+	jsonPath := filepath.Join(dir, "list.json")
+	if _, err := executeCommand(t, "list", "--json", "--output", jsonPath); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	content, err = os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Expected the JSON file to exist: %v", err)
+	}
+	var list models.BookmarkList
+	if err := json.Unmarshal(content, &list); err != nil {
+		t.Fatalf("Expected BookmarkList JSON in the file: %v\n%s", err, content)
+	}
+	if list.Count != 1 || len(list.Results) != 1 || list.Results[0].Title != "Example Site" {
+		t.Errorf("Unexpected list in the file: %+v", list)
+	}
+
+	_, err = executeCommand(t, "list", "--output", filepath.Join(dir, "missing", "list.txt"))
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected an error for a missing output directory, got: %v", err)
+	}
+	if _, err := executeCommand(t, "list", "--mkdir", "--output", filepath.Join(dir, "missing", "list.txt")); err != nil {
+		t.Errorf("Expected --mkdir to create the directory, got: %v", err)
+	}
+}
+
+// ================= TOKEN FILE TESTS =================
+
+func TestTokenFile(t *testing.T) {
+	var gotAuth []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	})
+	setTestEnv(t, server.URL, "env-token")
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("  file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	t.Run("takes precedence over LINKDING_TOKEN", func(t *testing.T) {
+		gotAuth = nil
+		if _, err := executeCommand(t, "list", "--token-file", tokenPath); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(gotAuth) != 1 || gotAuth[0] != "Token file-token" {
+			t.Errorf("Expected the token from the file, got %v", gotAuth)
+		}
+	})
+
+	t.Run("--token @file", func(t *testing.T) {
+		gotAuth = nil
+		if _, err := executeCommand(t, "list", "--token", "@"+tokenPath); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(gotAuth) != 1 || gotAuth[0] != "Token file-token" {
+			t.Errorf("Expected the token from the file, got %v", gotAuth)
+		}
+	})
+
+	t.Run("--token - reads stdin", func(t *testing.T) {
+		oldStdin := os.Stdin
+		defer func() { os.Stdin = oldStdin }()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Failed to create pipe: %v", err)
+		}
+		os.Stdin = r
+		go func() {
+			_, _ = w.WriteString("stdin-token\n")
+			_ = w.Close()
+		}()
+
+		gotAuth = nil
+		if _, err := executeCommand(t, "list", "--token", "-"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(gotAuth) != 1 || gotAuth[0] != "Token stdin-token" {
+			t.Errorf("Expected the token from stdin, got %v", gotAuth)
+		}
+	})
+
+	t.Run("config show reports the source without the token", func(t *testing.T) {
+		output, err := executeCommand(t, "config", "show", "--token-file", tokenPath)
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "(--token-file)") {
+			t.Errorf("Expected '(--token-file)' in output, got: %s", output)
+		}
+		if strings.Contains(output, "file-token") {
+			t.Errorf("Expected the token to be redacted, got: %s", output)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--token-file", filepath.Join(t.TempDir(), "missing"))
+		if err == nil || !strings.Contains(err.Error(), "failed to read token file for --token-file") {
+			t.Errorf("Expected an error for a missing token file, got: %v", err)
+		}
+
+		emptyPath := filepath.Join(t.TempDir(), "empty")
+		_ = os.WriteFile(emptyPath, []byte("\n"), 0600)
+		_, err = executeCommand(t, "list", "--token", "@"+emptyPath)
+		if err == nil || !strings.Contains(err.Error(), "is empty") {
+			t.Errorf("Expected an error for an empty token file, got: %v", err)
+		}
+
+		_, err = executeCommand(t, "list", "--token", "abc", "--token-file", tokenPath)
+		if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+			t.Errorf("Expected an error for --token with --token-file, got: %v", err)
+		}
+	})
+}
+
+// ================= CONFIG PROFILE TESTS =================
+
+func TestConfigProfiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `url: https://default.example.com
+token: default-token
+profiles:
+  work:
+    url: https://work.example.com
+    token: work-token
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	show := func(t *testing.T, args ...string) map[string]interface{} {
+		t.Helper()
+		output, err := executeCommand(t, append([]string{"--config", configPath, "config", "show", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v", err)
+		}
+		return result
+	}
+
+	t.Run("use and show", func(t *testing.T) {
+		output, err := executeCommand(t, "--config", configPath, "config", "use", "work")
+		if err != nil || !strings.Contains(output, "Now using profile 'work'") {
+			t.Fatalf("Expected config use to succeed, got %v: %s", err, output)
+		}
+
+		output, err = executeCommand(t, "--config", configPath, "config", "show")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Profile: work") || !strings.Contains(output, "https://work.example.com (profile work)") {
+			t.Errorf("Expected the active profile in output, got: %s", output)
+		}
+		if strings.Contains(output, "work-token") {
+			t.Errorf("Expected the token to be redacted, got: %s", output)
+		}
+
+		if _, err := executeCommand(t, "--config", configPath, "config", "use", "missing"); err == nil {
+			t.Error("Expected an error for a missing profile")
+		}
+	})
+
+	t.Run("env overrides the active profile but not --profile", func(t *testing.T) {
+		setTestEnv(t, "https://env.example.com", "env-token")
+
+		result := show(t)
+		if result["profile"] != "work" || result["url"] != "https://env.example.com" || result["url_source"] != "environment variable" {
+			t.Errorf("Expected env values over the active profile, got %v", result)
+		}
+
+		result = show(t, "--profile", "default")
+		if result["url"] != "https://default.example.com" || result["url_source"] != "config file" || result["token_source"] != "config file" {
+			t.Errorf("Expected --profile to ignore env values, got %v", result)
+		}
+	})
+
+	t.Run("--url and --token override the profile", func(t *testing.T) {
+		result := show(t, "--profile", "work", "--url", "https://flag.example.com", "--token", "flag-token")
+		if result["url"] != "https://flag.example.com" || result["url_source"] != "--url flag" || result["token_source"] != "--token flag" {
+			t.Errorf("Expected flags over the profile, got %v", result)
+		}
+	})
+
+	t.Run("init --profile adds a profile", func(t *testing.T) {
+		oldStdin := os.Stdin
+		defer func() { os.Stdin = oldStdin }()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("Failed to create pipe: %v", err)
+		}
+		os.Stdin = r
+		go func() {
+			_, _ = w.WriteString("https://lab.example.com\nlab-token\n")
+			_ = w.Close()
+		}()
+
+		output, err := executeCommand(t, "--config", configPath, "config", "init", "--profile", "lab")
+		if err != nil || !strings.Contains(output, "Profile 'lab' saved") {
+			t.Fatalf("Expected config init --profile to succeed, got %v: %s", err, output)
+		}
+
+		if result := show(t, "--profile", "lab"); result["url"] != "https://lab.example.com" {
+			t.Errorf("Expected the new profile, got %v", result)
+		}
+		if result := show(t, "--profile", "work"); result["url"] != "https://work.example.com" {
+			t.Errorf("Expected other profiles to be kept, got %v", result)
+		}
+		if result := show(t, "--profile", "default"); result["url"] != "https://default.example.com" {
+			t.Errorf("Expected the default connection to be kept, got %v", result)
+		}
+	})
+}
+
+// ================= BOOKMARKS CHECK TESTS =================
+
+func TestBookmarksCheck(t *testing.T) {
+	var leakedAuth []string
+	var mu sync.Mutex
+	sites := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			mu.Lock()
+			leakedAuth = append(leakedAuth, auth)
+			mu.Unlock()
+		}
+		switch r.URL.Path {
+		case "/ok":
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/slow":
+			time.Sleep(300 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer sites.Close()
+
+	bookmarks := []models.Bookmark{
+		mockBookmark(1, sites.URL+"/ok", "OK", nil),
+		mockBookmark(2, sites.URL+"/gone", "Gone", nil),
+		mockBookmark(3, sites.URL+"/moved", "Moved", nil),
+		mockBookmark(4, sites.URL+"/no-head", "No HEAD", nil),
+		mockBookmark(5, sites.URL+"/slow", "Slow", nil),
+	}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(bookmarks), Results: bookmarks})
+	})
+	setTestEnv(t, server.URL, "secret-token")
+
+	output, err := executeCommand(t, "bookmarks", "check", "--json", "--timeout", "100ms", "--concurrency", "3")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var checks []linkCheck
+	if err := json.Unmarshal([]byte(output), &checks); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\n%s", err, output)
+	}
+	if len(checks) != 5 {
+		t.Fatalf("Expected 5 results in bookmark order, got %+v", checks)
+	}
+	want := []struct {
+		status   int
+		final    string
+		hasError bool
+	}{
+		{200, "", false},
+		{404, "", false},
+		{200, sites.URL + "/ok", false},
+		{200, "", false},
+		{0, "", true},
+	}
+	for i, w := range want {
+		c := checks[i]
+		if c.ID != i+1 || c.Status != w.status || c.FinalURL != w.final || (c.Error != "") != w.hasError {
+			t.Errorf("Check %d: expected status %d, final %q, error %v; got %+v", i+1, w.status, w.final, w.hasError, c)
+		}
+	}
+	if len(leakedAuth) != 0 {
+		t.Errorf("Expected no credentials to be sent to checked sites, got %v", leakedAuth)
+	}
+
+	output, err = executeCommand(t, "bookmarks", "check", "--only-broken", "--timeout", "100ms")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "/gone") || !strings.Contains(output, "error:") || strings.Contains(output, "/no-head") {
+		t.Errorf("Expected only broken links in the table, got:\n%s", output)
+	}
+	if !strings.Contains(output, "2 of 5 links broken") {
+		t.Errorf("Expected a broken link summary, got:\n%s", output)
+	}
+
+	for _, value := range []string{"0", "17"} {
+		_, err = executeCommand(t, "bookmarks", "check", "--concurrency", value)
+		if err == nil || !strings.Contains(err.Error(), "--concurrency must be between 1 and 16") {
+			t.Errorf("Expected a range error for --concurrency %s, got: %v", value, err)
+		}
+	}
+}
+
+// ================= BOOKMARKS OPEN TESTS =================
+
+// stubLauncher replaces the browser launcher for the test and returns the
+// URLs it was asked to open.
+func stubLauncher(t *testing.T) *[]string {
+	t.Helper()
+	opened := &[]string{}
+	original := launchBrowser
+	launchBrowser = func(url string) error {
+		*opened = append(*opened, url)
+		return nil
+	}
+	t.Cleanup(func() { launchBrowser = original })
+	return opened
+}
+
+func setupOpenServer(t *testing.T, count int) *[]string {
+	t.Helper()
+	var queries []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bookmarks/42/" {
+			_ = json.NewEncoder(w).Encode(mockBookmark(42, "https://example.com/42", "Answer", nil))
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		results := []models.Bookmark{}
+		for i := 1; i <= count && i <= limit; i++ {
+			results = append(results, mockBookmark(i, fmt.Sprintf("https://example.com/%d", i), "Example", []string{"reading"}))
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: count, Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return &queries
+}
+
+func TestBookmarksOpen(t *testing.T) {
+	opened := stubLauncher(t)
+	queries := setupOpenServer(t, 10)
+
+	if _, err := executeCommand(t, "bookmarks", "open", "42"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if fmt.Sprint(*opened) != "[https://example.com/42]" {
+		t.Errorf("Expected bookmark 42 to be opened, got %v", *opened)
+	}
+
+	*opened = nil
+	if _, err := executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "3"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if fmt.Sprint(*opened) != "[https://example.com/1 https://example.com/2 https://example.com/3]" {
+		t.Errorf("Expected the first three matches to be opened, got %v", *opened)
+	}
+	if len(*queries) != 1 || !strings.Contains((*queries)[0], "reading") {
+		t.Errorf("Expected the tag filter to be sent, got %v", *queries)
+	}
+
+	*opened = nil
+	output, err := executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "2", "--print")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*opened) != 0 || output != "https://example.com/1\nhttps://example.com/2\n" {
+		t.Errorf("Expected --print to only print URLs, opened %v, output %q", *opened, output)
+	}
+
+	_, err = executeCommand(t, "bookmarks", "open")
+	if err == nil || !strings.Contains(err.Error(), "give a bookmark ID") {
+		t.Errorf("Expected an error without an ID or filters, got %v", err)
+	}
+	_, err = executeCommand(t, "bookmarks", "open", "42", "--tags", "reading")
+	if err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Errorf("Expected an error for an ID with filters, got %v", err)
+	}
+}
+
+func TestBookmarksOpenConfirmation(t *testing.T) {
+	opened := stubLauncher(t)
+	setupOpenServer(t, 10)
+
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdin = r
+	go func() {
+		_, _ = w.WriteString("n\n")
+		_ = w.Close()
+	}()
+
+	output, err := executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "8")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "About to open 8 bookmarks") || !strings.Contains(output, "Aborted") || len(*opened) != 0 {
+		t.Errorf("Expected the confirmation to abort, opened %v, output:\n%s", *opened, output)
+	}
+
+	_, err = executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "8", "-O", "yaml")
+	if err == nil || !strings.Contains(err.Error(), "requires --force") || len(*opened) != 0 {
+		t.Errorf("Expected YAML output to require --force, got %v, opened %v", err, *opened)
+	}
+
+	if _, err := executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "8", "--force"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*opened) != 8 {
+		t.Errorf("Expected --force to open all 8 bookmarks, got %v", *opened)
+	}
+
+	feedStdin(t, "yes\n")
+	if _, err := executeCommand(t, "bookmarks", "open", "--tags", "reading", "--limit", "8"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*opened) != 16 {
+		t.Errorf("Expected answering yes to open all 8 bookmarks again, got %v", *opened)
+	}
+}
+
+// ================= OUTPUT FORMAT TESTS =================
+
+func TestOutputFormatYAML(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bookmark := mockBookmark(1, "https://example.com", "Example: Site", []string{"test"})
+		bookmark.DateAdded = time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+		bookmark.DateModified = bookmark.DateAdded
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{bookmark}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "list", "-O", "yaml")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Expected valid YAML: %v\n%s", err, output)
+	}
+	if parsed["count"] != 1 {
+		t.Errorf("Expected count: 1, got %v", parsed["count"])
+	}
+	results, ok := parsed["results"].([]interface{})
+	if !ok || len(results) != 1 {
+		t.Fatalf("Expected one result, got %v", parsed["results"])
+	}
+	result := results[0].(map[string]interface{})
+	for _, key := range []string{"id", "url", "title", "tag_names", "date_added"} {
+		if _, ok := result[key]; !ok {
+			t.Errorf("Expected key %q in the YAML result, got %v", key, result)
+		}
+	}
+	if result["title"] != "Example: Site" {
+		t.Errorf("Expected the title to survive quoting, got %v", result["title"])
+	}
+	if strings.Contains(output, "{") {
+		t.Errorf("Expected block-style YAML, got:\n%s", output)
+	}
+
+	// --json and -O json print the same bytes
+	jsonFlag, err := executeCommand(t, "list", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	jsonFormat, err := executeCommand(t, "list", "-O", "json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if jsonFlag != jsonFormat {
+		t.Errorf("Expected --json and -O json to match:\n%s\n---\n%s", jsonFlag, jsonFormat)
+	}
+}
+
+func TestOutputFormatYAMLTagsAndBundles(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/api/tags/"):
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Tag{ID: 7, Name: "go"})
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 3, Name: "Go"})
+		default:
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 3, Name: "Go"})
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	for _, args := range [][]string{
+		{"tags", "create", "go"},
+		{"bundles", "create", "Go"},
+		{"bundles", "update", "3", "--name", "Go"},
+		{"bundles", "delete", "3"},
+		{"bundles", "duplicate", "3", "--name", "Go copy"},
+	} {
+		output, err := executeCommand(t, append(args, "-O", "yaml")...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		var parsed map[string]interface{}
+		if err := yaml.Unmarshal([]byte(output), &parsed); err != nil || parsed["id"] == nil {
+			t.Errorf("%v: expected YAML with an id, got %v:\n%s", args, err, output)
+		}
+	}
+}
+
+func TestOutputFormatInvalid(t *testing.T) {
+	setTestEnv(t, "https://linkding.example.com", "test-token")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"list", "-O", "xml"}, "invalid --output-format"},
+		{[]string{"list", "--json", "-O", "yaml"}, "--json cannot be combined"},
+		{[]string{"list", "-O", "yaml", "--select", ".count"}, "--select cannot be combined"},
+	}
+	for _, tt := range tests {
+		_, err := executeCommand(t, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+// ================= LIST FIELDS TESTS =================
+
+func TestListFields(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bookmarks := []models.Bookmark{
+			mockBookmark(1, "https://example.com/a-rather-long-path-that-should-not-be-truncated-at-all", "Example Site", []string{"test"}),
+			mockBookmark(2, "https://example.org", "Other Site", nil),
+		}
+		bookmarks[0].DateAdded = time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+		bookmarks[1].DateAdded = time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+		bookmarks[1].Unread = true
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: bookmarks})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	t.Setenv("TZ", "UTC")
+
+	output, err := executeCommand(t, "list", "--fields", "url,id,tags,date_added,unread")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	lines := strings.Split(output, "\n")
+	if fields := strings.Fields(lines[0]); !reflect.DeepEqual(fields, []string{"URL", "ID", "TAGS", "ADDED", "UNREAD"}) {
+		t.Errorf("Expected the columns in the requested order, got %v", fields)
+	}
+	if !strings.Contains(output, "https://example.com/a-rather-long-path-that-should-not-be-truncated-at-all") {
+		t.Errorf("Expected the full URL, got:\n%s", output)
+	}
+	if fields := strings.Fields(lines[3]); !reflect.DeepEqual(fields, []string{"https://example.org", "2", "-", "2024-02-01", "true"}) {
+		t.Errorf("Unexpected second row %v", fields)
+	}
+	if strings.Contains(output, "TITLE") || strings.Contains(output, "Example Site") {
+		t.Errorf("Expected unselected columns to be hidden, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Showing 2 of 2 total bookmarks") {
+		t.Errorf("Expected the summary line, got:\n%s", output)
+	}
+
+	// --no-header leaves just the rows
+	output, err = executeCommand(t, "list", "--fields", "id,url", "--no-header")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	rows := strings.Split(strings.TrimSpace(output), "\n")
+	if len(rows) != 2 || !reflect.DeepEqual(strings.Fields(rows[0]), []string{"1", "https://example.com/a-rather-long-path-that-should-not-be-truncated-at-all"}) {
+		t.Errorf("Expected two bare rows, got:\n%s", output)
+	}
+
+	// --fields has no effect on JSON
+	output, err = executeCommand(t, "list", "--fields", "id", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var list models.BookmarkList
+	if err := json.Unmarshal([]byte(output), &list); err != nil || len(list.Results) != 2 || list.Results[0].Title != "Example Site" {
+		t.Errorf("Expected full JSON output, got err=%v:\n%s", err, output)
+	}
+}
+
+func TestListFieldsUnknown(t *testing.T) {
+	setTestEnv(t, "https://linkding.example.com", "test-token")
+
+	_, err := executeCommand(t, "list", "--fields", "id,colour")
+	if err == nil || !strings.Contains(err.Error(), `unknown field "colour"`) || !strings.Contains(err.Error(), "date_added") {
+		t.Errorf("Expected an unknown field error listing valid names, got %v", err)
+	}
+
+	_, err = executeCommand(t, "list", "--fields", "id", "--added")
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined with --fields") {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+}
+
+// ================= SYNC COMMAND TESTS =================
+
+// setupSyncServer serves three bookmarks and records each write as
+// "<method> <path> <body>".
+func setupSyncServer(t *testing.T) *[]string {
+	t.Helper()
+	bookmarks := []models.Bookmark{
+		mockBookmark(1, "https://same.example.com", "Same", []string{"b", "a"}),
+		mockBookmark(2, "https://changed.example.com", "Old title", []string{"a"}),
+		mockBookmark(3, "https://gone.example.com", "Gone", nil),
+	}
+	bookmarks[0].Description = "same"
+	bookmarks[0].Notes = "kept"
+	bookmarks[1].Description = "changed"
+
+	writes := &[]string{}
+	var mu sync.Mutex
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(bookmarks), Results: bookmarks})
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		*writes = append(*writes, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body)))
+		mu.Unlock()
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(4, "https://new.example.com", "New", nil))
+		case "PATCH":
+			_ = json.NewEncoder(w).Encode(bookmarks[1])
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return writes
+}
+
+// writeSyncFile writes a backup file whose first entry matches bookmark 1
+// (tags in another order), second changes bookmark 2 and third is new.
+func writeSyncFile(t *testing.T) string {
+	t.Helper()
+	data := export.ExportData{
+		Version: export.FormatVersion,
+		Source:  "https://linkding.example.com",
+		Bookmarks: []export.ExportBookmark{
+			{URL: "https://same.example.com", Title: "Same", Description: "same", Tags: []string{"A", "b"}},
+			{URL: "https://changed.example.com", Title: "New title", Description: "changed", Tags: []string{"a"}},
+			{URL: "https://new.example.com", Title: "New", Tags: []string{"fresh"}, Unread: true},
+		},
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Failed to encode backup: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	return path
+}
+
+func TestSyncCommand(t *testing.T) {
+	writes := setupSyncServer(t)
+	path := writeSyncFile(t)
+
+	output, err := executeCommand(t, "sync", path)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	want := []string{
+		`POST /api/bookmarks/ {"url":"https://new.example.com","title":"New","unread":true,"tag_names":["fresh"]}`,
+		`PATCH /api/bookmarks/2/ {"title":"New title","description":"changed","tag_names":["a"]}`,
+	}
+	if !reflect.DeepEqual(*writes, want) {
+		t.Errorf("Expected writes %v, got %v", want, *writes)
+	}
+	for _, line := range []string{"1 bookmarks created", "1 bookmarks updated", "0 bookmarks deleted", "1 unchanged"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected %q in the summary, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestSyncCommandPrune(t *testing.T) {
+	writes := setupSyncServer(t)
+	path := writeSyncFile(t)
+
+	output, err := executeCommand(t, "sync", path, "--prune", "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("Expected no writes under --dry-run, got %v", *writes)
+	}
+	for _, line := range []string{"Dry run", "+ https://new.example.com", "~ https://changed.example.com", "- https://gone.example.com", "1 bookmarks deleted"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected %q in the dry run output, got:\n%s", line, output)
+		}
+	}
+
+	output, err = executeCommand(t, "sync", path, "--prune", "--force", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if (*writes)[len(*writes)-1] != "DELETE /api/bookmarks/3/" || len(*writes) != 3 {
+		t.Errorf("Expected only bookmark 3 to be deleted, got %v", *writes)
+	}
+	var result map[string]int
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	if result["created"] != 1 || result["updated"] != 1 || result["deleted"] != 1 || result["unchanged"] != 1 {
+		t.Errorf("Unexpected counts %v", result)
+	}
+}
+
+func TestSyncCommandUnchanged(t *testing.T) {
+	writes := setupSyncServer(t)
+	data := export.ExportData{
+		Version: export.FormatVersion,
+		Bookmarks: []export.ExportBookmark{
+			{URL: "https://same.example.com", Title: "Same", Description: "same", Tags: []string{"a", "b"}},
+		},
+	}
+	content, _ := json.Marshal(data)
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	output, err := executeCommand(t, "sync", path)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("Expected no writes, got %v", *writes)
+	}
+	if !strings.Contains(output, "1 unchanged") {
+		t.Errorf("Expected one unchanged bookmark, got:\n%s", output)
+	}
+
+	// Bookmarks missing from the file stay without --prune, and a bad file is rejected
+	if err := os.WriteFile(path, []byte(`{"bookmarks": [{"title": "no url"}]}`), 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	_, err = executeCommand(t, "sync", path, "--prune", "--force")
+	if err == nil || !strings.Contains(err.Error(), "does not match the export schema") {
+		t.Errorf("Expected a schema error, got %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("Expected no writes for an invalid file, got %v", *writes)
+	}
+}
+
+func TestSyncCommandNotes(t *testing.T) {
+	writes := setupSyncServer(t)
+	notes := "rewritten"
+	data := export.ExportData{
+		Version: export.FormatVersion,
+		Bookmarks: []export.ExportBookmark{
+			{URL: "https://same.example.com", Title: "Same", Description: "same", Notes: &notes, Tags: []string{"a", "b"}},
+		},
+	}
+	content, _ := json.Marshal(data)
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	if _, err := executeCommand(t, "sync", path); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	want := []string{`PATCH /api/bookmarks/1/ {"title":"Same","description":"same","notes":"rewritten","tag_names":["a","b"]}`}
+	if !reflect.DeepEqual(*writes, want) {
+		t.Errorf("Expected writes %v, got %v", want, *writes)
+	}
+}
+
+func TestSyncCommandPruneYAML(t *testing.T) {
+	writes := setupSyncServer(t)
+	path := writeSyncFile(t)
+
+	// Structured output cannot prompt, so deleting needs --force
+	_, err := executeCommand(t, "sync", path, "--prune", "-O", "yaml")
+	if err == nil || !strings.Contains(err.Error(), "requires --force") {
+		t.Fatalf("Expected --prune with YAML output to require --force, got %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("Expected no writes without --force, got %v", *writes)
+	}
+
+	output, err := executeCommand(t, "sync", path, "--prune", "--force", "-O", "yaml")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if strings.Contains(output, "Are you sure") || !strings.Contains(output, "deleted: 1") {
+		t.Errorf("Expected YAML counts without a prompt, got:\n%s", output)
+	}
+	if len(*writes) != 3 {
+		t.Errorf("Expected the create, update and delete, got %v", *writes)
+	}
+}
+
+// ================= COMPLETION TESTS =================
+
+func TestCompletionCommand(t *testing.T) {
+	output, err := executeCommand(t, "completion", "bash")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "bash completion V2 for linkdingctl") || !strings.Contains(output, "__start_linkdingctl") {
+		t.Errorf("Expected a bash completion script, got:\n%.200s", output)
+	}
+
+	for _, shell := range []string{"zsh", "fish", "powershell"} {
+		output, err := executeCommand(t, "completion", shell)
+		if err != nil || !strings.Contains(output, "linkdingctl") {
+			t.Errorf("Expected a %s completion script, got err=%v:\n%.200s", shell, err, output)
+		}
+	}
+
+	if _, err := executeCommand(t, "completion", "tcsh"); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+}
+
+func TestDynamicCompletion(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/tags/" {
+			tags := []models.Tag{{ID: 1, Name: "golang"}, {ID: 2, Name: "go-tools"}, {ID: 3, Name: "kubernetes"}}
+			_ = json.NewEncoder(w).Encode(models.TagList{Count: len(tags), Results: tags})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{
+			mockBookmark(12, "https://example.com/12", "Twelve", nil),
+			mockBookmark(30, "https://example.com/30", "Thirty", nil),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "__complete", "tags", "show", "go")
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+	if !strings.Contains(output, "golang\ngo-tools\n") || strings.Contains(output, "kubernetes") {
+		t.Errorf("Expected matching tag names, got:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "__complete", "list", "--tags", "golang,ku")
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+	if !strings.Contains(output, "golang,kubernetes\n") {
+		t.Errorf("Expected the last comma-separated tag to be completed, got:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "__complete", "get", "1")
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+	if !strings.Contains(output, "12\tTwelve\n") || strings.Contains(output, "Thirty") {
+		t.Errorf("Expected matching bookmark IDs, got:\n%s", output)
+	}
+
+	// Without a configuration the lookups return nothing instead of failing
+	setTestEnv(t, "", "")
+	cfgFile = filepath.Join(t.TempDir(), "missing.yaml")
+	output, err = executeCommand(t, "__complete", "tags", "show", "")
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+	if strings.Contains(output, "golang") || !strings.Contains(output, ":4") {
+		t.Errorf("Expected no suggestions, got:\n%s", output)
+	}
+}
+
+// ================= LIST ALL TESTS =================
+
+func TestListAllMultiPage(t *testing.T) {
+	var requests []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query.Get("offset"))
+		if strings.TrimSpace(query.Get("q")) != "k8s" || query.Get("limit") != "100" {
+			t.Errorf("Expected q=k8s and limit=100, got %s", r.URL.RawQuery)
+		}
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		total := 250
+		var bookmarks []models.Bookmark
+		for id := offset + 1; id <= total && id <= offset+100; id++ {
+			var tags []string
+			if id == 250 {
+				tags = []string{"skip"}
+			}
+			bookmarks = append(bookmarks, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), fmt.Sprintf("Bookmark %d", id), tags))
+		}
+		response := models.BookmarkList{Count: total, Results: bookmarks}
+		if offset+100 < total {
+			next := fmt.Sprintf("%s/api/bookmarks/?limit=100&offset=%d", "http://example.com", offset+100)
+			response.Next = &next
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	for _, args := range [][]string{
+		{"list", "--tags", "k8s", "--all", "--json"},
+		{"list", "--tags", "k8s", "--limit", "0", "--json"},
+	} {
+		requests = nil
+		output, err := executeCommand(t, args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		if !reflect.DeepEqual(requests, []string{"", "100", "200"}) {
+			t.Errorf("%v: expected three pages, got offsets %v", args, requests)
+		}
+		var list models.BookmarkList
+		if err := json.Unmarshal([]byte(output), &list); err != nil {
+			t.Fatalf("Expected JSON output: %v", err)
+		}
+		if list.Count != 250 || len(list.Results) != 250 || list.Results[249].ID != 250 || list.Next != nil {
+			t.Errorf("%v: expected all 250 bookmarks, got count %d, %d results", args, list.Count, len(list.Results))
+		}
+	}
+
+	output, err := executeCommand(t, "list", "--tags", "k8s", "--all", "--exclude-tags", "skip")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Showing 249 of 250 total bookmarks") || strings.Contains(output, "--offset") {
+		t.Errorf("Expected the full table without a paging hint, got:\n%s", output[len(output)-200:])
+	}
+
+	_, err = executeCommand(t, "list", "--limit", "0", "--offset", "100")
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined with --offset") {
+		t.Errorf("Expected an --offset conflict, got %v", err)
+	}
+}
+
+// ================= BUNDLES APPLY TESTS =================
+
+func TestBundlesApply(t *testing.T) {
+	var query string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bundles/1/" {
+			_ = json.NewEncoder(w).Encode(models.Bundle{
+				ID: 1, Name: "Go tools", Search: "golang", AllTags: "dev",
+				AnyTags: "cli web", ExcludedTags: "deprecated,Old",
+			})
+			return
+		}
+		query = r.URL.Query().Get("q")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 5, Results: []models.Bookmark{
+			mockBookmark(1, "https://example.com/1", "CLI tool", []string{"dev", "cli"}),
+			mockBookmark(2, "https://example.com/2", "Web tool", []string{"dev", "web"}),
+			mockBookmark(3, "https://example.com/3", "Deprecated", []string{"dev", "cli", "deprecated"}),
+			mockBookmark(4, "https://example.com/4", "Old", []string{"dev", "web", "old"}),
+			mockBookmark(5, "https://example.com/5", "No any-tag", []string{"dev"}),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "bundles", "apply", "1", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if query != "golang dev" {
+		t.Errorf("Expected the search and all-tags in the query, got %q", query)
+	}
+	var list models.BookmarkList
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatalf("Expected JSON output: %v", err)
+	}
+	var ids []int
+	for _, b := range list.Results {
+		ids = append(ids, b.ID)
+	}
+	if list.Count != 2 || !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("Expected bookmarks 1 and 2 (count 2), got %v (count %d)", ids, list.Count)
+	}
+
+	output, err = executeCommand(t, "bundles", "apply", "1", "--limit", "1")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "CLI tool") || strings.Contains(output, "Web tool") || !strings.Contains(output, "Showing the first 1 of more than 1 matching") {
+		t.Errorf("Expected one of two matches in the table, got:\n%s", output)
+	}
+
+	if _, err := executeCommand(t, "bundles", "apply", "x"); err == nil || !strings.Contains(err.Error(), "invalid bundle ID") {
+		t.Errorf("Expected an invalid ID error, got %v", err)
+	}
+}
+
+func TestBundlesApplyStopsPaging(t *testing.T) {
+	var offsets []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bundles/1/" {
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 1, Name: "Everything"})
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		var results []models.Bookmark
+		for id := offset + 1; id <= offset+100; id++ {
+			results = append(results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), "", nil))
+		}
+		next := "more"
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 300, Next: &next, Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	_, stderr, err := executeCommandStreams(t, "bundles", "apply", "1")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(offsets) != 1 {
+		t.Errorf("Expected paging to stop after the first page, got offsets %v", offsets)
+	}
+	if !strings.Contains(stderr, "Warning: more than 50 bookmarks match") {
+		t.Errorf("Expected a more-than warning, got: %q", stderr)
+	}
+
+	// The total is unknown, so JSON reports truncation instead of a count
+	output, _, err := executeCommandStreams(t, "bundles", "apply", "1", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected JSON output: %v", err)
+	}
+	if _, hasCount := result["count"]; hasCount || result["truncated"] != true || len(result["results"].([]interface{})) != 50 {
+		t.Errorf("Expected 50 results marked truncated without a count, got count %v, truncated %v", result["count"], result["truncated"])
+	}
+}
+
+// ================= BOOKMARKS ARCHIVE-ALL TESTS =================
+
+// setupBulkArchiveServer serves unarchived bookmarks 1-3 tagged "old",
+// untagged bookmark 5 whose title mentions "old", and archived bookmark 4,
+// and records each PATCH as "<id> <is_archived>".
+// Updates to failID return 500.
+func setupBulkArchiveServer(t *testing.T, failID int) *[]string {
+	t.Helper()
+	patches := &[]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "PATCH" {
+			var body models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bookmarks/"), "/")
+			*patches = append(*patches, fmt.Sprintf("%s %v", id, body.IsArchived != nil && *body.IsArchived))
+			if id == strconv.Itoa(failID) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "x", nil))
+			return
+		}
+		results := []models.Bookmark{}
+		if r.URL.Query().Get("archived") == "yes" {
+			results = append(results, mockBookmark(4, "https://example.com/4", "Four", []string{"old"}))
+		} else {
+			for id := 1; id <= 3; id++ {
+				results = append(results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), "", []string{"old"}))
+			}
+			results = append(results, mockBookmark(5, "https://example.com/5", "Old maps", nil))
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return patches
+}
+
+// feedStdin replaces os.Stdin with a pipe holding input for the rest of the
+// test.
+func feedStdin(t *testing.T, input string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin })
+	go func() {
+		_, _ = w.WriteString(input)
+		_ = w.Close()
+	}()
+}
+
+func TestBookmarksArchiveAllConfirm(t *testing.T) {
+	t.Run("abort", func(t *testing.T) {
+		patches := setupBulkArchiveServer(t, 0)
+		feedStdin(t, "n\n")
+		output, err := executeCommand(t, "bookmarks", "archive-all", "--tags", "old")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "About to archive 3 bookmark(s)") || !strings.Contains(output, "Aborted") {
+			t.Errorf("Expected a prompt and abort, got:\n%s", output)
+		}
+		if len(*patches) != 0 {
+			t.Errorf("Expected no PATCH after aborting, got %v", *patches)
+		}
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		patches := setupBulkArchiveServer(t, 0)
+		feedStdin(t, "y\n")
+		output, err := executeCommand(t, "bookmarks", "archive-all", "--tags", "old")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if want := "[1 true 2 true 3 true]"; fmt.Sprint(*patches) != want {
+			t.Errorf("Expected PATCHes %s, got %v", want, *patches)
+		}
+		if !strings.Contains(output, "3 bookmark(s) archived") {
+			t.Errorf("Expected a summary, got:\n%s", output)
+		}
+	})
+
+	t.Run("unarchive", func(t *testing.T) {
+		patches := setupBulkArchiveServer(t, 0)
+		if _, err := executeCommand(t, "bookmarks", "unarchive-all", "--tags", "old", "--force"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if want := "[4 false]"; fmt.Sprint(*patches) != want {
+			t.Errorf("Expected PATCHes %s, got %v", want, *patches)
+		}
+	})
+
+	t.Run("tags required", func(t *testing.T) {
+		_, err := executeCommand(t, "bookmarks", "archive-all", "--force")
+		if err == nil || !strings.Contains(err.Error(), "--tags is required") {
+			t.Errorf("Expected --tags to be required, got %v", err)
+		}
+	})
+}
+
+func TestBookmarksArchiveAllDryRun(t *testing.T) {
+	patches := setupBulkArchiveServer(t, 0)
+
+	output, err := executeCommand(t, "bookmarks", "archive-all", "--tags", "old", "--dry-run")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Would PATCH /api/bookmarks/3/") || !strings.Contains(output, `"is_archived":true`) {
+		t.Errorf("Expected planned PATCHes, got:\n%s", output)
+	}
+	if len(*patches) != 0 {
+		t.Errorf("Expected no PATCH under --dry-run, got %v", *patches)
+	}
+}
+
+func TestBookmarksArchiveAllPartialFailure(t *testing.T) {
+	patches := setupBulkArchiveServer(t, 2)
+
+	output, err := executeCommand(t, "bookmarks", "archive-all", "--tags", "old", "--force")
+	if err == nil || !strings.Contains(err.Error(), "some bookmarks failed to archive") {
+		t.Fatalf("Expected a partial failure error, got %v", err)
+	}
+	if len(*patches) != 3 {
+		t.Errorf("Expected every bookmark to be attempted, got %v", *patches)
+	}
+	if !strings.Contains(output, "2 bookmark(s) archived") || !strings.Contains(output, "✗ bookmark 2:") {
+		t.Errorf("Expected the failure to be reported, got:\n%s", output)
+	}
+}
+
+// ================= LIST FILTER TESTS =================
+
+func TestListFilter(t *testing.T) {
+	var query string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = strings.TrimSpace(r.URL.Query().Get("q"))
+		goUnread := mockBookmark(1, "https://example.com/1", "Go tips", []string{"go"})
+		goUnread.Unread = true
+		goShared := mockBookmark(2, "https://example.com/2", "Go shared", []string{"go"})
+		goShared.Unread, goShared.Shared = true, true
+		goRead := mockBookmark(3, "https://example.com/3", "Go read", []string{"go"})
+		rust := mockBookmark(4, "https://example.com/4", "Rust", []string{"rust"})
+		rust.Unread = true
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 4, Results: []models.Bookmark{goUnread, goShared, goRead, rust}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "list", "--filter", "unread AND tag:go AND NOT shared", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var list models.BookmarkList
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	if list.Count != 1 || len(list.Results) != 1 || list.Results[0].ID != 1 {
+		t.Errorf("Expected only bookmark 1, got %+v", list)
+	}
+
+	// --filter applies after server-side narrowing, and --limit after --filter
+	output, err = executeCommand(t, "list", "-q", "tips", "--filter", "tag:go OR tag:rust", "--limit", "2", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if query != "tips" {
+		t.Errorf("Expected --query to reach the server, got %q", query)
+	}
+	list = models.BookmarkList{}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	if list.Count != 4 || len(list.Results) != 2 {
+		t.Errorf("Expected 2 of 4 matches, got count %d with %d results", list.Count, len(list.Results))
+	}
+
+	_, err = executeCommand(t, "list", "--filter", "unread AND AND tag:go")
+	if err == nil || !strings.Contains(err.Error(), "unexpected AND at position 11") {
+		t.Errorf("Expected a parse error pointing at the second AND, got %v", err)
+	}
+}
+
+// ================= USER PROFILE SET TESTS =================
+
+func TestUserProfileSet(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/user/profile/" || r.Method != "PATCH" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.UserProfile{Theme: "dark", TagSearch: "strict", DisplayURL: false})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "user", "profile", "set", "--theme", "dark", "--display-url=false", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	want := map[string]interface{}{"theme": "dark", "display_url": false}
+	if len(bodies) != 1 || !reflect.DeepEqual(bodies[0], want) {
+		t.Errorf("Expected one PATCH with %v, got %v", want, bodies)
+	}
+	var profile models.UserProfile
+	if err := json.Unmarshal([]byte(output), &profile); err != nil || profile.Theme != "dark" {
+		t.Errorf("Expected the updated profile as JSON, got %q (%v)", output, err)
+	}
+
+	output, err = executeCommand(t, "user", "profile", "set", "--tag-search", "strict")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "✓ User profile updated") || !strings.Contains(output, "Tag Search:             strict") {
+		t.Errorf("Expected a confirmation and the profile, got:\n%s", output)
+	}
+
+	bodies = nil
+	_, err = executeCommand(t, "user", "profile", "set", "--theme", "purple")
+	if err == nil || !strings.Contains(err.Error(), "must be one of auto, light or dark") {
+		t.Errorf("Expected an invalid theme error, got %v", err)
+	}
+	_, err = executeCommand(t, "user", "profile", "set")
+	if err == nil || !strings.Contains(err.Error(), "no preferences to update") {
+		t.Errorf("Expected an error without flags, got %v", err)
+	}
+	if len(bodies) != 0 {
+		t.Errorf("Expected nothing sent for invalid input, got %v", bodies)
+	}
+}
+
+// ================= GET WITH CONTENT TESTS =================
+
+// setupSnapshotServer serves bookmark 1 and, when page is not empty, a
+// complete gzipped snapshot of it next to an older one and a pending one.
+// Without a page the bookmark has no assets.
+func setupSnapshotServer(t *testing.T, page string) {
+	t.Helper()
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/bookmarks/1/":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com/article", "Article", nil))
+		case "/api/bookmarks/1/assets/":
+			assets := []models.BookmarkAsset{}
+			if page != "" {
+				now := time.Now()
+				assets = append(assets,
+					models.BookmarkAsset{ID: 7, AssetType: "snapshot", Status: "complete", DateCreated: now.Add(-time.Hour)},
+					models.BookmarkAsset{ID: 8, AssetType: "snapshot", Status: "complete", DateCreated: now},
+					models.BookmarkAsset{ID: 9, AssetType: "snapshot", Status: "pending", DateCreated: now.Add(time.Hour)},
+				)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkAssetList{Count: len(assets), Results: assets})
+		case "/api/bookmarks/1/assets/8/download/":
+			gz := gzip.NewWriter(w)
+			_, _ = gz.Write([]byte(page))
+			_ = gz.Close()
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+}
+
+func TestGetWithContent(t *testing.T) {
+	body := strings.Repeat("word ", 150)
+	page := `<html><head><title>Article</title><style>p { color: red }</style></head>
+<body><h1>Heading &amp; more</h1><script>var x = 1;</script><p>` + body + `</p></body></html>`
+	setupSnapshotServer(t, page)
+
+	output, err := executeCommand(t, "get", "1", "--with-content")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Archived content:\nHeading & more\nword word") || !strings.HasSuffix(strings.TrimSpace(output), "…") {
+		t.Errorf("Expected an excerpt of the snapshot text, got:\n%s", output)
+	}
+	for _, hidden := range []string{"color: red", "var x", "<p>"} {
+		if strings.Contains(output, hidden) {
+			t.Errorf("Expected %q to be stripped, got:\n%s", hidden, output)
+		}
+	}
+
+	output, err = executeCommand(t, "get", "1", "--with-content", "--full", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result struct {
+		ID      int     `json:"id"`
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	if want := "Heading & more\n" + strings.TrimSpace(body); result.ID != 1 || result.Content == nil || *result.Content != want {
+		t.Errorf("Expected the full text in content, got %+v", result)
+	}
+
+	_, err = executeCommand(t, "get", "1", "--full")
+	if err == nil || !strings.Contains(err.Error(), "--full requires --with-content") {
+		t.Errorf("Expected --full to require --with-content, got %v", err)
+	}
+}
+
+func TestGetWithContentNoArchive(t *testing.T) {
+	setupSnapshotServer(t, "")
+
+	output, err := executeCommand(t, "get", "1", "--with-content")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "No archived content") {
+		t.Errorf("Expected a no-content note, got:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "get", "1", "--with-content", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, `"content": null`) {
+		t.Errorf("Expected a null content field, got:\n%s", output)
+	}
+}
+
+// ================= ASSETS DOWNLOAD TESTS =================
+
+// setupAssetsServer serves bookmark 1 with a snapshot, an upload, a pending
+// snapshot and an asset whose download fails, plus a favicon. Bookmark 2 has
+// no assets.
+func setupAssetsServer(t *testing.T, favicons bool) {
+	t.Helper()
+	var server *httptest.Server
+	server = setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/bookmarks/1/", "/api/bookmarks/2/":
+			id, _ := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/bookmarks/"), "/"))
+			b := mockBookmark(id, "https://example.com", "Example", nil)
+			b.FaviconURL = server.URL + "/static/https_example_com.png"
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(b)
+		case "/api/bookmarks/1/assets/":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkAssetList{Count: 4, Results: []models.BookmarkAsset{
+				{ID: 1, AssetType: "snapshot", Status: "complete", DisplayName: "HTML snapshot"},
+				{ID: 2, AssetType: "upload", Status: "complete", DisplayName: "paper.pdf"},
+				{ID: 3, AssetType: "snapshot", Status: "pending", DisplayName: "HTML snapshot"},
+				{ID: 4, AssetType: "upload", Status: "complete", DisplayName: "broken.bin"},
+			}})
+		case "/api/bookmarks/2/assets/":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.BookmarkAssetList{Results: []models.BookmarkAsset{}})
+		case "/api/bookmarks/1/assets/1/download/":
+			w.Header().Set("Content-Disposition", `attachment; filename="snapshot_1.html.gz"`)
+			_, _ = w.Write([]byte{0x1f, 0x8b, 0x00, 0xff})
+		case "/api/bookmarks/1/assets/2/download/":
+			_, _ = w.Write([]byte("%PDF-1.4"))
+		case "/api/bookmarks/1/assets/4/download/":
+			w.WriteHeader(http.StatusForbidden)
+		case "/api/user/profile/":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.UserProfile{EnableFavicons: favicons})
+		case "/static/https_example_com.png":
+			_, _ = w.Write([]byte("PNG"))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+}
+
+func TestAssetsDownload(t *testing.T) {
+	setupAssetsServer(t, true)
+	dir := t.TempDir()
+
+	output, err := executeCommand(t, "assets", "download", "1", "--dir", dir, "--favicon")
+	if err == nil || !strings.Contains(err.Error(), "some assets failed to download") {
+		t.Fatalf("Expected the failed asset to fail the command, got %v", err)
+	}
+
+	files := map[string][]byte{
+		"snapshot_1.html.gz":    {0x1f, 0x8b, 0x00, 0xff},
+		"paper.pdf":             []byte("%PDF-1.4"),
+		"https_example_com.png": []byte("PNG"),
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("Expected %s to hold %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(files) {
+		t.Errorf("Expected only %d files, got %d", len(files), len(entries))
+	}
+	for _, want := range []string{"✓ paper.pdf → paper.pdf", "⊘ HTML snapshot skipped: status is pending", "✗ broken.bin:", "Downloaded 3 of 5 file(s)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestAssetsDownloadNoAssets(t *testing.T) {
+	setupAssetsServer(t, false)
+	dir := t.TempDir()
+
+	output, err := executeCommand(t, "assets", "download", "2", "--dir", dir)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "Bookmark 2 has no assets") {
+		t.Errorf("Expected a no-assets note, got:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "assets", "download", "2", "--dir", dir, "--favicon", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result struct {
+		Assets []assetDownload `json:"assets"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected JSON output: %v\n%s", err, output)
+	}
+	if len(result.Assets) != 1 || result.Assets[0].Status != "skipped" || !strings.Contains(result.Assets[0].Reason, "disabled") {
+		t.Errorf("Expected the favicon to be skipped while favicons are off, got %+v", result.Assets)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files, got %d", len(entries))
+	}
+}
+
+// ================= RATE LIMIT TESTS =================
+
+func TestRateLimitFlag(t *testing.T) {
+	var times []time.Time
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	// get --with-content makes two requests: the bookmark, then its assets
+	if _, err := executeCommand(t, "get", "1", "--with-content", "--rate-limit", "10"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(times) != 2 || times[1].Sub(times[0]) < 90*time.Millisecond {
+		t.Errorf("Expected two requests about 100ms apart, got %v", times)
+	}
+
+	_, err := executeCommand(t, "get", "1", "--rate-limit", "-1")
+	if err == nil || !strings.Contains(err.Error(), "--rate-limit must be zero or greater") {
+		t.Errorf("Expected error for negative --rate-limit, got: %v", err)
+	}
+}
+
+// ================= PARALLEL TESTS =================
+
+func TestParallelFlag(t *testing.T) {
+	var mu sync.Mutex
+	offsets := map[string]bool{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		mu.Lock()
+		offsets[r.URL.Query().Get("offset")] = true
+		mu.Unlock()
+
+		list := models.BookmarkList{Count: 250, Results: []models.Bookmark{}}
+		for id := offset + 1; id <= 250 && id <= offset+100; id++ {
+			list.Results = append(list.Results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), "Example", nil))
+		}
+		if offset+100 < 250 {
+			next := fmt.Sprintf("/api/bookmarks/?offset=%d", offset+100)
+			list.Next = &next
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(list)
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "export", "--parallel", "3")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var exported struct {
+		Bookmarks []models.Bookmark `json:"bookmarks"`
+	}
+	if err := json.Unmarshal([]byte(output), &exported); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if len(exported.Bookmarks) != 250 {
+		t.Fatalf("Expected 250 bookmarks, got %d", len(exported.Bookmarks))
+	}
+	for i, b := range exported.Bookmarks {
+		if b.ID != i+1 {
+			t.Fatalf("Bookmark %d has ID %d; pages out of order", i, b.ID)
+		}
+	}
+	if len(offsets) != 3 {
+		t.Errorf("Expected 3 page requests, got %v", offsets)
+	}
+
+	for _, value := range []string{"0", "17"} {
+		_, err := executeCommand(t, "export", "--parallel", value)
+		if err == nil || !strings.Contains(err.Error(), "--parallel must be between 1 and 16") {
+			t.Errorf("Expected range error for --parallel %s, got: %v", value, err)
+		}
+	}
+}
+
+// ================= CONFIG MIGRATE TESTS =================
+
+func TestConfigMigrateKeyring(t *testing.T) {
+	gokeyring.MockInit()
+	t.Setenv("LINKDING_URL", "")
+	t.Setenv("LINKDING_TOKEN", "")
+
+	var authHeader string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := fmt.Sprintf("url: %s\ntoken: file-token-1234\n", server.URL)
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := executeCommand(t, "--config", configPath, "config", "migrate"); err == nil {
+		t.Error("Expected an error without --to-keyring or --to-file")
+	}
+
+	output, err := executeCommand(t, "--config", configPath, "config", "migrate", "--to-keyring")
+	if err != nil || !strings.Contains(output, "moved to the OS keyring") {
+		t.Fatalf("Expected migrate --to-keyring to succeed, got %v: %s", err, output)
+	}
+	saved, _ := os.ReadFile(configPath)
+	if strings.Contains(string(saved), "file-token-1234") {
+		t.Errorf("Expected the token removed from the config file:\n%s", saved)
+	}
+
+	// Commands read the token from the keyring
+	if _, err := executeCommand(t, "--config", configPath, "get", "1"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if authHeader != "Token file-token-1234" {
+		t.Errorf("Expected the keyring token to be sent, got %q", authHeader)
+	}
+	output, err = executeCommand(t, "--config", configPath, "config", "show")
+	if err != nil || !strings.Contains(output, "(OS keyring)") {
+		t.Errorf("Expected config show to name the keyring, got %v: %s", err, output)
+	}
+
+	output, err = executeCommand(t, "--config", configPath, "config", "migrate", "--to-file", "--json")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	var result map[string]string
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+	if result["token_store"] != "file" || result["profile"] != "default" {
+		t.Errorf("Unexpected result: %v", result)
+	}
+	saved, _ = os.ReadFile(configPath)
+	if !strings.Contains(string(saved), "file-token-1234") || strings.Contains(string(saved), "token_keyring") {
+		t.Errorf("Expected the token back in the config file:\n%s", saved)
+	}
+	if _, err := gokeyring.Get(config.KeyringService, "default"); !errors.Is(err, gokeyring.ErrNotFound) {
+		t.Errorf("Expected the keyring entry removed, got %v", err)
+	}
+}
+
+// ================= BOOKMARKS MOVE-TAGS TESTS =================
+
+func TestMovePrefixedTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		changed bool
+	}{
+		{"prefix rewritten", []string{"project-foo", "go"}, []string{"proj/foo", "go"}, true},
+		{"case-insensitive prefix", []string{"Project-Bar"}, []string{"proj/Bar"}, true},
+		{"prefix only at the start", []string{"my-project-foo", "projects"}, []string{"my-project-foo", "projects"}, false},
+		{"collision with existing tag merges", []string{"proj/foo", "project-foo", "go"}, []string{"proj/foo", "go"}, true},
+		{"two tags rewrite to one", []string{"project-foo", "PROJECT-foo"}, []string{"proj/foo"}, true},
+		{"no tags", nil, []string{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renames := map[string]string{}
+			got, changed := movePrefixedTags(tt.tags, "project-", "proj/", renames)
+			if !reflect.DeepEqual(got, tt.want) || changed != tt.changed {
+				t.Errorf("movePrefixedTags(%v) = %v, %v; want %v, %v", tt.tags, got, changed, tt.want, tt.changed)
+			}
+			if !changed && len(renames) != 0 {
+				t.Errorf("Expected no renames, got %v", renames)
+			}
+		})
+	}
+}
+
+// setupMoveTagsServer serves bookmarks with project- tags and records the
+// tag lists PATCHed, by bookmark ID.
+func setupMoveTagsServer(t *testing.T) map[int][]string {
+	t.Helper()
+	patches := map[int][]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "PATCH" {
+			var id int
+			_, _ = fmt.Sscanf(r.URL.Path, "/api/bookmarks/%d/", &id)
+			var update models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			patches[id] = *update.TagNames
+			_ = json.NewEncoder(w).Encode(mockBookmark(id, "https://example.com", "Example", *update.TagNames))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 3, Results: []models.Bookmark{
+			mockBookmark(1, "https://one.example.com", "One", []string{"project-foo", "go"}),
+			mockBookmark(2, "https://two.example.com", "Two", []string{"proj/foo", "project-foo"}),
+			mockBookmark(3, "https://three.example.com", "Three", []string{"other"}),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return patches
+}
+
+func TestBookmarksMoveTags(t *testing.T) {
+	t.Run("abort", func(t *testing.T) {
+		patches := setupMoveTagsServer(t)
+		feedStdin(t, "n\n")
+		output, err := executeCommand(t, "bookmarks", "move-tags", "--from-prefix", "project-", "--to-prefix", "proj/")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "About to rename 1 tag(s) on 2 bookmark(s)") || !strings.Contains(output, "project-foo → proj/foo") || !strings.Contains(output, "Aborted") {
+			t.Errorf("Expected a prompt and abort, got:\n%s", output)
+		}
+		if len(patches) != 0 {
+			t.Errorf("Expected no PATCH after aborting, got %v", patches)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		patches := setupMoveTagsServer(t)
+		output, err := executeCommand(t, "bookmarks", "move-tags", "--from-prefix", "project-", "--to-prefix", "proj/", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		want := map[int][]string{1: {"proj/foo", "go"}, 2: {"proj/foo"}}
+		if !reflect.DeepEqual(patches, want) {
+			t.Errorf("Expected PATCHes %v, got %v", want, patches)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+		}
+		if result["tags_rewritten"] != float64(1) || result["bookmarks_updated"] != float64(2) {
+			t.Errorf("Unexpected counts: %v", result)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		patches := setupMoveTagsServer(t)
+		output, err := executeCommand(t, "bookmarks", "move-tags", "--from-prefix", "project-", "--to-prefix", "proj/", "-O", "yaml")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(patches) != 2 {
+			t.Errorf("Expected both bookmarks patched without a prompt, got %v", patches)
+		}
+		var result map[string]interface{}
+		if err := yaml.Unmarshal([]byte(output), &result); err != nil || result["bookmarks_updated"] != 2 {
+			t.Errorf("Expected YAML counts, got %v:\n%s", err, output)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		patches := setupMoveTagsServer(t)
+		output, err := executeCommand(t, "bookmarks", "move-tags", "--from-prefix", "project-", "--to-prefix", "proj/", "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Would PATCH /api/bookmarks/2/") || strings.Contains(output, "/api/bookmarks/3/") {
+			t.Errorf("Expected PATCHes for bookmarks 1 and 2 only, got:\n%s", output)
+		}
+		if len(patches) != 0 {
+			t.Errorf("Expected no PATCH under --dry-run, got %v", patches)
+		}
+	})
+
+	t.Run("prefixes required", func(t *testing.T) {
+		if _, err := executeCommand(t, "bookmarks", "move-tags", "--from-prefix", "project-"); err == nil {
+			t.Error("Expected an error without --to-prefix")
+		}
+	})
+}
+
+// ================= QUIET TESTS =================
+
+func TestQuietFlag(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/bookmarks/" && r.Method == "POST":
+			var create models.BookmarkCreate
+			_ = json.NewDecoder(r.Body).Decode(&create)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(42, create.URL, "Example", create.TagNames))
+		case r.URL.Path == "/api/bookmarks/1/" && r.Method == "PATCH":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "New title", nil))
+		case r.URL.Path == "/api/bookmarks/2/":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("add prints only the ID", func(t *testing.T) {
+		output, err := executeCommand(t, "add", "https://example.com", "--quiet")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if output != "42\n" {
+			t.Errorf("Expected only the new ID, got: %q", output)
+		}
+	})
+
+	t.Run("update prints nothing", func(t *testing.T) {
+		output, err := executeCommand(t, "update", "1", "--title", "New title", "--quiet")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if output != "" {
+			t.Errorf("Expected no output, got: %q", output)
+		}
+	})
+
+	t.Run("errors are still returned", func(t *testing.T) {
+		if _, err := executeCommand(t, "update", "2", "--title", "New title", "--quiet"); err == nil {
+			t.Error("Expected an error for a failed update")
+		}
+	})
+
+	t.Run("json is unaffected", func(t *testing.T) {
+		output, err := executeCommand(t, "add", "https://example.com", "--quiet", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var bookmark models.Bookmark
+		if err := json.Unmarshal([]byte(output), &bookmark); err != nil || bookmark.ID != 42 {
+			t.Errorf("Expected the bookmark as JSON, got: %q (%v)", output, err)
+		}
+	})
+}
+
+// ================= BOOKMARKS COUNT TESTS =================
+
+// setupCountServer serves a fixed set of bookmarks. Each word of q must
+// match a tag, which is enough to check that filters reach the server.
+func setupCountServer(t *testing.T) *[]string {
+	t.Helper()
+	var queries []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+
+		dataset := []models.Bookmark{
+			mockBookmark(1, "https://one.example.com", "One", []string{"go", "cli"}),
+			mockBookmark(2, "https://two.example.com", "Two", []string{"go"}),
+			mockBookmark(3, "https://three.example.com", "Three", []string{"rust"}),
+			mockBookmark(4, "https://four.example.com", "Four", nil),
+			mockBookmark(5, "https://five.example.com", "Five", []string{"go", "rust"}),
+		}
+		dataset[0].Unread = true
+		dataset[1].Unread, dataset[1].Shared = true, true
+		dataset[2].IsArchived = true
+		dataset[3].Shared = true
+
+		var results []models.Bookmark
+		for _, b := range dataset {
+			match := true
+			for _, term := range strings.Fields(q) {
+				match = match && b.HasAnyTag([]string{term})
+			}
+			if match {
+				results = append(results, b)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return &queries
+}
+
+func TestCountBookmarks(t *testing.T) {
+	bookmarks := []models.Bookmark{
+		mockBookmark(1, "https://one.example.com", "One", []string{"b", "a"}),
+		mockBookmark(2, "https://two.example.com", "Two", []string{"b"}),
+		mockBookmark(3, "https://three.example.com", "Three", nil),
+	}
+	bookmarks[0].Unread = true
+	bookmarks[2].IsArchived, bookmarks[2].Shared = true, true
+
+	got := countBookmarks(bookmarks)
+	want := bookmarkCounts{
+		Total: 3, Unread: 1, Archived: 1, Shared: 1, Untagged: 1,
+		perTag: map[string]int{"a": 1, "b": 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countBookmarks() = %+v, want %+v", got, want)
+	}
+	wantTags := []models.TagWithCount{{Name: "b", Count: 2}, {Name: "a", Count: 1}}
+	if tags := got.tagsByCount(); !reflect.DeepEqual(tags, wantTags) {
+		t.Errorf("tagsByCount() = %v, want %v", tags, wantTags)
+	}
+}
+
+func TestBookmarksCount(t *testing.T) {
+	t.Run("totals", func(t *testing.T) {
+		setupCountServer(t)
+		output, err := executeCommand(t, "bookmarks", "count")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		for _, want := range []string{"Bookmarks:  5", "Unread:     2", "Archived:   1", "Shared:     2", "Untagged:   1"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in output, got:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "TAG") {
+			t.Errorf("Expected no per-tag table without --by-tag, got:\n%s", output)
+		}
+	})
+
+	t.Run("json with tag filter", func(t *testing.T) {
+		queries := setupCountServer(t)
+		output, err := executeCommand(t, "bookmarks", "count", "--tags", "go", "--by-tag", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(*queries) == 0 || (*queries)[0] != " go" {
+			t.Errorf("Expected the tag filter in q, got %q", *queries)
+		}
+		var counts bookmarkCounts
+		if err := json.Unmarshal([]byte(output), &counts); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+		}
+		want := bookmarkCounts{
+			Total: 3, Unread: 2, Archived: 0, Shared: 1, Untagged: 0,
+			ByTag: []models.TagWithCount{{Name: "go", Count: 3}, {Name: "cli", Count: 1}, {Name: "rust", Count: 1}},
+		}
+		if !reflect.DeepEqual(counts, want) {
+			t.Errorf("Expected %+v, got %+v", want, counts)
+		}
+	})
+
+	t.Run("query with by-tag table", func(t *testing.T) {
+		queries := setupCountServer(t)
+		output, err := executeCommand(t, "bookmarks", "count", "--query", "rust", "--by-tag")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(*queries) == 0 || (*queries)[0] != "rust" {
+			t.Errorf("Expected the query in q, got %q", *queries)
+		}
+		if !strings.Contains(output, "Bookmarks:  2") || !strings.Contains(output, "rust  2") {
+			t.Errorf("Expected counts for the rust bookmarks, got:\n%s", output)
+		}
+	})
+}
+
+// ================= CACHE TESTS =================
+
+func TestCacheFlags(t *testing.T) {
+	var full int
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Cached Title", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		output, err := executeCommand(t, "get", "1", "--cache-ttl", "1m")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Cached Title") {
+			t.Errorf("Expected the bookmark from run %d, got:\n%s", i+1, output)
+		}
+	}
+	if full != 1 {
+		t.Errorf("Expected the second run to reuse the cached body, got %d full responses", full)
+	}
+
+	if _, err := executeCommand(t, "get", "1", "--cache-ttl", "1m", "--no-cache"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if _, err := executeCommand(t, "get", "1"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if full != 3 {
+		t.Errorf("Expected --no-cache and the default to skip the cache, got %d full responses", full)
+	}
+
+	if _, err := executeCommand(t, "get", "1", "--cache-ttl", "-1m"); err == nil || !strings.Contains(err.Error(), "--cache-ttl") {
+		t.Errorf("Expected an error for a negative --cache-ttl, got: %v", err)
+	}
+}
+
+// ================= BULK TESTS =================
+
+const bulkTestOps = `{"op": "add", "url": "https://new.example.com", "title": "New", "tags": ["go"]}
+
+{"op": "update", "id": 99, "title": "Missing"}
+{"op": "update", "id": 2, "tags": []}
+{"op": "delete", "id": 3}
+`
+
+// setupBulkServer records the requests it receives; bookmark 99 is
+// forbidden.
+func setupBulkServer(t *testing.T) *[]string {
+	t.Helper()
+	var requests []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/bookmarks/99/":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(7, "https://new.example.com", "New", []string{"go"}))
+		case r.Method == "PATCH":
+			_ = json.NewEncoder(w).Encode(mockBookmark(2, "https://two.example.com", "Two", nil))
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return &requests
+}
+
+func writeBulkFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ops.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBulkCommand(t *testing.T) {
+	t.Run("continue past a failure", func(t *testing.T) {
+		requests := setupBulkServer(t)
+		output, err := executeCommand(t, "bulk", writeBulkFile(t, bulkTestOps))
+		if err == nil || !strings.Contains(err.Error(), "some operations failed") {
+			t.Errorf("Expected a failure error, got: %v", err)
+		}
+		want := []string{"POST /api/bookmarks/", "PATCH /api/bookmarks/99/", "PATCH /api/bookmarks/2/", "DELETE /api/bookmarks/3/"}
+		if !reflect.DeepEqual(*requests, want) {
+			t.Errorf("Expected requests %v, got %v", want, *requests)
+		}
+		for _, line := range []string{"✓ line 1: added bookmark 7", "✗ line 3:", "✓ line 5: deleted bookmark 3", "Completed: 3 succeeded, 1 failed"} {
+			if !strings.Contains(output, line) {
+				t.Errorf("Expected %q in output, got:\n%s", line, output)
+			}
+		}
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		requests := setupBulkServer(t)
+		output, err := executeCommand(t, "bulk", writeBulkFile(t, bulkTestOps), "--stop-on-error", "--json")
+		if err == nil {
+			t.Error("Expected an error")
+		}
+		if want := []string{"POST /api/bookmarks/", "PATCH /api/bookmarks/99/"}; !reflect.DeepEqual(*requests, want) {
+			t.Errorf("Expected requests %v, got %v", want, *requests)
+		}
+		var result struct {
+			Succeeded, Failed, Skipped int
+			Results                    []bulkResult
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+		}
+		if result.Succeeded != 1 || result.Failed != 1 || result.Skipped != 2 {
+			t.Errorf("Unexpected counts: %+v", result)
+		}
+		if len(result.Results) != 4 || result.Results[1].Line != 3 || result.Results[1].Status != "failed" || result.Results[3].Status != "skipped" {
+			t.Errorf("Unexpected results: %+v", result.Results)
+		}
+	})
+
+	t.Run("invalid lines run nothing", func(t *testing.T) {
+		requests := setupBulkServer(t)
+		ops := `{"op": "add", "url": "https://ok.example.com"}
+{"op": "update", "id": 2}
+{"op": "delete"}
+{"op": "move", "id": 1}
+{"op": "add", "url": "javascript:alert(1)"}
+not json
+`
+		_, err := executeCommand(t, "bulk", writeBulkFile(t, ops))
+		if err == nil {
+			t.Fatal("Expected a validation error")
+		}
+		for _, want := range []string{"line 2: update needs at least one", "line 3: delete needs a positive id", `line 4: unknown op "move"`, "line 5:", "line 6: invalid JSON"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in error, got: %v", want, err)
+			}
+		}
+		if len(*requests) != 0 {
+			t.Errorf("Expected no requests, got %v", *requests)
+		}
+	})
+
+	t.Run("allow scheme", func(t *testing.T) {
+		requests := setupBulkServer(t)
+		path := writeBulkFile(t, `{"op": "add", "url": "ftp://ftp.example.com/pub"}
+{"op": "update", "id": 2, "url": "ftp://ftp.example.com/new"}
+`)
+		_, err := executeCommand(t, "bulk", path)
+		if err == nil || !strings.Contains(err.Error(), "line 1:") || !strings.Contains(err.Error(), "line 2:") {
+			t.Errorf("Expected ftp URLs to be rejected by default, got: %v", err)
+		}
+		if len(*requests) != 0 {
+			t.Errorf("Expected no requests, got %v", *requests)
+		}
+
+		if _, err := executeCommand(t, "bulk", path, "--allow-scheme", "ftp"); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if want := []string{"POST /api/bookmarks/", "PATCH /api/bookmarks/2/"}; !reflect.DeepEqual(*requests, want) {
+			t.Errorf("Expected requests %v, got %v", want, *requests)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		requests := setupBulkServer(t)
+		output, err := executeCommand(t, "bulk", writeBulkFile(t, bulkTestOps), "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, "Would POST /api/bookmarks/") || !strings.Contains(output, "Would DELETE /api/bookmarks/3/") {
+			t.Errorf("Expected the planned requests, got:\n%s", output)
+		}
+		if len(*requests) != 0 {
+			t.Errorf("Expected no requests under --dry-run, got %v", *requests)
+		}
+	})
+}
+
+// ================= COLOR TESTS =================
+
+func TestColorOutput(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+	t.Setenv("NO_COLOR", "")
+
+	t.Run("not a terminal", func(t *testing.T) {
+		output, err := executeCommand(t, "add", "https://example.com")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "\x1b[") {
+			t.Errorf("Expected no ANSI escapes when output is not a terminal, got: %q", output)
+		}
+	})
+
+	// Pretend stdout and stderr are terminals
+	previous := isTerminal
+	isTerminal = func(*os.File) bool { return true }
+	t.Cleanup(func() { isTerminal = previous })
+
+	t.Run("terminal", func(t *testing.T) {
+		output, err := executeCommand(t, "add", "https://example.com")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(output, ansiGreen+"✓"+ansiReset+" Bookmark added") {
+			t.Errorf("Expected a green mark on a terminal, got: %q", output)
+		}
+	})
+
+	for name, args := range map[string][]string{
+		"no-color flag": {"add", "https://example.com", "--no-color"},
+		"json":          {"add", "https://example.com", "--json"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			output, err := executeCommand(t, args...)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if strings.Contains(output, "\x1b[") {
+				t.Errorf("Expected no ANSI escapes, got: %q", output)
+			}
+		})
+	}
+
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		output, err := executeCommand(t, "add", "https://example.com")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(output, "\x1b[") {
+			t.Errorf("Expected no ANSI escapes with NO_COLOR set, got: %q", output)
+		}
+	})
+}
+
+// ================= TAGS LIST-FOR TESTS =================
+
+func TestTagsListFor(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/bookmarks/1/":
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Tagged", []string{"go", "cli", "tools"}))
+		case "/api/bookmarks/2/":
+			_ = json.NewEncoder(w).Encode(mockBookmark(2, "https://example.org", "Untagged", nil))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"one per line", []string{"tags", "list-for", "1"}, "go\ncli\ntools\n"},
+		{"inline", []string{"tags", "list-for", "1", "--inline"}, "go,cli,tools\n"},
+		{"json", []string{"tags", "list-for", "1", "--json"}, "[\n  \"go\",\n  \"cli\",\n  \"tools\"\n]\n"},
+		{"no tags", []string{"tags", "list-for", "2"}, ""},
+		{"no tags inline", []string{"tags", "list-for", "2", "--inline"}, ""},
+		{"no tags json", []string{"tags", "list-for", "2", "--json"}, "[]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := executeCommand(t, tt.args...)
+			if err != nil {
+				t.Fatalf("Command failed: %v", err)
+			}
+			if output != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, output)
+			}
+		})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		if _, err := executeCommand(t, "tags", "list-for", "abc"); err == nil || !strings.Contains(err.Error(), "invalid bookmark ID") {
+			t.Errorf("Expected an invalid ID error, got: %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := executeCommand(t, "tags", "list-for", "99"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected a not found error, got: %v", err)
+		}
+	})
+}
+
+// ================= INTERACTIVE ADD TESTS =================
+
+// setupInteractiveAddServer records the bookmark created.
+func setupInteractiveAddServer(t *testing.T) *models.BookmarkCreate {
+	t.Helper()
+	created := &models.BookmarkCreate{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/bookmarks/" || r.Method != "POST" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(created)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(mockBookmark(5, created.URL, created.Title, created.TagNames))
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return created
+}
+
+func TestAddInteractive(t *testing.T) {
+	t.Run("all fields from stdin", func(t *testing.T) {
+		created := setupInteractiveAddServer(t)
+		feedStdin(t, "https://example.com\nExample\nA site\ngo, cli ,\ny\nn\n")
+		output, err := executeCommand(t, "add", "--interactive")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		want := models.BookmarkCreate{URL: "https://example.com", Title: "Example", Description: "A site", TagNames: []string{"go", "cli"}, Unread: true}
+		if !reflect.DeepEqual(*created, want) {
+			t.Errorf("Expected %+v, got %+v", want, *created)
+		}
+		if !strings.Contains(output, "URL: ") || !strings.Contains(output, "✓ Bookmark added") {
+			t.Errorf("Expected prompts and a success message, got:\n%s", output)
+		}
+	})
+
+	t.Run("url argument and flag defaults", func(t *testing.T) {
+		created := setupInteractiveAddServer(t)
+		feedStdin(t, "\n\n\n\n\n")
+		output, err := executeCommand(t, "add", "https://example.com", "-i", "--title", "From flag", "--tags", "go", "--shared")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		want := models.BookmarkCreate{URL: "https://example.com", Title: "From flag", TagNames: []string{"go"}, Shared: true}
+		if !reflect.DeepEqual(*created, want) {
+			t.Errorf("Expected %+v, got %+v", want, *created)
+		}
+		if strings.Contains(output, "\nURL: ") || !strings.Contains(output, "Title (blank to fetch it) [From flag]: ") || !strings.Contains(output, "(Y/n)") {
+			t.Errorf("Expected prompts with the flag defaults and no URL prompt, got:\n%s", output)
+		}
+	})
+
+	t.Run("empty url re-prompts", func(t *testing.T) {
+		created := setupInteractiveAddServer(t)
+		feedStdin(t, "\nhttps://example.com\n")
+		output, err := executeCommand(t, "add", "-i")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if created.URL != "https://example.com" || !strings.Contains(output, "A URL is required.") {
+			t.Errorf("Expected a second URL prompt, got %+v and:\n%s", *created, output)
+		}
+	})
+
+	t.Run("no url at end of input", func(t *testing.T) {
+		created := setupInteractiveAddServer(t)
+		feedStdin(t, "\n")
+		if _, err := executeCommand(t, "add", "-i"); err == nil || !strings.Contains(err.Error(), "a URL is required") {
+			t.Errorf("Expected a missing URL error, got: %v", err)
+		}
+		if created.URL != "" {
+			t.Errorf("Expected no bookmark created, got %+v", *created)
+		}
+	})
+
+	t.Run("url still required without interactive", func(t *testing.T) {
+		if _, err := executeCommand(t, "add"); err == nil {
+			t.Error("Expected an error without a URL")
+		}
+	})
+}
+
+// ================= PROGRESS TESTS =================
+
+func TestProgressOutput(t *testing.T) {
+	created := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			created++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(mockBookmark(created, "https://example.com", "Test", nil))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{
+			mockBookmark(100, "https://existing.example.com/1", "One", nil),
+			mockBookmark(101, "https://existing.example.com/2", "Two", nil),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	t.Setenv("NO_COLOR", "1")
+
+	file := filepath.Join(t.TempDir(), "bookmarks.json")
+	content := `{"version": "1", "bookmarks": [{"url": "https://a.example.com"}, {"url": "https://b.example.com"}, {"url": "https://c.example.com"}]}`
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("not a terminal", func(t *testing.T) {
+		_, stderr, err := executeCommandStreams(t, "import", file)
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(stderr, "Imported 3/3") || strings.Contains(stderr, "\r") {
+			t.Errorf("Expected no progress when stderr is not a terminal, got: %q", stderr)
+		}
+	})
+
+	// Pretend stdout and stderr are terminals
+	previous := isTerminal
+	isTerminal = func(*os.File) bool { return true }
+	t.Cleanup(func() { isTerminal = previous })
+
+	t.Run("import", func(t *testing.T) {
+		stdout, stderr, err := executeCommandStreams(t, "import", file)
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(stderr, "\r\x1b[KImported 3/3") {
+			t.Errorf("Expected progress on stderr, got: %q", stderr)
+		}
+		if strings.Contains(stdout, "Imported") {
+			t.Errorf("Expected no progress on stdout, got: %q", stdout)
+		}
+		if !strings.Contains(stderr, "\r\x1b[K  ✓ 3 new bookmarks added") {
+			t.Errorf("Expected the progress line cleared before the summary, got: %q", stderr)
+		}
+	})
+
+	t.Run("restore json", func(t *testing.T) {
+		stdout, stderr, err := executeCommandStreams(t, "restore", file, "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Errorf("Expected parseable JSON on stdout, got %v: %q", err, stdout)
+		}
+		if strings.Contains(stderr, "Restored") {
+			t.Errorf("Expected no progress under --json, got: %q", stderr)
+		}
+	})
+
+	t.Run("restore quiet", func(t *testing.T) {
+		_, stderr, err := executeCommandStreams(t, "restore", file, "--quiet")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if strings.Contains(stderr, "Restored") {
+			t.Errorf("Expected no progress under --quiet, got: %q", stderr)
+		}
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		_, stderr, err := executeCommandStreams(t, "backup", "--output", t.TempDir())
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(stderr, "Fetched 2/2") || !strings.Contains(stderr, "\r\x1b[KBackup created: ") {
+			t.Errorf("Expected fetch progress before the summary, got: %q", stderr)
+		}
+	})
+}
+
+// ================= TLS TESTS =================
+
+func TestTLSFlags(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 0, Results: []models.Bookmark{}})
+	}))
+	t.Cleanup(server.Close)
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("verified by default", func(t *testing.T) {
+		_, err := executeCommand(t, "config", "test")
+		if err == nil || !strings.Contains(err.Error(), "certificate") {
+			t.Errorf("Expected a certificate error, got: %v", err)
+		}
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		output, err := executeCommand(t, "config", "test", "--insecure")
+		if err != nil {
+			t.Fatalf("Expected --insecure to allow the connection, got: %v", err)
+		}
+		if !strings.Contains(output, "Successfully connected") {
+			t.Errorf("Expected success message, got: %s", output)
+		}
+	})
+
+	t.Run("cacert", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := executeCommand(t, "config", "test", "--cacert", caFile); err != nil {
+			t.Errorf("Expected --cacert to trust the server, got: %v", err)
+		}
+	})
+
+	t.Run("missing cacert", func(t *testing.T) {
+		_, err := executeCommand(t, "config", "test", "--cacert", filepath.Join(t.TempDir(), "missing.pem"))
+		if err == nil || !strings.Contains(err.Error(), "--cacert") {
+			t.Errorf("Expected a --cacert error, got: %v", err)
+		}
+	})
+}
+
+// ================= PROXY TESTS =================
+
+func TestProxyFlag(t *testing.T) {
+	var mu sync.Mutex
+	var proxied []string
+	proxy := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives the absolute URL of the target
+		mu.Lock()
+		proxied = append(proxied, r.URL.String())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bookmarks/1/" {
+			_ = json.NewEncoder(w).Encode(mockBookmark(1, "https://example.com", "Example", nil))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+			mockBookmark(1, "https://example.com", "Example", nil),
+		}})
+	})
+	setTestEnv(t, "http://linkding.invalid", "test-token")
+
+	output, err := executeCommand(t, "export", "--proxy", proxy.URL)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if !strings.Contains(output, "https://example.com") {
+		t.Errorf("Expected the bookmark fetched through the proxy, got: %s", output)
+	}
+	if _, err := executeCommand(t, "get", "1", "--proxy", proxy.URL); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if len(proxied) != 2 || !strings.HasPrefix(proxied[0], "http://linkding.invalid/api/bookmarks/?") ||
+		proxied[1] != "http://linkding.invalid/api/bookmarks/1/" {
+		t.Errorf("Expected both requests to go through the proxy, got %v", proxied)
+	}
+
+	for _, bad := range []string{"proxy.example.com:3128", "ftp://proxy.example.com"} {
+		if _, err := executeCommand(t, "get", "1", "--proxy", bad); err == nil || !strings.Contains(err.Error(), "invalid --proxy") {
+			t.Errorf("Expected an invalid --proxy error for %q, got: %v", bad, err)
+		}
+	}
+}
+
+// ================= RESTORE UPDATE-EXISTING TESTS =================
+
+func TestRestoreUpdateExisting(t *testing.T) {
+	var mu sync.Mutex
+	var writes []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+				mockBookmark(7, "https://existing.example.com", "Old title", []string{"old"}),
+			}})
+			return
+		}
+		mu.Lock()
+		writes = append(writes, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(mockBookmark(8, "https://new.example.com", "New", nil))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	file := filepath.Join(t.TempDir(), "backup.json")
+	content := `{"version": "1", "bookmarks": [
+		{"url": "https://existing.example.com", "title": "New title", "tags": ["fresh"]},
+		{"url": "https://new.example.com", "title": "New"}
+	]}`
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	restore := func(t *testing.T, args ...string) map[string]interface{} {
+		t.Helper()
+		writes = nil
+		output, err := executeCommand(t, append([]string{"restore", file, "--summary-only"}, args...)...)
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		var summary map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &summary); err != nil {
+			t.Fatalf("Expected a JSON summary, got %v: %s", err, output)
+		}
+		return summary
+	}
+
+	t.Run("adds and updates", func(t *testing.T) {
+		summary := restore(t)
+		if summary["added"] != float64(1) || summary["updated"] != float64(1) {
+			t.Errorf("Expected 1 added and 1 updated, got %v", summary)
+		}
+		if len(writes) != 2 || !slices.Contains(writes, "PATCH /api/bookmarks/7/") || !slices.Contains(writes, "POST /api/bookmarks/") {
+			t.Errorf("Expected a PATCH of bookmark 7 and a POST, got %v", writes)
+		}
+	})
+
+	t.Run("dry run counts updates", func(t *testing.T) {
+		summary := restore(t, "--dry-run")
+		if summary["added"] != float64(1) || summary["updated"] != float64(1) {
+			t.Errorf("Expected 1 would-add and 1 would-update, got %v", summary)
+		}
+		if len(writes) != 0 {
+			t.Errorf("Expected no writes under --dry-run, got %v", writes)
+		}
+	})
+
+	t.Run("leave existing alone", func(t *testing.T) {
+		summary := restore(t, "--update-existing=false")
+		if summary["added"] != float64(1) || summary["updated"] != float64(0) || summary["skipped"] != float64(1) {
+			t.Errorf("Expected 1 added and 1 skipped, got %v", summary)
+		}
+		if len(writes) != 1 || writes[0] != "POST /api/bookmarks/" {
+			t.Errorf("Expected only the new bookmark created, got %v", writes)
+		}
+
+		summary = restore(t, "--update-existing=false", "--dry-run")
+		if summary["added"] != float64(1) || summary["skipped"] != float64(1) {
+			t.Errorf("Expected 1 would-add and 1 skipped, got %v", summary)
+		}
+
+		_, stderr, err := executeCommandStreams(t, "restore", file, "--update-existing=false", "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if !strings.Contains(stderr, "⊘ 1 already existed and were left alone (--update-existing=false)") || strings.Contains(stderr, "--skip-duplicates") {
+			t.Errorf("Expected the skipped count to name restore's own flag, got: %s", stderr)
+		}
+	})
+
+	t.Run("not with wipe", func(t *testing.T) {
+		_, err := executeCommand(t, "restore", file, "--wipe", "--update-existing")
+		if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+			t.Errorf("Expected --wipe and --update-existing to be exclusive, got: %v", err)
+		}
+	})
+}
+
+// ================= TAG STATS TESTS =================
+
+// setupTagStatsServer serves the same bookmarks whatever the query, so the
+// command must itself skip bookmarks without the tag.
+func setupTagStatsServer(t *testing.T) {
+	t.Helper()
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		results := []models.Bookmark{
+			mockBookmark(1, "https://one.example.com", "One", []string{"go", "cli", "tools"}),
+			mockBookmark(2, "https://two.example.com", "Two", []string{"Go", "cli"}),
+			mockBookmark(3, "https://three.example.com", "Three", []string{"go", "web"}),
+			mockBookmark(4, "https://four.example.com", "Four", []string{"rust", "cli"}),
+			mockBookmark(5, "https://five.example.com", "Five", []string{"go"}),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+}
+
+func TestCountTagStats(t *testing.T) {
+	bookmarks := []models.Bookmark{
+		mockBookmark(1, "https://one.example.com", "One", []string{"go", "cli", "tools", "cli"}),
+		mockBookmark(2, "https://two.example.com", "Two", []string{"Go", "cli"}),
+		mockBookmark(3, "https://three.example.com", "Three", []string{"rust", "cli"}),
+	}
+	stats := countTagStats(bookmarks, "go")
+	if stats.Bookmarks != 2 {
+		t.Errorf("Expected 2 bookmarks, got %d", stats.Bookmarks)
+	}
+	want := []models.TagWithCount{{Name: "cli", Count: 2}, {Name: "tools", Count: 1}}
+	if !slices.Equal(stats.Tags, want) {
+		t.Errorf("Expected %v, got %v", want, stats.Tags)
+	}
+}
+
+func TestBookmarksTagStats(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		setupTagStatsServer(t)
+		output, err := executeCommand(t, "bookmarks", "tag-stats", "go")
+		if err != nil {
+			t.Fatalf("tag-stats failed: %v", err)
+		}
+		if !strings.Contains(output, "4 bookmark(s) tagged 'go'") {
+			t.Errorf("Expected the bookmark count, got:\n%s", output)
+		}
+		cli := strings.Index(output, "cli ")
+		tools := strings.Index(output, "tools ")
+		web := strings.Index(output, "web ")
+		if cli < 0 || tools < 0 || web < 0 || !(cli < tools && tools < web) {
+			t.Errorf("Expected cli, tools and web in order, got:\n%s", output)
+		}
+		if strings.Contains(output, "rust") {
+			t.Errorf("Expected bookmarks without the tag ignored, got:\n%s", output)
+		}
+	})
+
+	t.Run("json with top", func(t *testing.T) {
+		setupTagStatsServer(t)
+		output, err := executeCommand(t, "bookmarks", "tag-stats", "go", "--top", "1", "--json")
+		if err != nil {
+			t.Fatalf("tag-stats failed: %v", err)
+		}
+		var stats tagStats
+		if err := json.Unmarshal([]byte(output), &stats); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		want := []models.TagWithCount{{Name: "cli", Count: 2}}
+		if stats.Tag != "go" || stats.Bookmarks != 4 || !slices.Equal(stats.Tags, want) {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("no bookmarks", func(t *testing.T) {
+		setupTagStatsServer(t)
+		output, err := executeCommand(t, "bookmarks", "tag-stats", "python")
+		if err != nil {
+			t.Fatalf("tag-stats failed: %v", err)
+		}
+		if !strings.Contains(output, "No bookmarks tagged 'python'") {
+			t.Errorf("Expected a no bookmarks message, got:\n%s", output)
+		}
+	})
+
+	t.Run("negative top", func(t *testing.T) {
+		setupTagStatsServer(t)
+		_, err := executeCommand(t, "bookmarks", "tag-stats", "go", "--top", "-1")
+		if err == nil || !strings.Contains(err.Error(), "--top") {
+			t.Errorf("Expected a --top error, got: %v", err)
+		}
+	})
+}
+
+// ================= TEMPLATE TESTS =================
+
+func TestListTemplate(t *testing.T) {
+	requests := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 2, Results: []models.Bookmark{
+			mockBookmark(1, "https://one.example.com", "One", []string{"go", "cli"}),
+			mockBookmark(2, "https://two.example.com", "Two", nil),
+		}})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	t.Run("valid template", func(t *testing.T) {
+		output, err := executeCommand(t, "list", "--tags", "go", "--template", "{{.ID}} {{.Title}} {{range .TagNames}}#{{.}} {{end}}")
+		if err != nil {
+			t.Fatalf("list --template failed: %v", err)
+		}
+		if output != "1 One #go #cli \n2 Two \n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+
+	t.Run("template file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bookmark.tmpl")
+		if err := os.WriteFile(path, []byte(`{{.URL}} [{{join .TagNames ","}}]`), 0600); err != nil {
+			t.Fatal(err)
+		}
+		output, err := executeCommand(t, "list", "--template-file", path)
+		if err != nil {
+			t.Fatalf("list --template-file failed: %v", err)
+		}
+		if output != "https://one.example.com [go,cli]\nhttps://two.example.com []\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+	})
+
+	t.Run("parse error before any request", func(t *testing.T) {
+		requests = 0
+		_, err := executeCommand(t, "list", "--template", "{{.Title")
+		if err == nil || !strings.Contains(err.Error(), "invalid template") {
+			t.Errorf("Expected a template parse error, got: %v", err)
+		}
+		if requests != 0 {
+			t.Errorf("Expected no requests, got %d", requests)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--template", "{{.Nope}}")
+		if err == nil || !strings.Contains(err.Error(), "bookmark 1") || !strings.Contains(err.Error(), "Nope") {
+			t.Errorf("Expected a render error naming the field, got: %v", err)
+		}
+	})
+
+	t.Run("json conflicts", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--template", "{{.ID}}", "--json")
+		if err == nil || !strings.Contains(err.Error(), "--json") {
+			t.Errorf("Expected --template and --json to conflict, got: %v", err)
+		}
+	})
+}
+
+func TestGetTemplate(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockBookmark(7, "https://example.com", "Example", []string{"a", "b"}))
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	output, err := executeCommand(t, "get", "7", "--template", `{{.ID}}: {{join .TagNames ", "}}`)
+	if err != nil {
+		t.Fatalf("get --template failed: %v", err)
+	}
+	if output != "7: a, b\n" {
+		t.Errorf("Unexpected output: %q", output)
+	}
+
+	_, err = executeCommand(t, "get", "7", "--template-file", filepath.Join(t.TempDir(), "missing.tmpl"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read template file") {
+		t.Errorf("Expected a read error, got: %v", err)
+	}
+}
+
+// ================= LIST ID CURSOR TESTS =================
+
+func TestListIDBounds(t *testing.T) {
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var results []models.Bookmark
+		for id := 5; id >= 1; id-- {
+			results = append(results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), fmt.Sprintf("Bookmark %d", id), nil))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	listIDs := func(t *testing.T, args ...string) []int {
+		t.Helper()
+		output, err := executeCommand(t, append([]string{"list", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("list failed: %v", err)
+		}
+		var list models.BookmarkList
+		if err := json.Unmarshal([]byte(output), &list); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		var ids []int
+		for _, b := range list.Results {
+			ids = append(ids, b.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []int
+	}{
+		{"after-id is exclusive", []string{"--after-id", "3"}, []int{5, 4}},
+		{"before-id is exclusive", []string{"--before-id", "3"}, []int{2, 1}},
+		{"both bounds", []string{"--after-id", "1", "--before-id", "5"}, []int{4, 3, 2}},
+		{"with --all", []string{"--all", "--after-id", "4"}, []int{5}},
+		{"past the newest", []string{"--after-id", "5"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listIDs(t, tt.args...); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected IDs %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("empty range", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--after-id", "3", "--before-id", "4")
+		if err == nil || !strings.Contains(err.Error(), "no ID is both") {
+			t.Errorf("Expected an empty range error, got: %v", err)
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--after-id", "-1")
+		if err == nil || !strings.Contains(err.Error(), "must be positive") {
+			t.Errorf("Expected a negative ID error, got: %v", err)
+		}
+	})
+}
+
+// ================= LIST SORT TESTS =================
+
+func TestListSort(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2024, 1, n, 12, 0, 0, 0, time.UTC) }
+	fixture := []models.Bookmark{
+		mockBookmark(3, "https://c.example.com", "banana", nil),
+		mockBookmark(1, "https://a.example.com", "Cherry", nil),
+		mockBookmark(2, "https://b.example.com", "apple", nil),
+	}
+	fixture[0].DateAdded = day(2)
+	fixture[1].DateAdded = day(3)
+	fixture[2].DateAdded = day(1)
+
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(fixture), Results: fixture})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	tests := []struct {
+		name string
+		args []string
+		want []int
+	}{
+		{"title", []string{"--sort", "title"}, []int{2, 3, 1}},
+		{"title descending", []string{"--sort", "-title"}, []int{1, 3, 2}},
+		{"date added", []string{"--sort", "date_added"}, []int{2, 3, 1}},
+		{"date added descending", []string{"--sort", "-date_added"}, []int{1, 3, 2}},
+		{"url", []string{"--sort", "url"}, []int{1, 2, 3}},
+		{"id descending", []string{"--sort", "-id"}, []int{3, 2, 1}},
+		{"with --all", []string{"--all", "--sort", "id"}, []int{1, 2, 3}},
+		{"with --filter", []string{"--filter", "NOT shared", "--sort", "title", "--limit", "2"}, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := executeCommand(t, append([]string{"list", "--json"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			var list models.BookmarkList
+			if err := json.Unmarshal([]byte(output), &list); err != nil {
+				t.Fatalf("Invalid JSON: %v\n%s", err, output)
+			}
+			var ids []int
+			for _, b := range list.Results {
+				ids = append(ids, b.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("Expected IDs %v, got %v", tt.want, ids)
+			}
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := executeCommand(t, "list", "--sort", "tags")
+		if err == nil || !strings.Contains(err.Error(), `invalid --sort "tags"`) {
+			t.Errorf("Expected an invalid sort error, got: %v", err)
+		}
+	})
+}
+
+// ================= BOOKMARKS RANDOM TESTS =================
+
+func TestBookmarksRandom(t *testing.T) {
+	var queries []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		var results []models.Bookmark
+		for id := 10; id >= 1; id-- {
+			results = append(results, mockBookmark(id, fmt.Sprintf("https://example.com/%d", id), fmt.Sprintf("Bookmark %d", id), nil))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+
+	randomIDs := func(t *testing.T, args ...string) []int {
+		t.Helper()
+		output, err := executeCommand(t, append([]string{"bookmarks", "random", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("bookmarks random failed: %v", err)
+		}
+		var bookmarks []models.Bookmark
+		if err := json.Unmarshal([]byte(output), &bookmarks); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, output)
+		}
+		var ids []int
+		for _, b := range bookmarks {
+			ids = append(ids, b.ID)
+		}
+		return ids
+	}
+
+	t.Run("same seed, same selection", func(t *testing.T) {
+		first := randomIDs(t, "--count", "3", "--seed", "42")
+		if len(first) != 3 {
+			t.Fatalf("Expected 3 bookmarks, got %v", first)
+		}
+		if again := randomIDs(t, "--count", "3", "--seed", "42"); !slices.Equal(first, again) {
+			t.Errorf("Expected --seed 42 to repeat %v, got %v", first, again)
+		}
+		if other := randomIDs(t, "--count", "3", "--seed", "7"); slices.Equal(first, other) {
+			t.Errorf("Expected another seed to choose differently than %v", first)
+		}
+	})
+
+	t.Run("more than available", func(t *testing.T) {
+		ids := randomIDs(t, "--count", "50", "--seed", "1")
+		slices.Sort(ids)
+		if !slices.Equal(ids, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+			t.Errorf("Expected every bookmark once, got %v", ids)
+		}
+	})
+
+	t.Run("filters are sent", func(t *testing.T) {
+		queries = nil
+		randomIDs(t, "--tags", "reading", "--unread", "--seed", "1")
+		if len(queries) == 0 || !strings.Contains(queries[0], "unread=yes") || !strings.Contains(queries[0], "reading") {
+			t.Errorf("Expected the tag and unread filters in the request, got %v", queries)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		opened := stubLauncher(t)
+		ids := randomIDs(t, "--count", "2", "--seed", "42", "--open")
+		want := []string{fmt.Sprintf("https://example.com/%d", ids[0]), fmt.Sprintf("https://example.com/%d", ids[1])}
+		if !slices.Equal(*opened, want) {
+			t.Errorf("Expected %v to be opened, got %v", want, *opened)
+		}
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		_, err := executeCommand(t, "bookmarks", "random", "--count", "0")
+		if err == nil || !strings.Contains(err.Error(), "--count must be at least 1") {
+			t.Errorf("Expected a count error, got: %v", err)
+		}
+	})
+}
+
+// ================= BOOKMARKS REPARSE-TITLE TESTS =================
+
+// stubTitleFetcher replaces the page title fetcher for the test with one
+// that answers from titles, failing for URLs it does not have.
+func stubTitleFetcher(t *testing.T, titles map[string]string) {
+	t.Helper()
+	original := fetchPageTitle
+	fetchPageTitle = func(_ *http.Client, url string) (string, error) {
+		title, ok := titles[url]
+		if !ok {
+			return "", fmt.Errorf("connection refused")
+		}
+		return title, nil
+	}
+	t.Cleanup(func() { fetchPageTitle = original })
+}
+
+func TestBookmarksReparseTitle(t *testing.T) {
+	var patches []string
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "PATCH" {
+			body, _ := io.ReadAll(r.Body)
+			patches = append(patches, r.URL.Path+" "+string(body))
+			_ = json.NewEncoder(w).Encode(mockBookmark(2, "https://example.com/2", "Fresh", nil))
+			return
+		}
+		results := []models.Bookmark{
+			mockBookmark(1, "https://example.com/1", "Same", []string{"news"}),
+			mockBookmark(2, "https://example.com/2", "Stale", []string{"news"}),
+			mockBookmark(3, "https://example.com/3", "Gone", []string{"news"}),
+			// Found by the search for "news" but not tagged with it
+			mockBookmark(4, "https://example.com/4", "Breaking news", []string{"world"}),
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	stubTitleFetcher(t, map[string]string{
+		"https://example.com/1": "Same",
+		"https://example.com/2": "Fresh",
+		"https://example.com/4": "World",
+	})
+
+	t.Run("updates changed titles only", func(t *testing.T) {
+		patches = nil
+		output, err := executeCommand(t, "bookmarks", "reparse-title", "--tags", "news")
+		if err != nil {
+			t.Fatalf("Command failed: %v\n%s", err, output)
+		}
+		if len(patches) != 1 || patches[0] != `/api/bookmarks/2/ {"title":"Fresh"}` {
+			t.Errorf("Expected only bookmark 2 to be patched, got %v", patches)
+		}
+		if !strings.Contains(output, `✓ 2: "Stale" → "Fresh"`) {
+			t.Errorf("Expected the new title to be reported, got: %s", output)
+		}
+		if !strings.Contains(output, "✗ 3 (https://example.com/3): connection refused") {
+			t.Errorf("Expected the failed page to be reported, got: %s", output)
+		}
+		if !strings.Contains(output, "Retitled 1 of 3 bookmarks (1 unchanged, 1 skipped)") {
+			t.Errorf("Expected a summary, got: %s", output)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		patches = nil
+		output, err := executeCommand(t, "bookmarks", "reparse-title", "--tags", "news", "--dry-run")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if len(patches) != 0 {
+			t.Errorf("Expected no PATCH under --dry-run, got %v", patches)
+		}
+		if !strings.Contains(output, `Would retitle bookmark 2: "Stale" → "Fresh"`) {
+			t.Errorf("Expected old and new titles, got: %s", output)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		stdout, _, err := executeCommandStreams(t, "bookmarks", "reparse-title", "--tags", "news", "--json")
+		if err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		var refreshes []titleRefresh
+		if err := json.Unmarshal([]byte(stdout), &refreshes); err != nil {
+			t.Fatalf("Invalid JSON: %v\n%s", err, stdout)
+		}
+		if len(refreshes) != 3 || refreshes[0].Updated || !refreshes[1].Updated || refreshes[2].Error == "" {
+			t.Errorf("Unexpected refreshes: %+v", refreshes)
+		}
+	})
+
+	t.Run("needs IDs or tags", func(t *testing.T) {
+		_, err := executeCommand(t, "bookmarks", "reparse-title")
+		if err == nil || !strings.Contains(err.Error(), "give bookmark IDs") {
+			t.Errorf("Expected an error without IDs or tags, got: %v", err)
+		}
+	})
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		for _, value := range []string{"0", "10000"} {
+			_, err := executeCommand(t, "bookmarks", "reparse-title", "--tags", "news", "--concurrency", value)
+			if err == nil || !strings.Contains(err.Error(), "--concurrency must be between 1 and 16") {
+				t.Errorf("Expected a range error for --concurrency %s, got: %v", value, err)
+			}
+		}
+	})
+}
+
+func TestFetchPageTitle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			_, _ = fmt.Fprint(w, "<html><head><TITLE lang=\"en\">\n  Tips &amp; Tricks\n</TITLE></head></html>")
+		case "/untitled":
+			_, _ = fmt.Fprint(w, "<html><body>No title</body></html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	title, err := fetchPageTitle(server.Client(), server.URL+"/page")
+	if err != nil || title != "Tips & Tricks" {
+		t.Errorf("Expected \"Tips & Tricks\", got %q (%v)", title, err)
+	}
+	if _, err := fetchPageTitle(server.Client(), server.URL+"/untitled"); err == nil {
+		t.Error("Expected an error for a page without a title")
+	}
+	if _, err := fetchPageTitle(server.Client(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("Expected an HTTP 404 error, got: %v", err)
+	}
+}
+
+// ================= WATCH TESTS =================
+
+// setupWatchServer serves bookmarks 2 and 1 on the first poll and adds
+// bookmark 3 from the second. On the third poll it interrupts the test
+// process, which watch must treat as Ctrl+C.
+func setupWatchServer(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("sends SIGINT to the test process")
+	}
+	polls := 0
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		results := []models.Bookmark{
+			mockBookmark(2, "https://two.example.com", "Two", nil),
+			mockBookmark(1, "https://one.example.com", "One", nil),
+		}
+		if polls >= 2 {
+			results = append([]models.Bookmark{mockBookmark(3, "https://three.example.com", "Three", []string{"go", "cli"})}, results...)
+		}
+		if polls == 3 {
+			process, _ := os.FindProcess(os.Getpid())
+			_ = process.Signal(os.Interrupt)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(results), Results: results})
+	})
+	setTestEnv(t, server.URL, "test-token")
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("reports new bookmarks", func(t *testing.T) {
+		setupWatchServer(t)
+		stdout, _, err := executeCommandStreams(t, "watch", "--interval", "10ms")
+		if err != nil {
+			t.Fatalf("watch failed: %v", err)
+		}
+		if strings.Count(stdout, "https://three.example.com") != 1 {
+			t.Errorf("Expected the new bookmark reported once, got:\n%s", stdout)
+		}
+		if strings.Contains(stdout, "https://one.example.com") || strings.Contains(stdout, "https://two.example.com") {
+			t.Errorf("Expected existing bookmarks not reported, got:\n%s", stdout)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		setupWatchServer(t)
+		out := filepath.Join(t.TempDir(), "added.txt")
+		_, err := executeCommand(t, "watch", "--interval", "10ms",
+			"--exec", `echo "$LINKDING_BOOKMARK_ID $LINKDING_BOOKMARK_TAGS $LINKDING_BOOKMARK_URL $1 $2" >> `+out)
+		if err != nil {
+			t.Fatalf("watch failed: %v", err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("Expected --exec to run: %v", err)
+		}
+		if string(data) != "3 go,cli https://three.example.com https://three.example.com Three\n" {
+			t.Errorf("Unexpected --exec output: %q", data)
+		}
+	})
+
+	t.Run("exec keeps the server URL", func(t *testing.T) {
+		setupWatchServer(t)
+		serverURL := os.Getenv("LINKDING_URL")
+		out := filepath.Join(t.TempDir(), "env.txt")
+		_, err := executeCommand(t, "watch", "--interval", "10ms",
+			"--exec", `echo "$LINKDING_URL" >> `+out)
+		if err != nil {
+			t.Fatalf("watch failed: %v", err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("Expected --exec to run: %v", err)
+		}
+		if got := strings.TrimSpace(string(data)); got != serverURL {
+			t.Errorf("Expected LINKDING_URL to stay %q in --exec, got %q", serverURL, got)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		setupWatchServer(t)
+		stdout, _, err := executeCommandStreams(t, "watch", "--interval", "10ms", "--json")
+		if err != nil {
+			t.Fatalf("watch failed: %v", err)
+		}
+		var b models.Bookmark
+		if err := json.Unmarshal([]byte(stdout), &b); err != nil || b.ID != 3 {
+			t.Errorf("Expected bookmark 3 as JSON, got %q (%v)", stdout, err)
+		}
+	})
+}
+
+// ================= BOOKMARKS NOTES TESTS =================
+
+// setupNotesServer serves bookmark 1 with the given notes and records the
+// body of any PATCH.
+func setupNotesServer(t *testing.T, notes string) *[]models.BookmarkUpdate {
+	t.Helper()
+	patches := &[]models.BookmarkUpdate{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bookmark := mockBookmark(1, "https://example.com", "Example", nil)
+		bookmark.Notes = notes
+		if r.Method == http.MethodPatch {
+			var update models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			*patches = append(*patches, update)
+			bookmark.Notes = *update.Notes
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(bookmark)
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return patches
+}
+
+// stubEditor replaces the editor with one that checks the file holds want
+// and then saves text.
+func stubEditor(t *testing.T, want, text string) {
+	t.Helper()
+	original := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if string(data) != want {
+			t.Errorf("Expected the editor to open %q, got %q", want, data)
+		}
+		return os.WriteFile(path, []byte(text), 0600)
+	}
+	t.Cleanup(func() { runEditor = original })
+}
+
+func TestBookmarksNotes(t *testing.T) {
+	t.Run("print", func(t *testing.T) {
+		setupNotesServer(t, "Read section 3")
+		stdout, _, err := executeCommandStreams(t, "bookmarks", "notes", "1")
+		if err != nil {
+			t.Fatalf("notes failed: %v", err)
+		}
+		if stdout != "Read section 3\n" {
+			t.Errorf("Unexpected output: %q", stdout)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		setupNotesServer(t, "")
+		stdout, stderr, err := executeCommandStreams(t, "bookmarks", "notes", "1")
+		if err != nil {
+			t.Fatalf("notes failed: %v", err)
+		}
+		if stdout != "" || !strings.Contains(stderr, "has no notes") {
+			t.Errorf("Expected only a message on stderr, got stdout %q, stderr %q", stdout, stderr)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		setupNotesServer(t, "Read section 3")
+		output, err := executeCommand(t, "bookmarks", "notes", "1", "--json")
+		if err != nil {
+			t.Fatalf("notes failed: %v", err)
+		}
+		var got bookmarkNotes
+		if err := json.Unmarshal([]byte(output), &got); err != nil || got.ID != 1 || got.Notes != "Read section 3" || got.Updated != nil {
+			t.Errorf("Unexpected JSON %q (%v)", output, err)
+		}
+	})
+
+	t.Run("edit saves changes", func(t *testing.T) {
+		patches := setupNotesServer(t, "Read section 3")
+		stubEditor(t, "Read section 3\n", "Read sections 3 and 4\n")
+		output, err := executeCommand(t, "bookmarks", "notes", "1", "--edit")
+		if err != nil {
+			t.Fatalf("notes --edit failed: %v", err)
+		}
+		if len(*patches) != 1 || *(*patches)[0].Notes != "Read sections 3 and 4" {
+			t.Errorf("Expected one PATCH with the new notes, got %+v", *patches)
+		}
+		if !strings.Contains(output, "Saved notes of bookmark 1") {
+			t.Errorf("Expected a confirmation, got:\n%s", output)
+		}
+	})
+
+	t.Run("edit unchanged skips the update", func(t *testing.T) {
+		patches := setupNotesServer(t, "Read section 3")
+		stubEditor(t, "Read section 3\n", "Read section 3\n")
+		output, err := executeCommand(t, "bookmarks", "notes", "1", "--edit")
+		if err != nil {
+			t.Fatalf("notes --edit failed: %v", err)
+		}
+		if len(*patches) != 0 {
+			t.Errorf("Expected no PATCH, got %+v", *patches)
+		}
+		if !strings.Contains(output, "unchanged") {
+			t.Errorf("Expected an unchanged message, got:\n%s", output)
+		}
+	})
+
+	t.Run("edit empty notes", func(t *testing.T) {
+		patches := setupNotesServer(t, "")
+		stubEditor(t, "", "First note")
+		if _, err := executeCommand(t, "bookmarks", "notes", "1", "--edit"); err != nil {
+			t.Fatalf("notes --edit failed: %v", err)
+		}
+		if len(*patches) != 1 || *(*patches)[0].Notes != "First note" {
+			t.Errorf("Expected the new notes saved, got %+v", *patches)
+		}
+	})
+
+	t.Run("editor failure", func(t *testing.T) {
+		patches := setupNotesServer(t, "Read section 3")
+		original := runEditor
+		runEditor = func(path string) error { return fmt.Errorf("exit status 1") }
+		t.Cleanup(func() { runEditor = original })
+		_, err := executeCommand(t, "bookmarks", "notes", "1", "--edit")
+		if err == nil || !strings.Contains(err.Error(), "notes not saved") {
+			t.Errorf("Expected an editor error, got: %v", err)
+		}
+		if len(*patches) != 0 {
+			t.Errorf("Expected no PATCH, got %+v", *patches)
+		}
+	})
+}
+
+// ================= TAGS EXPORT/IMPORT TESTS =================
+
+// setupTagsServer serves the given tags and records the names of tags
+// created. Creating a tag named "taken" fails as a duplicate.
+func setupTagsServer(t *testing.T, names ...string) *[]string {
+	t.Helper()
+	created := &[]string{}
+	server := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			switch body["name"] {
+			case "taken":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"name":["Tag with this name already exists"]}`))
+			case "broken":
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				*created = append(*created, body["name"])
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(models.Tag{ID: 100, Name: body["name"]})
+			}
+			return
+		}
+		var tags []models.Tag
+		for i, name := range names {
+			tags = append(tags, models.Tag{ID: i + 1, Name: name})
+		}
+		_ = json.NewEncoder(w).Encode(models.TagList{Count: len(tags), Results: tags})
+	})
+	setTestEnv(t, server.URL, "test-token")
+	return created
+}
+
+func TestTagsExportImport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tags.json")
+
+	setupTagsServer(t, "go", "kubernetes", "unused")
+	output, err := executeCommand(t, "tags", "export", "-o", file)
+	if err != nil {
+		t.Fatalf("tags export failed: %v", err)
+	}
+	if !strings.Contains(output, "Exported 3 tags") {
+		t.Errorf("Expected a confirmation, got:\n%s", output)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		created := setupTagsServer(t, "Go")
+		output, err := executeCommand(t, "tags", "import", file)
+		if err != nil {
+			t.Fatalf("tags import failed: %v", err)
+		}
+		if !slices.Equal(*created, []string{"kubernetes", "unused"}) {
+			t.Errorf("Expected kubernetes and unused created, got %v", *created)
+		}
+		if !strings.Contains(output, "Created 2 tags") || !strings.Contains(output, "1 already existed") {
+			t.Errorf("Expected the counts, got:\n%s", output)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		created := setupTagsServer(t, "go")
+		output, err := executeCommand(t, "tags", "import", file, "--dry-run", "--json")
+		if err != nil {
+			t.Fatalf("tags import --dry-run failed: %v", err)
+		}
+		if len(*created) != 0 {
+			t.Errorf("Expected no tags created, got %v", *created)
+		}
+		if strings.Count(output, `"path": "/api/tags/"`) != 2 {
+			t.Errorf("Expected two planned requests, got:\n%s", output)
+		}
+	})
+}
+
+func TestTagsImport_ExistingAndFailures(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tags.json")
+	content := `{"version": "1", "tags": [{"name": "new"}, {"name": "taken"}, {"name": "broken"}, {"name": "NEW"}, {"name": " "}]}`
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	created := setupTagsServer(t)
+	output, err := executeCommand(t, "tags", "import", file, "--json")
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 tags could not be created") {
+		t.Errorf("Expected a failure for broken, got: %v", err)
+	}
+	var result tagsImportResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, output)
+	}
+	if result.Created != 1 || result.Skipped != 1 || result.Failed != 1 {
+		t.Errorf("Expected 1 created, 1 skipped and 1 failed, got %+v", result)
+	}
+	if !slices.Equal(*created, []string{"new"}) {
+		t.Errorf("Expected only new created, got %v", *created)
+	}
+}
+
+func TestTagsImport_NotATagsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(file, []byte(`{"version": "2", "bookmarks": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setupTagsServer(t)
+	_, err := executeCommand(t, "tags", "import", file)
+	if err == nil || !strings.Contains(err.Error(), "not a tags export") {
+		t.Errorf("Expected a format error, got: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Write a completion script for the given shell to stdout.
+
+Besides commands and flags, tag names (tags show, list --tags, ...) and
+bookmark IDs (get, update, delete, bookmarks open) are completed from your
+LinkDing instance. Those lookups stay silent when no configuration is found
+or the server cannot be reached.
+
+Examples:
+  # Bash
+  linkdingctl completion bash > /etc/bash_completion.d/linkdingctl
+
+  # Zsh
+  linkdingctl completion zsh > "${fpath[1]}/_linkdingctl"
+
+  # Fish
+  linkdingctl completion fish > ~/.config/fish/completions/linkdingctl.fish
+
+  # PowerShell
+  linkdingctl completion powershell | Out-String | Invoke-Expression`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+// completionTimeout bounds each lookup made while completing, so a slow or
+// unreachable server does not hang the shell.
+const completionTimeout = 5 * time.Second
+
+// completionIDLimit is the number of recent bookmarks offered as IDs.
+const completionIDLimit = 50
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell '%s'. Valid shells: bash, zsh, fish, powershell", args[0])
+	}
+}
+
+// completionClient returns a client for completion lookups, or nil when no
+// usable configuration is found. It does not retry.
+func completionClient() *api.Client {
+	cfg, err := loadConfig()
+	if err != nil || cfg.URL == "" || cfg.Token == "" {
+		return nil
+	}
+	return api.NewClientWithOptions(cfg.URL, cfg.Token, api.ClientOptions{
+		Headers:   extraHeaders,
+		Timeout:   completionTimeout,
+		Transport: transportOptions,
+	})
+}
+
+// completeTagNames suggests existing tag names. For comma-separated flags
+// such as --tags go,ku only the part after the last comma is completed.
+func completeTagNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client := completionClient()
+	if client == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tags, err := client.FetchAllTags()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prefix, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+	var names []string
+	for _, tag := range tags {
+		if strings.HasPrefix(strings.ToLower(tag.Name), strings.ToLower(partial)) {
+			names = append(names, prefix+tag.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTagArgs completes tag name arguments, up to max of them (0 for
+// no limit).
+func completeTagArgs(max int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if max > 0 && len(args) >= max {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeTagNames(cmd, args, toComplete)
+	}
+}
+
+// completeBookmarkID suggests the IDs of the most recent bookmarks, with
+// their titles as descriptions, for commands taking a single bookmark ID.
+func completeBookmarkID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client := completionClient()
+	if client == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	list, err := client.GetBookmarks("", nil, nil, nil, completionIDLimit, 0)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, b := range list.Results {
+		id := strconv.Itoa(b.ID)
+		if !strings.HasPrefix(id, toComplete) {
+			continue
+		}
+		title := b.Title
+		if title == "" {
+			title = b.URL
+		}
+		ids = append(ids, id+"\t"+title)
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -22,8 +22,21 @@ var configCmd = &cobra.Command{
 var configInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize configuration interactively",
-	Long:  `Create a new configuration file by prompting for LinkDing URL and API token.`,
+	Long: `Create a new configuration file by prompting for LinkDing URL and API token.
+
+With --profile NAME the connection is saved as a named profile, leaving the
+default connection and other profiles in the file untouched.
+
+Examples:
+  linkdingctl config init
+  linkdingctl config init --profile work`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if profileName != "" {
+			if err := config.ValidateProfileName(profileName); err != nil {
+				return err
+			}
+		}
+
 		reader := bufio.NewReader(os.Stdin)
 
 		// Get URL
@@ -59,12 +72,6 @@ var configInitCmd = &cobra.Command{
 			return fmt.Errorf("URL and token are required")
 		}
 
-		// Create config
-		cfg := &config.Config{
-			URL:   url,
-			Token: token,
-		}
-
 		// Determine config path
 		configPath := cfgFile
 		if configPath == "" {
@@ -75,6 +82,15 @@ var configInitCmd = &cobra.Command{
 			configPath = defaultPath
 		}
 
+		// Create config, keeping any flag defaults already in the file
+		defaults, _ := config.LoadDefaults(configPath)
+		cfg := &config.Config{
+			URL:      url,
+			Token:    token,
+			Profile:  profileName,
+			Defaults: defaults,
+		}
+
 		// Save config
 		if err := config.Save(cfg, configPath); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
@@ -85,10 +101,18 @@ var configInitCmd = &cobra.Command{
 				"status": "success",
 				"path":   configPath,
 			}
+			if profileName != "" {
+				output["profile"] = profileName
+			}
 			return json.NewEncoder(os.Stdout).Encode(output)
 		}
 
-		fmt.Printf("✓ Configuration saved to %s\n", configPath)
+		if profileName != "" {
+			statusf(os.Stdout, "✓ Profile '%s' saved to %s\n", profileName, configPath)
+			statusf(os.Stdout, "  Use it with --profile %s, or make it the default with 'linkdingctl config use %s'\n", profileName, profileName)
+			return nil
+		}
+		statusf(os.Stdout, "✓ Configuration saved to %s\n", configPath)
 		return nil
 	},
 }
@@ -103,24 +127,33 @@ var configShowCmd = &cobra.Command{
 			return err
 		}
 
-		// Determine the source of each config value
+		// Determine the source of each config value. Environment variables
+		// are ignored when --profile names a profile.
 		urlSource := "config file"
 		tokenSource := "config file"
+		if cfg.Profile != config.DefaultProfile {
+			urlSource = fmt.Sprintf("profile %s", cfg.Profile)
+			tokenSource = urlSource
+		}
+		if cfg.TokenFromKeyring {
+			tokenSource = "OS keyring"
+		}
 
 		if flagURL != "" {
 			urlSource = "--url flag"
-		} else if os.Getenv("LINKDING_URL") != "" {
+		} else if os.Getenv("LINKDING_URL") != "" && profileName == "" {
 			urlSource = "environment variable"
 		}
 
 		if flagToken != "" {
-			tokenSource = "--token flag"
-		} else if os.Getenv("LINKDING_TOKEN") != "" {
+			tokenSource = flagTokenSource
+		} else if os.Getenv("LINKDING_TOKEN") != "" && profileName == "" {
 			tokenSource = "environment variable"
 		}
 
 		if jsonOutput {
 			output := map[string]interface{}{
+				"profile":      cfg.Profile,
 				"url":          cfg.URL,
 				"url_source":   urlSource,
 				"token":        redactToken(cfg.Token),
@@ -129,6 +162,7 @@ var configShowCmd = &cobra.Command{
 			return json.NewEncoder(os.Stdout).Encode(output)
 		}
 
+		fmt.Printf("Profile: %s\n", cfg.Profile)
 		fmt.Printf("URL: %s (%s)\n", cfg.URL, urlSource)
 		fmt.Printf("Token: %s (%s)\n", redactToken(cfg.Token), tokenSource)
 		return nil
@@ -138,14 +172,21 @@ var configShowCmd = &cobra.Command{
 var configTestCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Test connection to LinkDing",
-	Long:  `Verify that the configured URL and token can successfully connect to LinkDing.`,
+	Long: `Verify that the configured URL and token can successfully connect to LinkDing.
+
+Also reports whether the token appears to be read-only or read-write. The
+write check sends an intentionally invalid bookmark that the server always
+rejects, so no data is created.
+
+With --verbose, also reports the LinkDing version (from the server's
+/health endpoint, where available) and the number of bookmarks.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 
-		client := api.NewClient(cfg.URL, cfg.Token)
+		client := newClient(cfg)
 		if err := client.TestConnection(); err != nil {
 			if jsonOutput {
 				output := map[string]string{
@@ -158,24 +199,200 @@ var configTestCmd = &cobra.Command{
 			return fmt.Errorf("✗ Connection failed: %w", err)
 		}
 
+		// Probe write access; a failed probe reports "unknown" but is not fatal.
+		// The probe is a POST, so it is skipped under --dry-run.
+		scope := api.TokenScopeUnknown
+		if !isDryRun() {
+			scope, _ = client.CheckTokenScope()
+		}
+
+		var details *serverDetails
+		if configTestVerbose {
+			details, err = fetchServerDetails(client)
+			if err != nil {
+				return err
+			}
+		}
+
+		if jsonOutput {
+			output := map[string]interface{}{
+				"status":      "success",
+				"url":         cfg.URL,
+				"token_scope": scope,
+			}
+			if details != nil {
+				output["version"] = details.Version
+				output["bookmark_count"] = details.BookmarkCount
+			}
+			return json.NewEncoder(os.Stdout).Encode(output)
+		}
+
+		statusf(os.Stdout, "✓ Successfully connected to %s\n", cfg.URL)
+		if details != nil {
+			version := details.Version
+			if version == "" {
+				version = "unknown"
+			}
+			fmt.Printf("  LinkDing version: %s\n", version)
+			fmt.Printf("  Bookmarks: %d\n", details.BookmarkCount)
+		}
+		fmt.Printf("  Token access: %s\n", scope)
+		if scope == api.TokenScopeReadOnly {
+			fmt.Println("  Warning: this token appears to be read-only; add, update, import and delete will fail")
+		}
+		return nil
+	},
+}
+
+var configTestVerbose bool
+
+// serverDetails is what config test --verbose reports beyond the
+// connection itself.
+type serverDetails struct {
+	Version       string
+	BookmarkCount int
+}
+
+// fetchServerDetails reads the server version and bookmark count. Older
+// servers without a /health endpoint report no version rather than an
+// error.
+func fetchServerDetails(client *api.Client) (*serverDetails, error) {
+	details := &serverDetails{}
+	details.Version, _ = client.ServerVersion()
+
+	list, err := client.GetBookmarks("", nil, nil, nil, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookmarks: %w", err)
+	}
+	details.BookmarkCount = list.Count
+	return details, nil
+}
+
+var configUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Set the profile used by default",
+	Long: `Save the named profile as the one commands use when --profile is not
+given. Use "default" to go back to the top-level connection.
+
+LINKDING_URL and LINKDING_TOKEN still override the active profile; an
+explicit --profile ignores them.
+
+Examples:
+  linkdingctl config use work
+  linkdingctl config use default`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		configPath := cfgFile
+		if configPath == "" {
+			defaultPath, err := config.DefaultConfigPath()
+			if err != nil {
+				return err
+			}
+			configPath = defaultPath
+		}
+
+		if err := config.SetActiveProfile(configPath, name); err != nil {
+			return err
+		}
+
 		if jsonOutput {
 			output := map[string]string{
-				"status": "success",
-				"url":    cfg.URL,
+				"status":  "success",
+				"profile": name,
 			}
 			return json.NewEncoder(os.Stdout).Encode(output)
 		}
 
-		fmt.Printf("✓ Successfully connected to %s\n", cfg.URL)
+		statusf(os.Stdout, "✓ Now using profile '%s'\n", name)
 		return nil
 	},
 }
 
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move the API token between the config file and the OS keyring",
+	Long: `Move the API token of a profile out of the plain-text config file into the
+OS keyring (macOS Keychain, Windows Credential Manager, or the Secret Service
+such as GNOME Keyring or KWallet on Linux), or back again.
+
+--to-keyring stores the token under the service "linkdingctl" with the
+profile name as account, and replaces it in the config file with a
+token_keyring entry. Commands then read the token from the keyring, unless
+LINKDING_TOKEN or --token supply one. --to-file writes the token back to the
+config file and removes it from the keyring.
+
+The profile is the one given with --profile, or the active profile.
+
+Examples:
+  linkdingctl config migrate --to-keyring
+  linkdingctl config migrate --to-keyring --profile work
+  linkdingctl config migrate --to-file`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+var (
+	configToKeyring bool
+	configToFile    bool
+)
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		defaultPath, err := config.DefaultConfigPath()
+		if err != nil {
+			return err
+		}
+		configPath = defaultPath
+	}
+
+	var profile, store string
+	var err error
+	if configToKeyring {
+		profile, err = config.MoveTokenToKeyring(configPath, profileName)
+		store = "keyring"
+	} else {
+		profile, err = config.MoveTokenToFile(configPath, profileName)
+		store = "file"
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		output := map[string]string{
+			"status":      "success",
+			"profile":     profile,
+			"token_store": store,
+			"path":        configPath,
+		}
+		return json.NewEncoder(os.Stdout).Encode(output)
+	}
+
+	if configToKeyring {
+		statusf(os.Stdout, "✓ Token of profile '%s' moved to the OS keyring (service %s)\n", profile, config.KeyringService)
+		return nil
+	}
+	statusf(os.Stdout, "✓ Token of profile '%s' moved back to %s\n", profile, configPath)
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configTestCmd)
+
+	configTestCmd.Flags().BoolVarP(&configTestVerbose, "verbose", "v", false, "Also report the server version and bookmark count")
+	configCmd.AddCommand(configUseCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	configMigrateCmd.Flags().BoolVar(&configToKeyring, "to-keyring", false, "Move the token from the config file to the OS keyring")
+	configMigrateCmd.Flags().BoolVar(&configToFile, "to-file", false, "Move the token from the OS keyring back to the config file")
+	configMigrateCmd.MarkFlagsMutuallyExclusive("to-keyring", "to-file")
+	configMigrateCmd.MarkFlagsOneRequired("to-keyring", "to-file")
 }
 
 // redactToken masks most of the token for security
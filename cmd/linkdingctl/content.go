@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// contentExcerptLength is the number of characters get --with-content shows
+// without --full.
+const contentExcerptLength = 500
+
+var (
+	// htmlHiddenPatterns match comments and elements whose text is never
+	// shown.
+	htmlHiddenPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?s)<!--.*?-->`),
+		regexp.MustCompile(`(?is)<head\b.*?</head\s*>`),
+		regexp.MustCompile(`(?is)<script\b.*?</script\s*>`),
+		regexp.MustCompile(`(?is)<style\b.*?</style\s*>`),
+		regexp.MustCompile(`(?is)<noscript\b.*?</noscript\s*>`),
+		regexp.MustCompile(`(?is)<template\b.*?</template\s*>`),
+		regexp.MustCompile(`(?is)<svg\b.*?</svg\s*>`),
+	}
+	// htmlBreakPattern matches tags that end a line of text.
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</?(p|div|h[1-6]|li|tr|blockquote|pre|section|article|header|footer|ul|ol|table)\b[^>]*>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// archivedContent returns the text of the bookmark's newest complete web
+// archive snapshot, or "" when it has none or the server keeps no assets.
+func archivedContent(client *api.Client, bookmarkID int) (string, error) {
+	assets, err := client.GetBookmarkAssets(bookmarkID)
+	if errors.Is(err, api.ErrAssetsUnsupported) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to list archived content: %w", err)
+	}
+
+	var snapshot *models.BookmarkAsset
+	for i := range assets {
+		a := &assets[i]
+		if a.AssetType != "snapshot" || a.Status != "complete" {
+			continue
+		}
+		if snapshot == nil || a.DateCreated.After(snapshot.DateCreated) {
+			snapshot = a
+		}
+	}
+	if snapshot == nil {
+		return "", nil
+	}
+
+	raw, _, err := client.DownloadBookmarkAsset(bookmarkID, snapshot.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to download archived content: %w", err)
+	}
+	// Snapshots are stored gzipped; depending on the server they are sent
+	// that way too
+	if bytes.HasPrefix(raw, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress archived content: %w", err)
+		}
+		if raw, err = io.ReadAll(gz); err != nil {
+			return "", fmt.Errorf("failed to decompress archived content: %w", err)
+		}
+	}
+	return snapshotText(string(raw)), nil
+}
+
+// snapshotText reduces an HTML page to its visible text, one block per line
+// with runs of whitespace collapsed.
+func snapshotText(page string) string {
+	for _, hidden := range htmlHiddenPatterns {
+		page = hidden.ReplaceAllString(page, " ")
+	}
+	page = htmlBreakPattern.ReplaceAllString(page, "\n")
+	page = html.UnescapeString(htmlTagPattern.ReplaceAllString(page, " "))
+
+	var lines []string
+	for _, line := range strings.Split(page, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// contentExcerpt shortens text to contentExcerptLength characters, marking
+// the cut with an ellipsis.
+func contentExcerpt(text string) string {
+	runes := []rune(text)
+	if len(runes) <= contentExcerptLength {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:contentExcerptLength])) + "…"
+}
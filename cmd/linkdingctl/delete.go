@@ -7,32 +7,68 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
 	"github.com/spf13/cobra"
 )
 
-var forceDelete bool
+var (
+	forceDelete bool
+	deleteTags  []string
+	deleteQuery string
+)
 
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
-	Use:   "delete <id>",
-	Short: "Delete a bookmark by ID",
+	Use:   "delete [id]",
+	Short: "Delete a bookmark by ID, or every match of a filter",
 	Long: `Delete a bookmark by ID. Requires confirmation unless --force or --json flag is set.
 
+With --tags and/or --query instead of an ID, every unarchived bookmark
+matching them is deleted; with --tags a bookmark must carry every tag
+given. The matches are listed and their number must be typed to confirm,
+unless --force is given; --json, --output-format yaml and --select
+require --force. A bookmark that could not be
+deleted is reported and the others are still deleted; the command then
+exits non-zero. Without an ID or a filter nothing is deleted.
+
+--dry-run fetches the bookmarks and shows what would be deleted, without
+asking for confirmation or deleting anything.
+
 Examples:
   linkdingctl delete 123
+  linkdingctl delete 123 --dry-run
   linkdingctl delete 123 --force
-  linkdingctl delete 123 --json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runDelete,
+  linkdingctl delete 123 --json
+  linkdingctl delete --tags temp --dry-run
+  linkdingctl delete --tags temp --query junk`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runDelete,
 }
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.Flags().BoolVarP(&forceDelete, "force", "f", false, "skip confirmation prompt")
+	deleteCmd.Flags().StringSliceVarP(&deleteTags, "tags", "T", []string{}, "Delete every bookmark with these tags (AND logic)")
+	_ = deleteCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	deleteCmd.Flags().StringVarP(&deleteQuery, "query", "q", "", "Delete every bookmark matching this search query")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	// A blank query or tag would match the whole library, so it does not count as a filter
+	deleteQuery = strings.TrimSpace(deleteQuery)
+	deleteTags = nonBlank(deleteTags)
+	filtered := deleteQuery != "" || len(deleteTags) > 0
+	if len(args) == 1 && filtered {
+		return fmt.Errorf("give a bookmark ID or --tags/--query, not both")
+	}
+	if len(args) == 0 {
+		if !filtered {
+			return fmt.Errorf("give a bookmark ID, or --tags or --query to delete every match")
+		}
+		return runDeleteMatching()
+	}
+
 	// Parse bookmark ID
 	id, err := strconv.Atoi(args[0])
 	if err != nil {
@@ -46,10 +82,28 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
+
+	if isDryRun() {
+		// Fetch the bookmark so a wrong ID fails here as it would for real
+		bookmark, err := client.GetBookmark(id)
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return reportDryRun(plannedRequest{Method: "DELETE", Path: fmt.Sprintf("/api/bookmarks/%d/", id)})
+		}
+		title := bookmark.Title
+		if title == "" {
+			title = bookmark.URL
+		}
+		fmt.Println("Dry run - no changes will be made")
+		fmt.Printf("  Would delete bookmark %d: %s\n", id, title)
+		return nil
+	}
 
-	// Get bookmark details for confirmation (unless force or json mode)
-	if !forceDelete && !jsonOutput {
+	// Get bookmark details for confirmation (unless force or structured output)
+	if !forceDelete && !structuredOutput() {
 		bookmark, err := client.GetBookmark(id)
 		if err != nil {
 			return err
@@ -82,11 +136,124 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		fmt.Printf("{\"deleted\": true, \"id\": %d}\n", id)
+	if structuredOutput() {
+		return writeJSON(map[string]interface{}{"deleted": true, "id": id})
+	}
+	statusf(os.Stdout, "✓ Bookmark %d deleted\n", id)
+
+	return nil
+}
+
+// runDeleteMatching deletes every bookmark matching --tags and --query.
+func runDeleteMatching() error {
+	if structuredOutput() && !forceDelete && !isDryRun() {
+		return fmt.Errorf("deleting by --tags or --query with structured output requires --force")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.BookmarkPages(deleteQuery, deleteTags, nil, nil).All()
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	// The search also finds the tag names in titles, URLs and descriptions
+	bookmarks = models.TaggedWithAll(bookmarks, deleteTags)
+
+	if len(bookmarks) == 0 {
+		if structuredOutput() {
+			return writeJSON(map[string]interface{}{"deleted": 0})
+		}
+		fmt.Println("No bookmarks match; nothing to delete.")
+		return nil
+	}
+
+	if isDryRun() {
+		if structuredOutput() {
+			requests := make([]plannedRequest, len(bookmarks))
+			for i, b := range bookmarks {
+				requests[i] = plannedRequest{Method: "DELETE", Path: fmt.Sprintf("/api/bookmarks/%d/", b.ID)}
+			}
+			return reportDryRun(requests...)
+		}
+		fmt.Println("Dry run - no changes will be made")
+		for _, b := range bookmarks {
+			title := b.Title
+			if title == "" {
+				title = b.URL
+			}
+			fmt.Printf("  Would delete bookmark %d: %s\n", b.ID, title)
+		}
+		return nil
+	}
+
+	// Ask for the count to be typed back unless --force
+	if !forceDelete {
+		fmt.Printf("About to delete %d bookmark(s) matching the filters:\n", len(bookmarks))
+		for _, b := range bookmarks {
+			title := b.Title
+			if title == "" {
+				title = b.URL
+			}
+			fmt.Printf("  %d: %s\n", b.ID, title)
+		}
+		fmt.Printf("Type %d to confirm: ", len(bookmarks))
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		if strings.TrimSpace(response) != strconv.Itoa(len(bookmarks)) {
+			fmt.Println("Delete cancelled")
+			return nil
+		}
+	}
+
+	deleted := 0
+	var failures []string
+	for _, b := range bookmarks {
+		if err := client.DeleteBookmark(b.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("bookmark %d: %v", b.ID, err))
+			continue
+		}
+		deleted++
+	}
+
+	if structuredOutput() {
+		output := map[string]interface{}{"deleted": deleted}
+		if len(failures) > 0 {
+			output["errors"] = failures
+		}
+		if err := writeJSON(output); err != nil {
+			return err
+		}
 	} else {
-		fmt.Printf("✓ Bookmark %d deleted\n", id)
+		statusf(os.Stdout, "✓ %d bookmark(s) deleted\n", deleted)
+		for _, f := range failures {
+			failf(os.Stderr, "✗ %s\n", f)
+		}
 	}
 
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d bookmarks could not be deleted", len(failures), len(bookmarks))
+	}
 	return nil
 }
+
+// nonBlank returns values trimmed of whitespace, without the empty ones.
+func nonBlank(values []string) []string {
+	var kept []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// plannedRequest is a write that --dry-run reported instead of sending.
+type plannedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// isDryRun reports whether writes must be skipped. Commands that also have
+// their own --dry-run flag pass its value so both behave the same.
+func isDryRun(local ...bool) bool {
+	if dryRun {
+		return true
+	}
+	for _, v := range local {
+		if v {
+			return true
+		}
+	}
+	return false
+}
+
+// reportDryRun prints the write requests a command would have sent.
+func reportDryRun(requests ...plannedRequest) error {
+	if structuredOutput() {
+		return writeJSON(map[string]interface{}{
+			"dry_run":  true,
+			"requests": requests,
+		})
+	}
+
+	fmt.Println("Dry run - no changes will be made")
+	for _, r := range requests {
+		fmt.Printf("  Would %s %s\n", r.Method, r.Path)
+		if r.Body != nil {
+			body, err := json.Marshal(r.Body)
+			if err != nil {
+				return fmt.Errorf("failed to encode request body: %w", err)
+			}
+			fmt.Printf("    %s\n", body)
+		}
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/export"
@@ -13,32 +16,106 @@ import (
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export bookmarks",
-	Long: `Export bookmarks to various formats (JSON, HTML, CSV).
+	Long: `Export bookmarks to various formats (JSON, JSON Lines, HTML, CSV, Org, Markdown).
+
+The jsonl format writes one bookmark object per line and streams each page
+as it arrives, so memory use stays flat for very large collections.
+
+With --anonymize, personal data is stripped so the export can be shared:
+URLs become https://anonymized.invalid/<hash> placeholders (salted per
+export), titles become "Bookmark <n>", and descriptions, notes and website
+titles/descriptions are blanked. IDs, tags, dates and the unread, shared and
+archived flags are kept.
+
+With --folder-prefix (HTML only), bookmarks are nested in folders named by
+their tags under that prefix: with --folder-prefix browser, a bookmark
+tagged browser/work/docs is written in folder work > docs. A bookmark goes
+in the folder of its first such tag in sorted order; bookmarks without one
+stay at the top level. Tags are written unchanged.
+
+The org format nests bookmarks under a top-level heading per tag, with
+bookmarks that have several tags under each of them and bookmarks without
+tags under a final :Untagged: heading. --group-by none writes a flat
+outline instead.
+
+With --include-bundles (JSON only), bundles are written alongside the
+bookmarks and 'linkdingctl restore' recreates them.
+
+--tags and --query limit the export to matching bookmarks in every format;
+the JSON "source" field records the filter.
+
+The directory of the --output file must exist unless --mkdir is given.
+
+--output-dir writes to a new file in that directory instead, named with
+the time and the format's extension, like backup does:
+  linkding-export-2026-01-22T103000.csv
+With --json, the path of the file is printed as {"file": "..."}.
 
 Examples:
   linkdingctl export > bookmarks.json
   linkdingctl export -f html -o bookmarks.html
-  linkdingctl export --tags homelab -f csv -o homelab.csv`,
+  linkdingctl export --tags homelab -f csv -o homelab.csv
+  linkdingctl export --tags work --query api -o work-api.json
+  linkdingctl export --exclude-tags private,nsfw -o bookmarks.json
+  linkdingctl export -f org -o bookmarks.org
+  linkdingctl export -f org --group-by none -o bookmarks.org
+  linkdingctl export -f html --folder-prefix browser -o bookmarks.html
+  linkdingctl export -f markdown -o bookmarks.md
+  linkdingctl export -f jsonl | jq -r .url
+  linkdingctl export --best-effort -o bookmarks.json
+  linkdingctl export --anonymize -o structure.json
+  linkdingctl export --include-bundles -o full.json
+  linkdingctl export -o ~/exports/2026/bookmarks.json --mkdir
+  linkdingctl export -f csv --output-dir ./exports
+  linkdingctl export --schema > linkdingctl-export.schema.json`,
 	RunE: runExport,
 }
 
 var (
-	exportFormat   string
-	exportOutput   string
-	exportTags     []string
-	exportArchived bool
+	exportFormat     string
+	exportOutput     string
+	exportOutputDir  string
+	exportTags       []string
+	exportQuery      string
+	exportArchived   bool
+	exportBestEffort bool
+	exportSchema     bool
+	exportGroupBy    string
+	exportExclude    []string
+	exportAnonymize  bool
+	exportMkdir      bool
+	exportBundles    bool
+	exportFolders    string
 )
 
 func init() {
 	rootCmd.AddCommand(exportCmd)
 
-	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Output format: json, html, csv")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Output format: json, jsonl, html, csv, org, markdown")
 	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
+	exportCmd.Flags().StringVar(&exportOutputDir, "output-dir", "", "Write to a timestamped file in this directory, named by format")
+	exportCmd.MarkFlagsMutuallyExclusive("output", "output-dir")
 	exportCmd.Flags().StringSliceVarP(&exportTags, "tags", "T", []string{}, "Export only bookmarks with these tags")
+	exportCmd.Flags().StringVarP(&exportQuery, "query", "q", "", "Export only bookmarks matching this search")
+	exportCmd.Flags().StringSliceVar(&exportExclude, "exclude-tags", []string{}, "Skip bookmarks with any of these tags (applied after --tags)")
+	_ = exportCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	_ = exportCmd.RegisterFlagCompletionFunc("exclude-tags", completeTagNames)
 	exportCmd.Flags().BoolVar(&exportArchived, "archived", true, "Include archived bookmarks")
+	exportCmd.Flags().BoolVar(&exportBestEffort, "best-effort", false, "Write the bookmarks fetched so far if a page fails to load")
+	exportCmd.Flags().StringVar(&exportGroupBy, "group-by", "", "Org outline layout: tag (a heading per tag) or none (default: tag)")
+	exportCmd.Flags().StringVar(&exportFolders, "folder-prefix", "", "Nest entries in folders from tags under this prefix (html only)")
+	exportCmd.Flags().BoolVar(&exportAnonymize, "anonymize", false, "Replace URLs with hashed placeholders, titles with numbers, and blank descriptions and notes")
+	exportCmd.Flags().BoolVar(&exportMkdir, "mkdir", false, "Create the --output file's directory or --output-dir (mode 0700) if it does not exist")
+	exportCmd.Flags().BoolVar(&exportBundles, "include-bundles", false, "Also export bundles (json only), so restore can recreate them")
+	exportCmd.Flags().BoolVar(&exportSchema, "schema", false, "Print the JSON Schema for the JSON export format and exit")
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
+	// The schema is static and needs no configuration or server
+	if exportSchema {
+		return writeJSON(export.JSONSchema())
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -46,22 +123,59 @@ func runExport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Validate format
 	switch exportFormat {
-	case "json", "html", "csv":
+	case "json", "jsonl", "html", "csv", "org", "markdown":
 		// All export formats are implemented
 	default:
-		return fmt.Errorf("invalid export format '%s'. Valid formats: json, html, csv", exportFormat)
+		return fmt.Errorf("invalid export format '%s'. Valid formats: json, jsonl, html, csv, org, markdown", exportFormat)
+	}
+
+	// Validate grouping; org outlines are grouped by tag unless told otherwise
+	groupBy := exportGroupBy
+	switch groupBy {
+	case "":
+		if exportFormat == "org" {
+			groupBy = "tag"
+		}
+	case "tag", "none":
+		if exportFormat != "org" {
+			return fmt.Errorf("--group-by is only supported for the org format")
+		}
+	default:
+		return fmt.Errorf("invalid --group-by '%s'. Valid values: tag, none", exportGroupBy)
+	}
+
+	if exportFolders != "" && exportFormat != "html" {
+		return fmt.Errorf("--folder-prefix is only supported for the html format")
+	}
+
+	if exportBundles {
+		if exportFormat != "json" {
+			return fmt.Errorf("--include-bundles is only supported for the json format")
+		}
+		// Bundle searches and names are not anonymized
+		if exportAnonymize {
+			return fmt.Errorf("--include-bundles cannot be combined with --anonymize")
+		}
+	}
+
+	output := exportOutput
+	if exportOutputDir != "" {
+		output = filepath.Join(exportOutputDir, exportFileName(exportFormat, time.Now()))
 	}
 
 	// Determine output writer
 	var writer *os.File
-	if exportOutput == "" {
+	if output == "" {
 		writer = os.Stdout
 	} else {
-		file, err := os.Create(exportOutput)
+		if err := ensureOutputDir(filepath.Dir(output), exportMkdir); err != nil {
+			return err
+		}
+		file, err := os.Create(output)
 		if err != nil {
 			return fmt.Errorf("failed to create output file: %w", err)
 		}
@@ -72,29 +186,80 @@ func runExport(cmd *cobra.Command, args []string) error {
 	// Create export options
 	options := export.ExportOptions{
 		Tags:            exportTags,
+		Query:           exportQuery,
+		ExcludeTags:     exportExclude,
 		IncludeArchived: exportArchived,
+		BestEffort:      exportBestEffort,
+		GroupBy:         groupBy,
+		FolderPrefix:    exportFolders,
+		Anonymize:       exportAnonymize,
+		IncludeBundles:  exportBundles,
 	}
 
 	// Perform export based on format
+	var exportErr error
 	switch exportFormat {
 	case "json":
-		if err := export.ExportJSON(client, writer, options); err != nil {
-			return err
-		}
+		exportErr = export.ExportJSON(client, writer, options)
+	case "jsonl":
+		exportErr = export.ExportJSONL(client, writer, options)
 	case "html":
-		if err := export.ExportHTML(client, writer, options); err != nil {
-			return err
-		}
+		exportErr = export.ExportHTML(client, writer, options)
 	case "csv":
-		if err := export.ExportCSV(client, writer, options); err != nil {
+		exportErr = export.ExportCSV(client, writer, options)
+	case "org":
+		exportErr = export.ExportOrg(client, writer, options)
+	case "markdown":
+		exportErr = export.ExportMarkdown(client, writer, options)
+	}
+	if exportErr != nil {
+		if !isPartialFetch(exportErr) {
+			return exportErr
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", exportErr)
+	}
+
+	// Report the generated file on stdout with --json, or the file written
+	// on stderr
+	if exportOutputDir != "" && jsonOutput {
+		result := map[string]interface{}{"file": output}
+		if exportErr != nil {
+			result["incomplete"] = true
+			result["error"] = exportErr.Error()
+		}
+		if err := writeJSON(result); err != nil {
 			return err
 		}
+	} else if output != "" {
+		statusf(os.Stderr, "Exported bookmarks to %s\n", output)
 	}
 
-	// Print success message to stderr if writing to file
-	if exportOutput != "" {
-		fmt.Fprintf(os.Stderr, "Exported bookmarks to %s\n", exportOutput)
+	if exportErr != nil {
+		return fmt.Errorf("export incomplete: not all bookmarks could be fetched")
 	}
 
 	return nil
 }
+
+// exportExtensions maps each export format to its file extension.
+var exportExtensions = map[string]string{
+	"json":     ".json",
+	"jsonl":    ".jsonl",
+	"html":     ".html",
+	"csv":      ".csv",
+	"org":      ".org",
+	"markdown": ".md",
+}
+
+// exportFileName names an --output-dir file after the time and format, as
+// backup names its files.
+func exportFileName(format string, t time.Time) string {
+	return "linkding-export-" + t.Format("2006-01-02T150405") + exportExtensions[format]
+}
+
+// isPartialFetch reports whether err came from a best-effort fetch that
+// stopped partway through pagination.
+func isPartialFetch(err error) bool {
+	var partial *api.PartialFetchError
+	return errors.As(err, &partial)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// bookmarkField is a bookmark attribute that --fields can select. name is
+// the key in the bookmark's JSON form.
+type bookmarkField struct {
+	name  string
+	label string
+	value func(b *models.Bookmark) interface{}
+}
+
+// bookmarkFields lists the selectable fields in the order they are shown.
+var bookmarkFields = []bookmarkField{
+	{"id", "ID", func(b *models.Bookmark) interface{} { return b.ID }},
+	{"url", "URL", func(b *models.Bookmark) interface{} { return b.URL }},
+	{"title", "Title", func(b *models.Bookmark) interface{} { return b.Title }},
+	{"description", "Description", func(b *models.Bookmark) interface{} { return b.Description }},
+	{"notes", "Notes", func(b *models.Bookmark) interface{} { return b.Notes }},
+	{"website_title", "Website title", func(b *models.Bookmark) interface{} { return b.WebsiteTitle }},
+	{"website_description", "Website desc", func(b *models.Bookmark) interface{} { return b.WebsiteDescription }},
+	{"tag_names", "Tags", func(b *models.Bookmark) interface{} { return b.TagNames }},
+	{"date_added", "Added", func(b *models.Bookmark) interface{} { return b.DateAdded }},
+	{"date_modified", "Modified", func(b *models.Bookmark) interface{} { return b.DateModified }},
+	{"unread", "Unread", func(b *models.Bookmark) interface{} { return b.Unread }},
+	{"shared", "Shared", func(b *models.Bookmark) interface{} { return b.Shared }},
+	{"is_archived", "Archived", func(b *models.Bookmark) interface{} { return b.IsArchived }},
+	{"favicon_url", "Favicon", func(b *models.Bookmark) interface{} { return b.FaviconURL }},
+}
+
+// fieldAliases maps shorthand field names to their JSON keys.
+var fieldAliases = map[string]string{
+	"tags":     "tag_names",
+	"archived": "is_archived",
+}
+
+// parseFields resolves --fields names, accepting JSON keys and the aliases
+// in fieldAliases. Duplicates are dropped; the requested order is kept.
+func parseFields(names []string) ([]bookmarkField, error) {
+	byName := make(map[string]bookmarkField, len(bookmarkFields))
+	for _, f := range bookmarkFields {
+		byName[f.name] = f
+	}
+
+	var fields []bookmarkField
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if alias, ok := fieldAliases[name]; ok {
+			name = alias
+		}
+		f, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (valid: %s)", name, validFieldNames())
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields requires at least one field name")
+	}
+	return fields, nil
+}
+
+func validFieldNames() string {
+	names := make([]string, len(bookmarkFields))
+	for i, f := range bookmarkFields {
+		names[i] = f.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// fieldSubset is a bookmark reduced to selected fields. It marshals as a
+// JSON object with the keys in the selected order.
+type fieldSubset struct {
+	bookmark *models.Bookmark
+	fields   []bookmarkField
+}
+
+func (s fieldSubset) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range s.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.name)
+		value, err := json.Marshal(f.value(s.bookmark))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// printFields prints the selected fields of a bookmark in the same layout
+// as the full get output.
+func printFields(b *models.Bookmark, fields []bookmarkField) {
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.label)+1)
+	}
+	for _, f := range fields {
+		fmt.Printf("%-*s %s\n", width, f.label+":", formatFieldValue(f.value(b)))
+	}
+}
+
+// formatFieldValue renders a field value for human output.
+func formatFieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case []string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return joinTags(v)
+	case time.Time:
+		return displayTime(v).Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -18,15 +17,58 @@ var getCmd = &cobra.Command{
 	Short: "Get a bookmark by ID",
 	Long: `Get a bookmark by ID and display its full details.
 
+With --fields, only the named fields are shown, in the order given; with
+--json the output is an object holding just those keys. Field names are the
+bookmark's JSON keys (id, url, title, description, notes, website_title,
+website_description, tag_names, date_added, date_modified, unread, shared,
+is_archived, favicon_url); "tags" and "archived" are accepted as shorthands.
+
+With --markdown-link, the bookmark is printed as a Markdown link,
+[Title](URL), ready to paste into a document.
+
+With --with-content, the text of the newest web archive snapshot LinkDing
+made of the page is shown after the details: the first 500 characters, or
+all of it with --full. This needs web archive integration (or local
+snapshots) enabled on the server. With --json the text is in a "content"
+field, null when there is no archived content.
+
+--template prints the bookmark through a Go text/template instead, and
+--template-file reads the template from a file; see 'linkdingctl list
+--help' for the fields.
+
 Examples:
   linkdingctl get 123
-  linkdingctl get 123 --json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runGet,
+  linkdingctl get 123 --json
+  linkdingctl get 123 --fields url,tags --json
+  linkdingctl get 123 --markdown-link
+  linkdingctl get 123 --with-content
+  linkdingctl get 123 --with-content --full --json
+  linkdingctl get 123 --template '{{.URL}} ({{join .TagNames ", "}})'`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runGet,
 }
 
+var (
+	getFields       []string
+	getMarkdownLink bool
+	getWithContent  bool
+	getFullContent  bool
+
+	getTemplate     string
+	getTemplateFile string
+)
+
 func init() {
 	rootCmd.AddCommand(getCmd)
+
+	getCmd.Flags().StringSliceVar(&getFields, "fields", nil, "Show only these fields (comma-separated, e.g. url,tags)")
+	getCmd.Flags().BoolVar(&getMarkdownLink, "markdown-link", false, "Print the bookmark as a Markdown link, [Title](URL)")
+	getCmd.Flags().BoolVar(&getWithContent, "with-content", false, "Also show the text of the archived web snapshot")
+	getCmd.Flags().BoolVar(&getFullContent, "full", false, "With --with-content, show all of the text instead of an excerpt")
+	getCmd.Flags().StringVar(&getTemplate, "template", "", "Print the bookmark with this Go template, e.g. '{{.ID}} {{.Title}}'")
+	getCmd.Flags().StringVar(&getTemplateFile, "template-file", "", "Print the bookmark with the Go template in this file")
+	getCmd.MarkFlagsMutuallyExclusive("fields", "markdown-link", "with-content", "template", "template-file")
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
@@ -36,6 +78,25 @@ func runGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid bookmark ID: %s (must be a number)", args[0])
 	}
 
+	if getMarkdownLink && structuredOutput() {
+		return fmt.Errorf("--markdown-link cannot be combined with --json or --select")
+	}
+	if getFullContent && !getWithContent {
+		return fmt.Errorf("--full requires --with-content")
+	}
+
+	var fields []bookmarkField
+	if cmd.Flags().Changed("fields") {
+		if fields, err = parseFields(getFields); err != nil {
+			return err
+		}
+	}
+
+	tmpl, err := parseBookmarkTemplate(getTemplate, getTemplateFile)
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -43,7 +104,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Fetch bookmark
 	bookmark, err := client.GetBookmark(id)
@@ -52,17 +113,69 @@ func runGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
+	if tmpl != nil {
+		return executeBookmarkTemplate(os.Stdout, tmpl, []models.Bookmark{*bookmark})
+	}
+	if getMarkdownLink {
+		fmt.Println(markdownLink(bookmark))
+		return nil
+	}
+	if fields != nil {
+		if structuredOutput() {
+			return writeJSON(fieldSubset{bookmark: bookmark, fields: fields})
+		}
+		printFields(bookmark, fields)
+		return nil
+	}
+	if getWithContent {
+		return outputBookmarkWithContent(client, bookmark)
+	}
+	if structuredOutput() {
 		return outputBookmarkJSON(bookmark)
 	}
 
 	return outputBookmarkHuman(bookmark)
 }
 
+// bookmarkWithContent is a bookmark with the text of its archived snapshot,
+// for get --with-content --json.
+type bookmarkWithContent struct {
+	*models.Bookmark
+	Content *string `json:"content"`
+}
+
+// outputBookmarkWithContent shows the bookmark followed by its archived
+// snapshot text, an excerpt unless --full is given.
+func outputBookmarkWithContent(client *api.Client, bookmark *models.Bookmark) error {
+	text, err := archivedContent(client, bookmark.ID)
+	if err != nil {
+		return err
+	}
+	if !getFullContent {
+		text = contentExcerpt(text)
+	}
+
+	if structuredOutput() {
+		output := bookmarkWithContent{Bookmark: bookmark}
+		if text != "" {
+			output.Content = &text
+		}
+		return writeJSON(output)
+	}
+
+	if err := outputBookmarkHuman(bookmark); err != nil {
+		return err
+	}
+	if text == "" {
+		fmt.Println("\nNo archived content")
+		return nil
+	}
+	fmt.Printf("\nArchived content:\n%s\n", text)
+	return nil
+}
+
 func outputBookmarkJSON(bookmark interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(bookmark)
+	return writeJSON(bookmark)
 }
 
 func outputBookmarkHuman(bookmark interface{}) error {
@@ -86,8 +199,8 @@ func outputBookmarkHuman(bookmark interface{}) error {
 	} else {
 		fmt.Printf("Tags:        -\n")
 	}
-	fmt.Printf("Added:       %s\n", b.DateAdded.Format("2006-01-02 15:04:05"))
-	fmt.Printf("Modified:    %s\n", b.DateModified.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Added:       %s\n", displayTime(b.DateAdded).Format("2006-01-02 15:04:05"))
+	fmt.Printf("Modified:    %s\n", displayTime(b.DateModified).Format("2006-01-02 15:04:05"))
 	fmt.Printf("Unread:      %t\n", b.Unread)
 	fmt.Printf("Shared:      %t\n", b.Shared)
 	fmt.Printf("Archived:    %t\n", b.IsArchived)
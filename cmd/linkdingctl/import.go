@@ -1,12 +1,13 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/export"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
 	"github.com/spf13/cobra"
 )
 
@@ -21,10 +22,80 @@ Format is auto-detected from file extension:
   .html, .htm → HTML/Netscape format
   .csv → CSV format
 
+Netscape bookmark files (browser exports) may use --format netscape, an alias
+for html. The ADD_DATE, LAST_MODIFIED, PRIVATE, TOSHARE, TOREAD and TAGS
+attributes are parsed; with --strict, malformed entries are reported as errors
+instead of being skipped. Folders are ignored unless --folders-as-tags is
+given, which tags each bookmark with its folder path joined by "/", such as
+browser/work/docs for a bookmark in Browser > Work > Docs (spaces in folder
+names become "-").
+
+CSV files need a url column; title, description, tags, unread, shared and
+archived are optional. Common alternative names are accepted (link or href
+for url, labels for tags, ...), a status column of "unread" or "archive"
+sets unread or archived, and unknown columns are ignored. --csv-dialect
+pocket reads Pocket's export (title,url,time_added,tags,status), whose tags
+are separated by "|"; time_added is ignored since LinkDing sets the date
+itself.
+
+Entries whose URL scheme is not http or https fail unless the scheme is
+added with --allow-scheme.
+
+An entry is a duplicate of an existing bookmark with exactly the same URL.
+With --normalize-urls, URLs are compared loosely instead: http and https,
+a leading www., a trailing slash, a default port and the #fragment make no
+difference, so http://www.example.com/ matches https://example.com.
+
+With --batch-size, entries are processed in batches with a --batch-pause
+between them, and the start of each batch is reported. An interrupted import
+can continue with --resume-from <entry>, where entries are numbered from 0 in
+file order.
+
+With --resume, progress is recorded in a checkpoint file after each entry,
+in the user cache directory (or at the path given with --checkpoint). If
+the import is interrupted or entries fail, run it again with --resume to
+skip the entries already done. The checkpoint is tied to the file's
+contents, so resuming with a different or edited file is an error. It is
+deleted once every entry has succeeded. --checkpoint alone records a fresh
+checkpoint, replacing any earlier one.
+
+With --summary-only, only the counts are printed, as a single JSON object
+{"added":N,"updated":N,"skipped":N,"failed":N}, leaving out per-entry errors
+so CI logs stay small. The exit status is the same as without it.
+
+With --concurrency N, entries are sent in batches of N (at most 16), whose
+bookmarks are created or updated in parallel. Errors are still reported by
+line, but the server sees the requests of a batch out of file order, and
+--stop-on-error lets the rest of the failed entry's batch finish.
+
+Once every entry has been processed, the bookmarks that could not be
+created or updated are tried once more, so a transient server error does
+not leave a gap; --retry-failed sets the number of extra attempts, and 0
+turns them off. Before a failed create is tried again the existing
+bookmarks are read once more, and one whose URL is now there (because the
+server saved it but the response was lost) counts as added rather than
+being created twice. Only entries that still fail are reported.
+
+By default a failed entry is reported and the import continues. With
+--stop-on-error the import ends at the first failure, which saves time when
+an auth or permission error would make every later request fail too.
+--fail-on-error keeps going but exits non-zero if any entry failed, for
+scripts and CI that only look at the exit status.
+
 Examples:
   linkdingctl import bookmarks.json
   linkdingctl import bookmarks.html --add-tags "imported"
-  linkdingctl import export.csv --dry-run`,
+  linkdingctl import export.csv --dry-run
+  linkdingctl import pocket.csv --csv-dialect pocket --add-tags pocket
+  linkdingctl import bookmarks.json --validate-only
+  linkdingctl import firefox.html -f netscape --strict
+  linkdingctl import chrome.html --folders-as-tags
+  linkdingctl import huge.json --limit 10 --offset 100 --dry-run
+  linkdingctl import huge.json --batch-size 100 --resume-from 400
+  linkdingctl import huge.json --resume
+  linkdingctl import huge.json --summary-only
+  linkdingctl import huge.json --summary-only --fail-on-error
+  linkdingctl import huge.json --concurrency 8`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImport,
 }
@@ -34,20 +105,86 @@ var (
 	importDryRun         bool
 	importSkipDuplicates bool
 	importAddTags        []string
+	importValidateOnly   bool
+	importStrict         bool
+	importLimit          int
+	importOffset         int
+	importStopOnError    bool
+	importContinue       bool
+	importAllowSchemes   []string
+	importBatchSize      int
+	importBatchPause     time.Duration
+	importResumeFrom     int
+	importResume         bool
+	importCheckpoint     string
+	importSummaryOnly    bool
+	importConcurrency    int
+	importCSVDialect     string
+	importFoldersAsTags  bool
+	importNormalizeURLs  bool
+	importRetryFailed    int
+	importFailOnError    bool
 )
 
 func init() {
 	rootCmd.AddCommand(importCmd)
 
-	importCmd.Flags().StringVarP(&importFormat, "format", "f", "auto", "Input format: json, html, csv (default: auto-detect)")
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "auto", "Input format: json, html (or netscape), csv (default: auto-detect)")
 	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without making changes")
 	importCmd.Flags().BoolVar(&importSkipDuplicates, "skip-duplicates", false, "Skip URLs that already exist (default: update them)")
+	importCmd.Flags().BoolVar(&importNormalizeURLs, "normalize-urls", false, "Match existing bookmarks ignoring http/https, www., trailing slashes and fragments")
 	importCmd.Flags().StringSliceVarP(&importAddTags, "add-tags", "T", []string{}, "Add these tags to all imported bookmarks")
+	importCmd.Flags().BoolVar(&importFoldersAsTags, "folders-as-tags", false, "Tag each bookmark with its folder path, like work/docs (HTML only)")
+	importCmd.Flags().StringVar(&importCSVDialect, "csv-dialect", export.CSVDialectDefault, "CSV layout: default, or pocket for Pocket's export")
+	_ = importCmd.RegisterFlagCompletionFunc("csv-dialect", cobra.FixedCompletions([]string{export.CSVDialectDefault, export.CSVDialectPocket}, cobra.ShellCompDirectiveNoFileComp))
+	importCmd.Flags().BoolVar(&importStrict, "strict", false, "Require a well-formed Netscape file and report malformed entries as errors (HTML only)")
+	importCmd.Flags().IntVar(&importLimit, "limit", 0, "Process at most this many entries from the file (default: all)")
+	importCmd.Flags().IntVar(&importOffset, "offset", 0, "Skip this many entries at the start of the file")
+	importCmd.Flags().BoolVar(&importStopOnError, "stop-on-error", false, "Abort at the first failed entry, keeping what was already imported")
+	importCmd.Flags().IntVar(&importRetryFailed, "retry-failed", 1, "Times to retry the bookmarks that failed, after the rest of the file (0 to not retry)")
+	importCmd.Flags().BoolVar(&importContinue, "continue-on-error", false, "Keep going past failed entries and report them at the end (default)")
+	importCmd.MarkFlagsMutuallyExclusive("stop-on-error", "continue-on-error")
+	importCmd.Flags().BoolVar(&importFailOnError, "fail-on-error", false, "Exit non-zero if any entry failed, after importing the rest")
+	importCmd.Flags().StringSliceVar(&importAllowSchemes, "allow-scheme", nil, "Also accept URLs with these schemes (default: http, https)")
+	importCmd.Flags().IntVar(&importBatchSize, "batch-size", 0, "Process entries in batches of this size, pausing between batches")
+	importCmd.Flags().DurationVar(&importBatchPause, "batch-pause", defaultBatchPause, "Pause between batches with --batch-size")
+	importCmd.Flags().IntVar(&importResumeFrom, "resume-from", 0, "Continue an interrupted import from this entry index")
+	importCmd.MarkFlagsMutuallyExclusive("offset", "resume-from")
+	importCmd.Flags().BoolVar(&importResume, "resume", false, "Record progress in a checkpoint and skip the entries an earlier --resume run finished")
+	importCmd.Flags().StringVar(&importCheckpoint, "checkpoint", "", "Record progress in this checkpoint file (default with --resume: in the user cache directory)")
+	importCmd.Flags().IntVar(&importConcurrency, "concurrency", 1, fmt.Sprintf("Number of bookmarks created or updated in parallel (max %d)", export.MaxConcurrency))
+	importCmd.Flags().BoolVar(&importSummaryOnly, "summary-only", false, "Print only the added/updated/skipped/failed counts as JSON")
+	importCmd.Flags().BoolVar(&importValidateOnly, "validate-only", false, "Check a JSON file against the export schema without contacting the server")
 }
 
 func runImport(cmd *cobra.Command, args []string) error {
 	filename := args[0]
 
+	// Validation is purely local and needs no configuration
+	if importValidateOnly {
+		return runImportValidate(filename)
+	}
+
+	if err := validateImportWindow(importLimit, importOffset); err != nil {
+		return err
+	}
+	if importResumeFrom < 0 {
+		return fmt.Errorf("--resume-from must be zero or greater")
+	}
+	if importCSVDialect != export.CSVDialectDefault && importCSVDialect != export.CSVDialectPocket {
+		return fmt.Errorf("invalid --csv-dialect %q: must be %s or %s", importCSVDialect, export.CSVDialectDefault, export.CSVDialectPocket)
+	}
+	if importRetryFailed < 0 {
+		return fmt.Errorf("--retry-failed must be zero or greater")
+	}
+	if importConcurrency < 1 || importConcurrency > export.MaxConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", export.MaxConcurrency)
+	}
+	pacer, err := newPacer(importBatchSize, importBatchPause, true)
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -55,88 +192,175 @@ func runImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Create import options
 	options := export.ImportOptions{
 		Format:         importFormat,
-		DryRun:         importDryRun,
+		DryRun:         isDryRun(importDryRun),
 		SkipDuplicates: importSkipDuplicates,
 		AddTags:        importAddTags,
+		Strict:         importStrict,
+		Limit:          importLimit,
+		Offset:         importOffset + importResumeFrom,
+		StopOnError:    importStopOnError,
+		AllowedSchemes: urlutil.AllowedSchemes(importAllowSchemes),
+		Pacer:          pacer,
+		Concurrency:    importConcurrency,
+		CSVDialect:     importCSVDialect,
+		FoldersAsTags:  importFoldersAsTags,
+		NormalizeURLs:  importNormalizeURLs,
+		RetryFailed:    importRetryFailed,
 	}
 
+	checkpoint, err := openCheckpoint(filename, importCheckpoint, importResume, options.DryRun)
+	if err != nil {
+		return err
+	}
+	options.Checkpoint = checkpoint
+
 	// Check if JSON output is requested
-	if jsonOutput {
+	if jsonOutput || importSummaryOnly {
 		return runImportJSON(client, filename, options)
 	}
 
 	// Perform import with progress display
-	if importDryRun {
+	if options.DryRun {
 		fmt.Fprintln(os.Stderr, "Dry run - no changes will be made")
 	}
-	fmt.Fprintln(os.Stderr, "Importing bookmarks...")
+	statusf(os.Stderr, "Importing bookmarks...\n")
 
+	progress := newProgress("Imported")
+	options.Progress = progress.update
 	result, err := export.ImportBookmarks(client, filename, options)
-	if err != nil {
-		return err
+	progress.clear()
+	if result != nil {
+		// Display results, including what was done before --stop-on-error ended the import
+		displayImportResult(result, "skipped (--skip-duplicates)")
 	}
+	finishCheckpoint(options.Checkpoint, result, err)
 
-	// Display results
-	displayImportResult(result)
+	return failedEntriesError(result, err, importFailOnError)
+}
+
+// failedEntriesError returns err, or for --fail-on-error an error counting
+// the failed entries of an import or restore that otherwise finished.
+func failedEntriesError(result *export.ImportResult, err error, failOnError bool) error {
+	if err == nil && failOnError && result != nil && result.Failed > 0 {
+		return fmt.Errorf("%d entries failed", result.Failed)
+	}
+	return err
+}
 
+// validateImportWindow checks the --limit and --offset flags of import and restore.
+func validateImportWindow(limit, offset int) error {
+	if limit < 0 {
+		return fmt.Errorf("--limit must be zero or greater")
+	}
+	if offset < 0 {
+		return fmt.Errorf("--offset must be zero or greater")
+	}
 	return nil
 }
 
 func runImportJSON(client *api.Client, filename string, options export.ImportOptions) error {
 	result, err := export.ImportBookmarks(client, filename, options)
+	finishCheckpoint(options.Checkpoint, result, err)
+	if result == nil {
+		return err
+	}
+
+	// Output as JSON, including what was done before --stop-on-error ended the import
+	output := outputImportResultJSON
+	if importSummaryOnly {
+		output = outputImportSummaryJSON
+	}
+	if encodeErr := output(result); encodeErr != nil {
+		return encodeErr
+	}
+	return failedEntriesError(result, err, importFailOnError)
+}
+
+func runImportValidate(filename string) error {
+	format := importFormat
+	if format == "" || format == "auto" {
+		format = export.DetectFormat(filename)
+	}
+	if format != "json" {
+		return fmt.Errorf("--validate-only supports JSON files only (got format '%s')", format)
+	}
+
+	file, err := export.OpenFile(filename)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = file.Close() }()
 
-	// Output as JSON
-	output := map[string]interface{}{
-		"added":   result.Added,
-		"updated": result.Updated,
-		"skipped": result.Skipped,
-		"failed":  result.Failed,
+	validationErrors, err := export.ValidateJSON(file)
+	if err != nil {
+		return err
 	}
 
-	if len(result.Errors) > 0 {
-		errors := make([]map[string]interface{}, len(result.Errors))
-		for i, e := range result.Errors {
-			errors[i] = map[string]interface{}{
-				"line":    e.Line,
-				"message": e.Message,
-			}
+	if jsonOutput {
+		if validationErrors == nil {
+			validationErrors = []export.ValidationError{}
+		}
+		output := map[string]interface{}{
+			"valid":  len(validationErrors) == 0,
+			"errors": validationErrors,
+		}
+		if err := writeJSON(output); err != nil {
+			return err
+		}
+	} else if len(validationErrors) == 0 {
+		statusf(os.Stdout, "✓ %s is valid\n", filename)
+	} else {
+		failf(os.Stderr, "✗ %s has %d schema error(s):\n", filename, len(validationErrors))
+		for _, e := range validationErrors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e)
 		}
-		output["errors"] = errors
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
 }
 
-func displayImportResult(result *export.ImportResult) {
+// displayImportResult prints the summary and errors of an import or restore.
+// skipped describes the entries left alone, naming the command's own flag.
+func displayImportResult(result *export.ImportResult, skipped string) {
 	// Display summary
 	if result.Added > 0 {
-		fmt.Fprintf(os.Stderr, "  ✓ %d new bookmarks added\n", result.Added)
+		statusf(os.Stderr, "  ✓ %d new bookmarks added\n", result.Added)
 	}
 	if result.Updated > 0 {
-		fmt.Fprintf(os.Stderr, "  ✓ %d existing bookmarks updated\n", result.Updated)
+		statusf(os.Stderr, "  ✓ %d existing bookmarks updated\n", result.Updated)
 	}
 	if result.Skipped > 0 {
-		fmt.Fprintf(os.Stderr, "  ⊘ %d skipped (--skip-duplicates)\n", result.Skipped)
+		statusf(os.Stderr, "  ⊘ %d %s\n", result.Skipped, skipped)
+	}
+	if result.Resumed > 0 {
+		statusf(os.Stderr, "  ↻ %d already processed by an earlier run (--resume)\n", result.Resumed)
 	}
 	if result.Failed > 0 {
-		fmt.Fprintf(os.Stderr, "  ✗ %d failed (see errors below)\n", result.Failed)
+		failf(os.Stderr, "  ✗ %d failed (see errors below)\n", result.Failed)
+	}
+	if result.BundlesAdded > 0 {
+		statusf(os.Stderr, "  ✓ %d bundles created\n", result.BundlesAdded)
+	}
+	if result.BundlesSkipped > 0 {
+		statusf(os.Stderr, "  ⊘ %d bundles already existed\n", result.BundlesSkipped)
 	}
 
 	// Display errors
-	if len(result.Errors) > 0 {
+	if len(result.Errors) > 0 || len(result.BundleErrors) > 0 {
 		fmt.Fprintln(os.Stderr, "\nErrors:")
 		for _, e := range result.Errors {
 			fmt.Fprintf(os.Stderr, "  Line %d: %s\n", e.Line, e.Message)
 		}
+		for _, e := range result.BundleErrors {
+			fmt.Fprintf(os.Stderr, "  Bundle %d: %s\n", e.Line, e.Message)
+		}
 	}
 }
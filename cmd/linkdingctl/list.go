@@ -1,13 +1,20 @@
 package main
 
 import (
-	"encoding/json"
+	"cmp"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"text/template"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/filter"
 	"github.com/rodstewart/linkding-cli/internal/models"
 	"github.com/spf13/cobra"
 )
@@ -18,11 +25,66 @@ var listCmd = &cobra.Command{
 	Short: "List bookmarks",
 	Long: `List bookmarks with optional filtering.
 
+--all, or --limit 0, pages through every match instead of showing a single
+page; --tags, --query, --unread and --archived still apply. It cannot be
+combined with --offset.
+
+With --output the results are written to a file in the chosen format; its
+directory must exist unless --mkdir is given.
+
+--fields picks and orders the table columns from: id, url, title,
+description, notes, website_title, website_description, tags, date_added,
+date_modified, unread, shared, archived, favicon_url. It does not affect
+--json output.
+--no-header drops the header and the summary line, for piping.
+
+--filter takes a boolean expression evaluated locally after the other
+filters have narrowed the fetch. Terms are tag:NAME, title:~REGEX, unread,
+shared and archived, combined with NOT, AND, OR and parentheses. Quote a
+term that contains spaces or parentheses: title:~"(?i)go (tips|tricks)".
+With --filter every match is fetched; the count is the number of bookmarks
+the expression keeps, and --offset and --limit then apply to those.
+
+--after-id and --before-id keep only bookmarks whose ID is strictly
+greater or less than the one given, for cursor-style paging that does not
+drift when bookmarks are added meanwhile: pass the last ID of one chunk as
+--after-id for the next. Like --exclude-tags they filter locally, over the
+single fetched page unless --all (or --filter) fetches every match.
+
+--sort orders the results by title, date_added, url or id; prefix the
+key with - to reverse it (-date_added is newest first). Titles compare
+case-insensitively and ties keep the server's order. Sorting is local, so
+without --all (or --filter) it orders only the single fetched page; with
+--filter it applies before --offset and --limit.
+
+--template prints each bookmark through a Go text/template, one per line,
+in place of the table; --template-file reads the template from a file.
+Fields are those of the bookmark's Go type (ID, URL, Title, Description,
+Notes, TagNames, DateAdded, DateModified, Unread, Shared, IsArchived, ...),
+and join joins a list: {{join .TagNames ","}}.
+
 Examples:
   linkdingctl list
   linkdingctl list --tags k8s,platform
+  linkdingctl list --tags k8s --exclude-tags deprecated
   linkdingctl list -q "kubernetes" --unread
-  linkdingctl list --limit 10`,
+  linkdingctl list --limit 10
+  linkdingctl list --tags k8s --all --json
+  linkdingctl list --added --modified
+  linkdingctl list --modified --absolute-dates
+  linkdingctl list --random-sample 20
+  linkdingctl list --random-sample 20 --seed 42 --tags k8s
+  linkdingctl list --random-sample 20 --all
+  linkdingctl list --tags k8s --markdown-link
+  linkdingctl list --tags k8s --json --output k8s.json
+  linkdingctl list --fields id,url --no-header
+  linkdingctl list --filter 'unread AND tag:go AND NOT shared'
+  linkdingctl list --tags k8s --filter '(tag:helm OR tag:kustomize) AND title:~(?i)guide'
+  linkdingctl list --all --after-id 1200 --json
+  linkdingctl list --all --sort title
+  linkdingctl list --tags k8s --all --sort -date_added
+  linkdingctl list --template '{{.ID}} {{.Title}} {{range .TagNames}}#{{.}} {{end}}'
+  linkdingctl list --tags k8s --template-file bookmark.tmpl`,
 	RunE: runList,
 }
 
@@ -33,6 +95,38 @@ var (
 	listArchived bool
 	listLimit    int
 	listOffset   int
+	listExclude  []string
+	listAfterID  int
+	listBeforeID int
+	listSort     string
+	// listSortCmp holds the parsed --sort; nil keeps the server's order.
+	listSortCmp func(a, b models.Bookmark) int
+
+	listShowAdded     bool
+	listShowModified  bool
+	listAbsoluteDates bool
+
+	listSample    int
+	listSampleAll bool
+	listSeed      int64
+
+	listMarkdownLink bool
+	listOutput       string
+	listMkdir        bool
+
+	listFields   []string
+	listNoHeader bool
+	// listColumns holds the parsed --fields; nil keeps the default columns.
+	listColumns []bookmarkField
+
+	listFilter string
+	// listFilterExpr holds the parsed --filter; nil keeps every bookmark.
+	listFilterExpr filter.Expr
+
+	listTemplate     string
+	listTemplateFile string
+	// listTmpl holds the parsed --template; nil keeps the table.
+	listTmpl *template.Template
 )
 
 func init() {
@@ -40,10 +134,32 @@ func init() {
 
 	listCmd.Flags().StringVarP(&listQuery, "query", "q", "", "Search query")
 	listCmd.Flags().StringSliceVarP(&listTags, "tags", "T", []string{}, "Filter by tags (AND logic)")
+	listCmd.Flags().StringSliceVar(&listExclude, "exclude-tags", []string{}, "Hide bookmarks with any of these tags (filters each fetched page)")
+	listCmd.Flags().IntVar(&listAfterID, "after-id", 0, "Show only bookmarks with an ID greater than this (filters each fetched page)")
+	listCmd.Flags().IntVar(&listBeforeID, "before-id", 0, "Show only bookmarks with an ID less than this (filters each fetched page)")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by title, date_added, url or id; prefix with - for descending")
+	_ = listCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(listSortCompletions(), cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	_ = listCmd.RegisterFlagCompletionFunc("exclude-tags", completeTagNames)
 	listCmd.Flags().BoolVarP(&listUnread, "unread", "u", false, "Show only unread")
 	listCmd.Flags().BoolVarP(&listArchived, "archived", "a", false, "Show only archived")
-	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 100, "Max results")
+	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 100, "Max results (0 for all, like --all)")
 	listCmd.Flags().IntVarP(&listOffset, "offset", "o", 0, "Pagination offset")
+	listCmd.Flags().BoolVar(&listShowAdded, "added", false, "Show the date each bookmark was added")
+	listCmd.Flags().BoolVar(&listShowModified, "modified", false, "Show the date each bookmark was last modified")
+	listCmd.Flags().BoolVar(&listAbsoluteDates, "absolute-dates", false, "Show full timestamps instead of relative times")
+	listCmd.Flags().IntVar(&listSample, "random-sample", 0, "Show N distinct bookmarks chosen at random from the matches")
+	listCmd.Flags().BoolVar(&listSampleAll, "all", false, "Fetch every match, page by page; with --random-sample, sample locally instead of fetching random offsets")
+	listCmd.Flags().Int64Var(&listSeed, "seed", 0, "Random seed for --random-sample, to repeat a sample")
+	listCmd.Flags().BoolVar(&listMarkdownLink, "markdown-link", false, "Print each bookmark as a Markdown link, [Title](URL), one per line")
+	listCmd.Flags().StringVar(&listOutput, "output", "", "Write the results to this file instead of stdout")
+	listCmd.Flags().BoolVar(&listMkdir, "mkdir", false, "Create the --output file's directory (mode 0700) if it does not exist")
+	listCmd.Flags().StringSliceVar(&listFields, "fields", nil, "Table columns to show, in order (e.g. id,url,tags,date_added)")
+	listCmd.Flags().BoolVar(&listNoHeader, "no-header", false, "Omit the table header and summary line")
+	listCmd.Flags().StringVar(&listFilter, "filter", "", "Boolean expression applied locally, e.g. 'unread AND tag:go AND NOT shared'")
+	listCmd.Flags().StringVar(&listTemplate, "template", "", "Print each bookmark with this Go template, e.g. '{{.ID}} {{.Title}}'")
+	listCmd.Flags().StringVar(&listTemplateFile, "template-file", "", "Print each bookmark with the Go template in this file")
+	listCmd.MarkFlagsMutuallyExclusive("template", "template-file", "markdown-link", "fields")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -54,7 +170,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Convert bool flags to pointers for API call
 	var unreadPtr, archivedPtr *bool
@@ -65,62 +181,499 @@ func runList(cmd *cobra.Command, args []string) error {
 		archivedPtr = &listArchived
 	}
 
+	if listMarkdownLink && structuredOutput() {
+		return fmt.Errorf("--markdown-link cannot be combined with --json or --select")
+	}
+	if listAfterID < 0 || listBeforeID < 0 {
+		return fmt.Errorf("--after-id and --before-id must be positive")
+	}
+	if listBeforeID > 0 && listAfterID >= listBeforeID-1 {
+		return fmt.Errorf("no ID is both greater than --after-id %d and less than --before-id %d", listAfterID, listBeforeID)
+	}
+	if listSortCmp, err = parseListSort(listSort); err != nil {
+		return err
+	}
+	if listOutput != "" {
+		if err := ensureOutputDir(filepath.Dir(listOutput), listMkdir); err != nil {
+			return err
+		}
+	}
+	listColumns = nil
+	if cmd.Flags().Changed("fields") {
+		if listShowAdded || listShowModified {
+			return fmt.Errorf("--added and --modified cannot be combined with --fields; select date_added or date_modified instead")
+		}
+		if listColumns, err = parseFields(listFields); err != nil {
+			return err
+		}
+	}
+
+	if listTmpl, err = parseBookmarkTemplate(listTemplate, listTemplateFile); err != nil {
+		return err
+	}
+
+	listFilterExpr = nil
+	if cmd.Flags().Changed("filter") {
+		if listFilterExpr, err = filter.Parse(listFilter); err != nil {
+			return fmt.Errorf("invalid --filter %q: %w", listFilter, err)
+		}
+	}
+
+	if cmd.Flags().Changed("random-sample") {
+		return runListSample(cmd, client, unreadPtr, archivedPtr)
+	}
+	if cmd.Flags().Changed("seed") {
+		return fmt.Errorf("--seed requires --random-sample")
+	}
+	if listFilterExpr != nil {
+		return runListFiltered(cmd, client, unreadPtr, archivedPtr)
+	}
+	if listSampleAll || (cmd.Flags().Changed("limit") && listLimit == 0) {
+		return runListAll(cmd, client, unreadPtr, archivedPtr)
+	}
+
 	// Fetch bookmarks
 	bookmarkList, err := client.GetBookmarks(listQuery, listTags, unreadPtr, archivedPtr, listLimit, listOffset)
 	if err != nil {
 		return err
 	}
 
-	// Output based on format
-	if jsonOutput {
-		return outputJSON(bookmarkList)
+	// Exclusion happens client-side, so count still reflects the server's total
+	bookmarkList.Results = sortListed(excludeListed(bookmarkList.Results))
+
+	return writeBookmarkList(bookmarkList)
+}
+
+// runListAll shows every match of the filters, fetched page by page. As with
+// a single page, the count is the number of matches before --exclude-tags
+// and the ID bounds.
+func runListAll(cmd *cobra.Command, client *api.Client, unread, archived *bool) error {
+	if cmd.Flags().Changed("offset") {
+		return fmt.Errorf("--all and --limit 0 cannot be combined with --offset")
+	}
+	if listSampleAll && cmd.Flags().Changed("limit") && listLimit != 0 {
+		return fmt.Errorf("--all cannot be combined with --limit")
+	}
+
+	all, err := client.BookmarkPages(listQuery, listTags, unread, archived).All()
+	if err != nil {
+		return err
+	}
+	total := len(all)
+
+	results := sortListed(excludeListed(all))
+	if results == nil {
+		results = []models.Bookmark{}
+	}
+	return writeBookmarkList(&models.BookmarkList{Count: total, Results: results})
+}
+
+// runListFiltered fetches every match of the server-side filters and keeps
+// those that pass --exclude-tags, the ID bounds and --filter. --offset and --limit page
+// through what is left, after --sort; --all or --limit 0 shows all of it.
+func runListFiltered(cmd *cobra.Command, client *api.Client, unread, archived *bool) error {
+	if listSampleAll && cmd.Flags().Changed("limit") && listLimit != 0 {
+		return fmt.Errorf("--all cannot be combined with --limit")
+	}
+
+	all, err := client.BookmarkPages(listQuery, listTags, unread, archived).All()
+	if err != nil {
+		return err
+	}
+	results := sortListed(filterBookmarks(excludeListed(all), listFilterExpr))
+	total := len(results)
+
+	results = results[min(listOffset, total):]
+	if !listSampleAll && listLimit > 0 && len(results) > listLimit {
+		results = results[:listLimit]
+	}
+	return writeBookmarkList(&models.BookmarkList{Count: total, Results: results})
+}
+
+// excludeListed drops the bookmarks carrying an --exclude-tags tag or
+// outside the --after-id and --before-id bounds.
+func excludeListed(bookmarks []models.Bookmark) []models.Bookmark {
+	bookmarks = models.ExcludeTagged(bookmarks, listExclude)
+	if listAfterID == 0 && listBeforeID == 0 {
+		return bookmarks
+	}
+	kept := make([]models.Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if b.ID > listAfterID && (listBeforeID == 0 || b.ID < listBeforeID) {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
+
+// listSortKeys are the keys --sort accepts, each comparing two bookmarks in
+// ascending order.
+var listSortKeys = map[string]func(a, b models.Bookmark) int{
+	"title": func(a, b models.Bookmark) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	},
+	"date_added": func(a, b models.Bookmark) int { return a.DateAdded.Compare(b.DateAdded) },
+	"url":        func(a, b models.Bookmark) int { return strings.Compare(a.URL, b.URL) },
+	"id":         func(a, b models.Bookmark) int { return cmp.Compare(a.ID, b.ID) },
+}
+
+// listSortCompletions returns every --sort value, ascending then descending.
+func listSortCompletions() []string {
+	var values []string
+	for _, key := range []string{"title", "date_added", "url", "id"} {
+		values = append(values, key, "-"+key)
+	}
+	return values
+}
+
+// parseListSort resolves a --sort value to a comparison, reversed when the
+// key starts with "-". An empty value returns nil.
+func parseListSort(value string) (func(a, b models.Bookmark) int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	key, desc := strings.CutPrefix(strings.ToLower(strings.TrimSpace(value)), "-")
+	compare, ok := listSortKeys[key]
+	if !ok {
+		return nil, fmt.Errorf("invalid --sort %q (use title, date_added, url or id, prefixed with - for descending)", value)
+	}
+	if desc {
+		return func(a, b models.Bookmark) int { return compare(b, a) }, nil
+	}
+	return compare, nil
+}
+
+// sortListed orders bookmarks by --sort, in place. Equal bookmarks keep
+// their order.
+func sortListed(bookmarks []models.Bookmark) []models.Bookmark {
+	if listSortCmp != nil {
+		slices.SortStableFunc(bookmarks, listSortCmp)
+	}
+	return bookmarks
+}
+
+// filterBookmarks returns the bookmarks matching expr, or all of them when
+// expr is nil. The result is never nil.
+func filterBookmarks(bookmarks []models.Bookmark, expr filter.Expr) []models.Bookmark {
+	kept := make([]models.Bookmark, 0, len(bookmarks))
+	for i := range bookmarks {
+		if expr == nil || expr.Match(&bookmarks[i]) {
+			kept = append(kept, bookmarks[i])
+		}
+	}
+	return kept
+}
+
+// runListSample shows --random-sample N distinct bookmarks from the matches.
+// By default each sampled bookmark is fetched from a random offset; with --all
+// every match is fetched and sampled locally. The same seed, filters and
+// library give the same sample.
+func runListSample(cmd *cobra.Command, client *api.Client, unread, archived *bool) error {
+	if listSample <= 0 {
+		return fmt.Errorf("--random-sample must be greater than zero")
+	}
+	if cmd.Flags().Changed("limit") || cmd.Flags().Changed("offset") {
+		return fmt.Errorf("--random-sample cannot be combined with --limit or --offset")
+	}
+	if listFilterExpr != nil && !listSampleAll {
+		return fmt.Errorf("--random-sample with --filter requires --all")
+	}
+
+	seed := listSeed
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+		if !structuredOutput() {
+			statusf(os.Stderr, "Sample seed: %d (use --seed to repeat)\n", seed)
+		}
+	}
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+
+	var sample []models.Bookmark
+	var total int
+	var err error
+	if listSampleAll {
+		sample, total, err = sampleFromAll(client, rng, unread, archived)
+	} else {
+		sample, total, err = sampleByOffset(client, rng, unread, archived)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeBookmarkList(&models.BookmarkList{Count: total, Results: sortListed(sample)})
+}
+
+// writeBookmarkList renders the list command's results to --output, or to
+// stdout when it is not set, and confirms on stderr where a file was written.
+func writeBookmarkList(bookmarkList *models.BookmarkList) error {
+	if listOutput == "" {
+		return renderBookmarkList(os.Stdout, bookmarkList)
+	}
+
+	file, err := os.Create(listOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := renderBookmarkList(file, bookmarkList); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	statusf(os.Stderr, "Wrote %d bookmarks to %s\n", len(bookmarkList.Results), listOutput)
+	return nil
+}
+
+// renderBookmarkList writes bookmarks as JSON, Markdown links, --template
+// output or a table.
+func renderBookmarkList(w io.Writer, bookmarkList *models.BookmarkList) error {
+	if structuredOutput() {
+		return outputJSON(w, bookmarkList)
+	}
+	if listTmpl != nil {
+		return executeBookmarkTemplate(w, listTmpl, bookmarkList.Results)
+	}
+	if listMarkdownLink {
+		printMarkdownLinks(w, bookmarkList.Results)
+		return nil
+	}
+	return outputTable(w, bookmarkList)
+}
+
+// sampleByOffset fetches one bookmark at a time from distinct random offsets
+// until it has listSample bookmarks that pass --exclude-tags, or runs out.
+func sampleByOffset(client *api.Client, rng *rand.Rand, unread, archived *bool) ([]models.Bookmark, int, error) {
+	first, err := client.GetBookmarks(listQuery, listTags, unread, archived, 1, 0)
+	if err != nil {
+		return nil, 0, err
 	}
+	total := first.Count
+
+	// Lazy Fisher-Yates shuffle of the offsets 0..total-1: swapped holds only
+	// the positions that have been displaced so far.
+	swapped := make(map[int]int)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	sample := []models.Bookmark{}
+	for i := 0; i < total && len(sample) < listSample; i++ {
+		j := i + rng.IntN(total-i)
+		offset := at(j)
+		swapped[j] = at(i)
 
-	return outputTable(bookmarkList)
+		page, err := client.GetBookmarks(listQuery, listTags, unread, archived, 1, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		sample = append(sample, excludeListed(page.Results)...)
+	}
+	return sample, total, nil
+}
+
+// sampleFromAll fetches every match, then shuffles and keeps listSample of them.
+func sampleFromAll(client *api.Client, rng *rand.Rand, unread, archived *bool) ([]models.Bookmark, int, error) {
+	all, err := client.BookmarkPages(listQuery, listTags, unread, archived).All()
+	if err != nil {
+		return nil, 0, err
+	}
+	total := len(all)
+
+	all = filterBookmarks(excludeListed(all), listFilterExpr)
+	rng.Shuffle(len(all), func(i, j int) { all[i], all[j] = all[j], all[i] })
+	if len(all) > listSample {
+		all = all[:listSample]
+	}
+	return all, total, nil
 }
 
-func outputJSON(bookmarkList interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(bookmarkList)
+func outputJSON(out io.Writer, bookmarkList interface{}) error {
+	return writeJSONTo(out, bookmarkList)
 }
 
-func outputTable(bookmarkList *models.BookmarkList) error {
-	if len(bookmarkList.Results) == 0 {
-		fmt.Println("No bookmarks found")
+func outputTable(out io.Writer, bookmarkList *models.BookmarkList) error {
+	if !outputTableRows(out, bookmarkList.Results) || listNoHeader {
 		return nil
 	}
 
+	// Show pagination info
+	_, _ = fmt.Fprintf(out, "\nShowing %d of %d total bookmarks\n", len(bookmarkList.Results), bookmarkList.Count)
+	if bookmarkList.Next != nil {
+		_, _ = fmt.Fprintf(out, "Use --offset %d to see more\n", listOffset+listLimit)
+	}
+
+	return nil
+}
+
+// outputTableRows writes the bookmarks table without the paging footer. It
+// reports false when there were no bookmarks to show.
+func outputTableRows(out io.Writer, bookmarks []models.Bookmark) bool {
+	if len(bookmarks) == 0 {
+		if !listNoHeader {
+			_, _ = fmt.Fprintln(out, "No bookmarks found")
+		}
+		return false
+	}
+
 	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	defer func() { _ = w.Flush() }()
 
 	// Header
-	_, _ = fmt.Fprintln(w, "ID\tTITLE\tTAGS\tDATE")
-	_, _ = fmt.Fprintln(w, "--\t-----\t----\t----")
+	var header []string
+	if listColumns != nil {
+		for _, f := range listColumns {
+			header = append(header, strings.ToUpper(f.label))
+		}
+	} else {
+		header = []string{"ID", "TITLE", "TAGS"}
+		header = append(header, dateColumnHeaders()...)
+	}
+	if !listNoHeader {
+		_, _ = fmt.Fprintln(w, strings.Join(header, "\t"))
+		_, _ = fmt.Fprintln(w, strings.Join(headerUnderline(header), "\t"))
+	}
 
 	// Rows
-	for _, bookmark := range bookmarkList.Results {
+	now := time.Now()
+	for _, bookmark := range bookmarks {
+		if listColumns != nil {
+			_, _ = fmt.Fprintln(w, strings.Join(fieldColumnValues(&bookmark, listColumns), "\t"))
+			continue
+		}
+
 		title := truncate(bookmark.Title, 50)
 		tags := strings.Join(bookmark.TagNames, ", ")
 		if tags == "" {
 			tags = "-"
 		}
 		tags = truncate(tags, 30)
-		date := bookmark.DateAdded.Format("2006-01-02")
 
-		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", bookmark.ID, title, tags, date)
+		row := []string{fmt.Sprintf("%d", bookmark.ID), title, tags}
+		row = append(row, dateColumnValues(bookmark, now)...)
+		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 
-	_ = w.Flush()
+	return true
+}
 
-	// Show pagination info
-	fmt.Printf("\nShowing %d of %d total bookmarks\n", len(bookmarkList.Results), bookmarkList.Count)
-	if bookmarkList.Next != nil {
-		fmt.Printf("Use --offset %d to see more\n", listOffset+listLimit)
+// dateColumnHeaders returns the date column headers for the list table.
+// Without --added or --modified the table keeps its original DATE column.
+func dateColumnHeaders() []string {
+	if !listShowAdded && !listShowModified {
+		return []string{"DATE"}
 	}
+	var headers []string
+	if listShowAdded {
+		headers = append(headers, "ADDED")
+	}
+	if listShowModified {
+		headers = append(headers, "MODIFIED")
+	}
+	return headers
+}
 
-	return nil
+// dateColumnValues returns the date cells for a bookmark, matching dateColumnHeaders.
+func dateColumnValues(bookmark models.Bookmark, now time.Time) []string {
+	if !listShowAdded && !listShowModified {
+		return []string{displayTime(bookmark.DateAdded).Format("2006-01-02")}
+	}
+	var values []string
+	if listShowAdded {
+		values = append(values, formatListDate(bookmark.DateAdded, now))
+	}
+	if listShowModified {
+		values = append(values, formatListDate(bookmark.DateModified, now))
+	}
+	return values
+}
+
+// fieldColumnValues returns the --fields cells for a bookmark. URLs are
+// never truncated so they stay usable when piped; dates follow the DATE
+// column unless --absolute-dates is set.
+func fieldColumnValues(bookmark *models.Bookmark, fields []bookmarkField) []string {
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		switch v := f.value(bookmark).(type) {
+		case time.Time:
+			if listAbsoluteDates {
+				row[i] = formatFieldValue(v)
+			} else {
+				row[i] = displayTime(v).Format("2006-01-02")
+			}
+		case []string:
+			row[i] = truncate(formatFieldValue(v), 30)
+		case string:
+			if f.name != "url" {
+				v = truncate(strings.Join(strings.Fields(v), " "), 50)
+			}
+			if v == "" {
+				v = "-"
+			}
+			row[i] = v
+		default:
+			row[i] = formatFieldValue(v)
+		}
+	}
+	return row
+}
+
+// formatListDate renders a date as a relative time, or as a full timestamp
+// when --absolute-dates is set.
+func formatListDate(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if listAbsoluteDates {
+		return displayTime(t).Format("2006-01-02 15:04:05")
+	}
+	return relativeTime(t, now)
+}
+
+// relativeTime describes t relative to now in human-friendly terms, e.g. "3 days ago".
+func relativeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	suffix := "ago"
+	if d < 0 {
+		d = -d
+		suffix = "from now"
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s %s", n, unit, suffix)
+}
+
+// headerUnderline returns a dashed underline for each header cell.
+func headerUnderline(header []string) []string {
+	underline := make([]string, len(header))
+	for i, h := range header {
+		underline[i] = strings.Repeat("-", len(h))
+	}
+	return underline
 }
 
 // truncate truncates a string to maxLen characters, adding "..." if truncated
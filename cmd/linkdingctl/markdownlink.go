@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rodstewart/linkding-cli/internal/export"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// markdownLink formats a bookmark as [Title](URL). Bookmarks without a title
+// fall back to the website title, then to the URL itself.
+func markdownLink(b *models.Bookmark) string {
+	text := b.Title
+	if text == "" {
+		text = b.WebsiteTitle
+	}
+	if text == "" {
+		text = b.URL
+	}
+	return export.MarkdownLink(text, b.URL)
+}
+
+// printMarkdownLinks writes one Markdown link per bookmark to w.
+func printMarkdownLinks(w io.Writer, bookmarks []models.Bookmark) {
+	for i := range bookmarks {
+		_, _ = fmt.Fprintln(w, markdownLink(&bookmarks[i]))
+	}
+}
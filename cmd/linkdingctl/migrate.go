@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/config"
+	"github.com/rodstewart/linkding-cli/internal/migrate"
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy bookmarks from one LinkDing instance to another",
+	Long: `Copy every bookmark, including archived ones, from a source LinkDing
+instance to a destination instance.
+
+The source is a named profile with --from-profile, read from a config file
+with --from-config, or given directly with --from-url and --from-token. The
+destination defaults to the current configuration (--config, --profile,
+LINKDING_URL/LINKDING_TOKEN, --url/--token) and can be set the same way with
+--to-profile, --to-config, --to-url and --to-token. Profiles are looked up
+in the --from-config or --to-config file if given, else in the current
+config file. Profiles and config files named for either side are read
+as-is, without environment overrides.
+
+Bookmarks are matched by URL. Existing ones on the destination are updated
+unless --skip-duplicates is set. With --unused-tags, tags that no bookmark
+uses are created too; with --bundles, bundles are copied unless one with the same
+name already exists.
+
+Examples:
+  linkdingctl migrate --from-profile personal --to-profile work --dry-run
+  linkdingctl migrate --from-config ~/old.yaml --dry-run
+  linkdingctl migrate --from-config ~/old.yaml --to-config ~/new.yaml --unused-tags --bundles
+  linkdingctl migrate --from-url https://old.example.com --from-token $OLD_TOKEN --skip-duplicates`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+var (
+	migrateFromProfile    string
+	migrateFromConfig     string
+	migrateFromURL        string
+	migrateFromToken      string
+	migrateToProfile      string
+	migrateToConfig       string
+	migrateToURL          string
+	migrateToToken        string
+	migrateSkipDuplicates bool
+	migrateUnusedTags     bool
+	migrateBundles        bool
+	migrateConcurrency    int
+)
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateFromProfile, "from-profile", "", "Config profile of the source instance")
+	migrateCmd.Flags().StringVar(&migrateFromConfig, "from-config", "", "Config file of the source instance")
+	migrateCmd.Flags().StringVar(&migrateFromURL, "from-url", "", "Source instance URL (overrides --from-config)")
+	migrateCmd.Flags().StringVar(&migrateFromToken, "from-token", "", "Source API token (overrides --from-config)")
+	migrateCmd.Flags().StringVar(&migrateToProfile, "to-profile", "", "Config profile of the destination instance (default: current configuration)")
+	migrateCmd.Flags().StringVar(&migrateToConfig, "to-config", "", "Config file of the destination instance (default: current configuration)")
+	migrateCmd.Flags().StringVar(&migrateToURL, "to-url", "", "Destination instance URL (overrides --to-config)")
+	migrateCmd.Flags().StringVar(&migrateToToken, "to-token", "", "Destination API token (overrides --to-config)")
+	migrateCmd.Flags().BoolVar(&migrateSkipDuplicates, "skip-duplicates", false, "Skip URLs that already exist on the destination (default: update them)")
+	migrateCmd.Flags().BoolVar(&migrateUnusedTags, "unused-tags", false, "Also create tags that no bookmark uses")
+	migrateCmd.Flags().BoolVar(&migrateBundles, "bundles", false, "Also copy bundles")
+	migrateCmd.Flags().IntVar(&migrateConcurrency, "concurrency", migrate.DefaultConcurrency, fmt.Sprintf("Number of bookmarks written in parallel (max %d)", migrate.MaxConcurrency))
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	if migrateConcurrency < 1 || migrateConcurrency > migrate.MaxConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", migrate.MaxConcurrency)
+	}
+
+	srcCfg, err := migrationConfig("from", migrateFromProfile, migrateFromConfig, migrateFromURL, migrateFromToken, false)
+	if err != nil {
+		return err
+	}
+	dstCfg, err := migrationConfig("to", migrateToProfile, migrateToConfig, migrateToURL, migrateToToken, true)
+	if err != nil {
+		return err
+	}
+	if strings.TrimRight(srcCfg.URL, "/") == strings.TrimRight(dstCfg.URL, "/") {
+		return fmt.Errorf("source and destination are the same instance (%s)", srcCfg.URL)
+	}
+
+	options := migrate.Options{
+		DryRun:         isDryRun(),
+		SkipDuplicates: migrateSkipDuplicates,
+		Tags:           migrateUnusedTags,
+		Bundles:        migrateBundles,
+		Concurrency:    migrateConcurrency,
+	}
+
+	if !structuredOutput() {
+		if options.DryRun {
+			fmt.Fprintln(os.Stderr, "Dry run - no changes will be made")
+		}
+		statusf(os.Stderr, "Migrating bookmarks from %s to %s...\n", srcCfg.URL, dstCfg.URL)
+	}
+
+	result, err := migrate.Run(newClient(srcCfg), newClient(dstCfg), options)
+	if result == nil {
+		return err
+	}
+
+	if structuredOutput() {
+		if encodeErr := outputMigrateResultJSON(result); encodeErr != nil {
+			return encodeErr
+		}
+	} else {
+		displayMigrateResult(result, options)
+	}
+
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("some items failed to migrate")
+	}
+	return nil
+}
+
+// migrationConfig resolves one side of a migration, "from" or "to", from a
+// profile or config file and URL/token overrides. A profile is read from
+// path, or from the current config file when path is empty. With useCurrent
+// set, the current configuration is the fallback when neither is named.
+func migrationConfig(direction, profile, path, url, token string, useCurrent bool) (*config.Config, error) {
+	side := "source"
+	if direction == "to" {
+		side = "destination"
+	}
+
+	cfg := &config.Config{}
+	switch {
+	case profile != "":
+		if path == "" {
+			path = cfgFile
+		}
+		loaded, err := config.LoadProfile(path, profile)
+		if err != nil {
+			return nil, fmt.Errorf("%s configuration error: %w", side, err)
+		}
+		cfg = loaded
+	case path != "":
+		loaded, err := config.LoadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s configuration error: %w", side, err)
+		}
+		cfg = loaded
+	case useCurrent && (url == "" || token == ""):
+		loaded, err := loadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("%s configuration error: %w", side, err)
+		}
+		cfg = loaded
+	}
+
+	if url != "" {
+		cfg.URL = url
+	}
+	if token != "" {
+		cfg.Token = token
+	}
+	if cfg.URL == "" || cfg.Token == "" {
+		return nil, fmt.Errorf("%s instance required: use --%s-profile, --%s-config, or --%s-url and --%s-token", side, direction, direction, direction, direction)
+	}
+	return cfg, nil
+}
+
+func displayMigrateResult(result *migrate.Result, options migrate.Options) {
+	statusf(os.Stderr, "  %d bookmarks on the source\n", result.Total)
+	if result.Added > 0 {
+		statusf(os.Stderr, "  ✓ %d new bookmarks added\n", result.Added)
+	}
+	if result.Updated > 0 {
+		statusf(os.Stderr, "  ✓ %d existing bookmarks updated\n", result.Updated)
+	}
+	if result.Skipped > 0 {
+		statusf(os.Stderr, "  ⊘ %d skipped (--skip-duplicates)\n", result.Skipped)
+	}
+	if options.Tags {
+		statusf(os.Stderr, "  ✓ %d unused tags created\n", result.TagsCreated)
+	}
+	if options.Bundles {
+		statusf(os.Stderr, "  ✓ %d bundles copied", result.BundlesCreated)
+		if result.BundlesSkipped > 0 {
+			statusf(os.Stderr, ", %d already existed", result.BundlesSkipped)
+		}
+		statusf(os.Stderr, "\n")
+	}
+	if result.Failed > 0 {
+		failf(os.Stderr, "  ✗ %d failed (see errors below)\n", result.Failed)
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Fprintln(os.Stderr, "\nErrors:")
+		for _, e := range result.Errors {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", e.Item, e.Message)
+		}
+	}
+}
+
+func outputMigrateResultJSON(result *migrate.Result) error {
+	output := map[string]interface{}{
+		"total":           result.Total,
+		"added":           result.Added,
+		"updated":         result.Updated,
+		"skipped":         result.Skipped,
+		"failed":          result.Failed,
+		"tags_created":    result.TagsCreated,
+		"bundles_created": result.BundlesCreated,
+		"bundles_skipped": result.BundlesSkipped,
+	}
+
+	if len(result.Errors) > 0 {
+		errors := make([]map[string]interface{}, len(result.Errors))
+		for i, e := range result.Errors {
+			errors[i] = map[string]interface{}{
+				"item":    e.Item,
+				"message": e.Message,
+			}
+		}
+		output["errors"] = errors
+	}
+
+	return writeJSON(output)
+}
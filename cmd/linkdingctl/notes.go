@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// bookmarksNotesCmd represents the bookmarks notes command
+var bookmarksNotesCmd = &cobra.Command{
+	Use:   "notes <id>",
+	Short: "Print or edit a bookmark's notes",
+	Long: `Print the notes of a bookmark, or edit them with --edit.
+
+--edit opens the notes in $VISUAL or $EDITOR (vi, or notepad on Windows, if
+neither is set) and saves them when the editor exits. Nothing is sent if
+the notes were not changed; trailing newlines are ignored. Saving an empty
+file clears the notes. --dry-run shows the request instead.
+
+Examples:
+  linkdingctl bookmarks notes 123
+  linkdingctl bookmarks notes 123 --edit
+  EDITOR="code --wait" linkdingctl bookmarks notes 123 --edit`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runBookmarksNotes,
+}
+
+var notesEdit bool
+
+func init() {
+	bookmarksCmd.AddCommand(bookmarksNotesCmd)
+
+	bookmarksNotesCmd.Flags().BoolVarP(&notesEdit, "edit", "e", false, "Edit the notes in $EDITOR")
+}
+
+// bookmarkNotes is the --json output of bookmarks notes. Updated is set
+// only with --edit.
+type bookmarkNotes struct {
+	ID      int    `json:"id"`
+	Notes   string `json:"notes"`
+	Updated *bool  `json:"updated,omitempty"`
+}
+
+func runBookmarksNotes(cmd *cobra.Command, args []string) error {
+	// Parse bookmark ID
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid bookmark ID: %s (must be a number)", args[0])
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmark, err := client.GetBookmark(id)
+	if err != nil {
+		return err
+	}
+
+	if !notesEdit {
+		if structuredOutput() {
+			return writeJSON(bookmarkNotes{ID: id, Notes: bookmark.Notes})
+		}
+		if bookmark.Notes == "" {
+			statusf(os.Stderr, "Bookmark %d has no notes\n", id)
+			return nil
+		}
+		fmt.Println(strings.TrimRight(bookmark.Notes, "\n"))
+		return nil
+	}
+
+	notes, err := editText(bookmark.Notes)
+	if err != nil {
+		return err
+	}
+	updated := notes != strings.TrimRight(bookmark.Notes, "\r\n")
+	if !updated {
+		if structuredOutput() {
+			return writeJSON(bookmarkNotes{ID: id, Notes: bookmark.Notes, Updated: &updated})
+		}
+		statusf(os.Stdout, "⊘ Notes of bookmark %d unchanged, nothing to save\n", id)
+		return nil
+	}
+
+	update := &models.BookmarkUpdate{Notes: &notes}
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "PATCH", Path: fmt.Sprintf("/api/bookmarks/%d/", id), Body: update})
+	}
+	if _, err := client.UpdateBookmark(id, update); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+
+	if structuredOutput() {
+		return writeJSON(bookmarkNotes{ID: id, Notes: notes, Updated: &updated})
+	}
+	statusf(os.Stdout, "✓ Saved notes of bookmark %d\n", id)
+	return nil
+}
+
+// editText opens text in the user's editor and returns what was saved,
+// without trailing newlines.
+func editText(text string) (string, error) {
+	file, err := os.CreateTemp("", "linkdingctl-notes-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := file.WriteString(text); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return "", fmt.Errorf("editor failed, notes not saved: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited notes: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// runEditor opens path in $VISUAL or $EDITOR and waits for it to exit.
+// The variable may include arguments, like "code --wait". Tests replace it.
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			args = []string{"notepad"}
+		}
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// ensureOutputDir checks that dir exists before a command writes files into
+// it. With mkdir set, a missing directory (and any missing parents) is
+// created with owner-only permissions instead, since exports hold the
+// user's full bookmark data.
+func ensureOutputDir(dir string, mkdir bool) error {
+	if mkdir {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		return nil
+	}
+
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("output directory %s does not exist (use --mkdir to create it)", dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to access output directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("output path %s is not a directory", dir)
+	}
+	return nil
+}
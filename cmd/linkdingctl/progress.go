@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// progressLine redraws a "label done/total" count in place on stderr while
+// a long import, restore or backup runs. A nil *progressLine prints
+// nothing, so callers need not check whether progress is shown.
+type progressLine struct {
+	label string
+	mu    sync.Mutex
+	drawn bool
+}
+
+// newProgress returns a progress line for label, or nil when progress
+// should not be shown: under --quiet or structured output, or when stderr
+// is not a terminal, where redrawn lines would only clutter a log.
+func newProgress(label string) *progressLine {
+	if isQuiet() || structuredOutput() || !isTerminal(os.Stderr) {
+		return nil
+	}
+	return &progressLine{label: label}
+}
+
+// update redraws the count. A total of 0 means the total is not known.
+func (p *progressLine) update(done, total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\r\x1b[K%s %d/%d", p.label, done, total)
+	} else {
+		fmt.Fprintf(os.Stderr, "\r\x1b[K%s %d", p.label, done)
+	}
+	p.drawn = true
+}
+
+// clear erases the count so the summary that follows starts on a clean
+// line.
+func (p *progressLine) clear() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.drawn {
+		fmt.Fprint(os.Stderr, "\r\x1b[K")
+		p.drawn = false
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// isQuiet reports whether --quiet asks for essential output only. It has no
+// effect on --json, --output-format or --select output.
+func isQuiet() bool {
+	return quiet && !structuredOutput()
+}
+
+// statusf prints a decorative status or progress line to w unless --quiet
+// is set. Errors and warnings are printed directly so they are never hidden.
+func statusf(w io.Writer, format string, a ...interface{}) {
+	if isQuiet() {
+		return
+	}
+	fmt.Fprint(w, colorMark(w, fmt.Sprintf(format, a...)))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// bookmarksRandomCmd represents the bookmarks random command
+var bookmarksRandomCmd = &cobra.Command{
+	Use:   "random",
+	Short: "Show random bookmarks to rediscover",
+	Long: `Fetch every bookmark matching --tags and --unread and show --count of
+them chosen at random. If fewer match, all of them are shown, in random
+order.
+
+The selection is seeded from the clock, and the seed is printed so that
+--seed can repeat it while the bookmarks stay the same. --open opens the
+chosen bookmarks in the default browser, at most 5 at a time.
+
+Examples:
+  linkdingctl bookmarks random
+  linkdingctl bookmarks random --count 5 --unread
+  linkdingctl bookmarks random --tags reading --open
+  linkdingctl bookmarks random --count 3 --seed 42`,
+	Args: cobra.NoArgs,
+	RunE: runBookmarksRandom,
+}
+
+var (
+	randomCount  int
+	randomTags   []string
+	randomUnread bool
+	randomSeed   int64
+	randomOpen   bool
+)
+
+func init() {
+	bookmarksCmd.AddCommand(bookmarksRandomCmd)
+
+	bookmarksRandomCmd.Flags().IntVarP(&randomCount, "count", "n", 1, "Number of bookmarks to show")
+	bookmarksRandomCmd.Flags().StringSliceVarP(&randomTags, "tags", "T", []string{}, "Choose only from bookmarks with these tags (AND logic)")
+	_ = bookmarksRandomCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	bookmarksRandomCmd.Flags().BoolVarP(&randomUnread, "unread", "u", false, "Choose only from unread bookmarks")
+	bookmarksRandomCmd.Flags().Int64Var(&randomSeed, "seed", 0, "Random seed, to repeat a selection")
+	bookmarksRandomCmd.Flags().BoolVar(&randomOpen, "open", false, "Open the chosen bookmarks in the default browser")
+}
+
+func runBookmarksRandom(cmd *cobra.Command, args []string) error {
+	if randomCount < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+	if randomOpen && randomCount > openConfirmThreshold {
+		return fmt.Errorf("--open opens at most %d bookmarks at once", openConfirmThreshold)
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	var unreadPtr *bool
+	if randomUnread {
+		unreadPtr = &randomUnread
+	}
+	all, err := client.BookmarkPages("", randomTags, unreadPtr, nil).All()
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		return fmt.Errorf("no bookmarks found")
+	}
+
+	seed := randomSeed
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+		if !structuredOutput() {
+			statusf(os.Stderr, "Seed: %d (use --seed to repeat)\n", seed)
+		}
+	}
+	chosen := pickRandom(all, randomCount, rand.New(rand.NewPCG(uint64(seed), 0)))
+
+	if randomOpen {
+		for _, b := range chosen {
+			if err := launchBrowser(b.URL); err != nil {
+				return fmt.Errorf("failed to open %s: %w", b.URL, err)
+			}
+		}
+	}
+
+	if structuredOutput() {
+		return writeJSON(chosen)
+	}
+	for _, b := range chosen {
+		title := b.Title
+		if title == "" {
+			title = b.URL
+		}
+		fmt.Printf("%d  %s\n    %s\n", b.ID, title, b.URL)
+	}
+	if randomOpen {
+		statusf(os.Stderr, "✓ Opened %d bookmark(s)\n", len(chosen))
+	}
+	return nil
+}
+
+// pickRandom shuffles bookmarks in place and returns the first count of
+// them, or all of them when there are fewer.
+func pickRandom(bookmarks []models.Bookmark, count int, rng *rand.Rand) []models.Bookmark {
+	rng.Shuffle(len(bookmarks), func(i, j int) { bookmarks[i], bookmarks[j] = bookmarks[j], bookmarks[i] })
+	return bookmarks[:min(count, len(bookmarks))]
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// bookmarksReparseTitleCmd represents the bookmarks reparse-title command
+var bookmarksReparseTitleCmd = &cobra.Command{
+	Use:   "reparse-title [id...]",
+	Short: "Refetch the page titles of bookmarks",
+	Long: `Fetch each bookmark's page again and set the bookmark's title to the
+page's <title>, for titles that went stale or were never filled in. Give
+bookmark IDs, or --tags to refresh every bookmark with those tags.
+
+Only titles that changed are saved. Pages are fetched --concurrency at a
+time, each within --timeout, straight from each site without the LinkDing
+token. A page that cannot be fetched or has no title is reported and
+skipped; the command exits non-zero only if saving a title fails.
+
+--dry-run shows each old and new title without saving anything.
+
+Examples:
+  linkdingctl bookmarks reparse-title 12 34
+  linkdingctl bookmarks reparse-title --tags untitled --dry-run
+  linkdingctl bookmarks reparse-title --tags news --concurrency 4 --timeout 5s`,
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runBookmarksReparseTitle,
+}
+
+var (
+	reparseTags        []string
+	reparseConcurrency int
+	reparseTimeout     time.Duration
+)
+
+func init() {
+	bookmarksCmd.AddCommand(bookmarksReparseTitleCmd)
+
+	bookmarksReparseTitleCmd.Flags().StringSliceVarP(&reparseTags, "tags", "T", []string{}, "Refresh every bookmark with these tags (AND logic)")
+	_ = bookmarksReparseTitleCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	bookmarksReparseTitleCmd.Flags().IntVar(&reparseConcurrency, "concurrency", defaultCheckConcurrency, fmt.Sprintf("Number of pages fetched in parallel (max %d)", maxCheckConcurrency))
+	bookmarksReparseTitleCmd.Flags().DurationVar(&reparseTimeout, "timeout", 10*time.Second, "Time limit for fetching each page")
+}
+
+// titleRefresh is the outcome of refetching one bookmark's title. NewTitle
+// is empty when the page could not be fetched.
+type titleRefresh struct {
+	ID       int    `json:"id"`
+	URL      string `json:"url"`
+	OldTitle string `json:"old_title"`
+	NewTitle string `json:"new_title,omitempty"`
+	Updated  bool   `json:"updated"`
+	Error    string `json:"error,omitempty"`
+}
+
+// changed reports whether the page has a title other than the bookmark's.
+func (r titleRefresh) changed() bool {
+	return r.Error == "" && r.NewTitle != r.OldTitle
+}
+
+func runBookmarksReparseTitle(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && len(reparseTags) > 0 {
+		return fmt.Errorf("give bookmark IDs or --tags, not both")
+	}
+	if len(args) == 0 && len(reparseTags) == 0 {
+		return fmt.Errorf("give bookmark IDs, or --tags to refresh every match")
+	}
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid bookmark ID: %s (must be a number)", arg)
+		}
+		ids[i] = id
+	}
+	if reparseConcurrency < 1 || reparseConcurrency > maxCheckConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d", maxCheckConcurrency)
+	}
+	if reparseTimeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	var bookmarks []models.Bookmark
+	if len(ids) > 0 {
+		for _, id := range ids {
+			bookmark, err := client.GetBookmark(id)
+			if err != nil {
+				return err
+			}
+			bookmarks = append(bookmarks, *bookmark)
+		}
+	} else {
+		bookmarks, err = client.FetchAllBookmarks(reparseTags, true)
+		if err != nil {
+			return err
+		}
+		// The search also finds the tag names in titles, URLs and descriptions
+		bookmarks = models.TaggedWithAll(bookmarks, reparseTags)
+	}
+
+	if !structuredOutput() {
+		statusf(os.Stderr, "Fetching %d pages...\n", len(bookmarks))
+	}
+	refreshes := refetchTitles(bookmarks, &http.Client{Timeout: reparseTimeout}, reparseConcurrency)
+
+	if isDryRun() {
+		if structuredOutput() {
+			return writeJSON(refreshes)
+		}
+		fmt.Println("Dry run - no changes will be made")
+		for _, r := range refreshes {
+			switch {
+			case r.Error != "":
+				failf(os.Stderr, "✗ %d (%s): %s\n", r.ID, r.URL, r.Error)
+			case r.changed():
+				fmt.Printf("  Would retitle bookmark %d: %q → %q\n", r.ID, r.OldTitle, r.NewTitle)
+			}
+		}
+		return nil
+	}
+
+	saveErr := saveTitles(client, refreshes, reparseConcurrency)
+	if structuredOutput() {
+		if err := writeJSON(refreshes); err != nil {
+			return err
+		}
+		return saveErr
+	}
+
+	updated, unchanged, skipped := 0, 0, 0
+	for _, r := range refreshes {
+		switch {
+		case r.Updated:
+			updated++
+			statusf(os.Stdout, "✓ %d: %q → %q\n", r.ID, r.OldTitle, r.NewTitle)
+		case r.Error != "":
+			skipped++
+			failf(os.Stderr, "✗ %d (%s): %s\n", r.ID, r.URL, r.Error)
+		default:
+			unchanged++
+		}
+	}
+	statusf(os.Stdout, "Retitled %d of %d bookmarks (%d unchanged, %d skipped)\n", updated, len(refreshes), unchanged, skipped)
+	return saveErr
+}
+
+// refetchTitles fetches each bookmark's page title using a pool of workers
+// and returns the outcomes in bookmark order.
+func refetchTitles(bookmarks []models.Bookmark, httpClient *http.Client, concurrency int) []titleRefresh {
+	refreshes := make([]titleRefresh, len(bookmarks))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b := bookmarks[i]
+				refreshes[i] = titleRefresh{ID: b.ID, URL: b.URL, OldTitle: b.Title}
+				title, err := fetchPageTitle(httpClient, b.URL)
+				if err != nil {
+					refreshes[i].Error = err.Error()
+					continue
+				}
+				refreshes[i].NewTitle = title
+			}
+		}()
+	}
+	for i := range bookmarks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return refreshes
+}
+
+// saveTitles PATCHes the changed titles and marks them updated. A failed
+// save is recorded in its refresh and reported in the returned error.
+func saveTitles(client *api.Client, refreshes []titleRefresh, concurrency int) error {
+	var patches []api.BookmarkPatch
+	var changed []int
+	for i, r := range refreshes {
+		if r.changed() {
+			title := r.NewTitle
+			patches = append(patches, api.BookmarkPatch{ID: r.ID, Update: &models.BookmarkUpdate{Title: &title}})
+			changed = append(changed, i)
+		}
+	}
+
+	failed := 0
+	for j, result := range client.UpdateBookmarks(patches, concurrency) {
+		r := &refreshes[changed[j]]
+		if result.Err != nil {
+			failed++
+			r.Error = fmt.Sprintf("failed to save title: %v", result.Err)
+			continue
+		}
+		r.Updated = true
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d titles could not be saved", failed, len(patches))
+	}
+	return nil
+}
+
+// titlePattern matches an HTML document's <title> element.
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// fetchPageTitle requests a page and returns its <title>, unescaped and
+// with whitespace collapsed. Only the start of the page is read. Tests
+// replace it to avoid the network.
+var fetchPageTitle = func(httpClient *http.Client, url string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "linkdingctl/"+version)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return "", err
+	}
+	match := titlePattern.FindSubmatch(page)
+	if match == nil {
+		return "", fmt.Errorf("no <title> in page")
+	}
+	title := strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+	if title == "" {
+		return "", fmt.Errorf("page title is empty")
+	}
+	return title, nil
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/export"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
 	"github.com/spf13/cobra"
 )
 
@@ -22,21 +24,65 @@ Without --wipe: Equivalent to 'linkdingctl import <file>'
   - Existing bookmarks are updated
   - New bookmarks are added
 
+A bookmark already exists when one with the same URL is on the server; it is
+updated to match the backup's title, description, tags and unread, shared
+and archived flags. With --update-existing=false it is left alone, so only
+missing bookmarks are added. --dry-run reads the bookmarks on the server
+(sending no changes) to report how many would be added, updated or left
+alone.
+
 With --wipe: Deletes ALL existing bookmarks before importing (DANGEROUS)
   - Requires interactive confirmation
   - Cannot be undone
+  - Not allowed for incremental backups (backup --since)
+
+An incremental backup only holds bookmarks modified since its start time;
+bookmarks it does not contain are left alone.
+
+Bundles in a backup made with --include-bundles are recreated after the
+bookmarks; bundles whose name already exists are left alone.
+
+With --summary-only, only the added/updated/skipped/failed counts are
+printed, as a single JSON object.
+
+A failed entry is reported and the restore continues; with --fail-on-error
+the command then exits non-zero if any entry failed.
+
+With --resume, progress is recorded in a checkpoint file in the user cache
+directory (or at the path given with --checkpoint) as entries are
+restored. If a restore is interrupted or entries fail, run it again with
+--resume to skip the entries already done.
 
 Examples:
   linkdingctl restore backup.json
   linkdingctl restore backup.json --dry-run
-  linkdingctl restore backup.json --wipe`,
+  linkdingctl restore backup.json --update-existing=false
+  linkdingctl restore backup.json --wipe
+  linkdingctl restore backup.json --limit 5 --dry-run
+  linkdingctl restore backup.json --batch-size 200 --resume-from 1000
+  linkdingctl restore backup.json --resume
+  linkdingctl restore backup.json --summary-only
+  linkdingctl restore backup.json --summary-only --fail-on-error`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRestore,
 }
 
 var (
-	restoreDryRun bool
-	restoreWipe   bool
+	restoreDryRun       bool
+	restoreWipe         bool
+	restoreLimit        int
+	restoreOffset       int
+	restoreStopOnError  bool
+	restoreContinue     bool
+	restoreAllowSchemes []string
+	restoreBatchSize    int
+	restoreBatchPause   time.Duration
+	restoreResumeFrom   int
+	restoreResume       bool
+	restoreCheckpoint   string
+	restoreSummaryOnly  bool
+	restoreFailOnError  bool
+	restoreUpdate       bool
 )
 
 func init() {
@@ -44,11 +90,42 @@ func init() {
 
 	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Show what would be restored without making changes")
 	restoreCmd.Flags().BoolVar(&restoreWipe, "wipe", false, "Delete all existing bookmarks before restore (DANGEROUS)")
+	restoreCmd.Flags().BoolVar(&restoreUpdate, "update-existing", true, "Update bookmarks whose URL already exists to match the backup; =false leaves them alone")
+	restoreCmd.MarkFlagsMutuallyExclusive("wipe", "update-existing")
+	restoreCmd.Flags().IntVar(&restoreLimit, "limit", 0, "Restore at most this many entries from the file (default: all)")
+	restoreCmd.Flags().IntVar(&restoreOffset, "offset", 0, "Skip this many entries at the start of the file")
+	restoreCmd.Flags().BoolVar(&restoreStopOnError, "stop-on-error", false, "Abort at the first failed entry, keeping what was already restored")
+	restoreCmd.Flags().BoolVar(&restoreContinue, "continue-on-error", false, "Keep going past failed entries and report them at the end (default)")
+	restoreCmd.Flags().StringSliceVar(&restoreAllowSchemes, "allow-scheme", nil, "Also accept URLs with these schemes (default: http, https)")
+	restoreCmd.MarkFlagsMutuallyExclusive("stop-on-error", "continue-on-error")
+	restoreCmd.Flags().BoolVar(&restoreFailOnError, "fail-on-error", false, "Exit non-zero if any entry failed, after restoring the rest")
+	restoreCmd.Flags().IntVar(&restoreBatchSize, "batch-size", 0, "Restore entries in batches of this size, pausing between batches")
+	restoreCmd.Flags().DurationVar(&restoreBatchPause, "batch-pause", defaultBatchPause, "Pause between batches with --batch-size")
+	restoreCmd.Flags().IntVar(&restoreResumeFrom, "resume-from", 0, "Continue an interrupted restore from this entry index")
+	restoreCmd.MarkFlagsMutuallyExclusive("offset", "resume-from")
+	restoreCmd.Flags().BoolVar(&restoreResume, "resume", false, "Record progress in a checkpoint and skip the entries an earlier --resume run finished")
+	restoreCmd.Flags().StringVar(&restoreCheckpoint, "checkpoint", "", "Record progress in this checkpoint file (default with --resume: in the user cache directory)")
+	restoreCmd.Flags().BoolVar(&restoreSummaryOnly, "summary-only", false, "Print only the added/updated/skipped/failed counts as JSON")
 }
 
 func runRestore(cmd *cobra.Command, args []string) error {
 	filename := args[0]
 
+	if err := validateImportWindow(restoreLimit, restoreOffset); err != nil {
+		return err
+	}
+	if restoreResumeFrom < 0 {
+		return fmt.Errorf("--resume-from must be zero or greater")
+	}
+	// A partial restore after a wipe would lose the rest of the bookmarks
+	if restoreWipe && (restoreLimit > 0 || restoreOffset > 0 || restoreResumeFrom > 0 || restoreResume) {
+		return fmt.Errorf("--wipe cannot be combined with --limit, --offset, --resume-from or --resume")
+	}
+	pacer, err := newPacer(restoreBatchSize, restoreBatchPause, true)
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -56,52 +133,95 @@ func runRestore(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
+
+	dry := isDryRun(restoreDryRun)
+
+	since, err := export.IncrementalSince(filename)
+	if err != nil {
+		return err
+	}
+	if since != nil && restoreWipe {
+		return fmt.Errorf("--wipe cannot be used with an incremental backup: it only holds bookmarks modified since %s",
+			since.Format(time.RFC3339))
+	}
+
+	// Open the checkpoint before wiping, so a bad --checkpoint path stops the restore early
+	checkpoint, err := openCheckpoint(filename, restoreCheckpoint, restoreResume, dry)
+	if err != nil {
+		return err
+	}
 
 	// If --wipe is specified, handle deletion with confirmation
 	if restoreWipe {
-		if err := handleWipe(client); err != nil {
+		if err := handleWipe(client, dry); err != nil {
+			finishCheckpoint(checkpoint, nil, err)
 			return err
 		}
 	}
 
 	// Import the backup file
 	options := export.ImportOptions{
-		Format:         "auto",
-		DryRun:         restoreDryRun,
-		SkipDuplicates: false,
-		AddTags:        []string{},
+		Format:          "auto",
+		DryRun:          dry,
+		PreviewExisting: !restoreWipe,
+		SkipDuplicates:  !restoreUpdate,
+		AddTags:         []string{},
+		Limit:           restoreLimit,
+		Offset:          restoreOffset + restoreResumeFrom,
+		StopOnError:     restoreStopOnError,
+		AllowedSchemes:  urlutil.AllowedSchemes(restoreAllowSchemes),
+		Pacer:           pacer,
+		Checkpoint:      checkpoint,
+		Bundles:         true,
 	}
 
-	if !jsonOutput {
-		if restoreDryRun {
+	machineOutput := jsonOutput || restoreSummaryOnly
+	if !machineOutput {
+		if dry {
 			fmt.Fprintln(os.Stderr, "Dry run - no changes will be made")
 		}
-		if restoreWipe && !restoreDryRun {
-			fmt.Fprintln(os.Stderr, "Restoring bookmarks...")
-		} else if !restoreDryRun {
-			fmt.Fprintln(os.Stderr, "Importing bookmarks...")
+		if since != nil {
+			statusf(os.Stderr, "Incremental backup of changes since %s; other bookmarks are left alone\n", since.Format(time.RFC3339))
+		}
+		if restoreWipe && !dry {
+			statusf(os.Stderr, "Restoring bookmarks...\n")
+		} else if !dry {
+			statusf(os.Stderr, "Importing bookmarks...\n")
 		}
 	}
 
+	var progress *progressLine
+	if !machineOutput {
+		progress = newProgress("Restored")
+		options.Progress = progress.update
+	}
 	result, err := export.ImportBookmarks(client, filename, options)
-	if err != nil {
+	progress.clear()
+	defer finishCheckpoint(checkpoint, result, err)
+	if result == nil {
 		return err
 	}
 
-	// Display results
-	if !jsonOutput {
-		displayImportResult(result)
-	} else {
-		// JSON output - reuse import command's JSON output logic
-		return outputImportResultJSON(result)
+	// Display results, including what was done before --stop-on-error ended the restore
+	switch {
+	case restoreSummaryOnly:
+		if encodeErr := outputImportSummaryJSON(result); encodeErr != nil {
+			return encodeErr
+		}
+	case jsonOutput:
+		if encodeErr := outputImportResultJSON(result); encodeErr != nil {
+			return encodeErr
+		}
+	default:
+		displayImportResult(result, "already existed and were left alone (--update-existing=false)")
 	}
 
-	return nil
+	return failedEntriesError(result, err, restoreFailOnError)
 }
 
 // handleWipe deletes all existing bookmarks with user confirmation
-func handleWipe(client *api.Client) error {
+func handleWipe(client *api.Client, dry bool) error {
 	// Get count of existing bookmarks
 	bookmarks, err := client.GetBookmarks("", []string{}, nil, nil, 1, 0)
 	if err != nil {
@@ -117,7 +237,7 @@ func handleWipe(client *api.Client) error {
 	}
 
 	// For dry-run, just show what would happen
-	if restoreDryRun {
+	if dry {
 		if !jsonOutput {
 			fmt.Fprintf(os.Stderr, "Dry run: Would delete %d existing bookmarks\n", count)
 		}
@@ -148,25 +268,13 @@ func handleWipe(client *api.Client) error {
 	fmt.Fprintln(os.Stderr, "Deleting existing bookmarks...")
 
 	// Fetch all bookmarks
-	allBookmarks := []int{}
-	offset := 0
-	limit := 100
-
-	for {
-		bookmarkList, err := client.GetBookmarks("", []string{}, nil, nil, limit, offset)
-		if err != nil {
-			return fmt.Errorf("failed to fetch bookmarks: %w", err)
-		}
-
-		for _, b := range bookmarkList.Results {
-			allBookmarks = append(allBookmarks, b.ID)
-		}
-
-		if bookmarkList.Next == nil || len(bookmarkList.Results) == 0 {
-			break
-		}
-
-		offset += limit
+	existing, err := client.BookmarkPages("", []string{}, nil, nil).All()
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	allBookmarks := make([]int, 0, len(existing))
+	for _, b := range existing {
+		allBookmarks = append(allBookmarks, b.ID)
 	}
 
 	// Delete each bookmark
@@ -181,9 +289,9 @@ func handleWipe(client *api.Client) error {
 	}
 
 	if failed > 0 {
-		fmt.Fprintf(os.Stderr, "Deleted %d bookmarks, %d failed\n", deleted, failed)
+		statusf(os.Stderr, "Deleted %d bookmarks, %d failed\n", deleted, failed)
 	} else {
-		fmt.Fprintf(os.Stderr, "Deleted %d bookmarks\n", deleted)
+		statusf(os.Stderr, "Deleted %d bookmarks\n", deleted)
 	}
 
 	return nil
@@ -197,6 +305,13 @@ func outputImportResultJSON(result *export.ImportResult) error {
 		"skipped": result.Skipped,
 		"failed":  result.Failed,
 	}
+	if result.Resumed > 0 {
+		output["resumed"] = result.Resumed
+	}
+	if result.BundlesAdded > 0 || result.BundlesSkipped > 0 || len(result.BundleErrors) > 0 {
+		output["bundles_added"] = result.BundlesAdded
+		output["bundles_skipped"] = result.BundlesSkipped
+	}
 
 	if len(result.Errors) > 0 {
 		errors := make([]map[string]interface{}, len(result.Errors))
@@ -208,8 +323,30 @@ func outputImportResultJSON(result *export.ImportResult) error {
 		}
 		output["errors"] = errors
 	}
+	if len(result.BundleErrors) > 0 {
+		bundleErrors := make([]map[string]interface{}, len(result.BundleErrors))
+		for i, e := range result.BundleErrors {
+			bundleErrors[i] = map[string]interface{}{
+				"bundle":  e.Line,
+				"message": e.Message,
+			}
+		}
+		output["bundle_errors"] = bundleErrors
+	}
 
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
+
+// outputImportSummaryJSON outputs only the import counts on one line, for
+// --summary-only
+func outputImportSummaryJSON(result *export.ImportResult) error {
+	summary := struct {
+		Added   int `json:"added"`
+		Updated int `json:"updated"`
+		Skipped int `json:"skipped"`
+		Failed  int `json:"failed"`
+	}{result.Added, result.Updated, result.Skipped, result.Failed}
+	return json.NewEncoder(os.Stdout).Encode(summary)
+}
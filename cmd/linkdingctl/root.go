@@ -2,18 +2,74 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgFile    string
-	jsonOutput bool
-	debugMode  bool
-	flagURL    string
-	flagToken  string
+	cfgFile     string
+	profileName string
+	jsonOutput  bool
+	yamlOutput  bool
+	outputFmt   string
+	debugMode   bool
+	flagURL     string
+	flagToken   string
+	tokenFile   string
+	selectExpr  string
+	retryCount  int
+	retryOn     string
+	retryWait   time.Duration
+	timeout     time.Duration
+	dryRun      bool
+	headers     []string
+	forceHeader bool
+	profiling   bool
+	timezone    string
+	quiet       bool
+	noColor     bool
+
+	http2        bool
+	maxIdleConns int
+	idleTimeout  time.Duration
+	rateLimit    float64
+	parallel     int
+	cacheTTL     time.Duration
+	noCache      bool
+	insecure     bool
+	caCertFile   string
+	proxyURL     string
+)
+
+// Retry defaults: a transient failure is retried twice (three attempts in
+// all), and no request keeps retrying for longer than retryDeadline.
+const (
+	defaultRetries   = 2
+	defaultRetryWait = time.Second
+	retryDeadline    = 2 * time.Minute
+)
+
+// maxParallel caps --parallel so a large library cannot flood the server.
+const maxParallel = 16
+
+// Client options built from global flags before any command runs.
+var (
+	retryPolicy      api.RetryPolicy
+	transportOptions api.TransportOptions
+	cacheOptions     api.CacheOptions
+	extraHeaders     http.Header
+	requestTimer     *api.RequestTimer
+	commandStart     time.Time
 )
 
 // rootCmd represents the base command
@@ -24,13 +80,18 @@ var rootCmd = &cobra.Command{
 
 Configure your LinkDing connection with 'linkdingctl config init', then use commands like
 'linkdingctl add', 'linkdingctl list', and 'linkdingctl get' to manage your bookmarks.`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	PersistentPreRunE: setupGlobals,
 }
 
 // Execute runs the root command
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if requestTimer != nil {
+		printTimingSummary(os.Stderr, requestTimer, time.Since(commandStart))
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -40,15 +101,284 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path (default ~/.config/linkdingctl/config.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output as JSON instead of human-readable")
+	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output-format", "O", formatTable, "output format: table, json or yaml (--json is short for json)")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug logging")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "named profile from the config file (default: the one set by 'config use')")
 	rootCmd.PersistentFlags().StringVar(&flagURL, "url", "", "LinkDing instance URL (overrides config and env)")
-	rootCmd.PersistentFlags().StringVar(&flagToken, "token", "", "API token (overrides config and env)")
+	rootCmd.PersistentFlags().StringVar(&flagToken, "token", "", "API token (overrides config and env); '-' reads it from stdin, '@path' from a file")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "read the API token from this file, keeping it out of shell history and ps")
+	rootCmd.PersistentFlags().StringVar(&selectExpr, "select", "", "extract values from JSON output with a path like '.results[].url'")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "show the changes a command would make without sending them")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "never color output (color is also off when output is not a terminal or NO_COLOR is set)")
+	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "print only essential output, such as the ID of a new bookmark; errors still go to stderr")
+	rootCmd.PersistentFlags().StringArrayVar(&headers, "header", nil, "extra HTTP header for every request, as 'Name: Value' (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&forceHeader, "force-header", false, "allow --header to override the Authorization header")
+	rootCmd.PersistentFlags().BoolVar(&profiling, "profile-timing", false, "print time spent in API calls vs local processing to stderr")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", api.DefaultTimeout, "time limit for each API request, e.g. 10s or 2m (paginated fetches apply it per page)")
+	rootCmd.PersistentFlags().StringVar(&timezone, "timezone", "", "IANA time zone for dates in human output, e.g. Europe/Berlin (default: local, honours TZ)")
+	rootCmd.PersistentFlags().IntVar(&retryCount, "retries", defaultRetries, "retry failed idempotent requests up to this many times (0 disables retries)")
+	rootCmd.PersistentFlags().DurationVar(&retryWait, "retry-wait", defaultRetryWait, "wait before the first retry; doubles for each later retry, with jitter, up to 30s")
+	rootCmd.PersistentFlags().StringVar(&retryOn, "retry-on", "5xx,conn", "comma-separated status codes that trigger a retry; '5xx' for all server errors, 'conn' for connection errors")
+	rootCmd.PersistentFlags().BoolVar(&http2, "http2", true, "allow HTTP/2; --http2=false forces HTTP/1.1 for proxies that mishandle it")
+	rootCmd.PersistentFlags().IntVar(&maxIdleConns, "max-idle-conns", 0, "idle connections kept open for reuse (default: Go's, 2 per host)")
+	rootCmd.PersistentFlags().DurationVar(&idleTimeout, "idle-timeout", 0, "how long idle connections are kept open (default 90s)")
+	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "skip TLS certificate verification, e.g. for a self-signed certificate (prefer --cacert)")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "cacert", "", "PEM file of CA certificates to trust instead of the system ones")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "send API requests through this proxy, e.g. http://proxy:3128 (default: HTTP_PROXY/HTTPS_PROXY)")
+	rootCmd.PersistentFlags().IntVar(&parallel, "parallel", 1, fmt.Sprintf("fetch up to this many pages at once when listing every bookmark (max %d)", maxParallel))
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "cache GET responses on disk and revalidate them with the server (ETag) for this long, e.g. 10m (default: no caching)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "ignore --cache-ttl, including a default set in the config file")
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 0, "send at most this many API requests per second, e.g. 5 or 0.5 (default: no limit)")
+}
+
+// setupGlobals applies config file flag defaults and validates global flags
+// that every command depends on.
+func setupGlobals(cmd *cobra.Command, args []string) error {
+	if err := applyConfigDefaults(cmd); err != nil {
+		return err
+	}
+
+	if err := resolveFlagToken(); err != nil {
+		return err
+	}
+
+	var err error
+	if yamlOutput, err = resolveOutputFormat(outputFmt, jsonOutput); err != nil {
+		return err
+	}
+	// Check --select now rather than after every page has been fetched
+	if selectExpr != "" {
+		if _, err := parseSelector(selectExpr); err != nil {
+			return err
+		}
+	}
+
+	if retryCount < 0 {
+		return fmt.Errorf("--retries must be zero or greater")
+	}
+	if retryWait < 0 {
+		return fmt.Errorf("--retry-wait must be zero or greater")
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+	codes, connErrors, err := api.ParseRetryOn(retryOn)
+	if err != nil {
+		return err
+	}
+	retryPolicy = api.RetryPolicy{
+		MaxRetries:       retryCount,
+		StatusCodes:      codes,
+		ConnectionErrors: connErrors,
+		Wait:             retryWait,
+		Jitter:           true,
+		Deadline:         retryDeadline,
+	}
+
+	if maxIdleConns < 0 {
+		return fmt.Errorf("--max-idle-conns must be zero or greater")
+	}
+	if idleTimeout < 0 {
+		return fmt.Errorf("--idle-timeout must be zero or greater")
+	}
+	transportOptions = api.TransportOptions{
+		DisableHTTP2:       !http2,
+		MaxIdleConns:       maxIdleConns,
+		IdleConnTimeout:    idleTimeout,
+		InsecureSkipVerify: insecure,
+	}
+	if caCertFile != "" {
+		if transportOptions.RootCAs, err = api.LoadCACerts(caCertFile); err != nil {
+			return fmt.Errorf("--cacert: %w", err)
+		}
+	}
+	if proxyURL != "" {
+		if transportOptions.Proxy, err = parseProxyURL(proxyURL); err != nil {
+			return err
+		}
+	}
+
+	if rateLimit < 0 {
+		return fmt.Errorf("--rate-limit must be zero or greater")
+	}
+	if parallel < 1 || parallel > maxParallel {
+		return fmt.Errorf("--parallel must be between 1 and %d", maxParallel)
+	}
+
+	if cacheTTL < 0 {
+		return fmt.Errorf("--cache-ttl must be zero or greater")
+	}
+	cacheOptions = api.CacheOptions{}
+	if cacheTTL > 0 && !noCache {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return fmt.Errorf("--cache-ttl: cannot find a cache directory: %w", err)
+		}
+		cacheOptions = api.CacheOptions{Dir: filepath.Join(dir, "linkdingctl"), TTL: cacheTTL}
+	}
+
+	extraHeaders = nil
+	for _, spec := range headers {
+		name, value, err := api.ParseHeader(spec)
+		if err != nil {
+			return err
+		}
+		if name == "Authorization" && !forceHeader {
+			return fmt.Errorf("--header cannot override Authorization without --force-header")
+		}
+		if extraHeaders == nil {
+			extraHeaders = http.Header{}
+		}
+		extraHeaders.Add(name, value)
+	}
+
+	if displayZone, err = resolveTimezone(timezone); err != nil {
+		return err
+	}
+
+	requestTimer = nil
+	if profiling {
+		requestTimer = api.NewRequestTimer()
+		commandStart = time.Now()
+	}
+
+	return nil
+}
+
+// parseProxyURL checks the --proxy URL. http, https and socks5 proxies
+// are supported.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid --proxy %q: use a URL such as http://proxy.example.com:3128", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("invalid --proxy %q: scheme must be http, https or socks5", raw)
+	}
+}
+
+// printTimingSummary writes the --profile-timing report. Processing is the
+// wall time not spent waiting on API calls.
+func printTimingSummary(w io.Writer, timer *api.RequestTimer, elapsed time.Duration) {
+	apiTime := timer.Total()
+	processing := elapsed - apiTime
+	if processing < 0 {
+		processing = 0
+	}
+	percent := func(d time.Duration) float64 {
+		if elapsed <= 0 {
+			return 0
+		}
+		return float64(d) * 100 / float64(elapsed)
+	}
+
+	fmt.Fprintln(w, "Timing:")
+	fmt.Fprintf(w, "  Total:        %s\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "  API calls:    %s (%.0f%%)\n", apiTime.Round(time.Millisecond), percent(apiTime))
+	fmt.Fprintf(w, "  Processing:   %s (%.0f%%)\n", processing.Round(time.Millisecond), percent(processing))
+	fmt.Fprintf(w, "  Requests:     %d\n", timer.Requests())
+	fmt.Fprintf(w, "  Avg latency:  %s\n", timer.Average().Round(time.Millisecond))
+}
+
+// applyConfigDefaults sets flags that were not given on the command line
+// from the config file's "defaults" section. Precedence is: explicit flag >
+// command-specific config default > global config default > built-in default.
+// Top-level defaults only set global flags, so a key like limit cannot reach
+// an unrelated command's flag of the same name (import --limit, say); a
+// command's own flags need a section keyed by the command. Defaults do not
+// mark a flag as changed.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	defaults, err := config.LoadDefaults(cfgFile)
+	if err != nil {
+		// A broken config file is reported when the command loads it
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Skipping config defaults: %v\n", err)
+		}
+		return nil
+	}
+
+	values := map[string]string{}
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	for key, value := range defaults {
+		if _, nested := value.(map[string]interface{}); nested {
+			continue
+		}
+		if cmd.Root().PersistentFlags().Lookup(key) == nil {
+			if debugMode {
+				fmt.Fprintf(os.Stderr, "[DEBUG] Skipping config default %s: not a global flag; set it under a command\n", key)
+			}
+			continue
+		}
+		values[key] = defaultFlagValue(value)
+	}
+	if scoped, ok := defaults[commandPath].(map[string]interface{}); ok {
+		for key, value := range scoped {
+			values[key] = defaultFlagValue(value)
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(values[name]); err != nil {
+			return fmt.Errorf("invalid config default for --%s: %w", name, err)
+		}
+		if debugMode {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Config default --%s=%s\n", name, values[name])
+		}
+	}
+
+	return nil
+}
+
+// defaultFlagValue formats a config default as a flag value. A YAML list
+// becomes its elements joined with commas, as slice flags expect.
+func defaultFlagValue(value interface{}) string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// newClient creates an API client for cfg using the global client options.
+func newClient(cfg *config.Config) *api.Client {
+	return api.NewClientWithOptions(cfg.URL, cfg.Token, clientOptions())
+}
+
+// clientOptions returns the client options set by the global flags.
+func clientOptions() api.ClientOptions {
+	return api.ClientOptions{
+		Retry:     retryPolicy,
+		Headers:   extraHeaders,
+		Timer:     requestTimer,
+		Timeout:   timeout,
+		Transport: transportOptions,
+		RateLimit: rateLimit,
+		Parallel:  parallel,
+		Cache:     cacheOptions,
+	}
 }
 
 // loadConfig loads the configuration from file and environment variables,
 // then applies CLI flag overrides if provided.
 func loadConfig() (*config.Config, error) {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := config.LoadProfile(cfgFile, profileName)
 
 	// If config loading failed but we have both URL and token from CLI flags,
 	// we can proceed without a config file
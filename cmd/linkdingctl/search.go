@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search bookmarks with filters LinkDing does not support",
+	Long: `Fetch every matching bookmark and filter it locally by date added, title,
+URL or description.
+
+--query and --tags are sent to LinkDing to narrow the fetch; the other
+filters are then applied to each result. All filters must match.
+
+Dates for --added-before and --added-after are YYYY-MM-DD, taken as midnight
+in the --timezone zone (local by default), or full RFC 3339 timestamps.
+--added-before is exclusive and --added-after inclusive.
+
+Examples:
+  linkdingctl search --added-before 2023-01-01
+  linkdingctl search -q kubernetes --title-regex '(?i)^operator'
+  linkdingctl search --url-regex 'github\.com/[^/]+$' --json
+  linkdingctl search --tags go --description-contains generics`,
+	Args: cobra.NoArgs,
+	RunE: runSearch,
+}
+
+var (
+	searchQuery               string
+	searchTags                []string
+	searchArchived            bool
+	searchAddedBefore         string
+	searchAddedAfter          string
+	searchTitleRegex          string
+	searchURLRegex            string
+	searchDescriptionContains string
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVarP(&searchQuery, "query", "q", "", "Search query sent to LinkDing before local filtering")
+	searchCmd.Flags().StringSliceVarP(&searchTags, "tags", "T", []string{}, "Filter by tags (AND logic)")
+	_ = searchCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	searchCmd.Flags().BoolVarP(&searchArchived, "archived", "a", false, "Search archived bookmarks instead")
+	searchCmd.Flags().StringVar(&searchAddedBefore, "added-before", "", "Only bookmarks added before this date (YYYY-MM-DD or RFC 3339)")
+	searchCmd.Flags().StringVar(&searchAddedAfter, "added-after", "", "Only bookmarks added on or after this date (YYYY-MM-DD or RFC 3339)")
+	searchCmd.Flags().StringVar(&searchTitleRegex, "title-regex", "", "Only bookmarks whose title matches this regular expression")
+	searchCmd.Flags().StringVar(&searchURLRegex, "url-regex", "", "Only bookmarks whose URL matches this regular expression")
+	searchCmd.Flags().StringVar(&searchDescriptionContains, "description-contains", "", "Only bookmarks whose description contains this text (case-insensitive)")
+}
+
+// searchFilter holds the client-side predicates of the search command. Zero
+// fields match everything.
+type searchFilter struct {
+	addedBefore         time.Time
+	addedAfter          time.Time
+	titleRegex          *regexp.Regexp
+	urlRegex            *regexp.Regexp
+	descriptionContains string
+}
+
+// newSearchFilter parses the search flags into a searchFilter.
+func newSearchFilter() (*searchFilter, error) {
+	filter := &searchFilter{descriptionContains: strings.ToLower(searchDescriptionContains)}
+	var err error
+	if filter.addedBefore, err = parseSearchDate("--added-before", searchAddedBefore); err != nil {
+		return nil, err
+	}
+	if filter.addedAfter, err = parseSearchDate("--added-after", searchAddedAfter); err != nil {
+		return nil, err
+	}
+	if !filter.addedBefore.IsZero() && !filter.addedAfter.IsZero() && !filter.addedAfter.Before(filter.addedBefore) {
+		return nil, fmt.Errorf("--added-after must be earlier than --added-before")
+	}
+	if filter.titleRegex, err = compileSearchRegex("--title-regex", searchTitleRegex); err != nil {
+		return nil, err
+	}
+	if filter.urlRegex, err = compileSearchRegex("--url-regex", searchURLRegex); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseSearchDate parses a YYYY-MM-DD date in the display zone or an RFC 3339
+// timestamp. An empty value gives the zero time.
+func parseSearchDate(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, displayLocation()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q: use YYYY-MM-DD or an RFC 3339 timestamp such as 2023-01-01T00:00:00Z", flag, value)
+}
+
+// compileSearchRegex compiles a regex flag. An empty value gives nil.
+func compileSearchRegex(flag, value string) (*regexp.Regexp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	return re, nil
+}
+
+// matches reports whether a bookmark passes every filter.
+func (f *searchFilter) matches(b *models.Bookmark) bool {
+	if !f.addedBefore.IsZero() && !b.DateAdded.Before(f.addedBefore) {
+		return false
+	}
+	if !f.addedAfter.IsZero() && b.DateAdded.Before(f.addedAfter) {
+		return false
+	}
+	if f.titleRegex != nil && !f.titleRegex.MatchString(b.Title) {
+		return false
+	}
+	if f.urlRegex != nil && !f.urlRegex.MatchString(b.URL) {
+		return false
+	}
+	if f.descriptionContains != "" && !strings.Contains(strings.ToLower(b.Description), f.descriptionContains) {
+		return false
+	}
+	return true
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	// Parse the filters first so bad input fails before any request
+	filter, err := newSearchFilter()
+	if err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	archived := searchArchived
+	bookmarks, err := client.BookmarkPages(searchQuery, searchTags, nil, &archived).All()
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+
+	results := []models.Bookmark{}
+	for i := range bookmarks {
+		if filter.matches(&bookmarks[i]) {
+			results = append(results, bookmarks[i])
+		}
+	}
+
+	bookmarkList := &models.BookmarkList{Count: len(results), Results: results}
+	if structuredOutput() {
+		return outputJSON(os.Stdout, bookmarkList)
+	}
+	return outputTable(os.Stdout, bookmarkList)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// selectorStep is a single step of a --select expression.
+type selectorStep struct {
+	field   string // object field name (empty for array steps)
+	iterate bool   // [] - iterate over all array elements
+	index   int    // [N] - a single array element (when isIndex is true)
+	isIndex bool
+}
+
+// parseSelector parses a minimal JSONPath-style expression such as
+// ".results[].url", ".results[0].tag_names[]" or ".count".
+func parseSelector(expr string) ([]selectorStep, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr[0] != '.' {
+		return nil, fmt.Errorf("invalid selector %q: must start with '.'", expr)
+	}
+
+	var steps []selectorStep
+	i := 0
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			i++
+			start := i
+			for i < len(expr) && expr[i] != '.' && expr[i] != '[' {
+				i++
+			}
+			if name := expr[start:i]; name != "" {
+				steps = append(steps, selectorStep{field: name})
+			} else if i < len(expr) && expr[i] == '.' {
+				return nil, fmt.Errorf("invalid selector %q: empty field name at position %d", expr, start)
+			}
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid selector %q: missing ']' at position %d", expr, i)
+			}
+			inner := expr[i+1 : i+end]
+			if inner == "" {
+				steps = append(steps, selectorStep{iterate: true})
+			} else {
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("invalid selector %q: bad array index %q", expr, inner)
+				}
+				steps = append(steps, selectorStep{index: n, isIndex: true})
+			}
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("invalid selector %q: unexpected character %q at position %d", expr, expr[i], i)
+		}
+	}
+
+	return steps, nil
+}
+
+// applySelector walks the decoded JSON value with the given steps and
+// returns every value reached. Missing fields and out-of-range indexes
+// yield no values rather than an error.
+func applySelector(value interface{}, steps []selectorStep) ([]interface{}, error) {
+	current := []interface{}{value}
+
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range current {
+			switch {
+			case step.field != "":
+				obj, ok := v.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("cannot select field %q from non-object value", step.field)
+				}
+				if fv, ok := obj[step.field]; ok {
+					next = append(next, fv)
+				}
+			case step.iterate:
+				arr, ok := v.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("cannot iterate over non-array value")
+				}
+				next = append(next, arr...)
+			case step.isIndex:
+				arr, ok := v.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("cannot index non-array value")
+				}
+				if step.index < len(arr) {
+					next = append(next, arr[step.index])
+				}
+			}
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// writeSelected prints each selected value on its own line. Strings are
+// printed raw; other scalars and composite values are printed as compact JSON.
+func writeSelected(w io.Writer, v interface{}, expr string) error {
+	steps, err := parseSelector(expr)
+	if err != nil {
+		return err
+	}
+
+	// Round-trip through JSON so the selector sees the same field names as --json
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	values, err := applySelector(decoded, steps)
+	if err != nil {
+		return fmt.Errorf("select %s: %w", expr, err)
+	}
+
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		out, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode selected value: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, string(out)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// structuredOutput reports whether a command should emit machine output
+// (--json, --output-format json or yaml, or --select).
+func structuredOutput() bool {
+	return jsonOutput || yamlOutput || selectExpr != ""
+}
+
+// writeJSON writes v to stdout as indented JSON, or applies the --select
+// expression and prints the extracted values line by line. With
+// --output-format yaml it writes YAML instead.
+func writeJSON(v interface{}) error {
+	return writeJSONTo(os.Stdout, v)
+}
+
+// writeJSONTo is writeJSON for an arbitrary writer.
+func writeJSONTo(w io.Writer, v interface{}) error {
+	if selectExpr != "" {
+		return writeSelected(w, v, selectExpr)
+	}
+	if yamlOutput {
+		return writeYAML(w, v)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about your bookmarks",
+	Long: `Show a summary of your bookmark collection, or a histogram of how many
+bookmarks were added over time with --by-day, --by-month or --by-year.
+
+Examples:
+  linkdingctl stats
+  linkdingctl stats --by-month
+  linkdingctl stats --by-year --json`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+var (
+	statsByDay   bool
+	statsByMonth bool
+	statsByYear  bool
+)
+
+// histogramWidth is the length of the longest bar in a text histogram
+const histogramWidth = 40
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsByDay, "by-day", false, "Histogram of bookmarks added per day")
+	statsCmd.Flags().BoolVar(&statsByMonth, "by-month", false, "Histogram of bookmarks added per month")
+	statsCmd.Flags().BoolVar(&statsByYear, "by-year", false, "Histogram of bookmarks added per year")
+	statsCmd.MarkFlagsMutuallyExclusive("by-day", "by-month", "by-year")
+}
+
+// histogramBucket is one period of an added-over-time histogram.
+type histogramBucket struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+
+	granularity := ""
+	switch {
+	case statsByDay:
+		granularity = "day"
+	case statsByMonth:
+		granularity = "month"
+	case statsByYear:
+		granularity = "year"
+	}
+
+	if granularity == "" {
+		return outputStatsSummary(bookmarks)
+	}
+
+	series := addedHistogram(bookmarks, granularity)
+	if structuredOutput() {
+		return writeJSON(map[string]interface{}{
+			"granularity": granularity,
+			"total":       len(bookmarks),
+			"series":      series,
+		})
+	}
+
+	if len(series) == 0 {
+		fmt.Println("No bookmarks found.")
+		return nil
+	}
+
+	maxCount := 0
+	for _, b := range series {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+	}
+	for _, b := range series {
+		bar := strings.Repeat("█", b.Count*histogramWidth/maxCount)
+		if bar == "" && b.Count > 0 {
+			bar = "▏"
+		}
+		fmt.Printf("%-10s %-*s %d\n", b.Period, histogramWidth, bar, b.Count)
+	}
+	fmt.Printf("\nTotal: %d bookmarks\n", len(bookmarks))
+
+	return nil
+}
+
+// outputStatsSummary prints overall counts for the collection.
+func outputStatsSummary(bookmarks []models.Bookmark) error {
+	counts := countBookmarks(bookmarks)
+	if structuredOutput() {
+		return writeJSON(map[string]int{
+			"total":    counts.Total,
+			"unread":   counts.Unread,
+			"shared":   counts.Shared,
+			"archived": counts.Archived,
+			"untagged": counts.Untagged,
+			"tags":     len(counts.perTag),
+		})
+	}
+
+	printBookmarkCounts(counts)
+	fmt.Printf("Tags:       %d\n", len(counts.perTag))
+	return nil
+}
+
+// bookmarkCounts tallies bookmarks by state, for stats and bookmarks count.
+type bookmarkCounts struct {
+	Total    int                   `json:"total"`
+	Unread   int                   `json:"unread"`
+	Archived int                   `json:"archived"`
+	Shared   int                   `json:"shared"`
+	Untagged int                   `json:"untagged"`
+	ByTag    []models.TagWithCount `json:"by_tag,omitempty"`
+
+	// perTag counts the bookmarks carrying each tag
+	perTag map[string]int
+}
+
+// countBookmarks tallies bookmarks by state and per tag.
+func countBookmarks(bookmarks []models.Bookmark) bookmarkCounts {
+	counts := bookmarkCounts{Total: len(bookmarks), perTag: make(map[string]int)}
+	for _, b := range bookmarks {
+		if b.Unread {
+			counts.Unread++
+		}
+		if b.IsArchived {
+			counts.Archived++
+		}
+		if b.Shared {
+			counts.Shared++
+		}
+		if len(b.TagNames) == 0 {
+			counts.Untagged++
+		}
+		for _, tag := range b.TagNames {
+			counts.perTag[tag]++
+		}
+	}
+	return counts
+}
+
+// tagsByCount returns the per-tag counts sorted by count descending and
+// then by name.
+func (c bookmarkCounts) tagsByCount() []models.TagWithCount {
+	tags := make([]models.TagWithCount, 0, len(c.perTag))
+	for name, count := range c.perTag {
+		tags = append(tags, models.TagWithCount{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags
+}
+
+// printBookmarkCounts prints the state counts as labelled lines.
+func printBookmarkCounts(counts bookmarkCounts) {
+	fmt.Printf("Bookmarks:  %d\n", counts.Total)
+	fmt.Printf("Unread:     %d\n", counts.Unread)
+	fmt.Printf("Archived:   %d\n", counts.Archived)
+	fmt.Printf("Shared:     %d\n", counts.Shared)
+	fmt.Printf("Untagged:   %d\n", counts.Untagged)
+}
+
+// addedHistogram buckets bookmarks by DateAdded (display zone) at the given
+// granularity. Month and year series include empty periods between the first
+// and last bookmark; day series list only days with bookmarks.
+func addedHistogram(bookmarks []models.Bookmark, granularity string) []histogramBucket {
+	counts := make(map[string]int)
+	var first, last time.Time
+	for _, b := range bookmarks {
+		if b.DateAdded.IsZero() {
+			continue
+		}
+		added := displayTime(b.DateAdded)
+		counts[periodKey(added, granularity)]++
+		if first.IsZero() || added.Before(first) {
+			first = added
+		}
+		if added.After(last) {
+			last = added
+		}
+	}
+
+	if len(counts) == 0 {
+		return []histogramBucket{}
+	}
+
+	var series []histogramBucket
+	if granularity == "day" {
+		periods := make([]string, 0, len(counts))
+		for period := range counts {
+			periods = append(periods, period)
+		}
+		sort.Strings(periods)
+		for _, period := range periods {
+			series = append(series, histogramBucket{Period: period, Count: counts[period]})
+		}
+		return series
+	}
+
+	// Walk every period from the first to the last bookmark
+	current := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, displayLocation())
+	if granularity == "year" {
+		current = time.Date(first.Year(), 1, 1, 0, 0, 0, 0, displayLocation())
+	}
+	end := periodKey(last, granularity)
+	for {
+		period := periodKey(current, granularity)
+		series = append(series, histogramBucket{Period: period, Count: counts[period]})
+		if period == end {
+			break
+		}
+		if granularity == "year" {
+			current = current.AddDate(1, 0, 0)
+		} else {
+			current = current.AddDate(0, 1, 0)
+		}
+	}
+	return series
+}
+
+// periodKey formats t as the histogram period for granularity.
+func periodKey(t time.Time, granularity string) string {
+	switch granularity {
+	case "day":
+		return t.Format("2006-01-02")
+	case "year":
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01")
+	}
+}
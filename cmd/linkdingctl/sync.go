@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/export"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync <file>",
+	Short: "Make LinkDing match a JSON backup file",
+	Long: `Reconcile LinkDing with a JSON file in the backup/export format, so the
+file can be kept under version control as the source of truth.
+
+Bookmarks are matched by URL. Bookmarks missing from LinkDing are created,
+and existing ones are updated when their title, description, tags or notes
+differ from the file. Files from before notes were backed up have none, and
+the notes in LinkDing are then left alone. With --prune, bookmarks that are
+not in the file, including archived ones, are deleted after confirmation
+(skipped with --force). With structured output, --prune requires --force
+when anything would be deleted.
+
+The file is checked against the export schema before anything is changed.
+
+Examples:
+  linkdingctl sync bookmarks.json --dry-run
+  linkdingctl sync bookmarks.json
+  linkdingctl sync bookmarks.json --prune --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSync,
+}
+
+var (
+	syncDryRun bool
+	syncPrune  bool
+	syncForce  bool
+)
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would change without making changes")
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Delete bookmarks that are not in the file")
+	syncCmd.Flags().BoolVarP(&syncForce, "force", "f", false, "Skip the --prune confirmation prompt")
+}
+
+// syncUpdate pairs a LinkDing bookmark with the file entry it must match.
+type syncUpdate struct {
+	existing models.Bookmark
+	want     export.ExportBookmark
+}
+
+// syncPlan is the set of changes that makes LinkDing match the file.
+type syncPlan struct {
+	create    []export.ExportBookmark
+	update    []syncUpdate
+	remove    []models.Bookmark
+	unchanged int
+}
+
+// syncResult counts what a sync did, or would do under --dry-run.
+type syncResult struct {
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Failed    int
+	Errors    []string
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	data, err := readSyncFile(args[0])
+	if err != nil {
+		return err
+	}
+	if data.IncrementalSince != nil && syncPrune {
+		return fmt.Errorf("--prune cannot be used with an incremental backup: it only holds bookmarks modified since %s",
+			data.IncrementalSince.Format(time.RFC3339))
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	existing, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	plan := planSync(data.Bookmarks, existing, syncPrune)
+
+	dry := isDryRun(syncDryRun)
+	if dry {
+		result := &syncResult{
+			Created:   len(plan.create),
+			Updated:   len(plan.update),
+			Deleted:   len(plan.remove),
+			Unchanged: plan.unchanged,
+		}
+		if structuredOutput() {
+			return outputSyncResultJSON(result, true)
+		}
+		fmt.Fprintln(os.Stderr, "Dry run - no changes will be made")
+		displaySyncPlan(plan)
+		displaySyncResult(result)
+		return nil
+	}
+
+	// Ask before deleting unless --force; structured output cannot prompt
+	if len(plan.remove) > 0 && !syncForce && structuredOutput() {
+		return fmt.Errorf("deleting %d bookmark(s) with --prune and structured output requires --force", len(plan.remove))
+	}
+	if len(plan.remove) > 0 && !syncForce {
+		fmt.Printf("About to delete %d bookmark(s) that are not in %s.\n", len(plan.remove), args[0])
+		fmt.Printf("Are you sure? (y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Sync cancelled")
+			return nil
+		}
+	}
+
+	result := applySync(client, plan)
+
+	if structuredOutput() {
+		if err := outputSyncResultJSON(result, false); err != nil {
+			return err
+		}
+	} else {
+		displaySyncResult(result)
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("some bookmarks failed to sync")
+	}
+	return nil
+}
+
+// readSyncFile reads a JSON backup file, rejecting it if it does not match
+// the export schema so a malformed file cannot drive a --prune.
+func readSyncFile(filename string) (*export.ExportData, error) {
+	file, err := export.OpenFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	validationErrors, err := export.ValidateJSON(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if len(validationErrors) > 0 {
+		return nil, fmt.Errorf("%s does not match the export schema: %s (run 'linkdingctl import --validate-only %s' for details)",
+			filename, validationErrors[0], filename)
+	}
+
+	var data export.ExportData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return &data, nil
+}
+
+// planSync diffs the file's bookmarks against LinkDing's by URL. When a URL
+// appears more than once in the file, its first entry is used.
+func planSync(entries []export.ExportBookmark, existing []models.Bookmark, prune bool) *syncPlan {
+	byURL := make(map[string]models.Bookmark, len(existing))
+	for _, b := range existing {
+		byURL[b.URL] = b
+	}
+
+	plan := &syncPlan{}
+	inFile := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if inFile[entry.URL] {
+			continue
+		}
+		inFile[entry.URL] = true
+
+		current, ok := byURL[entry.URL]
+		switch {
+		case !ok:
+			plan.create = append(plan.create, entry)
+		case syncDiffers(current, entry):
+			plan.update = append(plan.update, syncUpdate{existing: current, want: entry})
+		default:
+			plan.unchanged++
+		}
+	}
+
+	if prune {
+		for _, b := range existing {
+			if !inFile[b.URL] {
+				plan.remove = append(plan.remove, b)
+			}
+		}
+	}
+	return plan
+}
+
+// syncDiffers reports whether a bookmark's title, description, tags or
+// notes differ from its file entry. Tags are compared ignoring order and
+// case, as LinkDing treats them; notes only when the entry has them.
+func syncDiffers(b models.Bookmark, entry export.ExportBookmark) bool {
+	if b.Title != entry.Title || b.Description != entry.Description {
+		return true
+	}
+	if entry.Notes != nil && b.Notes != *entry.Notes {
+		return true
+	}
+	return !sameTags(b.TagNames, entry.Tags)
+}
+
+// sameTags reports whether two tag lists hold the same tags.
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	normalize := func(tags []string) []string {
+		out := make([]string, len(tags))
+		for i, t := range tags {
+			out[i] = strings.ToLower(t)
+		}
+		sort.Strings(out)
+		return out
+	}
+	na, nb := normalize(a), normalize(b)
+	for i := range na {
+		if na[i] != nb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// applySync creates, updates and deletes bookmarks according to the plan,
+// continuing past failures.
+func applySync(client *api.Client, plan *syncPlan) *syncResult {
+	result := &syncResult{Unchanged: plan.unchanged}
+	fail := func(url string, err error) {
+		result.Failed++
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", url, err))
+	}
+
+	for _, entry := range plan.create {
+		create := &models.BookmarkCreate{
+			URL:         entry.URL,
+			Title:       entry.Title,
+			Description: entry.Description,
+			TagNames:    entry.Tags,
+			IsArchived:  entry.Archived,
+			Unread:      entry.Unread,
+			Shared:      entry.Shared,
+		}
+		if entry.Notes != nil {
+			create.Notes = *entry.Notes
+		}
+		if _, err := client.CreateBookmark(create); err != nil {
+			fail(entry.URL, err)
+			continue
+		}
+		result.Created++
+	}
+
+	for _, u := range plan.update {
+		tags := u.want.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		update := &models.BookmarkUpdate{
+			Title:       &u.want.Title,
+			Description: &u.want.Description,
+			Notes:       u.want.Notes,
+			TagNames:    &tags,
+		}
+		if _, err := client.UpdateBookmark(u.existing.ID, update); err != nil {
+			fail(u.want.URL, err)
+			continue
+		}
+		result.Updated++
+	}
+
+	for _, b := range plan.remove {
+		if err := client.DeleteBookmark(b.ID); err != nil {
+			fail(b.URL, err)
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result
+}
+
+// displaySyncPlan lists each change a dry run would make.
+func displaySyncPlan(plan *syncPlan) {
+	for _, entry := range plan.create {
+		statusf(os.Stderr, "  + %s\n", entry.URL)
+	}
+	for _, u := range plan.update {
+		statusf(os.Stderr, "  ~ %s\n", u.want.URL)
+	}
+	for _, b := range plan.remove {
+		statusf(os.Stderr, "  - %s\n", b.URL)
+	}
+}
+
+func displaySyncResult(result *syncResult) {
+	statusf(os.Stderr, "  ✓ %d bookmarks created\n", result.Created)
+	statusf(os.Stderr, "  ✓ %d bookmarks updated\n", result.Updated)
+	statusf(os.Stderr, "  ✓ %d bookmarks deleted\n", result.Deleted)
+	statusf(os.Stderr, "  ⊘ %d unchanged\n", result.Unchanged)
+	if result.Failed > 0 {
+		failf(os.Stderr, "  ✗ %d failed (see errors below)\n", result.Failed)
+		fmt.Fprintln(os.Stderr, "\nErrors:")
+		for _, e := range result.Errors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e)
+		}
+	}
+}
+
+func outputSyncResultJSON(result *syncResult, dry bool) error {
+	output := map[string]interface{}{
+		"created":   result.Created,
+		"updated":   result.Updated,
+		"deleted":   result.Deleted,
+		"unchanged": result.Unchanged,
+		"failed":    result.Failed,
+	}
+	if dry {
+		output["dry_run"] = true
+	}
+	if len(result.Errors) > 0 {
+		output["errors"] = result.Errors
+	}
+	return writeJSON(output)
+}
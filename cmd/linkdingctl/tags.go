@@ -1,11 +1,14 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/models"
@@ -30,22 +33,50 @@ var (
 	tagsSort        string
 	tagsUnused      bool
 	tagsRenameForce bool
+	tagsMergeForce  bool
 	tagsDeleteForce bool
+	tagsDeleteKeep  bool
+	tagsShowLimit   int
+	tagsShowAll     bool
+
+	tagsRenameBatchSize  int
+	tagsRenameBatchPause time.Duration
+
+	tagsCooccurMinCount int
+	tagsCooccurTop      int
+
+	tagsListForInline bool
 )
 
+// defaultResultCap is how many bookmarks client-heavy commands show when
+// neither --limit nor --all is given.
+const defaultResultCap = 50
+
 func init() {
 	rootCmd.AddCommand(tagsCmd)
 	tagsCmd.AddCommand(tagsCreateCmd)
 	tagsCmd.AddCommand(tagsGetCmd)
 	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsMergeCmd)
 	tagsCmd.AddCommand(tagsDeleteCmd)
 	tagsCmd.AddCommand(tagsShowCmd)
+	tagsCmd.AddCommand(tagsCooccurrenceCmd)
+	tagsCmd.AddCommand(tagsListForCmd)
 
 	tagsCmd.Flags().StringVarP(&tagsSort, "sort", "s", "name", "Sort by: name, count")
 	tagsCmd.Flags().BoolVar(&tagsUnused, "unused", false, "Show only tags with 0 bookmarks")
 
 	tagsRenameCmd.Flags().BoolVarP(&tagsRenameForce, "force", "f", false, "Skip confirmation")
+	tagsRenameCmd.Flags().IntVar(&tagsRenameBatchSize, "batch-size", 0, "Update bookmarks in batches of this size, pausing between batches")
+	tagsRenameCmd.Flags().DurationVar(&tagsRenameBatchPause, "batch-pause", defaultBatchPause, "Pause between batches with --batch-size")
+	tagsMergeCmd.Flags().BoolVarP(&tagsMergeForce, "force", "f", false, "Skip confirmation")
 	tagsDeleteCmd.Flags().BoolVarP(&tagsDeleteForce, "force", "f", false, "Skip confirmation and remove tag from all bookmarks")
+	tagsDeleteCmd.Flags().BoolVar(&tagsDeleteKeep, "keep-tag", false, "Only remove the tag from bookmarks; keep the tag itself")
+	tagsShowCmd.Flags().IntVarP(&tagsShowLimit, "limit", "l", 0, fmt.Sprintf("Max results (default: %d)", defaultResultCap))
+	tagsShowCmd.Flags().BoolVar(&tagsShowAll, "all", false, "Show every matching bookmark")
+	tagsCooccurrenceCmd.Flags().IntVar(&tagsCooccurMinCount, "min-count", 2, "Only show pairs that appear together on at least this many bookmarks")
+	tagsCooccurrenceCmd.Flags().IntVar(&tagsCooccurTop, "top", 20, "Show at most this many pairs (0 for all)")
+	tagsListForCmd.Flags().BoolVar(&tagsListForInline, "inline", false, "Print the tags on one line, comma-separated")
 }
 
 // tagsCreateCmd represents the tags create command
@@ -76,7 +107,11 @@ func runTagsCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
+
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "POST", Path: "/api/tags/", Body: map[string]string{"name": tagName}})
+	}
 
 	// Create the tag
 	tag, err := client.CreateTag(tagName)
@@ -85,13 +120,15 @@ func runTagsCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(tag)
+	if structuredOutput() {
+		return writeJSON(tag)
+	}
+	if isQuiet() {
+		fmt.Println(tag.ID)
+		return nil
 	}
 
-	fmt.Printf("✓ Tag created: %s\n", tag.Name)
+	statusf(os.Stdout, "✓ Tag created: %s\n", tag.Name)
 	fmt.Printf("  ID: %d\n", tag.ID)
 
 	return nil
@@ -124,7 +161,7 @@ func runTagsGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Get the tag
 	tag, err := client.GetTag(tagID)
@@ -133,15 +170,13 @@ func runTagsGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(tag)
+	if structuredOutput() {
+		return writeJSON(tag)
 	}
 
 	fmt.Printf("Tag: %s\n", tag.Name)
 	fmt.Printf("  ID: %d\n", tag.ID)
-	fmt.Printf("  Date Added: %s\n", tag.DateAdded.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Date Added: %s\n", displayTime(tag.DateAdded).Format("2006-01-02 15:04:05"))
 
 	return nil
 }
@@ -154,7 +189,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Fetch all tags to get complete list (including unused ones)
 	allTagsList, err := client.FetchAllTags()
@@ -213,7 +248,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
+	if structuredOutput() {
 		return outputTagsJSON(tagsWithCount)
 	}
 
@@ -221,9 +256,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 }
 
 func outputTagsJSON(tags []models.TagWithCount) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(tags)
+	return writeJSON(tags)
 }
 
 func outputTagsTable(tags []models.TagWithCount) error {
@@ -264,17 +297,28 @@ This command will:
 2. Update each bookmark to replace the old tag with the new tag
 3. Show progress as bookmarks are updated
 
+Use --batch-size to update bookmarks in batches with a --batch-pause between
+them. An interrupted rename can simply be run again: bookmarks already
+renamed no longer carry the old tag, so only the rest are updated.
+
 Examples:
   linkdingctl tags rename oldtag newtag
-  linkdingctl tags rename "old tag" "new tag" --force`,
-	Args: cobra.ExactArgs(2),
-	RunE: runTagsRename,
+  linkdingctl tags rename "old tag" "new tag" --force
+  linkdingctl tags rename old new --force --batch-size 50 --batch-pause 2s`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeTagArgs(1),
+	RunE:              runTagsRename,
 }
 
 func runTagsRename(cmd *cobra.Command, args []string) error {
 	oldTag := args[0]
 	newTag := args[1]
 
+	pacer, err := newPacer(tagsRenameBatchSize, tagsRenameBatchPause, false)
+	if err != nil {
+		return err
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
@@ -282,7 +326,7 @@ func runTagsRename(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Get all bookmarks with the old tag (including archived)
 	allBookmarks, err := client.FetchAllBookmarks([]string{oldTag}, true)
@@ -294,6 +338,15 @@ func runTagsRename(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no bookmarks found with tag '%s'", oldTag)
 	}
 
+	if isDryRun() {
+		return reportDryRun(retagRequests(allBookmarks, func(tag string) (string, bool) {
+			if tag == oldTag {
+				return newTag, true
+			}
+			return tag, true
+		})...)
+	}
+
 	// Ask for confirmation unless --force is used
 	if !tagsRenameForce {
 		fmt.Printf("This will rename tag '%s' to '%s' on %d bookmark(s).\n", oldTag, newTag, len(allBookmarks))
@@ -311,8 +364,10 @@ func runTagsRename(cmd *cobra.Command, args []string) error {
 	errorCount := 0
 
 	for i, bookmark := range allBookmarks {
+		pacer.Next(i)
+
 		// Show progress
-		fmt.Printf("Updating bookmark %d/%d (ID: %d)...\n", i+1, len(allBookmarks), bookmark.ID)
+		statusf(os.Stdout, "Updating bookmark %d/%d (ID: %d)...\n", i+1, len(allBookmarks), bookmark.ID)
 
 		// Build new tag list: replace old tag with new tag
 		newTags := make([]string, 0, len(bookmark.TagNames))
@@ -340,7 +395,7 @@ func runTagsRename(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show summary
-	fmt.Printf("\nCompleted: %d successful, %d errors\n", successCount, errorCount)
+	statusf(os.Stdout, "\nCompleted: %d successful, %d errors\n", successCount, errorCount)
 
 	if errorCount > 0 {
 		return fmt.Errorf("some bookmarks failed to update")
@@ -349,6 +404,157 @@ func runTagsRename(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// tagsMergeCmd represents the tags merge command
+var tagsMergeCmd = &cobra.Command{
+	Use:   "merge <target> <source>...",
+	Short: "Merge several tags into one across all bookmarks",
+	Long: `Replace each source tag with the target tag on every bookmark that uses
+it. A bookmark that already has the target, or has several source tags, ends
+up with the target once.
+
+The source tags themselves are left in place as unused tags; remove them with
+'linkdingctl tags delete'.
+
+Examples:
+  linkdingctl tags merge javascript js java-script
+  linkdingctl tags merge javascript js java-script --dry-run
+  linkdingctl tags merge k8s kubernetes kube --force`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeTagArgs(0),
+	RunE:              runTagsMerge,
+}
+
+func runTagsMerge(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	// Tags are case-insensitive in LinkDing, so sources are keyed in lower case
+	sources := make([]string, 0, len(args)-1)
+	sourceByKey := make(map[string]string, len(args)-1)
+	for _, source := range args[1:] {
+		if strings.EqualFold(source, target) {
+			return fmt.Errorf("source tag '%s' is the same as the target", source)
+		}
+		if key := strings.ToLower(source); sourceByKey[key] == "" {
+			sourceByKey[key] = source
+			sources = append(sources, source)
+		}
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	// Get all bookmarks with any source tag (including archived), once each
+	var allBookmarks []models.Bookmark
+	seen := make(map[int]bool)
+	for _, source := range sources {
+		bookmarks, err := client.FetchAllBookmarks([]string{source}, true)
+		if err != nil {
+			return fmt.Errorf("failed to fetch bookmarks with tag '%s': %w", source, err)
+		}
+		// The search also matches the tag name in titles, URLs and descriptions
+		for _, bookmark := range bookmarks {
+			if !seen[bookmark.ID] && bookmark.HasAnyTag(sources) {
+				seen[bookmark.ID] = true
+				allBookmarks = append(allBookmarks, bookmark)
+			}
+		}
+	}
+
+	if len(allBookmarks) == 0 {
+		return fmt.Errorf("no bookmarks found with tags '%s'", strings.Join(sources, "', '"))
+	}
+
+	if isDryRun() {
+		requests := make([]plannedRequest, 0, len(allBookmarks))
+		for _, bookmark := range allBookmarks {
+			newTags := mergeTagNames(bookmark.TagNames, target, sourceByKey)
+			requests = append(requests, plannedRequest{
+				Method: "PATCH",
+				Path:   fmt.Sprintf("/api/bookmarks/%d/", bookmark.ID),
+				Body:   &models.BookmarkUpdate{TagNames: &newTags},
+			})
+		}
+		return reportDryRun(requests...)
+	}
+
+	// Ask for confirmation unless --force is used
+	if !tagsMergeForce {
+		fmt.Printf("This will merge tags '%s' into '%s' on %d bookmark(s).\n", strings.Join(sources, "', '"), target, len(allBookmarks))
+		fmt.Print("Continue? (y/N): ")
+
+		var response string
+		if _, err := fmt.Scanln(&response); err != nil || (response != "y" && response != "Y") {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	// Update each bookmark, counting successes per source tag
+	successCount := 0
+	errorCount := 0
+	updated := make(map[string]int, len(sources))
+
+	for i, bookmark := range allBookmarks {
+		// Show progress
+		statusf(os.Stdout, "Updating bookmark %d/%d (ID: %d)...\n", i+1, len(allBookmarks), bookmark.ID)
+
+		newTags := mergeTagNames(bookmark.TagNames, target, sourceByKey)
+		update := &models.BookmarkUpdate{
+			TagNames: &newTags,
+		}
+
+		_, err := client.UpdateBookmark(bookmark.ID, update)
+		if err != nil {
+			fmt.Printf("  Error: %v\n", err)
+			errorCount++
+			continue
+		}
+
+		successCount++
+		for _, tag := range bookmark.TagNames {
+			if source, ok := sourceByKey[strings.ToLower(tag)]; ok {
+				updated[source]++
+			}
+		}
+	}
+
+	// Show summary
+	statusf(os.Stdout, "\nCompleted: %d successful, %d errors\n", successCount, errorCount)
+	for _, source := range sources {
+		statusf(os.Stdout, "  %s → %s: %d bookmark(s) updated\n", source, target, updated[source])
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("some bookmarks failed to update")
+	}
+
+	return nil
+}
+
+// mergeTagNames replaces each source tag in tags with target, keeping the
+// first position the target takes and dropping repeats. sourceByKey holds
+// the source tags keyed in lower case; tags match regardless of case.
+func mergeTagNames(tags []string, target string, sourceByKey map[string]string) []string {
+	merged := make([]string, 0, len(tags))
+	hasTarget := false
+	for _, tag := range tags {
+		if _, isSource := sourceByKey[strings.ToLower(tag)]; isSource || strings.EqualFold(tag, target) {
+			if hasTarget {
+				continue
+			}
+			hasTarget = true
+			tag = target
+		}
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
 // tagsDeleteCmd represents the tags delete command
 var tagsDeleteCmd = &cobra.Command{
 	Use:   "delete <tag-name>",
@@ -358,11 +564,17 @@ var tagsDeleteCmd = &cobra.Command{
 By default, this command only works if the tag has 0 bookmarks.
 Use --force to remove the tag from all bookmarks first.
 
+Once no bookmarks use the tag, the tag itself is deleted. Servers that do
+not support deleting tags keep it as an unused tag. Use --keep-tag to only
+remove the tag from bookmarks.
+
 Examples:
   linkdingctl tags delete unused-tag
-  linkdingctl tags delete "old tag" --force`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTagsDelete,
+  linkdingctl tags delete "old tag" --force
+  linkdingctl tags delete "old tag" --force --keep-tag`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTagArgs(1),
+	RunE:              runTagsDelete,
 }
 
 func runTagsDelete(cmd *cobra.Command, args []string) error {
@@ -375,7 +587,7 @@ func runTagsDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Get all bookmarks with the tag to check count
 	allBookmarks, err := client.FetchAllBookmarks([]string{tagName}, true)
@@ -390,15 +602,42 @@ func runTagsDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("tag '%s' has %d bookmark(s). Remove tag from bookmarks first or use --force to remove from all", tagName, bookmarkCount)
 	}
 
-	// If tag has no bookmarks, we can just confirm deletion
+	if isDryRun() {
+		requests := retagRequests(allBookmarks, func(tag string) (string, bool) {
+			return tag, tag != tagName
+		})
+		if !tagsDeleteKeep {
+			tag, err := findTagByName(client, tagName)
+			if err != nil {
+				return err
+			}
+			if tag != nil {
+				requests = append(requests, plannedRequest{
+					Method: "DELETE",
+					Path:   fmt.Sprintf("/api/tags/%d/", tag.ID),
+				})
+			}
+		}
+		return reportDryRun(requests...)
+	}
+
+	// If tag has no bookmarks, only the tag itself needs deleting
 	if bookmarkCount == 0 {
-		fmt.Printf("Tag '%s' has no bookmarks and will be removed.\n", tagName)
-		return nil
+		fmt.Printf("Tag '%s' has no bookmarks.\n", tagName)
+		if tagsDeleteKeep {
+			fmt.Printf("Tag '%s' kept (--keep-tag).\n", tagName)
+			return nil
+		}
+		return deleteTagObject(client, tagName)
 	}
 
 	// If we get here, --force is set and tag has bookmarks
 	// Ask for confirmation
-	fmt.Printf("This will remove tag '%s' from %d bookmark(s).\n", tagName, bookmarkCount)
+	if tagsDeleteKeep {
+		fmt.Printf("This will remove tag '%s' from %d bookmark(s).\n", tagName, bookmarkCount)
+	} else {
+		fmt.Printf("This will remove tag '%s' from %d bookmark(s) and delete the tag.\n", tagName, bookmarkCount)
+	}
 	fmt.Print("Continue? (y/N): ")
 
 	var response string
@@ -413,7 +652,7 @@ func runTagsDelete(cmd *cobra.Command, args []string) error {
 
 	for i, bookmark := range allBookmarks {
 		// Show progress
-		fmt.Printf("Updating bookmark %d/%d (ID: %d)...\n", i+1, bookmarkCount, bookmark.ID)
+		statusf(os.Stdout, "Updating bookmark %d/%d (ID: %d)...\n", i+1, bookmarkCount, bookmark.ID)
 
 		// Build new tag list: remove the tag
 		newTags := make([]string, 0, len(bookmark.TagNames)-1)
@@ -439,16 +678,81 @@ func runTagsDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Show summary
-	fmt.Printf("\nCompleted: %d successful, %d errors\n", successCount, errorCount)
-	fmt.Printf("Tag '%s' has been removed from all bookmarks.\n", tagName)
+	statusf(os.Stdout, "\nCompleted: %d successful, %d errors\n", successCount, errorCount)
 
+	// The tag is still in use if any update failed, so leave it in place
 	if errorCount > 0 {
 		return fmt.Errorf("some bookmarks failed to update")
 	}
 
+	fmt.Printf("Tag '%s' has been removed from all bookmarks.\n", tagName)
+	if tagsDeleteKeep {
+		fmt.Printf("Tag '%s' kept as an unused tag (--keep-tag).\n", tagName)
+		return nil
+	}
+	return deleteTagObject(client, tagName)
+}
+
+// deleteTagObject deletes the tag itself once no bookmarks use it, reporting
+// which action was taken.
+func deleteTagObject(client *api.Client, tagName string) error {
+	tag, err := findTagByName(client, tagName)
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		fmt.Printf("Tag '%s' does not exist on the server; nothing left to delete.\n", tagName)
+		return nil
+	}
+
+	err = client.DeleteTag(tag.ID)
+	if errors.Is(err, api.ErrTagDeleteUnsupported) {
+		fmt.Printf("Note: the server does not support deleting tags; '%s' remains as an unused tag.\n", tag.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete tag '%s': %w", tag.Name, err)
+	}
+
+	statusf(os.Stdout, "✓ Tag '%s' deleted\n", tag.Name)
 	return nil
 }
 
+// findTagByName looks up a tag by name, ignoring case as LinkDing does.
+// It returns nil if no tag matches.
+func findTagByName(client *api.Client, name string) (*models.Tag, error) {
+	tags, err := client.FetchAllTags()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		if strings.EqualFold(tags[i].Name, name) {
+			return &tags[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// retagRequests plans a tag update for each bookmark. mapTag returns the
+// replacement for a tag and whether to keep it.
+func retagRequests(bookmarks []models.Bookmark, mapTag func(string) (string, bool)) []plannedRequest {
+	requests := make([]plannedRequest, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		newTags := make([]string, 0, len(bookmark.TagNames))
+		for _, tag := range bookmark.TagNames {
+			if mapped, keep := mapTag(tag); keep {
+				newTags = append(newTags, mapped)
+			}
+		}
+		requests = append(requests, plannedRequest{
+			Method: "PATCH",
+			Path:   fmt.Sprintf("/api/bookmarks/%d/", bookmark.ID),
+			Body:   &models.BookmarkUpdate{TagNames: &newTags},
+		})
+	}
+	return requests
+}
+
 // tagsShowCmd represents the tags show command
 var tagsShowCmd = &cobra.Command{
 	Use:   "show <tag-name>",
@@ -457,11 +761,17 @@ var tagsShowCmd = &cobra.Command{
 
 This is equivalent to: linkdingctl list --tags <tag-name>
 
+Without --limit or --all, at most 50 bookmarks are shown and a warning is
+printed when more match.
+
 Examples:
   linkdingctl tags show kubernetes
+  linkdingctl tags show kubernetes --limit 10
+  linkdingctl tags show kubernetes --all
   linkdingctl tags show "web dev" --json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTagsShow,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTagArgs(1),
+	RunE:              runTagsShow,
 }
 
 func runTagsShow(cmd *cobra.Command, args []string) error {
@@ -474,26 +784,239 @@ func runTagsShow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
-	// Fetch all bookmarks with the specified tag (including archived)
-	allBookmarks, err := client.FetchAllBookmarks([]string{tagName}, true)
-	if err != nil {
-		return err
+	if tagsShowAll && tagsShowLimit > 0 {
+		return fmt.Errorf("cannot use --limit with --all")
 	}
 
-	// Construct BookmarkList from results for display compatibility
-	bookmarkList := &models.BookmarkList{
-		Count:    len(allBookmarks),
-		Next:     nil,
-		Previous: nil,
-		Results:  allBookmarks,
+	var bookmarkList *models.BookmarkList
+	if tagsShowAll {
+		// Fetch all bookmarks with the specified tag (including archived)
+		allBookmarks, err := client.FetchAllBookmarks([]string{tagName}, true)
+		if err != nil {
+			return err
+		}
+		bookmarkList = &models.BookmarkList{Count: len(allBookmarks), Results: allBookmarks}
+	} else {
+		limit := tagsShowLimit
+		if limit <= 0 {
+			limit = defaultResultCap
+		}
+		page, err := client.GetBookmarks("", []string{tagName}, nil, nil, limit, 0)
+		if err != nil {
+			return err
+		}
+		if tagsShowLimit <= 0 {
+			warnResultCap(len(page.Results), page.Count)
+		}
+		// Pagination hints from list do not apply here
+		bookmarkList = &models.BookmarkList{Count: page.Count, Results: page.Results}
 	}
 
 	// Output based on format
-	if jsonOutput {
-		return outputJSON(bookmarkList)
+	if structuredOutput() {
+		return outputJSON(os.Stdout, bookmarkList)
+	}
+
+	return outputTable(os.Stdout, bookmarkList)
+}
+
+// warnResultCap tells the user on stderr that only the first shown of total
+// matching bookmarks are displayed.
+func warnResultCap(shown, total int) {
+	if total > shown {
+		fmt.Fprintf(os.Stderr, "Warning: showing first %d of %d matching bookmarks. Use --all to show everything or --limit to change the cap.\n", shown, total)
+	}
+}
+
+// tagsCooccurrenceCmd represents the tags cooccurrence command
+var tagsCooccurrenceCmd = &cobra.Command{
+	Use:   "cooccurrence",
+	Short: "Show which tags appear together on bookmarks",
+	Long: `Count how often each pair of tags appears on the same bookmark, most
+frequent first.
+
+OVERLAP is the pair count divided by the count of the rarer tag. At 100%,
+the rarer tag never appears without the other, which often means one of
+them is redundant and the two can be merged with 'tags rename'.
+
+Examples:
+  linkdingctl tags cooccurrence
+  linkdingctl tags cooccurrence --min-count 5 --top 10
+  linkdingctl tags cooccurrence --top 0 --json`,
+	Args: cobra.NoArgs,
+	RunE: runTagsCooccurrence,
+}
+
+// tagPair is a pair of tags and how often they occur, together and alone.
+type tagPair struct {
+	TagA    string  `json:"tag_a"`
+	TagB    string  `json:"tag_b"`
+	Count   int     `json:"count"`
+	CountA  int     `json:"count_a"`
+	CountB  int     `json:"count_b"`
+	Overlap float64 `json:"overlap"`
+}
+
+func runTagsCooccurrence(cmd *cobra.Command, args []string) error {
+	if tagsCooccurMinCount < 1 {
+		return fmt.Errorf("--min-count must be at least 1")
+	}
+	if tagsCooccurTop < 0 {
+		return fmt.Errorf("--top must be zero or greater")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
 	}
 
-	return outputTable(bookmarkList)
+	pairs := tagCooccurrence(bookmarks, tagsCooccurMinCount)
+	total := len(pairs)
+	if tagsCooccurTop > 0 && len(pairs) > tagsCooccurTop {
+		pairs = pairs[:tagsCooccurTop]
+	}
+
+	if structuredOutput() {
+		return writeJSON(pairs)
+	}
+
+	if len(pairs) == 0 {
+		fmt.Println("No tag pairs found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TAG A\tTAG B\tCOUNT\tOVERLAP")
+	_, _ = fmt.Fprintln(w, "-----\t-----\t-----\t-------")
+	for _, p := range pairs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%.0f%%\n", p.TagA, p.TagB, p.Count, p.Overlap*100)
+	}
+	_ = w.Flush()
+
+	if len(pairs) < total {
+		fmt.Printf("\nShowing top %d of %d pairs\n", len(pairs), total)
+	} else {
+		fmt.Printf("\nTotal: %d pairs\n", total)
+	}
+	return nil
+}
+
+// tagCooccurrence counts the tag pairs on bookmarks that occur together at
+// least minCount times, sorted by count (descending) then by name. Within a
+// pair, TagA sorts before TagB; repeated tags on one bookmark count once.
+func tagCooccurrence(bookmarks []models.Bookmark, minCount int) []tagPair {
+	type key struct{ a, b string }
+	tagCounts := make(map[string]int)
+	pairCounts := make(map[key]int)
+
+	for _, b := range bookmarks {
+		seen := make(map[string]bool, len(b.TagNames))
+		var tags []string
+		for _, tag := range b.TagNames {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(tags)
+		for i, a := range tags {
+			tagCounts[a]++
+			for _, other := range tags[i+1:] {
+				pairCounts[key{a, other}]++
+			}
+		}
+	}
+
+	pairs := []tagPair{}
+	for k, count := range pairCounts {
+		if count < minCount {
+			continue
+		}
+		countA, countB := tagCounts[k.a], tagCounts[k.b]
+		pairs = append(pairs, tagPair{
+			TagA:    k.a,
+			TagB:    k.b,
+			Count:   count,
+			CountA:  countA,
+			CountB:  countB,
+			Overlap: float64(count) / float64(min(countA, countB)),
+		})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Count != pairs[j].Count {
+			return pairs[i].Count > pairs[j].Count
+		}
+		if pairs[i].TagA != pairs[j].TagA {
+			return pairs[i].TagA < pairs[j].TagA
+		}
+		return pairs[i].TagB < pairs[j].TagB
+	})
+	return pairs
+}
+
+// tagsListForCmd represents the tags list-for command
+var tagsListForCmd = &cobra.Command{
+	Use:   "list-for <bookmark-id>",
+	Short: "Print the tags of one bookmark",
+	Long: `Print the tags of a bookmark, one per line, without the rest of the
+details 'get' shows. A bookmark without tags prints nothing.
+
+Examples:
+  linkdingctl tags list-for 123
+  linkdingctl tags list-for 123 --inline
+  linkdingctl tags list-for 123 --json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runTagsListFor,
+}
+
+func runTagsListFor(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid bookmark ID: %s (must be a number)", args[0])
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	bookmark, err := client.GetBookmark(id)
+	if err != nil {
+		return err
+	}
+
+	tags := bookmark.TagNames
+	if tags == nil {
+		tags = []string{}
+	}
+	if structuredOutput() {
+		return writeJSON(tags)
+	}
+	if tagsListForInline {
+		if len(tags) > 0 {
+			fmt.Println(strings.Join(tags, ","))
+		}
+		return nil
+	}
+	for _, tag := range tags {
+		fmt.Println(tag)
+	}
+	return nil
 }
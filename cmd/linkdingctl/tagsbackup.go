@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// tagsExportCmd represents the tags export command
+var tagsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Save every tag to a JSON file",
+	Long: `Write every tag, used or not, to a JSON file that 'tags import' can
+recreate on another instance. Bookmarks are not included; use 'backup' for
+those.
+
+Examples:
+  linkdingctl tags export -o tags.json
+  linkdingctl tags export > tags.json`,
+	Args: cobra.NoArgs,
+	RunE: runTagsExport,
+}
+
+// tagsImportCmd represents the tags import command
+var tagsImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create the tags saved by tags export",
+	Long: `Create each tag in a file written by 'tags export'. Tags that already
+exist, compared case-insensitively as LinkDing does, are skipped. A tag
+that could not be created is reported and the rest are still created; the
+command then exits non-zero. --dry-run shows the requests instead.
+
+Examples:
+  linkdingctl tags import tags.json
+  linkdingctl tags import tags.json --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTagsImport,
+}
+
+// tagsFileVersion is the version of the tags export format.
+const tagsFileVersion = "1"
+
+// tagsFile is the document written by tags export.
+type tagsFile struct {
+	Version    string       `json:"version"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Tags       []models.Tag `json:"tags"`
+}
+
+// tagsImportResult is the outcome of tags import.
+type tagsImportResult struct {
+	Created int      `json:"created"`
+	Skipped int      `json:"skipped"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+var tagsExportOutput string
+
+func init() {
+	tagsCmd.AddCommand(tagsExportCmd)
+	tagsCmd.AddCommand(tagsImportCmd)
+
+	tagsExportCmd.Flags().StringVarP(&tagsExportOutput, "output", "o", "", "Output file (default: stdout)")
+}
+
+func runTagsExport(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	tags, err := client.FetchAllTags()
+	if err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	data := tagsFile{Version: tagsFileVersion, ExportedAt: time.Now().UTC(), Tags: tags}
+
+	if tagsExportOutput == "" {
+		return writeTagsFile(os.Stdout, data)
+	}
+	if err := ensureOutputDir(filepath.Dir(tagsExportOutput), false); err != nil {
+		return err
+	}
+	file, err := os.Create(tagsExportOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeTagsFile(file, data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	statusf(os.Stderr, "Exported %d tags to %s\n", len(tags), tagsExportOutput)
+	return nil
+}
+
+func writeTagsFile(w io.Writer, data tagsFile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to write tags: %w", err)
+	}
+	return nil
+}
+
+// readTagsFile reads the tag names from a tags export, dropping blank names
+// and repeats.
+func readTagsFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	var data tagsFile
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if data.Version != tagsFileVersion {
+		return nil, fmt.Errorf("%s is not a tags export (version %q, expected %q)", path, data.Version, tagsFileVersion)
+	}
+
+	seen := make(map[string]bool, len(data.Tags))
+	names := make([]string, 0, len(data.Tags))
+	for _, tag := range data.Tags {
+		name := strings.TrimSpace(tag.Name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func runTagsImport(cmd *cobra.Command, args []string) error {
+	names, err := readTagsFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	existing, err := client.FetchAllTags()
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool, len(existing))
+	for _, tag := range existing {
+		have[strings.ToLower(tag.Name)] = true
+	}
+
+	var result tagsImportResult
+	var missing []string
+	for _, name := range names {
+		if have[strings.ToLower(name)] {
+			result.Skipped++
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	if isDryRun() {
+		requests := make([]plannedRequest, 0, len(missing))
+		for _, name := range missing {
+			requests = append(requests, plannedRequest{Method: "POST", Path: "/api/tags/", Body: map[string]string{"name": name}})
+		}
+		if err := reportDryRun(requests...); err != nil {
+			return err
+		}
+		if !structuredOutput() && result.Skipped > 0 {
+			statusf(os.Stdout, "  ⊘ %d tags already exist and would be skipped\n", result.Skipped)
+		}
+		return nil
+	}
+
+	for _, name := range missing {
+		_, err := client.CreateTag(name)
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.Is(err, api.ErrTagExists):
+			// Created since the existing tags were read
+			result.Skipped++
+		default:
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
+			if !structuredOutput() {
+				failf(os.Stderr, "✗ %s: %v\n", name, err)
+			}
+		}
+	}
+
+	if structuredOutput() {
+		if err := writeJSON(result); err != nil {
+			return err
+		}
+	} else {
+		statusf(os.Stdout, "✓ Created %d tags\n", result.Created)
+		if result.Skipped > 0 {
+			statusf(os.Stdout, "  ⊘ %d already existed\n", result.Skipped)
+		}
+		if result.Failed > 0 {
+			statusf(os.Stdout, "  ✗ %d failed\n", result.Failed)
+		}
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d tags could not be created", result.Failed, len(missing))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// templateFuncs are the functions available to --template, beyond the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"join": func(elems []string, sep string) string { return strings.Join(elems, sep) },
+}
+
+// parseBookmarkTemplate parses the --template text, or the contents of
+// --template-file, so that syntax errors are reported before any request
+// is sent. It returns nil when neither is set.
+func parseBookmarkTemplate(text, file string) (*template.Template, error) {
+	if text != "" && file != "" {
+		return nil, fmt.Errorf("--template and --template-file cannot be combined")
+	}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		return nil, nil
+	}
+	if structuredOutput() {
+		return nil, fmt.Errorf("--template cannot be combined with --json or --select")
+	}
+
+	tmpl, err := template.New("bookmark").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// executeBookmarkTemplate renders tmpl once per bookmark, each followed by
+// a newline. Fields that a bookmark does not have only fail here, when the
+// template is run.
+func executeBookmarkTemplate(w io.Writer, tmpl *template.Template, bookmarks []models.Bookmark) error {
+	for i := range bookmarks {
+		if err := tmpl.Execute(w, &bookmarks[i]); err != nil {
+			return fmt.Errorf("failed to render template for bookmark %d: %w", bookmarks[i].ID, err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	// Embed the zone database so --timezone works on systems without one
+	_ "time/tzdata"
+)
+
+// displayZone is the time zone for dates in human output, set from
+// --timezone. Nil means the local zone, which Go derives from TZ.
+var displayZone *time.Location
+
+// resolveTimezone loads the zone named by --timezone, or returns nil for the
+// local zone when name is empty.
+func resolveTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone %q: use an IANA name such as Europe/Berlin or UTC", name)
+	}
+	return loc, nil
+}
+
+// displayLocation returns the zone dates are shown in.
+func displayLocation() *time.Location {
+	if displayZone != nil {
+		return displayZone
+	}
+	return time.Local
+}
+
+// displayTime converts t to the display zone. JSON output keeps the API's
+// timestamps unchanged and does not use this.
+func displayTime(t time.Time) time.Time {
+	return t.In(displayLocation())
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// flagTokenSource describes where flagToken came from, for config show:
+// "--token flag", "--token-file" or "stdin".
+var flagTokenSource string
+
+// resolveFlagToken replaces flagToken with the token it refers to. The token
+// is read from --token-file, from a file with --token @path, or from stdin
+// with --token -. The token itself is never printed.
+func resolveFlagToken() error {
+	flagTokenSource = ""
+	if tokenFile != "" && flagToken != "" {
+		return fmt.Errorf("--token and --token-file cannot be combined")
+	}
+
+	switch {
+	case tokenFile != "":
+		token, err := readTokenFile("--token-file", tokenFile)
+		if err != nil {
+			return err
+		}
+		flagToken, flagTokenSource = token, "--token-file"
+	case flagToken == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return fmt.Errorf("no token on stdin (--token -)")
+		}
+		flagToken, flagTokenSource = token, "stdin"
+	case strings.HasPrefix(flagToken, "@"):
+		token, err := readTokenFile("--token", strings.TrimPrefix(flagToken, "@"))
+		if err != nil {
+			return err
+		}
+		flagToken, flagTokenSource = token, "--token-file"
+	case flagToken != "":
+		flagTokenSource = "--token flag"
+	}
+	return nil
+}
+
+// readTokenFile reads a token from path, trimming surrounding whitespace.
+func readTokenFile(flag, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file for %s: %w", flag, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file for %s is empty", flag)
+	}
+	return token, nil
+}
@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
-	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/models"
 	"github.com/spf13/cobra"
 )
@@ -32,8 +32,9 @@ Examples:
   linkdingctl update 123 --add-tags "reviewed"
   linkdingctl update 123 --title "New Title" --archive
   linkdingctl update 123 --remove-tags "outdated" --add-tags "current"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUpdate,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBookmarkID,
+	RunE:              runUpdate,
 }
 
 func init() {
@@ -43,14 +44,15 @@ func init() {
 	updateCmd.Flags().StringVarP(&updateDescription, "description", "d", "", "New description")
 	updateCmd.Flags().StringVarP(&updateNotes, "notes", "n", "", "New notes")
 	updateCmd.Flags().StringSliceVarP(&updateTags, "tags", "T", nil, "Replace tags (comma-separated)")
+	_ = updateCmd.RegisterFlagCompletionFunc("tags", completeTagNames)
 	updateCmd.Flags().StringSliceVar(&updateAddTags, "add-tags", nil, "Add tags to existing (comma-separated)")
 	updateCmd.Flags().StringSliceVar(&updateRemoveTags, "remove-tags", nil, "Remove specific tags (comma-separated)")
 	updateCmd.Flags().BoolVarP(&updateArchive, "archive", "a", false, "Archive the bookmark")
 	updateCmd.Flags().BoolVar(&updateUnarchive, "unarchive", false, "Unarchive the bookmark")
 
 	// Create bool pointers for flags that need to detect if they were set
-	updateCmd.Flags().BoolP("unread", "u", false, "Set unread status")
-	updateCmd.Flags().BoolP("shared", "s", false, "Set shared status")
+	updateCmd.Flags().BoolP("unread", "u", false, "Set unread status (--unread=false to mark as read)")
+	updateCmd.Flags().BoolP("shared", "s", false, "Set shared status (--shared=false to unshare)")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
@@ -76,7 +78,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create API client
-	client := api.NewClient(cfg.URL, cfg.Token)
+	client := newClient(cfg)
 
 	// Build update request
 	update := &models.BookmarkUpdate{}
@@ -147,6 +149,10 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		update.TagNames = &newTags
 	}
 
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "PATCH", Path: fmt.Sprintf("/api/bookmarks/%d/", id), Body: update})
+	}
+
 	// Perform update
 	bookmark, err := client.UpdateBookmark(id, update)
 	if err != nil {
@@ -154,11 +160,14 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output based on format
-	if jsonOutput {
+	if structuredOutput() {
 		return outputBookmarkJSON(bookmark)
 	}
+	if isQuiet() {
+		return nil
+	}
 
-	fmt.Printf("✓ Bookmark updated: %s\n", bookmark.Title)
+	statusf(os.Stdout, "✓ Bookmark updated: %s\n", bookmark.Title)
 	fmt.Printf("  ID: %d\n", bookmark.ID)
 	fmt.Printf("  URL: %s\n", bookmark.URL)
 	if len(bookmark.TagNames) > 0 {
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
-	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
 	"github.com/spf13/cobra"
 )
 
@@ -25,7 +27,7 @@ var userProfileCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewClient(cfg.URL, cfg.Token)
+		client := newClient(cfg)
 		profile, err := client.GetUserProfile()
 		if err != nil {
 			if jsonOutput {
@@ -39,38 +41,181 @@ var userProfileCmd = &cobra.Command{
 			return fmt.Errorf("failed to retrieve user profile: %w", err)
 		}
 
-		if jsonOutput {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			return enc.Encode(profile)
+		if structuredOutput() {
+			return writeJSON(profile)
 		}
 
-		// Helper function to convert bool to enabled/disabled
-		boolToStatus := func(b bool) string {
-			if b {
-				return "enabled"
-			}
-			return "disabled"
-		}
-
-		fmt.Printf("Theme:                  %s\n", profile.Theme)
-		fmt.Printf("Bookmark Date Display:  %s\n", profile.BookmarkDateDisplay)
-		fmt.Printf("Bookmark Link Target:   %s\n", profile.BookmarkLinkTarget)
-		fmt.Printf("Web Archive:            %s\n", profile.WebArchiveIntegration)
-		fmt.Printf("Tag Search:             %s\n", profile.TagSearch)
-		fmt.Printf("Sharing:                %s\n", boolToStatus(profile.EnableSharing))
-		fmt.Printf("Public Sharing:         %s\n", boolToStatus(profile.EnablePublicSharing))
-		fmt.Printf("Favicons:               %s\n", boolToStatus(profile.EnableFavicons))
-		fmt.Printf("Display URL:            %s\n", boolToStatus(profile.DisplayURL))
-		fmt.Printf("Permanent Notes:        %s\n", boolToStatus(profile.PermanentNotes))
-		fmt.Printf("Search Sort:            %s\n", profile.SearchPreferences.Sort)
-		fmt.Printf("Search Shared:          %s\n", profile.SearchPreferences.Shared)
-		fmt.Printf("Search Unread:          %s\n", profile.SearchPreferences.Unread)
+		printUserProfile(profile)
 		return nil
 	},
 }
 
+var userProfileSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Update user profile preferences",
+	Long: `Update profile preferences. Only the flags given are sent; everything else
+is left as it is.
+
+Boolean settings take true or false, as in --enable-favicons=false.
+
+Examples:
+  linkdingctl user profile set --theme dark
+  linkdingctl user profile set --bookmark-date-display absolute --enable-favicons
+  linkdingctl user profile set --tag-search strict --display-url=false --json`,
+	Args: cobra.NoArgs,
+	RunE: runUserProfileSet,
+}
+
+var (
+	profileTheme                 string
+	profileBookmarkDateDisplay   string
+	profileBookmarkLinkTarget    string
+	profileWebArchiveIntegration string
+	profileTagSearch             string
+	profileEnableSharing         bool
+	profileEnablePublicSharing   bool
+	profileEnableFavicons        bool
+	profileDisplayURL            bool
+	profilePermanentNotes        bool
+)
+
+// profileChoices lists the accepted values of each string preference flag.
+var profileChoices = map[string][]string{
+	"theme":                   {"auto", "light", "dark"},
+	"bookmark-date-display":   {"relative", "absolute", "hidden"},
+	"bookmark-link-target":    {"_blank", "_self"},
+	"web-archive-integration": {"disabled", "enabled"},
+	"tag-search":              {"strict", "lax"},
+}
+
 func init() {
 	rootCmd.AddCommand(userCmd)
 	userCmd.AddCommand(userProfileCmd)
+	userProfileCmd.AddCommand(userProfileSetCmd)
+
+	flags := userProfileSetCmd.Flags()
+	flags.StringVar(&profileTheme, "theme", "", "Theme: auto, light or dark")
+	flags.StringVar(&profileBookmarkDateDisplay, "bookmark-date-display", "", "Bookmark dates: relative, absolute or hidden")
+	flags.StringVar(&profileBookmarkLinkTarget, "bookmark-link-target", "", "Where bookmark links open: _blank or _self")
+	flags.StringVar(&profileWebArchiveIntegration, "web-archive-integration", "", "Internet Archive snapshots: enabled or disabled")
+	flags.StringVar(&profileTagSearch, "tag-search", "", "Tag search mode: strict or lax")
+	flags.BoolVar(&profileEnableSharing, "enable-sharing", false, "Allow sharing bookmarks with other users")
+	flags.BoolVar(&profileEnablePublicSharing, "enable-public-sharing", false, "Allow sharing bookmarks publicly")
+	flags.BoolVar(&profileEnableFavicons, "enable-favicons", false, "Show favicons")
+	flags.BoolVar(&profileDisplayURL, "display-url", false, "Show bookmark URLs")
+	flags.BoolVar(&profilePermanentNotes, "permanent-notes", false, "Always show notes")
+	for name, choices := range profileChoices {
+		_ = userProfileSetCmd.RegisterFlagCompletionFunc(name, cobra.FixedCompletions(choices, cobra.ShellCompDirectiveNoFileComp))
+	}
+}
+
+func runUserProfileSet(cmd *cobra.Command, args []string) error {
+	// Build update request with only specified fields (PATCH semantics),
+	// validating choices before anything is sent
+	update := &models.UserProfileUpdate{}
+	hasUpdates := false
+
+	choiceFlags := []struct {
+		flag  string
+		value *string
+		field **string
+	}{
+		{"theme", &profileTheme, &update.Theme},
+		{"bookmark-date-display", &profileBookmarkDateDisplay, &update.BookmarkDateDisplay},
+		{"bookmark-link-target", &profileBookmarkLinkTarget, &update.BookmarkLinkTarget},
+		{"web-archive-integration", &profileWebArchiveIntegration, &update.WebArchiveIntegration},
+		{"tag-search", &profileTagSearch, &update.TagSearch},
+	}
+	for _, f := range choiceFlags {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		if !slices.Contains(profileChoices[f.flag], *f.value) {
+			return fmt.Errorf("invalid --%s %q: must be one of %s", f.flag, *f.value, joinChoices(profileChoices[f.flag]))
+		}
+		*f.field = f.value
+		hasUpdates = true
+	}
+
+	boolFlags := []struct {
+		flag  string
+		value *bool
+		field **bool
+	}{
+		{"enable-sharing", &profileEnableSharing, &update.EnableSharing},
+		{"enable-public-sharing", &profileEnablePublicSharing, &update.EnablePublicSharing},
+		{"enable-favicons", &profileEnableFavicons, &update.EnableFavicons},
+		{"display-url", &profileDisplayURL, &update.DisplayURL},
+		{"permanent-notes", &profilePermanentNotes, &update.PermanentNotes},
+	}
+	for _, f := range boolFlags {
+		if cmd.Flags().Changed(f.flag) {
+			*f.field = f.value
+			hasUpdates = true
+		}
+	}
+
+	if !hasUpdates {
+		return fmt.Errorf("no preferences to update (see 'linkdingctl user profile set --help')")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	if isDryRun() {
+		return reportDryRun(plannedRequest{Method: "PATCH", Path: "/api/user/profile/", Body: update})
+	}
+
+	client := newClient(cfg)
+	profile, err := client.UpdateUserProfile(update)
+	if err != nil {
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+
+	if structuredOutput() {
+		return writeJSON(profile)
+	}
+
+	if isQuiet() {
+		return nil
+	}
+	statusf(os.Stdout, "✓ User profile updated\n")
+	printUserProfile(profile)
+	return nil
+}
+
+// joinChoices formats accepted values as "a, b or c".
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+}
+
+// printUserProfile prints the profile preferences one per line.
+func printUserProfile(profile *models.UserProfile) {
+	// Helper function to convert bool to enabled/disabled
+	boolToStatus := func(b bool) string {
+		if b {
+			return "enabled"
+		}
+		return "disabled"
+	}
+
+	fmt.Printf("Theme:                  %s\n", profile.Theme)
+	fmt.Printf("Bookmark Date Display:  %s\n", profile.BookmarkDateDisplay)
+	fmt.Printf("Bookmark Link Target:   %s\n", profile.BookmarkLinkTarget)
+	fmt.Printf("Web Archive:            %s\n", profile.WebArchiveIntegration)
+	fmt.Printf("Tag Search:             %s\n", profile.TagSearch)
+	fmt.Printf("Sharing:                %s\n", boolToStatus(profile.EnableSharing))
+	fmt.Printf("Public Sharing:         %s\n", boolToStatus(profile.EnablePublicSharing))
+	fmt.Printf("Favicons:               %s\n", boolToStatus(profile.EnableFavicons))
+	fmt.Printf("Display URL:            %s\n", boolToStatus(profile.DisplayURL))
+	fmt.Printf("Permanent Notes:        %s\n", boolToStatus(profile.PermanentNotes))
+	fmt.Printf("Search Sort:            %s\n", profile.SearchPreferences.Sort)
+	fmt.Printf("Search Shared:          %s\n", profile.SearchPreferences.Shared)
+	fmt.Printf("Search Unread:          %s\n", profile.SearchPreferences.Unread)
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print bookmarks as they are added",
+	Long: `Poll LinkDing every --interval and print each bookmark added since the
+last poll, oldest first. Bookmarks that already exist when watch starts are
+not shown. Press Ctrl+C to stop.
+
+New bookmarks are recognized by their ID being higher than any seen
+before, so bookmarks added while archived are not reported. A failed poll
+is reported and watching continues.
+
+--exec runs a shell command for each new bookmark, with the bookmark in the
+environment as LINKDING_BOOKMARK_ID, LINKDING_BOOKMARK_URL,
+LINKDING_BOOKMARK_TITLE and LINKDING_BOOKMARK_TAGS (comma-separated); under
+sh the URL and title are also $1 and $2. LINKDING_URL and LINKDING_TOKEN
+keep pointing at your LinkDing server, so the command can run linkdingctl.
+A command that fails is reported and does not stop watching.
+
+With --json each new bookmark is printed as its own JSON document.
+
+Examples:
+  linkdingctl watch
+  linkdingctl watch --interval 5m
+  linkdingctl watch --exec 'notify-send "New bookmark" "$2"'
+  linkdingctl watch --select .url`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+var (
+	watchInterval time.Duration
+	watchExec     string
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "Time between polls")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "Shell command to run for each new bookmark")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be greater than zero")
+	}
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Create API client
+	client := newClient(cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The first poll only finds where to start
+	newest, err := newestBookmarkID(client)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	if !structuredOutput() {
+		statusf(os.Stderr, "Watching for new bookmarks every %s (Ctrl+C to stop)\n", watchInterval)
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		added, err := bookmarksAfter(client, newest)
+		if err != nil {
+			failf(os.Stderr, "✗ Poll failed: %v\n", err)
+			continue
+		}
+		for _, b := range added {
+			if err := reportNewBookmark(b); err != nil {
+				return err
+			}
+			newest = max(newest, b.ID)
+		}
+	}
+}
+
+// newestBookmarkID returns the highest bookmark ID on the first page, which
+// holds the most recently added bookmarks, or 0 when there are none.
+func newestBookmarkID(client *api.Client) (int, error) {
+	bookmarkList, err := client.GetBookmarks("", nil, nil, nil, api.DefaultPageSize, 0)
+	if err != nil {
+		return 0, err
+	}
+	newest := 0
+	for _, b := range bookmarkList.Results {
+		newest = max(newest, b.ID)
+	}
+	return newest, nil
+}
+
+// bookmarksAfter returns the bookmarks with an ID above id, oldest first.
+// Bookmarks come newest first, so pages are read only until one holds a
+// bookmark that was already seen.
+func bookmarksAfter(client *api.Client, id int) ([]models.Bookmark, error) {
+	var added []models.Bookmark
+	pages := client.BookmarkPages("", nil, nil, nil)
+	for {
+		bookmarks, ok, err := pages.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		seen := false
+		for _, b := range bookmarks {
+			if b.ID > id {
+				added = append(added, b)
+			} else {
+				seen = true
+			}
+		}
+		if seen {
+			break
+		}
+	}
+
+	for i, j := 0, len(added)-1; i < j; i, j = i+1, j-1 {
+		added[i], added[j] = added[j], added[i]
+	}
+	return added, nil
+}
+
+// reportNewBookmark prints a new bookmark and runs --exec for it.
+func reportNewBookmark(b models.Bookmark) error {
+	if structuredOutput() {
+		if err := writeJSON(b); err != nil {
+			return err
+		}
+	} else {
+		title := b.Title
+		if title == "" {
+			title = b.URL
+		}
+		fmt.Printf("%s  %d  %s\n    %s\n", displayTime(b.DateAdded).Format("2006-01-02 15:04"), b.ID, title, b.URL)
+	}
+
+	if watchExec == "" {
+		return nil
+	}
+	if err := watchCommand(b).Run(); err != nil {
+		failf(os.Stderr, "✗ --exec failed for bookmark %d: %v\n", b.ID, err)
+	}
+	return nil
+}
+
+// watchCommand builds the --exec command for a bookmark. Its output goes
+// to watch's own stdout and stderr. The bookmark variables carry a
+// LINKDING_BOOKMARK_ prefix so they never replace the LINKDING_URL the
+// command's own linkdingctl calls would send the token to.
+func watchCommand(b models.Bookmark) *exec.Cmd {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", watchExec)
+	} else {
+		c = exec.Command("sh", "-c", watchExec, "sh", b.URL, b.Title)
+	}
+	c.Env = append(os.Environ(),
+		fmt.Sprintf("LINKDING_BOOKMARK_ID=%d", b.ID),
+		"LINKDING_BOOKMARK_URL="+b.URL,
+		"LINKDING_BOOKMARK_TITLE="+b.Title,
+		"LINKDING_BOOKMARK_TAGS="+strings.Join(b.TagNames, ","),
+	)
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	return c
+}
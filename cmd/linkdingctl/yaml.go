@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output-format.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// resolveOutputFormat applies --output-format, which --json abbreviates, and
+// reports whether YAML was chosen. Since table is the default, --json wins
+// over it.
+func resolveOutputFormat(format string, jsonFlag bool) (bool, error) {
+	switch format {
+	case "", formatTable:
+		return false, nil
+	case formatJSON:
+		jsonOutput = true
+		return false, nil
+	case formatYAML:
+		if jsonFlag {
+			return false, fmt.Errorf("--json cannot be combined with --output-format yaml")
+		}
+		if selectExpr != "" {
+			return false, fmt.Errorf("--select cannot be combined with --output-format yaml")
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --output-format '%s'. Valid values: table, json, yaml", format)
+	}
+}
+
+// writeYAML writes v as YAML with the same keys, in the same order, as its
+// JSON output. It goes through JSON so json tags and custom marshalers apply.
+func writeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// JSON is valid YAML; parsing it into a node keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle clears the flow and quoting styles a node parsed from JSON
+// carries, so it is written as ordinary block YAML. Strings that need quotes
+// to keep their type are still quoted by the encoder.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// BookmarkResult is the outcome of one request of a batch: the bookmark the
+// server returned, or the error.
+type BookmarkResult struct {
+	Bookmark *models.Bookmark
+	Err      error
+}
+
+// BookmarkPatch is one update sent by UpdateBookmarks.
+type BookmarkPatch struct {
+	ID     int
+	Update *models.BookmarkUpdate
+}
+
+// CreateBookmarks creates bookmarks with up to concurrency requests in
+// flight. LinkDing has no endpoint that creates several bookmarks at once,
+// so each is still a request of its own, retried and rate limited like
+// CreateBookmark. The result at index i is the outcome of creates[i]; a
+// failed creation does not stop the others.
+func (c *Client) CreateBookmarks(creates []*models.BookmarkCreate, concurrency int) []BookmarkResult {
+	results := make([]BookmarkResult, len(creates))
+	forEachConcurrently(len(creates), concurrency, func(i int) {
+		results[i].Bookmark, results[i].Err = c.CreateBookmark(creates[i])
+	})
+	return results
+}
+
+// UpdateBookmarks is CreateBookmarks for updates: the result at index i is
+// the outcome of patches[i].
+func (c *Client) UpdateBookmarks(patches []BookmarkPatch, concurrency int) []BookmarkResult {
+	results := make([]BookmarkResult, len(patches))
+	forEachConcurrently(len(patches), concurrency, func(i int) {
+		results[i].Bookmark, results[i].Err = c.UpdateBookmark(patches[i].ID, patches[i].Update)
+	})
+	return results
+}
+
+// forEachConcurrently calls fn for every index below n, at most concurrency
+// at a time (at least one), and returns once every call has returned.
+func forEachConcurrently(n, concurrency int, fn func(i int)) {
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(concurrency, 1), n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
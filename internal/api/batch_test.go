@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestCreateBookmarks(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		// Later requests answer first, so results arrive out of order
+		var n int
+		_, _ = fmt.Sscanf(body.URL, "https://example.com/%d", &n)
+		time.Sleep(time.Duration(10-n) * time.Millisecond)
+		if strings.HasSuffix(body.URL, "/3") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"detail": "not allowed"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 100 + n, URL: body.URL})
+	}))
+	defer server.Close()
+
+	var creates []*models.BookmarkCreate
+	for i := 0; i < 6; i++ {
+		creates = append(creates, &models.BookmarkCreate{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+
+	client := NewClient(server.URL, "test-token")
+	results := client.CreateBookmarks(creates, 3)
+	if len(results) != len(creates) {
+		t.Fatalf("Expected %d results, got %d", len(creates), len(results))
+	}
+	for i, result := range results {
+		if i == 3 {
+			if result.Err == nil || result.Bookmark != nil {
+				t.Errorf("Expected only an error at index 3, got %+v", result)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("Expected index %d to succeed, got %v", i, result.Err)
+		} else if result.Bookmark.ID != 100+i {
+			t.Errorf("Expected bookmark %d at index %d, got %d", 100+i, i, result.Bookmark.ID)
+		}
+	}
+	if peak < 2 || peak > 3 {
+		t.Errorf("Expected between 2 and 3 requests in flight, got %d", peak)
+	}
+}
+
+func TestUpdateBookmarks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/bookmarks/2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var id int
+		_, _ = fmt.Sscanf(r.URL.Path, "/api/bookmarks/%d/", &id)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: id})
+	}))
+	defer server.Close()
+
+	title := "New"
+	update := &models.BookmarkUpdate{Title: &title}
+	client := NewClient(server.URL, "test-token")
+	results := client.UpdateBookmarks([]BookmarkPatch{{ID: 1, Update: update}, {ID: 2, Update: update}, {ID: 3, Update: update}}, 0)
+	if len(results) != 3 || results[0].Err != nil || results[1].Err == nil || results[2].Err != nil {
+		t.Fatalf("Expected only index 1 to fail, got %+v", results)
+	}
+	if results[0].Bookmark.ID != 1 || results[2].Bookmark.ID != 3 {
+		t.Errorf("Expected results aligned with the patches, got %+v", results)
+	}
+}
+
+func TestCreateBookmarks_Empty(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1", "test-token")
+	if results := client.CreateBookmarks(nil, 4); len(results) != 0 {
+		t.Errorf("Expected no results, got %+v", results)
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// CacheOptions enables conditional requests for GETs. Responses carrying an
+// ETag or Last-Modified header are stored in Dir; later requests for the same
+// URL send If-None-Match/If-Modified-Since and reuse the stored body when the
+// server answers 304 Not Modified.
+type CacheOptions struct {
+	// Dir holds one file per cached URL. It is created (mode 0700) when
+	// the first response is stored.
+	Dir string
+	// TTL is how long a stored response may be revalidated; older entries
+	// are fetched again in full. Zero disables the cache.
+	TTL time.Duration
+}
+
+// responseCache stores GET responses on disk, keyed on the request URL and
+// the token so profiles sharing a cache directory never see each other's
+// data.
+type responseCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is the file stored for one URL.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+	Stored       time.Time `json:"stored"`
+	Body         []byte    `json:"body"`
+}
+
+// newResponseCache returns a cache for the options, or nil when caching is
+// disabled.
+func newResponseCache(options CacheOptions) *responseCache {
+	if options.TTL <= 0 || options.Dir == "" {
+		return nil
+	}
+	return &responseCache{dir: options.Dir, ttl: options.TTL, now: time.Now}
+}
+
+func (c *responseCache) path(url, token string) string {
+	sum := sha256.Sum256([]byte(url + "\n" + token))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// lookup returns the stored entry for url if it is younger than the TTL.
+// A missing or unreadable entry is a miss.
+func (c *responseCache) lookup(url, token string) *cacheEntry {
+	data, err := os.ReadFile(c.path(url, token))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return nil
+	}
+	if c.now().Sub(entry.Stored) > c.ttl {
+		return nil
+	}
+	return &entry
+}
+
+// addConditions asks the server to answer 304 if entry is still current.
+func (entry *cacheEntry) addConditions(req *http.Request) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// resolve turns a 304 for a cached entry into a 200 carrying the stored
+// body, and stores a 200 that the server marked with validators. Other
+// responses pass through unchanged.
+func (c *responseCache) resolve(url, token string, entry *cacheEntry, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		entry.Stored = c.now()
+		_ = c.store(token, entry)
+
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Header.Set("Content-Type", entry.ContentType)
+		resp.Header.Set("Content-Length", strconv.Itoa(len(entry.Body)))
+		resp.ContentLength = int64(len(entry.Body))
+		resp.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	// A cache that cannot be written only costs the next request
+	_ = c.store(token, &cacheEntry{
+		URL:          url,
+		ETag:         etag,
+		LastModified: lastModified,
+		ContentType:  resp.Header.Get("Content-Type"),
+		Stored:       c.now(),
+		Body:         body,
+	})
+	return resp, nil
+}
+
+// store writes entry through a temporary file so a concurrent reader never
+// sees half of it.
+func (c *responseCache) store(token string, entry *cacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.URL, token))
+}
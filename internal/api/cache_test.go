@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newETagServer serves one page of tags with an ETag and answers 304 when
+// the client already has it. It counts full responses.
+func newETagServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var full atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count": 1, "results": [{"id": 1, "name": "go"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &full
+}
+
+func TestCache_ReusesBodyOnNotModified(t *testing.T) {
+	server, full := newETagServer(t)
+	dir := t.TempDir()
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Cache: CacheOptions{Dir: dir, TTL: time.Hour}})
+
+	for i := 0; i < 3; i++ {
+		tags, err := client.GetTags(0, 0)
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i+1, err)
+		}
+		if len(tags.Results) != 1 || tags.Results[0].Name != "go" {
+			t.Fatalf("request %d: unexpected tags %+v", i+1, tags.Results)
+		}
+	}
+	if got := full.Load(); got != 1 {
+		t.Errorf("expected 1 full response and 304s after it, got %d full responses", got)
+	}
+
+	// A new client, as in a later run, reuses the entry on disk
+	client = NewClientWithOptions(server.URL, "test-token", ClientOptions{Cache: CacheOptions{Dir: dir, TTL: time.Hour}})
+	if _, err := client.GetTags(0, 0); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if got := full.Load(); got != 1 {
+		t.Errorf("expected the cache to survive a new client, got %d full responses", got)
+	}
+}
+
+func TestCache_KeyedOnToken(t *testing.T) {
+	server, full := newETagServer(t)
+	dir := t.TempDir()
+	options := ClientOptions{Cache: CacheOptions{Dir: dir, TTL: time.Hour}}
+
+	if _, err := NewClientWithOptions(server.URL, "token-a", options).GetTags(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClientWithOptions(server.URL, "token-b", options).GetTags(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := full.Load(); got != 2 {
+		t.Errorf("expected each token to get its own entry, got %d full responses", got)
+	}
+}
+
+func TestCache_ExpiredEntryIsRefetched(t *testing.T) {
+	server, full := newETagServer(t)
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Cache: CacheOptions{Dir: t.TempDir(), TTL: time.Minute}})
+	clock := time.Now()
+	client.cache.now = func() time.Time { return clock }
+
+	if _, err := client.GetTags(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	clock = clock.Add(2 * time.Minute)
+	if _, err := client.GetTags(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := full.Load(); got != 2 {
+		t.Errorf("expected an expired entry to be fetched again, got %d full responses", got)
+	}
+}
+
+func TestCache_DisabledByDefault(t *testing.T) {
+	server, full := newETagServer(t)
+	dir := t.TempDir()
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Cache: CacheOptions{Dir: dir}})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTags(0, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := full.Load(); got != 2 {
+		t.Errorf("expected no caching without a TTL, got %d full responses", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing written to the cache dir, got %d entries", len(entries))
+	}
+}
@@ -3,9 +3,12 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"strings"
@@ -19,44 +22,203 @@ type Client struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+	retry      RetryPolicy
+	headers    http.Header
+	timer      *RequestTimer
+	limiter    *rateLimiter
+	parallel   int
+	cache      *responseCache
+	progress   func(fetched, total int)
+	sleep      func(time.Duration)
+	now        func() time.Time
+}
+
+// DefaultTimeout is how long a single request may take when
+// ClientOptions.Timeout is not set.
+const DefaultTimeout = 30 * time.Second
+
+// ClientOptions configures optional client behavior.
+type ClientOptions struct {
+	Retry RetryPolicy
+	// Headers are added to every request, e.g. for an authenticating proxy.
+	// An Authorization entry replaces the token header.
+	Headers http.Header
+	// Timer, if set, records the time spent in every request.
+	Timer *RequestTimer
+	// Timeout limits each request, including reading its response body;
+	// paginated fetches apply it to every page separately. Zero means
+	// DefaultTimeout.
+	Timeout time.Duration
+	// Transport tunes protocol selection and connection reuse.
+	Transport TransportOptions
+	// RateLimit caps the requests sent per second, retries included, across
+	// every goroutine using the client. Zero means no limit.
+	RateLimit float64
+	// Parallel is the number of pages FetchAllBookmarks requests at once
+	// after the first; 0 or 1 fetches them one after another.
+	Parallel int
+	// Cache, if its TTL is set, revalidates GET responses with the server
+	// instead of downloading unchanged data again.
+	Cache CacheOptions
+	// Progress, if set, is called as FetchAllBookmarks and its variants
+	// read each page, with the bookmarks fetched so far and the total the
+	// server reported. With Parallel it may be called from several
+	// goroutines, one call at a time.
+	Progress func(fetched, total int)
 }
 
 // NewClient creates a new LinkDing API client.
 func NewClient(baseURL, token string) *Client {
+	return NewClientWithOptions(baseURL, token, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new LinkDing API client with the given options.
+func NewClientWithOptions(baseURL, token string, options ClientOptions) *Client {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
 	return &Client{
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		token:      token,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Timeout: timeout, Transport: newTransport(options.Transport)},
+		retry:      options.Retry,
+		headers:    options.Headers,
+		timer:      options.Timer,
+		limiter:    newRateLimiter(options.RateLimit),
+		parallel:   options.Parallel,
+		cache:      newResponseCache(options.Cache),
+		progress:   options.Progress,
+		sleep:      time.Sleep,
+		now:        time.Now,
 	}
 }
 
-// doRequest performs an HTTP request with authentication headers.
+// doRequest performs an HTTP request with authentication headers, retrying
+// according to the client's retry policy.
 func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
-	var bodyReader io.Reader
+	return c.doRequestContext(context.Background(), method, path, body)
+}
+
+// doRequestContext is doRequest for a request that is abandoned, without
+// further retries, once ctx is cancelled.
+func (c *Client) doRequestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, c.baseURL+path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	var cached *cacheEntry
+	cacheable := c.cache != nil && method == http.MethodGet
+	if cacheable {
+		cached = c.cache.lookup(c.baseURL+path, c.token)
 	}
 
-	req.Header.Set("Authorization", "Token "+c.token)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	first := c.now()
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(jsonBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Token "+c.token)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for name, values := range c.headers {
+			req.Header[name] = values
+		}
+		if cached != nil {
+			cached.addConditions(req)
+		}
+
+		if c.limiter != nil {
+			c.limiter.wait()
+		}
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if c.timer != nil {
+			if err != nil {
+				c.timer.record(time.Since(start))
+			} else {
+				resp.Body = &timedBody{ReadCloser: resp.Body, timer: c.timer, start: start}
+			}
+		}
+		retry := c.retry.shouldRetry(method, resp, err, attempt) && ctx.Err() == nil
+		var wait time.Duration
+		if retry {
+			now := c.now()
+			wait = c.retry.delay(resp, now, attempt)
+			// Give up rather than wait past the overall deadline
+			if c.retry.Deadline > 0 && now.Add(wait).Sub(first) > c.retry.Deadline {
+				retry = false
+			}
+		}
+		if !retry {
+			if err != nil && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if certErr := certificateError(err); certErr != nil {
+				return nil, fmt.Errorf("cannot verify the TLS certificate of %s: %w", c.baseURL, certErr)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("cannot connect to %s. Is LinkDing running?", c.baseURL)
+			}
+			if cacheable {
+				return c.cache.resolve(c.baseURL+path, c.token, cached, resp)
+			}
+			return resp, nil
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		c.sleep(wait)
+	}
+}
+
+// ParseHeader parses a "Name: Value" header specification.
+func ParseHeader(spec string) (string, string, error) {
+	name, value, ok := strings.Cut(spec, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid header %q: expected 'Name: Value'", spec)
 	}
+	name = strings.TrimSpace(name)
+	value = strings.TrimSpace(value)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("cannot connect to %s. Is LinkDing running?", c.baseURL)
+	if name == "" {
+		return "", "", fmt.Errorf("invalid header %q: empty name", spec)
 	}
+	for _, r := range name {
+		if !isHeaderNameChar(r) {
+			return "", "", fmt.Errorf("invalid header %q: illegal character %q in name", spec, r)
+		}
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return "", "", fmt.Errorf("invalid header %q: value must not contain newlines", spec)
+	}
+
+	return http.CanonicalHeaderKey(name), value, nil
+}
 
-	return resp, nil
+// isHeaderNameChar reports whether r is allowed in an HTTP header name (RFC 7230 tchar).
+func isHeaderNameChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	default:
+		return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+	}
 }
 
 // decodeResponse checks the status code and decodes the JSON response body into dest.
@@ -103,8 +265,58 @@ func (c *Client) TestConnection() error {
 	return nil
 }
 
+// ServerVersion returns the LinkDing version reported by the server's
+// /health endpoint, or "" if the server does not report one.
+func (c *Client) ServerVersion() (string, error) {
+	resp, err := c.doRequest("GET", "/health", nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var health struct {
+		Version string `json:"version"`
+	}
+	if err := c.decodeResponse(resp, http.StatusOK, &health); err != nil {
+		return "", err
+	}
+	return health.Version, nil
+}
+
+// Token scope values reported by CheckTokenScope.
+const (
+	TokenScopeReadWrite = "read-write"
+	TokenScopeReadOnly  = "read-only"
+	TokenScopeUnknown   = "unknown"
+)
+
+// CheckTokenScope infers whether the token may create or modify data.
+// It sends a deliberately invalid bookmark (empty URL) that the server can
+// never accept: a validation error means the write was permitted, while an
+// authentication or permission error means the token is read-only.
+func (c *Client) CheckTokenScope() (string, error) {
+	resp, err := c.doRequest("POST", "/api/bookmarks/", map[string]string{"url": ""})
+	if err != nil {
+		return TokenScopeUnknown, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return TokenScopeReadWrite, nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusMethodNotAllowed:
+		return TokenScopeReadOnly, nil
+	default:
+		return TokenScopeUnknown, nil
+	}
+}
+
 // GetBookmarks retrieves a list of bookmarks with optional filters.
 func (c *Client) GetBookmarks(query string, tags []string, unread, archived *bool, limit, offset int) (*models.BookmarkList, error) {
+	return c.getBookmarks(context.Background(), query, tags, unread, archived, limit, offset)
+}
+
+func (c *Client) getBookmarks(ctx context.Context, query string, tags []string, unread, archived *bool, limit, offset int) (*models.BookmarkList, error) {
 	params := url.Values{}
 	if query != "" {
 		params.Set("q", query)
@@ -130,7 +342,7 @@ func (c *Client) GetBookmarks(query string, tags []string, unread, archived *boo
 		path += "?" + params.Encode()
 	}
 
-	resp, err := c.doRequest("GET", path, nil)
+	resp, err := c.doRequestContext(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +376,88 @@ func (c *Client) GetBookmark(id int) (*models.Bookmark, error) {
 	return &bookmark, nil
 }
 
+// ErrAssetsUnsupported is returned by GetBookmarkAssets when the assets
+// endpoint answers 404: the server predates the assets API, or the bookmark
+// does not exist.
+var ErrAssetsUnsupported = errors.New("server does not provide bookmark assets")
+
+// GetBookmarkAssets lists the assets (web archive snapshots and uploads) of
+// a bookmark.
+func (c *Client) GetBookmarkAssets(bookmarkID int) ([]models.BookmarkAsset, error) {
+	path := fmt.Sprintf("/api/bookmarks/%d/assets/", bookmarkID)
+
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAssetsUnsupported
+	}
+
+	var assets models.BookmarkAssetList
+	if err := c.decodeResponse(resp, http.StatusOK, &assets); err != nil {
+		return nil, err
+	}
+	return assets.Results, nil
+}
+
+// DownloadBookmarkAsset returns the content of a bookmark asset as stored by
+// LinkDing, with the file name the server suggests for it ("" if none).
+func (c *Client) DownloadBookmarkAsset(bookmarkID, assetID int) ([]byte, string, error) {
+	path := fmt.Sprintf("/api/bookmarks/%d/assets/%d/download/", bookmarkID, assetID)
+	return c.download(path, fmt.Sprintf("asset %d of bookmark %d not found", assetID, bookmarkID))
+}
+
+// DownloadFavicon returns the favicon LinkDing cached for a bookmark, given
+// the bookmark's favicon_url. The URL must be on the LinkDing server, so the
+// token is never sent to another host.
+func (c *Client) DownloadFavicon(faviconURL string) ([]byte, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LinkDing URL: %w", err)
+	}
+	ref, err := base.Parse(faviconURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid favicon URL %q: %w", faviconURL, err)
+	}
+	if ref.Host != base.Host {
+		return nil, fmt.Errorf("favicon URL %s is not on the LinkDing server", faviconURL)
+	}
+
+	path := strings.TrimPrefix(ref.RequestURI(), strings.TrimSuffix(base.Path, "/"))
+	content, _, err := c.download(path, fmt.Sprintf("favicon %s not found", faviconURL))
+	return content, err
+}
+
+// download GETs path and returns the body of a 200 response, with the file
+// name from its Content-Disposition header. notFound is the error for a 404.
+func (c *Client) download(path, notFound string) ([]byte, string, error) {
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", errors.New(notFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", c.handleErrorResponse(resp)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read download: %w", err)
+	}
+	var filename string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		filename = params["filename"]
+	}
+	return content, filename, nil
+}
+
 // CreateBookmark creates a new bookmark.
 func (c *Client) CreateBookmark(bookmark *models.BookmarkCreate) (*models.Bookmark, error) {
 	resp, err := c.doRequest("POST", "/api/bookmarks/", bookmark)
@@ -219,12 +513,50 @@ func (c *Client) DeleteBookmark(id int) error {
 	return nil
 }
 
+// PartialFetchError is returned by best-effort fetches when a page fails
+// after earlier pages were retrieved. The bookmarks returned alongside it are
+// the ones fetched before the failure.
+type PartialFetchError struct {
+	Offset  int   // offset of the page that failed
+	Fetched int   // number of items retrieved before the failure
+	Err     error // underlying error
+}
+
+func (e *PartialFetchError) Error() string {
+	return fmt.Sprintf("failed to fetch page at offset %d after retrieving %d bookmarks: %v", e.Offset, e.Fetched, e.Err)
+}
+
+func (e *PartialFetchError) Unwrap() error {
+	return e.Err
+}
+
 // FetchAllBookmarks retrieves all bookmarks, handling pagination automatically.
 // If includeArchived is false, only non-archived bookmarks are fetched.
 func (c *Client) FetchAllBookmarks(tags []string, includeArchived bool) ([]models.Bookmark, error) {
+	return c.fetchAllBookmarks("", tags, includeArchived, false)
+}
+
+// FetchAllBookmarksBestEffort behaves like FetchAllBookmarks, but when a page
+// fails after others succeeded it returns the bookmarks fetched so far together
+// with a *PartialFetchError instead of discarding them.
+func (c *Client) FetchAllBookmarksBestEffort(tags []string, includeArchived bool) ([]models.Bookmark, error) {
+	return c.fetchAllBookmarks("", tags, includeArchived, true)
+}
+
+// FetchMatchingBookmarks behaves like FetchAllBookmarks, but only returns
+// bookmarks that also match the search query.
+func (c *Client) FetchMatchingBookmarks(query string, tags []string, includeArchived bool) ([]models.Bookmark, error) {
+	return c.fetchAllBookmarks(query, tags, includeArchived, false)
+}
+
+// FetchMatchingBookmarksBestEffort is FetchMatchingBookmarks with the partial
+// results of FetchAllBookmarksBestEffort.
+func (c *Client) FetchMatchingBookmarksBestEffort(query string, tags []string, includeArchived bool) ([]models.Bookmark, error) {
+	return c.fetchAllBookmarks(query, tags, includeArchived, true)
+}
+
+func (c *Client) fetchAllBookmarks(query string, tags []string, includeArchived, bestEffort bool) ([]models.Bookmark, error) {
 	var allBookmarks []models.Bookmark
-	limit := 100
-	offset := 0
 
 	var archivedPtr *bool
 	if !includeArchived {
@@ -232,21 +564,53 @@ func (c *Client) FetchAllBookmarks(tags []string, includeArchived bool) ([]model
 		archivedPtr = &archived
 	}
 
+	if c.parallel > 1 {
+		return c.fetchBookmarksParallel(query, tags, archivedPtr, bestEffort)
+	}
+
+	total := 0
+	pages := NewPaginator(func(limit, offset int) ([]models.Bookmark, bool, error) {
+		bookmarkList, err := c.GetBookmarks(query, tags, nil, archivedPtr, limit, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		total = bookmarkList.Count
+		return bookmarkList.Results, bookmarkList.Next != nil, nil
+	})
 	for {
-		bookmarkList, err := c.GetBookmarks("", tags, nil, archivedPtr, limit, offset)
+		offset := pages.Offset()
+		bookmarks, ok, err := pages.Next()
 		if err != nil {
+			if bestEffort && offset > 0 {
+				return allBookmarks, &PartialFetchError{Offset: offset, Fetched: len(allBookmarks), Err: err}
+			}
 			return nil, fmt.Errorf("failed to fetch bookmarks: %w", err)
 		}
-
-		allBookmarks = append(allBookmarks, bookmarkList.Results...)
-
-		if bookmarkList.Next == nil || len(bookmarkList.Results) == 0 {
-			break
+		if !ok {
+			return allBookmarks, nil
 		}
-		offset += limit
+		allBookmarks = append(allBookmarks, bookmarks...)
+		c.reportProgress(len(allBookmarks), total)
 	}
+}
+
+// reportProgress passes fetch progress to ClientOptions.Progress, if set.
+func (c *Client) reportProgress(fetched, total int) {
+	if c.progress != nil {
+		c.progress(fetched, total)
+	}
+}
 
-	return allBookmarks, nil
+// BookmarkPages returns a Paginator over the bookmarks matching the given
+// filters, as accepted by GetBookmarks.
+func (c *Client) BookmarkPages(query string, tags []string, unread, archived *bool) *Paginator[models.Bookmark] {
+	return NewPaginator(func(limit, offset int) ([]models.Bookmark, bool, error) {
+		bookmarkList, err := c.GetBookmarks(query, tags, unread, archived, limit, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		return bookmarkList.Results, bookmarkList.Next != nil, nil
+	})
 }
 
 // GetTags retrieves a list of tags with optional pagination.
@@ -279,27 +643,28 @@ func (c *Client) GetTags(limit, offset int) (*models.TagList, error) {
 
 // FetchAllTags retrieves all tags, handling pagination automatically.
 func (c *Client) FetchAllTags() ([]models.Tag, error) {
-	var allTags []models.Tag
-	limit := 100
-	offset := 0
+	tags, err := c.TagPages().All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+	return tags, nil
+}
 
-	for {
+// TagPages returns a Paginator over all tags.
+func (c *Client) TagPages() *Paginator[models.Tag] {
+	return NewPaginator(func(limit, offset int) ([]models.Tag, bool, error) {
 		tagList, err := c.GetTags(limit, offset)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+			return nil, false, err
 		}
-
-		allTags = append(allTags, tagList.Results...)
-
-		if tagList.Next == nil || len(tagList.Results) == 0 {
-			break
-		}
-		offset += limit
-	}
-
-	return allTags, nil
+		return tagList.Results, tagList.Next != nil, nil
+	})
 }
 
+// ErrTagExists is returned by CreateTag when the server already has a tag
+// with that name.
+var ErrTagExists = errors.New("tag already exists")
+
 // CreateTag creates a new tag with the given name.
 func (c *Client) CreateTag(name string) (*models.Tag, error) {
 	body := map[string]string{"name": name}
@@ -313,7 +678,7 @@ func (c *Client) CreateTag(name string) (*models.Tag, error) {
 	if resp.StatusCode == http.StatusBadRequest {
 		respBody, _ := io.ReadAll(resp.Body)
 		if strings.Contains(string(respBody), "already exists") || strings.Contains(string(respBody), "duplicate") {
-			return nil, fmt.Errorf("tag '%s' already exists", name)
+			return nil, fmt.Errorf("%w: %s", ErrTagExists, name)
 		}
 		return nil, fmt.Errorf("invalid tag name: %s", string(respBody))
 	}
@@ -346,6 +711,31 @@ func (c *Client) GetTag(id int) (*models.Tag, error) {
 	return &tag, nil
 }
 
+// ErrTagDeleteUnsupported is returned by DeleteTag when the server does not
+// allow deleting tag objects. Older LinkDing versions only list and create tags.
+var ErrTagDeleteUnsupported = errors.New("server does not support deleting tags")
+
+// DeleteTag deletes the tag object with the given ID.
+func (c *Client) DeleteTag(id int) error {
+	path := fmt.Sprintf("/api/tags/%d/", id)
+
+	resp, err := c.doRequest("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return ErrTagDeleteUnsupported
+	case http.StatusNotFound:
+		return fmt.Errorf("tag with ID %d not found", id)
+	}
+	return c.handleErrorResponse(resp)
+}
+
 // GetUserProfile retrieves the user profile information.
 func (c *Client) GetUserProfile() (*models.UserProfile, error) {
 	resp, err := c.doRequest("GET", "/api/user/profile/", nil)
@@ -368,6 +758,29 @@ func (c *Client) GetUserProfile() (*models.UserProfile, error) {
 	return &profile, nil
 }
 
+// UpdateUserProfile updates the authenticated user's profile preferences
+// with PATCH semantics and returns the updated profile.
+func (c *Client) UpdateUserProfile(update *models.UserProfileUpdate) (*models.UserProfile, error) {
+	resp, err := c.doRequest("PATCH", "/api/user/profile/", update)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication failed. Check your API token")
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("insufficient permissions for this operation")
+	}
+
+	var profile models.UserProfile
+	if err := c.decodeResponse(resp, http.StatusOK, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
 // GetBundles retrieves a list of bundles with optional pagination.
 func (c *Client) GetBundles(limit, offset int) (*models.BundleList, error) {
 	params := url.Values{}
@@ -398,25 +811,22 @@ func (c *Client) GetBundles(limit, offset int) (*models.BundleList, error) {
 
 // FetchAllBundles retrieves all bundles, handling pagination automatically.
 func (c *Client) FetchAllBundles() ([]models.Bundle, error) {
-	var allBundles []models.Bundle
-	limit := 100
-	offset := 0
+	bundles, err := c.BundlePages().All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bundles: %w", err)
+	}
+	return bundles, nil
+}
 
-	for {
+// BundlePages returns a Paginator over all bundles.
+func (c *Client) BundlePages() *Paginator[models.Bundle] {
+	return NewPaginator(func(limit, offset int) ([]models.Bundle, bool, error) {
 		bundleList, err := c.GetBundles(limit, offset)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch bundles: %w", err)
+			return nil, false, err
 		}
-
-		allBundles = append(allBundles, bundleList.Results...)
-
-		if bundleList.Next == nil || len(bundleList.Results) == 0 {
-			break
-		}
-		offset += limit
-	}
-
-	return allBundles, nil
+		return bundleList.Results, bundleList.Next != nil, nil
+	})
 }
 
 // GetBundle retrieves a single bundle by ID.
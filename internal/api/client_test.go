@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -73,6 +77,25 @@ func TestTestConnection_Unauthorized(t *testing.T) {
 	}
 }
 
+func TestServerVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/links/health" {
+			t.Errorf("expected path '/links/health', got '%s'", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "1.39.1", "status": "healthy"}`))
+	}))
+	defer server.Close()
+
+	version, err := NewClient(server.URL+"/links", "test-token").ServerVersion()
+	if err != nil {
+		t.Fatalf("ServerVersion() failed: %v", err)
+	}
+	if version != "1.39.1" {
+		t.Errorf("expected version '1.39.1', got '%s'", version)
+	}
+}
+
 func TestGetBookmarks_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/bookmarks/" {
@@ -363,6 +386,9 @@ func TestCreateTag_Duplicate(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for duplicate tag, got nil")
 	}
+	if !errors.Is(err, ErrTagExists) {
+		t.Errorf("expected ErrTagExists, got %v", err)
+	}
 }
 
 // TestGetTag_Success tests getting a tag by ID
@@ -534,6 +560,139 @@ func TestGetUserProfile_Forbidden(t *testing.T) {
 	}
 }
 
+// TestBookmarkAssets tests listing and downloading bookmark assets
+func TestBookmarkAssets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/bookmarks/1/assets/":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"count": 1,
+				"results": []map[string]interface{}{
+					{"id": 5, "bookmark": 1, "asset_type": "snapshot", "status": "complete", "content_type": "text/html"},
+				},
+			})
+		case "/api/bookmarks/1/assets/5/download/":
+			w.Header().Set("Content-Disposition", `attachment; filename="snapshot_1.html.gz"`)
+			_, _ = w.Write([]byte("<html>snapshot</html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	assets, err := client.GetBookmarkAssets(1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(assets) != 1 || assets[0].ID != 5 || assets[0].AssetType != "snapshot" || assets[0].Status != "complete" {
+		t.Fatalf("unexpected assets: %+v", assets)
+	}
+
+	content, filename, err := client.DownloadBookmarkAsset(1, 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(content) != "<html>snapshot</html>" || filename != "snapshot_1.html.gz" {
+		t.Errorf("unexpected content %q or file name %q", content, filename)
+	}
+
+	if _, err := client.GetBookmarkAssets(2); !errors.Is(err, ErrAssetsUnsupported) {
+		t.Errorf("expected ErrAssetsUnsupported for a 404, got %v", err)
+	}
+	if _, _, err := client.DownloadBookmarkAsset(1, 6); err == nil || err.Error() != "asset 6 of bookmark 1 not found" {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+// TestDownloadFavicon tests fetching a favicon from the LinkDing server only
+func TestDownloadFavicon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/linkding/static/https_example_com.png" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("PNG"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/linkding/", "test-token")
+	content, err := client.DownloadFavicon(server.URL + "/linkding/static/https_example_com.png")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(content) != "PNG" {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	if _, err := client.DownloadFavicon("https://elsewhere.example.com/static/x.png"); err == nil || !strings.Contains(err.Error(), "not on the LinkDing server") {
+		t.Errorf("expected a foreign host to be refused, got %v", err)
+	}
+}
+
+// TestUpdateUserProfile_Partial tests that only the given fields are sent
+func TestUpdateUserProfile_Partial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/user/profile/" {
+			t.Errorf("expected path /api/user/profile/, got %s", r.URL.Path)
+		}
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH method, got %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		want := map[string]interface{}{"theme": "dark", "enable_favicons": false}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("expected body %v, got %v", want, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"theme":           "dark",
+			"tag_search":      "lax",
+			"enable_favicons": false,
+		})
+	}))
+	defer server.Close()
+
+	theme, favicons := "dark", false
+	client := NewClient(server.URL, "test-token")
+	profile, err := client.UpdateUserProfile(&models.UserProfileUpdate{Theme: &theme, EnableFavicons: &favicons})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if profile.Theme != "dark" || profile.TagSearch != "lax" {
+		t.Errorf("expected the updated profile, got %+v", profile)
+	}
+}
+
+// TestUpdateUserProfile_Forbidden tests 403 response
+func TestUpdateUserProfile_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	theme := "light"
+	client := NewClient(server.URL, "test-token")
+	_, err := client.UpdateUserProfile(&models.UserProfileUpdate{Theme: &theme})
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	expectedMsg := "insufficient permissions for this operation"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error '%s', got '%v'", expectedMsg, err)
+	}
+}
+
 // TestFetchAllBookmarks_MultiPage tests that FetchAllBookmarks correctly handles pagination
 func TestFetchAllBookmarks_MultiPage(t *testing.T) {
 	pageNum := 0
@@ -1141,3 +1300,146 @@ func TestDeleteBundle_NotFound(t *testing.T) {
 		t.Errorf("expected error '%s', got '%v'", expectedMsg, err)
 	}
 }
+
+// TestCheckTokenScope tests write-access detection from the probe response
+func TestCheckTokenScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected string
+	}{
+		{"validation error means writable", http.StatusBadRequest, TokenScopeReadWrite},
+		{"forbidden means read-only", http.StatusForbidden, TokenScopeReadOnly},
+		{"unauthorized means read-only", http.StatusUnauthorized, TokenScopeReadOnly},
+		{"unexpected status is unknown", http.StatusInternalServerError, TokenScopeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/api/bookmarks/" {
+					t.Errorf("expected POST /api/bookmarks/, got %s %s", r.Method, r.URL.Path)
+				}
+				var body map[string]string
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if body["url"] != "" {
+					t.Errorf("expected probe with empty URL, got '%s'", body["url"])
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token")
+			scope, err := client.CheckTokenScope()
+			if err != nil {
+				t.Fatalf("CheckTokenScope() failed: %v", err)
+			}
+			if scope != tt.expected {
+				t.Errorf("expected scope '%s', got '%s'", tt.expected, scope)
+			}
+		})
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantName  string
+		wantValue string
+		wantErr   bool
+	}{
+		{spec: "X-Auth-Request-Email: me@example.com", wantName: "X-Auth-Request-Email", wantValue: "me@example.com"},
+		{spec: "cf-access-token:abc", wantName: "Cf-Access-Token", wantValue: "abc"},
+		{spec: "X-Empty:", wantName: "X-Empty", wantValue: ""},
+		{spec: "X-Url: https://a.example.com:8080", wantName: "X-Url", wantValue: "https://a.example.com:8080"},
+		{spec: "NoColon", wantErr: true},
+		{spec: ": value", wantErr: true},
+		{spec: "Bad Name: value", wantErr: true},
+		{spec: "X-Inject: a\r\nEvil: b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		name, value, err := ParseHeader(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseHeader(%q): expected error", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseHeader(%q): unexpected error: %v", tt.spec, err)
+			continue
+		}
+		if name != tt.wantName || value != tt.wantValue {
+			t.Errorf("ParseHeader(%q) = %q, %q; want %q, %q", tt.spec, name, value, tt.wantName, tt.wantValue)
+		}
+	}
+}
+
+func TestClientCustomHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Add("X-Proxy-User", "alice")
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Headers: headers})
+	if err := client.TestConnection(); err != nil {
+		t.Fatalf("TestConnection() failed: %v", err)
+	}
+
+	if received.Get("X-Proxy-User") != "alice" {
+		t.Errorf("Expected custom header to be sent, got %v", received)
+	}
+	if received.Get("Authorization") != "Token test-token" {
+		t.Errorf("Expected token header alongside custom headers, got %q", received.Get("Authorization"))
+	}
+}
+
+func TestClientTimeoutOption(t *testing.T) {
+	client := NewClient("https://test.example.com", "test-token")
+	if client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("Expected default timeout %s, got %s", DefaultTimeout, client.httpClient.Timeout)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client = NewClientWithOptions(server.URL, "test-token", ClientOptions{Timeout: 50 * time.Millisecond})
+	err := client.TestConnection()
+	expectedMsg := fmt.Sprintf("cannot connect to %s. Is LinkDing running?", server.URL)
+	if err == nil || err.Error() != expectedMsg {
+		t.Errorf("expected error '%s', got '%v'", expectedMsg, err)
+	}
+}
+
+func TestClientTimeoutIsPerPage(t *testing.T) {
+	var pages atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each page is well within the timeout; all three together are not
+		time.Sleep(40 * time.Millisecond)
+		page := int(pages.Add(1))
+		list := models.BookmarkList{Count: 3, Results: []models.Bookmark{{ID: page}}}
+		if page < 3 {
+			next := server.URL + "/api/bookmarks/"
+			list.Next = &next
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Timeout: 100 * time.Millisecond})
+	bookmarks, err := client.FetchAllBookmarks(nil, false)
+	if err != nil {
+		t.Fatalf("FetchAllBookmarks() failed: %v", err)
+	}
+	if len(bookmarks) != 3 {
+		t.Errorf("Expected 3 bookmarks, got %d", len(bookmarks))
+	}
+}
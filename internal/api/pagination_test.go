@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/models"
 )
@@ -313,3 +317,292 @@ func TestFetchAllTags(t *testing.T) {
 		t.Errorf("expected last tag ID 150, got %d", allTags[149].ID)
 	}
 }
+
+// newMidPaginationFailureServer returns a server whose first page succeeds
+// and whose second page fails with a 500.
+func newMidPaginationFailureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		if offset == "100" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("database unavailable"))
+			return
+		}
+
+		nextURL := "/api/bookmarks/?offset=100&limit=100"
+		response := models.BookmarkList{
+			Count:   300,
+			Next:    &nextURL,
+			Results: make([]models.Bookmark, 100),
+		}
+		for i := 0; i < 100; i++ {
+			response.Results[i] = models.Bookmark{ID: i + 1, URL: "https://example.com"}
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+// TestFetchAllBookmarks_MidPaginationFailure tests that the default fetch is all-or-nothing
+func TestFetchAllBookmarks_MidPaginationFailure(t *testing.T) {
+	server := newMidPaginationFailureServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if bookmarks != nil {
+		t.Errorf("expected no bookmarks in all-or-nothing mode, got %d", len(bookmarks))
+	}
+
+	var partial *PartialFetchError
+	if errors.As(err, &partial) {
+		t.Error("expected a plain error, not a PartialFetchError")
+	}
+}
+
+// TestFetchAllBookmarksBestEffort_MidPaginationFailure tests that best-effort keeps fetched pages
+func TestFetchAllBookmarksBestEffort_MidPaginationFailure(t *testing.T) {
+	server := newMidPaginationFailureServer(t)
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	bookmarks, err := client.FetchAllBookmarksBestEffort(nil, true)
+	if err == nil {
+		t.Fatal("expected partial fetch error, got nil")
+	}
+
+	var partial *PartialFetchError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected PartialFetchError, got %T: %v", err, err)
+	}
+	if partial.Offset != 100 {
+		t.Errorf("expected failure at offset 100, got %d", partial.Offset)
+	}
+	if partial.Fetched != 100 {
+		t.Errorf("expected 100 fetched before failure, got %d", partial.Fetched)
+	}
+	if len(bookmarks) != 100 {
+		t.Errorf("expected 100 bookmarks from the first page, got %d", len(bookmarks))
+	}
+}
+
+// TestFetchAllBookmarksBestEffort_FirstPageFailure tests that a failing first page is a hard error
+func TestFetchAllBookmarksBestEffort_FirstPageFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	bookmarks, err := client.FetchAllBookmarksBestEffort(nil, true)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var partial *PartialFetchError
+	if errors.As(err, &partial) {
+		t.Error("expected a plain error when nothing was fetched")
+	}
+	if bookmarks != nil {
+		t.Errorf("expected no bookmarks, got %d", len(bookmarks))
+	}
+}
+
+// newNumberedBookmarksServer serves total bookmarks numbered from 1 in pages
+// of the requested limit. Each request first passes through hook, which may
+// write an error response and return false.
+func newNumberedBookmarksServer(t *testing.T, total int, hook func(w http.ResponseWriter, r *http.Request, offset int) bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit != DefaultPageSize {
+			t.Errorf("expected limit %d, got %d", DefaultPageSize, limit)
+		}
+		if hook != nil && !hook(w, r, offset) {
+			return
+		}
+
+		response := models.BookmarkList{Count: total, Results: []models.Bookmark{}}
+		for id := offset + 1; id <= total && id <= offset+limit; id++ {
+			response.Results = append(response.Results, models.Bookmark{ID: id})
+		}
+		if offset+limit < total {
+			next := "/api/bookmarks/?offset=" + strconv.Itoa(offset+limit)
+			response.Next = &next
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+// TestFetchAllBookmarks_Parallel tests that pages fetched in parallel come
+// back complete and in order, with no more requests in flight than allowed
+func TestFetchAllBookmarks_Parallel(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := newNumberedBookmarksServer(t, 950, func(w http.ResponseWriter, r *http.Request, offset int) bool {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if n <= seen || maxInFlight.CompareAndSwap(seen, n) {
+				break
+			}
+		}
+		// Later pages answer sooner, so they finish out of order
+		if offset > 0 {
+			time.Sleep(time.Duration(1000-offset) * time.Millisecond / 40)
+		}
+		return true
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Parallel: 4})
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		t.Fatalf("FetchAllBookmarks() failed: %v", err)
+	}
+
+	if len(bookmarks) != 950 {
+		t.Fatalf("expected 950 bookmarks, got %d", len(bookmarks))
+	}
+	for i, b := range bookmarks {
+		if b.ID != i+1 {
+			t.Fatalf("bookmark %d has ID %d, want %d", i, b.ID, i+1)
+		}
+	}
+	if got := maxInFlight.Load(); got < 2 || got > 4 {
+		t.Errorf("expected 2 to 4 requests in flight, got at most %d", got)
+	}
+}
+
+// TestFetchAllBookmarks_ParallelSinglePage tests that a single page needs
+// no further requests
+func TestFetchAllBookmarks_ParallelSinglePage(t *testing.T) {
+	var requests atomic.Int32
+	server := newNumberedBookmarksServer(t, 40, func(w http.ResponseWriter, r *http.Request, offset int) bool {
+		requests.Add(1)
+		return true
+	})
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Parallel: 4})
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		t.Fatalf("FetchAllBookmarks() failed: %v", err)
+	}
+	if len(bookmarks) != 40 || requests.Load() != 1 {
+		t.Errorf("expected 40 bookmarks from 1 request, got %d from %d", len(bookmarks), requests.Load())
+	}
+}
+
+// newParallelFailureServer fails the page at offset 300 and holds pages
+// after it until their request is cancelled.
+func newParallelFailureServer(t *testing.T) *httptest.Server {
+	return newNumberedBookmarksServer(t, 1000, func(w http.ResponseWriter, r *http.Request, offset int) bool {
+		switch {
+		case offset == 300:
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusForbidden)
+			return false
+		case offset > 300:
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+				t.Errorf("request for offset %d was not cancelled", offset)
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// TestFetchAllBookmarks_ParallelFailure tests that a failing page cancels the
+// requests still running and fails the fetch
+func TestFetchAllBookmarks_ParallelFailure(t *testing.T) {
+	server := newParallelFailureServer(t)
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Parallel: 3})
+	start := time.Now()
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetch took %v; pending requests were not cancelled", elapsed)
+	}
+	if bookmarks != nil {
+		t.Errorf("expected no bookmarks, got %d", len(bookmarks))
+	}
+	var partial *PartialFetchError
+	if errors.As(err, &partial) {
+		t.Error("expected a plain error, not a PartialFetchError")
+	}
+}
+
+// TestFetchAllBookmarksBestEffort_ParallelFailure tests that best-effort
+// keeps the unbroken run of pages before the first missing one
+func TestFetchAllBookmarksBestEffort_ParallelFailure(t *testing.T) {
+	server := newParallelFailureServer(t)
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Parallel: 3})
+	bookmarks, err := client.FetchAllBookmarksBestEffort(nil, true)
+
+	var partial *PartialFetchError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected PartialFetchError, got %T: %v", err, err)
+	}
+	// Pages before 300 may be cancelled too if they were still running
+	if partial.Offset == 0 || partial.Offset > 300 {
+		t.Errorf("expected failure at offset 100 to 300, got %d", partial.Offset)
+	}
+	if len(bookmarks) != partial.Offset || partial.Fetched != partial.Offset {
+		t.Errorf("expected %d bookmarks, got %d (Fetched %d)", partial.Offset, len(bookmarks), partial.Fetched)
+	}
+	for i, b := range bookmarks {
+		if b.ID != i+1 {
+			t.Fatalf("bookmark %d has ID %d, want %d", i, b.ID, i+1)
+		}
+	}
+}
+
+// TestFetchAllBookmarks_ProxiedNextURL tests paging through a reverse proxy
+// that serves LinkDing under a subpath, while the server reports Next links
+// with its internal host. Pages are requested by offset under the configured
+// base URL; the Next link is never followed.
+func TestFetchAllBookmarks_ProxiedNextURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/linkding/api/bookmarks/" {
+			t.Errorf("request outside the base path: %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		response := models.BookmarkList{Count: 250, Results: []models.Bookmark{}}
+		for id := offset + 1; id <= 250 && id <= offset+DefaultPageSize; id++ {
+			response.Results = append(response.Results, models.Bookmark{ID: id})
+		}
+		if offset+DefaultPageSize < 250 {
+			next := "http://linkding.internal:9090/api/bookmarks/?limit=100&offset=" + strconv.Itoa(offset+DefaultPageSize)
+			response.Next = &next
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	for _, parallel := range []int{1, 3} {
+		client := NewClientWithOptions(server.URL+"/linkding/", "test-token", ClientOptions{Parallel: parallel})
+		bookmarks, err := client.FetchAllBookmarks(nil, true)
+		if err != nil {
+			t.Fatalf("FetchAllBookmarks() with parallel %d failed: %v", parallel, err)
+		}
+		if len(bookmarks) != 250 || bookmarks[249].ID != 250 {
+			t.Errorf("parallel %d: expected 250 bookmarks in order, got %d", parallel, len(bookmarks))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+// DefaultPageSize is the number of items requested per page when walking a
+// paginated list endpoint.
+const DefaultPageSize = 100
+
+// PageFunc fetches the page of items starting at offset, returning the items
+// and whether the server reported a next page.
+type PageFunc[T any] func(limit, offset int) (items []T, hasNext bool, err error)
+
+// Paginator walks a LinkDing list endpoint page by page using limit/offset,
+// stopping when the server reports no next page or returns an empty page.
+// The server's next URL is never followed: behind a reverse proxy it may
+// name an internal host or lack the public subpath, so pages are always
+// requested relative to the client's base URL.
+type Paginator[T any] struct {
+	fetch    PageFunc[T]
+	pageSize int
+	offset   int
+	done     bool
+}
+
+// NewPaginator creates a Paginator that requests pages of DefaultPageSize items.
+func NewPaginator[T any](fetch PageFunc[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch, pageSize: DefaultPageSize}
+}
+
+// WithPageSize sets the number of items requested per page.
+func (p *Paginator[T]) WithPageSize(size int) *Paginator[T] {
+	if size > 0 {
+		p.pageSize = size
+	}
+	return p
+}
+
+// Offset returns the offset of the next page to be fetched.
+func (p *Paginator[T]) Offset() int {
+	return p.offset
+}
+
+// Next fetches the next page. It returns false once every page has been
+// read. After an error the same page is requested again on the next call.
+func (p *Paginator[T]) Next() ([]T, bool, error) {
+	if p.done {
+		return nil, false, nil
+	}
+
+	items, hasNext, err := p.fetch(p.pageSize, p.offset)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !hasNext || len(items) == 0 {
+		p.done = true
+	} else {
+		p.offset += p.pageSize
+	}
+	return items, true, nil
+}
+
+// All fetches every remaining page and returns the combined items.
+func (p *Paginator[T]) All() ([]T, error) {
+	var all []T
+	for {
+		items, ok, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return all, nil
+		}
+		all = append(all, items...)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// TestPaginator_MultiPage tests that the paginator walks offsets until the
+// last page reports no next page
+func TestPaginator_MultiPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var offsets []int
+	pages := NewPaginator(func(limit, offset int) ([]int, bool, error) {
+		offsets = append(offsets, offset)
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[offset:end], end < len(items), nil
+	}).WithPageSize(2)
+
+	all, err := pages.All()
+	if err != nil {
+		t.Fatalf("All() failed: %v", err)
+	}
+	if !reflect.DeepEqual(all, items) {
+		t.Errorf("All() = %v, want %v", all, items)
+	}
+	if !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("Expected offsets [0 2 4], got %v", offsets)
+	}
+
+	// A finished paginator makes no further requests
+	if _, ok, _ := pages.Next(); ok {
+		t.Error("Expected Next() to report no more pages")
+	}
+	if len(offsets) != 3 {
+		t.Errorf("Expected no request after the last page, got offsets %v", offsets)
+	}
+}
+
+// TestPaginator_EmptyPageStops tests that an empty page ends pagination even
+// if the server claims there is a next page
+func TestPaginator_EmptyPageStops(t *testing.T) {
+	calls := 0
+	all, err := NewPaginator(func(limit, offset int) ([]string, bool, error) {
+		calls++
+		return nil, true, nil
+	}).All()
+	if err != nil {
+		t.Fatalf("All() failed: %v", err)
+	}
+	if len(all) != 0 || calls != 1 {
+		t.Errorf("Expected a single empty page, got %v after %d calls", all, calls)
+	}
+}
+
+// TestPaginator_ErrorKeepsOffset tests that a failed page is retried at the
+// same offset on the next call
+func TestPaginator_ErrorKeepsOffset(t *testing.T) {
+	fail := true
+	pages := NewPaginator(func(limit, offset int) ([]int, bool, error) {
+		if offset == 100 && fail {
+			fail = false
+			return nil, false, errors.New("boom")
+		}
+		return []int{offset}, offset == 0, nil
+	})
+
+	if _, _, err := pages.Next(); err != nil {
+		t.Fatalf("First page failed: %v", err)
+	}
+	if _, _, err := pages.Next(); err == nil {
+		t.Fatal("Expected second page to fail")
+	}
+	if pages.Offset() != 100 {
+		t.Errorf("Expected offset to stay at 100 after a failure, got %d", pages.Offset())
+	}
+	items, ok, err := pages.Next()
+	if err != nil || !ok || !reflect.DeepEqual(items, []int{100}) {
+		t.Errorf("Expected retried page [100], got %v, %v, %v", items, ok, err)
+	}
+}
+
+// TestFetchAllBundles tests that FetchAllBundles fetches all pages
+func TestFetchAllBundles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextURL := "/api/bundles/?offset=100&limit=100"
+		var response models.BundleList
+		switch r.URL.Query().Get("offset") {
+		case "", "0":
+			response = models.BundleList{Count: 101, Next: &nextURL, Results: make([]models.Bundle, 100)}
+			for i := range response.Results {
+				response.Results[i] = models.Bundle{ID: i + 1}
+			}
+		case "100":
+			response = models.BundleList{Count: 101, Results: []models.Bundle{{ID: 101}}}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	bundles, err := client.FetchAllBundles()
+	if err != nil {
+		t.Fatalf("FetchAllBundles() failed: %v", err)
+	}
+	if len(bundles) != 101 || bundles[100].ID != 101 {
+		t.Errorf("Expected 101 bundles across 2 pages, got %d", len(bundles))
+	}
+}
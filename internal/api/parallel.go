@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// fetchBookmarksParallel is fetchAllBookmarks for a client with Parallel
+// above 1. The first page gives the total count, from which the offsets of
+// the remaining pages are known; up to c.parallel of those are then fetched
+// at once and put back together in offset order. Bookmarks added while the
+// pages are being fetched may be missed, as when paging sequentially.
+//
+// The first failing page cancels every request still in flight or waiting.
+// In best-effort mode the pages before the earliest missing one are
+// returned with a *PartialFetchError for that page.
+func (c *Client) fetchBookmarksParallel(query string, tags []string, archived *bool, bestEffort bool) ([]models.Bookmark, error) {
+	first, err := c.GetBookmarks(query, tags, nil, archived, DefaultPageSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookmarks: %w", err)
+	}
+	c.reportProgress(len(first.Results), first.Count)
+	if first.Next == nil || len(first.Results) == 0 || first.Count <= len(first.Results) {
+		return first.Results, nil
+	}
+
+	pageCount := (first.Count + DefaultPageSize - 1) / DefaultPageSize
+	pages := make([][]models.Bookmark, pageCount)
+	fetched := make([]bool, pageCount)
+	pages[0], fetched[0] = first.Results, true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+		received = len(first.Results)
+	)
+	next := make(chan int)
+	workers := min(c.parallel, pageCount-1)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range next {
+				list, err := c.getBookmarks(ctx, query, tags, nil, archived, DefaultPageSize, page*DefaultPageSize)
+				mu.Lock()
+				if err != nil {
+					// Requests cancelled after the first failure fail
+					// too; only the original error is reported
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					pages[page], fetched[page] = list.Results, true
+					received += len(list.Results)
+					c.reportProgress(received, first.Count)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for page := 1; page < pageCount; page++ {
+		select {
+		case next <- page:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	var all []models.Bookmark
+	for page := range pages {
+		if !fetched[page] {
+			if bestEffort {
+				return all, &PartialFetchError{Offset: page * DefaultPageSize, Fetched: len(all), Err: firstErr}
+			}
+			return nil, fmt.Errorf("failed to fetch bookmarks: %w", firstErr)
+		}
+		all = append(all, pages[page]...)
+	}
+	return all, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket holding a single token: requests are spaced
+// at least interval apart, and the first one goes out at once. Callers
+// reserve their slot under the lock, so concurrent workers sharing a client
+// share its rate.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+// newRateLimiter returns a limiter allowing perSecond requests per second, or
+// nil (no limit) when perSecond is not positive.
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}
+}
+
+// wait blocks until the caller may send its request.
+func (l *rateLimiter) wait() {
+	l.mu.Lock()
+	now := l.now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay > 0 {
+		l.sleep(delay)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit_SpacesRequestsAcrossWorkers(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"count": 0, "results": []}`))
+	}))
+	defer server.Close()
+
+	// 20 requests per second: 6 requests from 3 workers need at least 5
+	// intervals of 50ms
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{RateLimit: 20})
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < 3; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2; i++ {
+				if _, err := client.GetBookmarks("", nil, nil, nil, 1, 0); err != nil {
+					t.Errorf("request failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("expected 6 requests at 20/s to take at least 250ms, took %v", elapsed)
+	}
+	if requests.Load() != 6 {
+		t.Errorf("expected 6 requests, got %d", requests.Load())
+	}
+}
+
+func TestRateLimit_OffByDefault(t *testing.T) {
+	if newRateLimiter(0) != nil {
+		t.Error("expected no limiter for a rate of 0")
+	}
+
+	client := NewClient("http://localhost", "test-token")
+	if client.limiter != nil {
+		t.Error("expected no limiter by default")
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+	l := newRateLimiter(2)
+	l.now = func() time.Time { return now }
+	l.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	// The first request goes out at once, the next two wait their turn
+	l.wait()
+	l.wait()
+	l.wait()
+	if len(slept) != 2 || slept[0] != 500*time.Millisecond || slept[1] != time.Second {
+		t.Fatalf("expected waits of 500ms and 1s, got %v", slept)
+	}
+
+	// After an idle period no wait is needed and no credit builds up
+	now = now.Add(10 * time.Second)
+	slept = nil
+	l.wait()
+	l.wait()
+	if len(slept) != 1 || slept[0] != 500*time.Millisecond {
+		t.Errorf("expected a single 500ms wait after idling, got %v", slept)
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRetryAfter caps how long a server-provided Retry-After may delay a retry.
+const maxRetryAfter = 60 * time.Second
+
+// maxBackoff caps the exponentially growing wait between retries.
+const maxBackoff = 30 * time.Second
+
+// RetryPolicy controls which failed requests are retried and how often.
+type RetryPolicy struct {
+	MaxRetries       int           // number of retries after the first attempt (0 disables retries)
+	StatusCodes      []int         // HTTP statuses that trigger a retry
+	ConnectionErrors bool          // retry when the server cannot be reached
+	Wait             time.Duration // delay before the first retry when no Retry-After is given; doubles for each later retry, up to 30s
+	Jitter           bool          // randomize each wait to between half and all of its value, so clients retrying together spread out
+	Deadline         time.Duration // overall time allowed for a request and its retries; a retry that would start later is not made (0 means no limit)
+}
+
+// DefaultRetryStatusCodes returns the statuses retried when --retry-on is not
+// given: every 5xx server error.
+func DefaultRetryStatusCodes() []int {
+	codes := make([]int, 0, 100)
+	for code := 500; code <= 599; code++ {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// ParseRetryOn parses a --retry-on value: a comma-separated list of HTTP
+// status codes (400-599), "5xx" for all server errors, and "conn" to retry
+// connection errors. For example "502,503,504,conn" or "429,5xx".
+func ParseRetryOn(spec string) ([]int, bool, error) {
+	seen := map[int]bool{}
+	connErrors := false
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		switch part {
+		case "":
+			continue
+		case "conn", "connection":
+			connErrors = true
+		case "5xx":
+			for _, code := range DefaultRetryStatusCodes() {
+				seen[code] = true
+			}
+		default:
+			code, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid --retry-on value '%s': expected a status code, '5xx' or 'conn'", part)
+			}
+			if code < 400 || code > 599 {
+				return nil, false, fmt.Errorf("invalid --retry-on status %d: must be between 400 and 599", code)
+			}
+			seen[code] = true
+		}
+	}
+
+	if len(seen) == 0 && !connErrors {
+		return nil, false, fmt.Errorf("--retry-on must list at least one status code or 'conn'")
+	}
+
+	codes := make([]int, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes, connErrors, nil
+}
+
+// isIdempotent reports whether a request with this method can be safely
+// repeated. POST is never retried to avoid duplicate bookmarks; the client's
+// PATCH requests set absolute values, so repeating one changes nothing.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether the outcome of attempt (0-based) warrants another try.
+func (p RetryPolicy) shouldRetry(method string, resp *http.Response, err error, attempt int) bool {
+	if attempt >= p.MaxRetries || !isIdempotent(method) {
+		return false
+	}
+	if err != nil {
+		return p.ConnectionErrors && certificateError(err) == nil
+	}
+	for _, code := range p.StatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before retry number attempt+1. A
+// Retry-After header on a retried response takes precedence over the
+// exponential backoff.
+func (p RetryPolicy) delay(resp *http.Response, now time.Time, attempt int) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return wait
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// backoff returns Wait doubled attempt times, capped at maxBackoff, with
+// jitter applied if enabled.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.Wait
+	for i := 0; i < attempt && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, maxBackoff)
+	if p.Jitter && wait > 1 {
+		wait = wait/2 + rand.N(wait/2+1)
+	}
+	return wait
+}
+
+// parseRetryAfter interprets a Retry-After header given either as seconds or
+// as an HTTP date, capped at maxRetryAfter.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if when, err := http.ParseTime(value); err == nil {
+		wait = when.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
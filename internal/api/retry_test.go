@@ -0,0 +1,327 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRetryOn(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		wantCodes []int
+		wantConn  bool
+		wantErr   bool
+	}{
+		{name: "explicit codes", spec: "502,503,504", wantCodes: []int{502, 503, 504}},
+		{name: "codes and conn", spec: "429, 503, conn", wantCodes: []int{429, 503}, wantConn: true},
+		{name: "conn only", spec: "connection", wantCodes: []int{}, wantConn: true},
+		{name: "duplicates collapse", spec: "503,503", wantCodes: []int{503}},
+		{name: "5xx expands", spec: "5xx", wantCodes: DefaultRetryStatusCodes()},
+		{name: "not a number", spec: "abc", wantErr: true},
+		{name: "out of range", spec: "200", wantErr: true},
+		{name: "too large", spec: "600", wantErr: true},
+		{name: "empty", spec: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codes, conn, err := ParseRetryOn(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("Expected codes %v, got %v", tt.wantCodes, codes)
+			}
+			if conn != tt.wantConn {
+				t.Errorf("Expected conn=%v, got %v", tt.wantConn, conn)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "5", want: 5 * time.Second, wantOK: true},
+		{value: "3600", want: maxRetryAfter, wantOK: true},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second, wantOK: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// newRetryTestClient returns a client whose sleeps are recorded instead of performed.
+func newRetryTestClient(url string, policy RetryPolicy) (*Client, *[]time.Duration) {
+	var sleeps []time.Duration
+	client := NewClientWithOptions(url, "test-token", ClientOptions{Retry: policy})
+	client.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return client, &sleeps
+}
+
+func TestDoRequest_Retry(t *testing.T) {
+	t.Run("retries configured status then succeeds", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, sleeps := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 3, StatusCodes: []int{503}, Wait: time.Second,
+		})
+		resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || attempts != 3 {
+			t.Errorf("Expected success on attempt 3, got status %d after %d attempts", resp.StatusCode, attempts)
+		}
+		if !reflect.DeepEqual(*sleeps, []time.Duration{time.Second, 2 * time.Second}) {
+			t.Errorf("Expected waits of 1s then 2s, got %v", *sleeps)
+		}
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, sleeps := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 1, StatusCodes: []int{429}, Wait: time.Second,
+		})
+		resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if len(*sleeps) != 1 || (*sleeps)[0] != 7*time.Second {
+			t.Errorf("Expected a single 7s wait from Retry-After, got %v", *sleeps)
+		}
+	})
+
+	t.Run("status outside retry-on is not retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, _ := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 3, StatusCodes: []int{502, 503}, Wait: time.Second,
+		})
+		resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		client, _ := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 2, StatusCodes: []int{502}, Wait: time.Second,
+		})
+		resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadGateway || attempts != 3 {
+			t.Errorf("Expected final 502 after 3 attempts, got %d after %d", resp.StatusCode, attempts)
+		}
+	})
+
+	t.Run("POST is never retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, _ := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 3, StatusCodes: []int{503}, Wait: time.Second,
+		})
+		resp, err := client.doRequest("POST", "/api/bookmarks/", map[string]string{"url": "https://example.com"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if attempts != 1 {
+			t.Errorf("Expected POST to be sent once, got %d attempts", attempts)
+		}
+	})
+
+	t.Run("connection errors only retried with conn", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+
+		client, sleeps := newRetryTestClient(url, RetryPolicy{
+			MaxRetries: 2, StatusCodes: []int{503}, ConnectionErrors: true, Wait: time.Second,
+		})
+		if _, err := client.doRequest("GET", "/api/bookmarks/", nil); err == nil {
+			t.Fatal("Expected connection error")
+		}
+		if len(*sleeps) != 2 {
+			t.Errorf("Expected 2 retries on connection error, got %d", len(*sleeps))
+		}
+
+		client, sleeps = newRetryTestClient(url, RetryPolicy{
+			MaxRetries: 2, StatusCodes: []int{503}, Wait: time.Second,
+		})
+		if _, err := client.doRequest("GET", "/api/bookmarks/", nil); err == nil {
+			t.Fatal("Expected connection error")
+		}
+		if len(*sleeps) != 0 {
+			t.Errorf("Expected no retries without conn, got %d", len(*sleeps))
+		}
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{Wait: time.Second}
+	var got []time.Duration
+	for attempt := 0; attempt < 7; attempt++ {
+		got = append(got, p.backoff(attempt))
+	}
+	want := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	p.Jitter = true
+	for attempt := 0; attempt < 5; attempt++ {
+		full := RetryPolicy{Wait: time.Second}.backoff(attempt)
+		for i := 0; i < 20; i++ {
+			if wait := p.backoff(attempt); wait < full/2 || wait > full {
+				t.Fatalf("Expected jittered wait for attempt %d within [%s, %s], got %s", attempt, full/2, full, wait)
+			}
+		}
+	}
+}
+
+func TestDoRequest_RetryPatchAndDeadline(t *testing.T) {
+	t.Run("PATCH is retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 2, StatusCodes: DefaultRetryStatusCodes(), Wait: time.Second,
+		})
+		resp, err := client.doRequest("PATCH", "/api/bookmarks/1/", map[string]string{"title": "x"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if attempts != 2 || resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected PATCH to succeed on the second attempt, got %d after %d", resp.StatusCode, attempts)
+		}
+	})
+
+	t.Run("4xx is not retried by default", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client, _ := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 3, StatusCodes: DefaultRetryStatusCodes(), ConnectionErrors: true, Wait: time.Second,
+		})
+		resp, err := client.doRequest("GET", "/api/bookmarks/1/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if attempts != 1 {
+			t.Errorf("Expected a 404 to be returned without retrying, got %d attempts", attempts)
+		}
+	})
+
+	t.Run("stops before the deadline", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, sleeps := newRetryTestClient(server.URL, RetryPolicy{
+			MaxRetries: 10, StatusCodes: []int{503}, Wait: time.Second, Deadline: 10 * time.Second,
+		})
+		// Sleeping advances a fake clock
+		clock := time.Now()
+		client.now = func() time.Time { return clock }
+		client.sleep = func(d time.Duration) {
+			*sleeps = append(*sleeps, d)
+			clock = clock.Add(d)
+		}
+
+		resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+		// Waits of 1s, 2s and 4s fit in 10s; the next 8s wait would not
+		if !reflect.DeepEqual(*sleeps, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}) || attempts != 4 {
+			t.Errorf("Expected 4 attempts with waits of 1s, 2s and 4s, got %d attempts and %v", attempts, *sleeps)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// RequestTimer accumulates how long a client spends in API calls. A request
+// is timed from when it is sent until its response body is closed, so reading
+// and decoding the body counts as API time. Retried attempts are counted as
+// separate requests. It is safe for concurrent use.
+type RequestTimer struct {
+	mu       sync.Mutex
+	requests int
+	total    time.Duration
+}
+
+// NewRequestTimer creates an empty RequestTimer.
+func NewRequestTimer() *RequestTimer {
+	return &RequestTimer{}
+}
+
+// Requests returns the number of requests recorded.
+func (t *RequestTimer) Requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.requests
+}
+
+// Total returns the time spent in all recorded requests.
+func (t *RequestTimer) Total() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Average returns the mean request latency, or zero if nothing was recorded.
+func (t *RequestTimer) Average() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.requests == 0 {
+		return 0
+	}
+	return t.total / time.Duration(t.requests)
+}
+
+func (t *RequestTimer) record(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests++
+	t.total += d
+}
+
+// timedBody records a request's duration when its response body is closed.
+type timedBody struct {
+	io.ReadCloser
+	timer *RequestTimer
+	start time.Time
+	once  sync.Once
+}
+
+func (b *timedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.timer.record(time.Since(b.start)) })
+	return err
+}
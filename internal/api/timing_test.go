@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimer_RecordsEachAttempt(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":0,"results":[]}`))
+	}))
+	defer server.Close()
+
+	timer := NewRequestTimer()
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{
+		Retry: RetryPolicy{MaxRetries: 1, StatusCodes: []int{503}},
+		Timer: timer,
+	})
+	client.sleep = func(time.Duration) {}
+
+	if _, err := client.GetBookmarks("", nil, nil, nil, 10, 0); err != nil {
+		t.Fatalf("GetBookmarks() failed: %v", err)
+	}
+
+	if timer.Requests() != 2 {
+		t.Errorf("Requests() = %d, want 2 (retried attempt counts)", timer.Requests())
+	}
+	if timer.Total() <= 0 {
+		t.Errorf("Total() = %v, want > 0", timer.Total())
+	}
+	if timer.Average() != timer.Total()/2 {
+		t.Errorf("Average() = %v, want %v", timer.Average(), timer.Total()/2)
+	}
+}
+
+func TestRequestTimer_RecordsConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	timer := NewRequestTimer()
+	client := NewClientWithOptions(url, "test-token", ClientOptions{Timer: timer})
+	if err := client.TestConnection(); err == nil {
+		t.Fatal("Expected connection error")
+	}
+	if timer.Requests() != 1 {
+		t.Errorf("Requests() = %d, want 1", timer.Requests())
+	}
+}
+
+func TestRequestTimer_Empty(t *testing.T) {
+	timer := NewRequestTimer()
+	if timer.Requests() != 0 || timer.Total() != 0 || timer.Average() != 0 {
+		t.Errorf("Expected zero values for an empty timer")
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// TransportOptions tunes the HTTP transport a client uses. The zero value
+// keeps Go's defaults.
+type TransportOptions struct {
+	// DisableHTTP2 forces HTTP/1.1, for reverse proxies that mishandle HTTP/2.
+	DisableHTTP2 bool
+	// MaxIdleConns is the number of idle connections kept open for reuse.
+	// Every request goes to one host, so it also sets the per-host limit,
+	// which otherwise defaults to 2. Zero keeps the defaults.
+	MaxIdleConns int
+	// IdleConnTimeout is how long an idle connection is kept before it is
+	// closed. Zero keeps the default of 90 seconds.
+	IdleConnTimeout time.Duration
+	// InsecureSkipVerify accepts any server certificate, such as a
+	// self-signed one. Connections can then be intercepted.
+	InsecureSkipVerify bool
+	// RootCAs, if set, replaces the system roots used to verify the
+	// server's certificate; see LoadCACerts.
+	RootCAs *x509.CertPool
+	// Proxy, if set, sends every request through this proxy. Otherwise the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+	Proxy *url.URL
+}
+
+// LoadCACerts reads a PEM bundle of CA certificates for
+// TransportOptions.RootCAs.
+func LoadCACerts(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// certificateError returns the reason the server's TLS certificate was
+// rejected if err is such a failure, which retrying cannot fix, or nil.
+func certificateError(err error) error {
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return certErr.Err
+	}
+	return nil
+}
+
+// newTransport builds an HTTP transport from Go's default transport and the
+// given options.
+func newTransport(options TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.DisableHTTP2 {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		transport.Protocols = protocols
+		transport.ForceAttemptHTTP2 = false
+	}
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+		transport.MaxIdleConnsPerHost = options.MaxIdleConns
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	if options.Proxy != nil {
+		transport.Proxy = http.ProxyURL(options.Proxy)
+	}
+	if options.InsecureSkipVerify || options.RootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: options.InsecureSkipVerify,
+			RootCAs:            options.RootCAs,
+		}
+	}
+	return transport
+}
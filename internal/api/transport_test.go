@@ -0,0 +1,155 @@
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestNewTransport_Defaults(t *testing.T) {
+	transport := newTransport(TransportOptions{})
+	defaults := http.DefaultTransport.(*http.Transport)
+
+	if transport.MaxIdleConns != defaults.MaxIdleConns || transport.MaxIdleConnsPerHost != defaults.MaxIdleConnsPerHost {
+		t.Errorf("Expected default idle connection limits, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != defaults.IdleConnTimeout {
+		t.Errorf("Expected default idle timeout, got %s", transport.IdleConnTimeout)
+	}
+	if transport.Protocols != nil {
+		t.Errorf("Expected default protocols, got %v", transport.Protocols)
+	}
+	if transport.Proxy == nil {
+		t.Error("Expected the proxy to come from the environment")
+	}
+}
+
+func TestNewTransport_Tuning(t *testing.T) {
+	transport := newTransport(TransportOptions{MaxIdleConns: 16, IdleConnTimeout: 5 * time.Second})
+
+	if transport.MaxIdleConns != 16 || transport.MaxIdleConnsPerHost != 16 {
+		t.Errorf("Expected 16 idle connections, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 5*time.Second {
+		t.Errorf("Expected 5s idle timeout, got %s", transport.IdleConnTimeout)
+	}
+}
+
+func TestClient_DisableHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Proto", r.Proto)
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{})
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	serverTLS := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	tests := []struct {
+		name    string
+		options TransportOptions
+		want    string
+	}{
+		{"default negotiates HTTP/2", TransportOptions{}, "HTTP/2.0"},
+		{"disabled forces HTTP/1.1", TransportOptions{DisableHTTP2: true}, "HTTP/1.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Transport: tt.options})
+			transport := client.httpClient.Transport.(*http.Transport)
+			transport.TLSClientConfig = &tls.Config{RootCAs: serverTLS.RootCAs}
+
+			resp, err := client.doRequest("GET", "/api/bookmarks/", nil)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			_ = resp.Body.Close()
+			if got := resp.Header.Get("X-Proto"); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_TLSVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{})
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	roots, err := LoadCACerts(caFile)
+	if err != nil {
+		t.Fatalf("LoadCACerts() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options TransportOptions
+		wantErr bool
+	}{
+		{"self-signed rejected by default", TransportOptions{}, true},
+		{"insecure skips verification", TransportOptions{InsecureSkipVerify: true}, false},
+		{"trusted CA bundle", TransportOptions{RootCAs: roots}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClientWithOptions(server.URL, "test-token", ClientOptions{Transport: tt.options})
+			err := client.TestConnection()
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "cannot verify the TLS certificate")) {
+				t.Errorf("Expected a certificate error, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected the connection to succeed, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadCACerts_NoCertificates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCACerts(path); err == nil {
+		t.Error("Expected an error for a file without certificates")
+	}
+}
+
+func TestClient_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives the absolute URL of the target
+		proxied = append(proxied, r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{{ID: 1, URL: "https://example.com"}}})
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client := NewClientWithOptions("http://linkding.invalid", "test-token", ClientOptions{Transport: TransportOptions{Proxy: proxyURL}})
+	bookmarks, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		t.Fatalf("FetchAllBookmarks() failed: %v", err)
+	}
+	if len(bookmarks) != 1 {
+		t.Errorf("Expected 1 bookmark, got %d", len(bookmarks))
+	}
+	if len(proxied) != 1 || !strings.HasPrefix(proxied[0], "http://linkding.invalid/api/bookmarks/") {
+		t.Errorf("Expected the request to go through the proxy, got %v", proxied)
+	}
+}
@@ -0,0 +1,38 @@
+// Package batch paces bulk operations so they run in fixed-size batches.
+package batch
+
+import "time"
+
+// Pacer splits a sequence of operations into batches of Size items and
+// pauses between batches. A nil Pacer or a Size of zero does nothing.
+type Pacer struct {
+	Size  int
+	Pause time.Duration
+	// OnBatch, if set, is called with the index of the first item of each batch.
+	OnBatch func(index int)
+	// Sleep waits between batches; nil means time.Sleep.
+	Sleep func(time.Duration)
+
+	count int
+}
+
+// Next must be called before each item is processed, with the item's index
+// in the full sequence. It pauses when the previous batch is complete.
+func (p *Pacer) Next(index int) {
+	if p == nil || p.Size <= 0 {
+		return
+	}
+	if p.count%p.Size == 0 {
+		if p.count > 0 && p.Pause > 0 {
+			sleep := p.Sleep
+			if sleep == nil {
+				sleep = time.Sleep
+			}
+			sleep(p.Pause)
+		}
+		if p.OnBatch != nil {
+			p.OnBatch(index)
+		}
+	}
+	p.count++
+}
@@ -0,0 +1,44 @@
+package batch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPacer_ChunkBoundaries(t *testing.T) {
+	var starts []int
+	var pauses []time.Duration
+	p := &Pacer{
+		Size:    3,
+		Pause:   time.Second,
+		OnBatch: func(index int) { starts = append(starts, index) },
+		Sleep:   func(d time.Duration) { pauses = append(pauses, d) },
+	}
+
+	// Items 10-16, as when resuming part way through a sequence
+	for i := 10; i <= 16; i++ {
+		p.Next(i)
+	}
+
+	if !reflect.DeepEqual(starts, []int{10, 13, 16}) {
+		t.Errorf("Expected batches to start at 10, 13, 16, got %v", starts)
+	}
+	// No pause before the first batch
+	if len(pauses) != 2 {
+		t.Errorf("Expected 2 pauses between 3 batches, got %d", len(pauses))
+	}
+}
+
+func TestPacer_Disabled(t *testing.T) {
+	calls := 0
+	p := &Pacer{Size: 0, Pause: time.Hour, Sleep: func(time.Duration) { calls++ }}
+	for i := 0; i < 5; i++ {
+		p.Next(i)
+	}
+	var nilPacer *Pacer
+	nilPacer.Next(0)
+	if calls != 0 {
+		t.Errorf("Expected no pauses without a batch size, got %d", calls)
+	}
+}
@@ -5,14 +5,33 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/spf13/viper"
 )
 
+// DefaultProfile names the connection stored at the top level of the
+// config file, outside the "profiles" section.
+const DefaultProfile = "default"
+
+// profileNamePattern restricts profile names to characters that are safe
+// as config keys.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // Config represents the application configuration
 type Config struct {
 	URL   string
 	Token string
+	// Profile is the named profile URL and Token were read from, or
+	// DefaultProfile for the top-level connection.
+	Profile string
+	// TokenFromKeyring reports that Token was read from the OS keyring,
+	// which the config file marks with token_keyring
+	TokenFromKeyring bool
+	// Defaults holds default flag values from the "defaults" section. Scalar
+	// entries apply to any command with that flag; a nested map keyed by a
+	// command path (e.g. "list" or "tags show") applies only to that command.
+	Defaults map[string]interface{}
 }
 
 // migrateFromOldPath attempts to migrate config from old path (~/.config/linkdingctl/)
@@ -60,6 +79,21 @@ func migrateFromOldPath(newConfigPath string) (bool, error) {
 // Load loads configuration from file and environment variables.
 // Environment variables take precedence over config file values.
 func Load(configPath string) (*Config, error) {
+	return LoadProfile(configPath, "")
+}
+
+// LoadProfile loads configuration like Load, reading the URL and token from
+// a named profile in the "profiles" section. An empty profile selects the
+// file's active_profile, or the top-level connection when none is set.
+// Environment variables override the active profile but are ignored when a
+// profile is named explicitly.
+func LoadProfile(configPath, profile string) (*Config, error) {
+	if profile != "" {
+		if err := ValidateProfileName(profile); err != nil {
+			return nil, err
+		}
+	}
+
 	v := viper.New()
 
 	// Set config file path
@@ -85,15 +119,6 @@ func Load(configPath string) (*Config, error) {
 		v.SetConfigType("yaml")
 	}
 
-	// Environment variable bindings (higher priority)
-	v.SetEnvPrefix("LINKDING")
-	if err := v.BindEnv("url"); err != nil {
-		return nil, fmt.Errorf("failed to bind LINKDING_URL environment variable: %w", err)
-	}
-	if err := v.BindEnv("token"); err != nil {
-		return nil, fmt.Errorf("failed to bind LINKDING_TOKEN environment variable: %w", err)
-	}
-
 	// Read config file (ignore if not found)
 	if err := v.ReadInConfig(); err != nil {
 		// Ignore "not found" errors - we'll validate required fields later
@@ -106,8 +131,46 @@ func Load(configPath string) (*Config, error) {
 	}
 
 	cfg := &Config{
-		URL:   v.GetString("url"),
-		Token: v.GetString("token"),
+		URL:      v.GetString("url"),
+		Token:    v.GetString("token"),
+		Profile:  DefaultProfile,
+		Defaults: v.GetStringMap("defaults"),
+	}
+	keyringAccount := v.GetString(keyringKey)
+
+	explicit := profile != ""
+	if !explicit {
+		profile = v.GetString("active_profile")
+	}
+	if profile != "" && profile != DefaultProfile {
+		key := "profiles." + profile
+		if !v.IsSet(key) {
+			return nil, fmt.Errorf("profile '%s' not found in config file", profile)
+		}
+		cfg.Profile = profile
+		cfg.URL = v.GetString(key + ".url")
+		cfg.Token = v.GetString(key + ".token")
+		keyringAccount = v.GetString(key + "." + keyringKey)
+	}
+
+	// Environment variables (higher priority, unless a profile was named)
+	if !explicit {
+		if url := os.Getenv("LINKDING_URL"); url != "" {
+			cfg.URL = url
+		}
+		if token := os.Getenv("LINKDING_TOKEN"); token != "" {
+			cfg.Token = token
+		}
+	}
+
+	// The keyring is only asked when nothing else supplied the token, as it
+	// may prompt to be unlocked
+	if cfg.Token == "" && keyringAccount != "" {
+		token, err := readKeyringToken(keyringAccount)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Token, cfg.TokenFromKeyring = token, true
 	}
 
 	// Validate that required fields are present
@@ -118,6 +181,74 @@ func Load(configPath string) (*Config, error) {
 	return cfg, nil
 }
 
+// ValidateProfileName returns an error if name cannot be used as a profile
+// name.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s': use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// LoadFile loads configuration from the file at configPath only, ignoring
+// the LINKDING_* environment variables. It is used where a command talks to
+// more than one instance and the environment must not override either.
+func LoadFile(configPath string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if filepath.Ext(configPath) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	cfg := &Config{
+		URL:      v.GetString("url"),
+		Token:    v.GetString("token"),
+		Defaults: v.GetStringMap("defaults"),
+	}
+	if account := v.GetString(keyringKey); cfg.Token == "" && account != "" {
+		token, err := readKeyringToken(account)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Token, cfg.TokenFromKeyring = token, true
+	}
+	if cfg.URL == "" || cfg.Token == "" {
+		return nil, fmt.Errorf("config file %s must set both url and token", configPath)
+	}
+
+	return cfg, nil
+}
+
+// LoadDefaults reads only the "defaults" section of the config file. A
+// missing file yields no defaults; URL and token are not required.
+func LoadDefaults(configPath string) (map[string]interface{}, error) {
+	if configPath == "" {
+		defaultPath, err := DefaultConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		configPath = defaultPath
+	}
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if filepath.Ext(configPath) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return v.GetStringMap("defaults"), nil
+}
+
 // DefaultConfigPath returns the default configuration file path
 func DefaultConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -127,19 +258,82 @@ func DefaultConfigPath() (string, error) {
 	return filepath.Join(homeDir, ".config", "linkdingctl", "config.yaml"), nil
 }
 
-// Save writes configuration to the specified path
+// Save writes configuration to the specified path. The URL and token go to
+// the profile named by cfg.Profile, or to the top level for DefaultProfile
+// or an empty name. Other profiles already in the file are kept.
 func Save(cfg *Config, configPath string) error {
+	v, err := readForUpdate(configPath)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Profile == "" || cfg.Profile == DefaultProfile {
+		v.Set("url", cfg.URL)
+		v.Set("token", cfg.Token)
+	} else {
+		if err := ValidateProfileName(cfg.Profile); err != nil {
+			return err
+		}
+		v.Set("profiles."+cfg.Profile+".url", cfg.URL)
+		v.Set("profiles."+cfg.Profile+".token", cfg.Token)
+	}
+	if len(cfg.Defaults) > 0 {
+		v.Set("defaults", cfg.Defaults)
+	}
+
+	return writeConfig(v, configPath)
+}
+
+// SetActiveProfile records the profile that commands use when --profile is
+// not given. DefaultProfile selects the top-level connection again.
+func SetActiveProfile(configPath, profile string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	v, err := readForUpdate(configPath)
+	if err != nil {
+		return err
+	}
+
+	if profile == DefaultProfile {
+		if v.GetString("url") == "" {
+			return fmt.Errorf("no default profile in config file. Run 'linkdingctl config init' to set up")
+		}
+		v.Set("active_profile", "")
+	} else {
+		if !v.IsSet("profiles." + profile) {
+			return fmt.Errorf("profile '%s' not found in config file. Run 'linkdingctl config init --profile %s' to create it", profile, profile)
+		}
+		v.Set("active_profile", profile)
+	}
+
+	return writeConfig(v, configPath)
+}
+
+// readForUpdate reads the config file at configPath, if it exists, so that
+// changes keep the settings they do not touch.
+func readForUpdate(configPath string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if filepath.Ext(configPath) == "" {
+		v.SetConfigType("yaml")
+	}
+	if _, err := os.Stat(configPath); err == nil {
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+	return v, nil
+}
+
+// writeConfig writes v to configPath with owner-only permissions.
+func writeConfig(v *viper.Viper, configPath string) error {
 	// Ensure directory exists with restricted permissions (owner-only)
 	dir := filepath.Dir(configPath)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	v := viper.New()
-	v.SetConfigFile(configPath)
-	v.Set("url", cfg.URL)
-	v.Set("token", cfg.Token)
-
 	if err := v.WriteConfig(); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
@@ -420,3 +420,193 @@ func TestMigration_SkipIfNoOldConfig(t *testing.T) {
 		t.Error("new config file was created when no old config existed (should not migrate)")
 	}
 }
+
+func TestLoadDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	content := []byte("defaults:\n  json: true\n  limit: 50\n  tags show:\n    all: true\n")
+	if err := os.WriteFile(configPath, content, 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	// URL and token are not required to read defaults
+	defaults, err := LoadDefaults(configPath)
+	if err != nil {
+		t.Fatalf("LoadDefaults() failed: %v", err)
+	}
+	if defaults["json"] != true {
+		t.Errorf("expected json default true, got %v", defaults["json"])
+	}
+	if defaults["limit"] != 50 {
+		t.Errorf("expected limit default 50, got %v", defaults["limit"])
+	}
+	scoped, ok := defaults["tags show"].(map[string]interface{})
+	if !ok || scoped["all"] != true {
+		t.Errorf("expected command-scoped defaults for 'tags show', got %v", defaults["tags show"])
+	}
+
+	// A missing file yields no defaults and no error
+	defaults, err = LoadDefaults(filepath.Join(tmpDir, "missing.yaml"))
+	if err != nil || len(defaults) != 0 {
+		t.Errorf("expected no defaults for missing file, got %v, %v", defaults, err)
+	}
+}
+
+func TestSave_PreservesDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	cfg := &Config{
+		URL:      "https://test.example.com",
+		Token:    "token",
+		Defaults: map[string]interface{}{"json": true},
+	}
+	if err := Save(cfg, configPath); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if loaded.Defaults["json"] != true {
+		t.Errorf("expected defaults to round-trip, got %v", loaded.Defaults)
+	}
+}
+
+func TestLoadFile_IgnoresEnv(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "source.yaml")
+	if err := os.WriteFile(configPath, []byte("url: https://file.example.com\ntoken: file-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("LINKDING_URL", "https://env.example.com")
+	t.Setenv("LINKDING_TOKEN", "env-token")
+
+	cfg, err := LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+	if cfg.URL != "https://file.example.com" || cfg.Token != "file-token" {
+		t.Errorf("expected values from the file, got URL %q and token %q", cfg.URL, cfg.Token)
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	partial := filepath.Join(t.TempDir(), "partial.yaml")
+	if err := os.WriteFile(partial, []byte("url: https://file.example.com\n"), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	if _, err := LoadFile(partial); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("expected a missing token error, got %v", err)
+	}
+}
+
+const profilesConfig = `url: https://default.example.com
+token: default-token
+profiles:
+  work:
+    url: https://work.example.com
+    token: work-token
+  test:
+    url: https://test.example.com
+    token: test-token
+`
+
+func TestLoadProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadProfile(configPath, "")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Profile != DefaultProfile || cfg.URL != "https://default.example.com" {
+		t.Errorf("expected the top-level connection, got %+v", cfg)
+	}
+
+	cfg, err = LoadProfile(configPath, "work")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Profile != "work" || cfg.URL != "https://work.example.com" || cfg.Token != "work-token" {
+		t.Errorf("expected the work profile, got %+v", cfg)
+	}
+
+	if _, err := LoadProfile(configPath, "missing"); err == nil || !strings.Contains(err.Error(), "profile 'missing' not found") {
+		t.Errorf("expected a missing profile error, got %v", err)
+	}
+	if _, err := LoadProfile(configPath, "a.b"); err == nil || !strings.Contains(err.Error(), "invalid profile name") {
+		t.Errorf("expected an invalid name error, got %v", err)
+	}
+}
+
+func TestLoadProfile_EnvPrecedence(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(profilesConfig+"active_profile: test\n"), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("LINKDING_URL", "https://env.example.com")
+	t.Setenv("LINKDING_TOKEN", "env-token")
+
+	// The environment overrides the active profile...
+	cfg, err := LoadProfile(configPath, "")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Profile != "test" || cfg.URL != "https://env.example.com" || cfg.Token != "env-token" {
+		t.Errorf("expected env values over the active profile, got %+v", cfg)
+	}
+
+	// ...but not a profile named explicitly
+	cfg, err = LoadProfile(configPath, "work")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.URL != "https://work.example.com" || cfg.Token != "work-token" {
+		t.Errorf("expected the named profile over env values, got %+v", cfg)
+	}
+}
+
+func TestSave_Profile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := Save(&Config{URL: "https://default.example.com", Token: "default-token"}, configPath); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if err := Save(&Config{URL: "https://work.example.com", Token: "work-token", Profile: "work"}, configPath); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	cfg, err := LoadProfile(configPath, "")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.URL != "https://default.example.com" {
+		t.Errorf("expected saving a profile to keep the default connection, got %+v", cfg)
+	}
+
+	if err := SetActiveProfile(configPath, "work"); err != nil {
+		t.Fatalf("SetActiveProfile() failed: %v", err)
+	}
+	cfg, err = LoadProfile(configPath, "")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Profile != "work" || cfg.Token != "work-token" {
+		t.Errorf("expected the active profile to be used, got %+v", cfg)
+	}
+
+	if err := SetActiveProfile(configPath, "default"); err != nil {
+		t.Fatalf("SetActiveProfile() failed: %v", err)
+	}
+	if cfg, _ := LoadProfile(configPath, ""); cfg == nil || cfg.Profile != DefaultProfile {
+		t.Errorf("expected 'default' to select the top-level connection, got %+v", cfg)
+	}
+
+	if err := SetActiveProfile(configPath, "missing"); err == nil {
+		t.Error("expected an error for a missing profile")
+	}
+}
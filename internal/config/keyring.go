@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// KeyringService is the service name tokens are stored under in the OS
+// keyring. The account is the profile name.
+const KeyringService = "linkdingctl"
+
+// keyringKey is the config key that marks a profile's token as stored in
+// the OS keyring. Its value is the keyring account holding the token.
+const keyringKey = "token_keyring"
+
+// secretStore is the subset of an OS keyring the config needs. Tests
+// replace secrets with an in-memory store.
+type secretStore interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// osKeyring stores secrets in the macOS Keychain, the Windows Credential
+// Manager or the Secret Service (GNOME Keyring, KWallet) on Linux.
+type osKeyring struct{}
+
+func (osKeyring) Get(service, account string) (string, error) {
+	return gokeyring.Get(service, account)
+}
+
+func (osKeyring) Set(service, account, secret string) error {
+	return gokeyring.Set(service, account, secret)
+}
+
+func (osKeyring) Delete(service, account string) error {
+	return gokeyring.Delete(service, account)
+}
+
+var secrets secretStore = osKeyring{}
+
+// readKeyringToken reads the token stored for account.
+func readKeyringToken(account string) (string, error) {
+	token, err := secrets.Get(KeyringService, account)
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return "", fmt.Errorf("no token for '%s' in the OS keyring. Run 'linkdingctl config init' to set it again", account)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read token from the OS keyring: %w", err)
+	}
+	return token, nil
+}
+
+// MoveTokenToKeyring moves the token of a profile from the config file at
+// configPath into the OS keyring and leaves a token_keyring marker in its
+// place, so Load reads the token from the keyring from then on. An empty
+// profile selects the file's active profile. It returns the profile moved.
+func MoveTokenToKeyring(configPath, profile string) (string, error) {
+	v, err := readExisting(configPath)
+	if err != nil {
+		return "", err
+	}
+	profile, prefix, err := profileSection(v, profile)
+	if err != nil {
+		return "", err
+	}
+
+	if v.GetString(prefix+keyringKey) != "" && v.GetString(prefix+"token") == "" {
+		return "", fmt.Errorf("the token of profile '%s' is already in the OS keyring", profile)
+	}
+	token := v.GetString(prefix + "token")
+	if token == "" {
+		return "", fmt.Errorf("profile '%s' has no token in the config file", profile)
+	}
+
+	if err := secrets.Set(KeyringService, profile, token); err != nil {
+		return "", fmt.Errorf("failed to store token in the OS keyring: %w", err)
+	}
+	settings := v.AllSettings()
+	section := sectionMap(settings, prefix)
+	delete(section, "token")
+	section[keyringKey] = profile
+	if err := rewriteConfig(settings, configPath); err != nil {
+		// Leave the token where it was rather than in two places
+		_ = secrets.Delete(KeyringService, profile)
+		return "", err
+	}
+	return profile, nil
+}
+
+// MoveTokenToFile reverses MoveTokenToKeyring: the token is written back to
+// the config file and removed from the OS keyring. It returns the profile
+// moved.
+func MoveTokenToFile(configPath, profile string) (string, error) {
+	v, err := readExisting(configPath)
+	if err != nil {
+		return "", err
+	}
+	profile, prefix, err := profileSection(v, profile)
+	if err != nil {
+		return "", err
+	}
+
+	account := v.GetString(prefix + keyringKey)
+	if account == "" {
+		return "", fmt.Errorf("the token of profile '%s' is not in the OS keyring", profile)
+	}
+	token, err := readKeyringToken(account)
+	if err != nil {
+		return "", err
+	}
+
+	settings := v.AllSettings()
+	section := sectionMap(settings, prefix)
+	delete(section, keyringKey)
+	section["token"] = token
+	if err := rewriteConfig(settings, configPath); err != nil {
+		return "", err
+	}
+	if err := secrets.Delete(KeyringService, account); err != nil && !errors.Is(err, gokeyring.ErrNotFound) {
+		return "", fmt.Errorf("token saved to the config file, but it could not be removed from the OS keyring: %w", err)
+	}
+	return profile, nil
+}
+
+// readExisting reads the config file at configPath, which must exist.
+func readExisting(configPath string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return v, nil
+}
+
+// profileSection resolves an empty profile to the active one and returns it
+// with the key prefix of its settings: "" for the top-level connection or
+// "profiles.NAME.".
+func profileSection(v *viper.Viper, profile string) (string, string, error) {
+	if profile == "" {
+		profile = v.GetString("active_profile")
+	}
+	if profile == "" || profile == DefaultProfile {
+		return DefaultProfile, "", nil
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return "", "", err
+	}
+	if !v.IsSet("profiles." + profile) {
+		return "", "", fmt.Errorf("profile '%s' not found in config file", profile)
+	}
+	return profile, "profiles." + profile + ".", nil
+}
+
+// sectionMap returns the map holding the settings under prefix.
+func sectionMap(settings map[string]interface{}, prefix string) map[string]interface{} {
+	if prefix == "" {
+		return settings
+	}
+	// viper lower-cases keys
+	name := strings.ToLower(prefix[len("profiles.") : len(prefix)-1])
+	profiles, _ := settings["profiles"].(map[string]interface{})
+	section, ok := profiles[name].(map[string]interface{})
+	if !ok {
+		section = make(map[string]interface{})
+		if profiles != nil {
+			profiles[name] = section
+		}
+	}
+	return section
+}
+
+// rewriteConfig replaces the config file with settings. Unlike Save it can
+// drop keys, which viper cannot unset.
+func rewriteConfig(settings map[string]interface{}, configPath string) error {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return writeConfig(v, configPath)
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// memKeyring is an in-memory secretStore.
+type memKeyring struct {
+	items  map[string]string
+	setErr error
+}
+
+func (m *memKeyring) Get(service, account string) (string, error) {
+	secret, ok := m.items[service+"/"+account]
+	if !ok {
+		return "", gokeyring.ErrNotFound
+	}
+	return secret, nil
+}
+
+func (m *memKeyring) Set(service, account, secret string) error {
+	if m.setErr != nil {
+		return m.setErr
+	}
+	m.items[service+"/"+account] = secret
+	return nil
+}
+
+func (m *memKeyring) Delete(service, account string) error {
+	if _, ok := m.items[service+"/"+account]; !ok {
+		return gokeyring.ErrNotFound
+	}
+	delete(m.items, service+"/"+account)
+	return nil
+}
+
+// useMemKeyring replaces the OS keyring for the rest of the test.
+func useMemKeyring(t *testing.T) *memKeyring {
+	t.Helper()
+	store := &memKeyring{items: make(map[string]string)}
+	previous := secrets
+	secrets = store
+	t.Cleanup(func() { secrets = previous })
+	return store
+}
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	return configPath
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	t.Setenv("LINKDING_URL", "")
+	t.Setenv("LINKDING_TOKEN", "")
+	store := useMemKeyring(t)
+	configPath := writeTestConfig(t, "url: https://links.example.com\ntoken: secret-token\ndefaults:\n  limit: 20\n")
+
+	profile, err := MoveTokenToKeyring(configPath, "")
+	if err != nil {
+		t.Fatalf("MoveTokenToKeyring() failed: %v", err)
+	}
+	if profile != DefaultProfile {
+		t.Errorf("expected profile %q, got %q", DefaultProfile, profile)
+	}
+	if store.items["linkdingctl/default"] != "secret-token" {
+		t.Errorf("token not stored in keyring: %v", store.items)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "secret-token") {
+		t.Errorf("token still in config file:\n%s", content)
+	}
+	if !strings.Contains(string(content), "token_keyring: default") || !strings.Contains(string(content), "limit: 20") {
+		t.Errorf("expected marker and other settings kept:\n%s", content)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.Token != "secret-token" || !cfg.TokenFromKeyring {
+		t.Errorf("expected token from keyring, got %q (from keyring: %v)", cfg.Token, cfg.TokenFromKeyring)
+	}
+
+	if _, err := MoveTokenToKeyring(configPath, ""); err == nil || !strings.Contains(err.Error(), "already in the OS keyring") {
+		t.Errorf("expected error moving twice, got: %v", err)
+	}
+
+	if _, err := MoveTokenToFile(configPath, ""); err != nil {
+		t.Fatalf("MoveTokenToFile() failed: %v", err)
+	}
+	if len(store.items) != 0 {
+		t.Errorf("expected keyring entry removed, got %v", store.items)
+	}
+	cfg, err = Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.Token != "secret-token" || cfg.TokenFromKeyring {
+		t.Errorf("expected token from file, got %q (from keyring: %v)", cfg.Token, cfg.TokenFromKeyring)
+	}
+	content, _ = os.ReadFile(configPath)
+	if strings.Contains(string(content), "token_keyring") {
+		t.Errorf("marker left in config file:\n%s", content)
+	}
+
+	if _, err := MoveTokenToFile(configPath, ""); err == nil || !strings.Contains(err.Error(), "not in the OS keyring") {
+		t.Errorf("expected error moving back twice, got: %v", err)
+	}
+}
+
+func TestKeyring_Profile(t *testing.T) {
+	t.Setenv("LINKDING_URL", "")
+	t.Setenv("LINKDING_TOKEN", "")
+	store := useMemKeyring(t)
+	configPath := writeTestConfig(t, `url: https://home.example.com
+token: home-token
+active_profile: Work
+profiles:
+  Work:
+    url: https://work.example.com
+    token: work-token
+`)
+
+	// The active profile is moved when none is named
+	profile, err := MoveTokenToKeyring(configPath, "")
+	if err != nil {
+		t.Fatalf("MoveTokenToKeyring() failed: %v", err)
+	}
+	if profile != "Work" || store.items["linkdingctl/Work"] != "work-token" {
+		t.Errorf("expected the Work token in the keyring, got profile %q and %v", profile, store.items)
+	}
+
+	cfg, err := LoadProfile(configPath, "Work")
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Token != "work-token" || !cfg.TokenFromKeyring {
+		t.Errorf("expected work token from keyring, got %q", cfg.Token)
+	}
+	cfg, err = LoadProfile(configPath, DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadProfile() failed: %v", err)
+	}
+	if cfg.Token != "home-token" || cfg.TokenFromKeyring {
+		t.Errorf("expected the default token left in the file, got %q", cfg.Token)
+	}
+
+	// The environment wins without reading the keyring
+	delete(store.items, "linkdingctl/Work")
+	t.Setenv("LINKDING_TOKEN", "env-token")
+	if cfg, err := Load(configPath); err != nil || cfg.Token != "env-token" {
+		t.Errorf("expected env token, got %v, %v", cfg, err)
+	}
+	t.Setenv("LINKDING_TOKEN", "")
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "no token for 'Work' in the OS keyring") {
+		t.Errorf("expected missing keyring entry error, got: %v", err)
+	}
+}
+
+func TestKeyring_SetFailureKeepsFile(t *testing.T) {
+	store := useMemKeyring(t)
+	store.setErr = errors.New("no secret service available")
+	original := "url: https://links.example.com\ntoken: secret-token\n"
+	configPath := writeTestConfig(t, original)
+
+	if _, err := MoveTokenToKeyring(configPath, ""); err == nil || !strings.Contains(err.Error(), "no secret service available") {
+		t.Errorf("expected keyring error, got: %v", err)
+	}
+	content, _ := os.ReadFile(configPath)
+	if string(content) != original {
+		t.Errorf("config file changed after a failed move:\n%s", content)
+	}
+}
+
+func TestLoadFile_Keyring(t *testing.T) {
+	store := useMemKeyring(t)
+	store.items["linkdingctl/default"] = "secret-token"
+	configPath := writeTestConfig(t, "url: https://links.example.com\ntoken_keyring: default\n")
+
+	cfg, err := LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("LoadFile() failed: %v", err)
+	}
+	if cfg.Token != "secret-token" {
+		t.Errorf("expected token from keyring, got %q", cfg.Token)
+	}
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// anonymizedHost is the host used for placeholder URLs. The .invalid TLD is
+// reserved and never resolves.
+const anonymizedHost = "anonymized.invalid"
+
+// Anonymize returns copies of bookmarks with personal data removed, for
+// sharing the structure of a collection:
+//
+//   - URL is replaced with https://anonymized.invalid/<hash>, where the hash
+//     is a salted SHA-256 of the original URL. The salt is random per call,
+//     so identical URLs share a placeholder within one export but cannot be
+//     matched against known URLs or across exports.
+//   - Title becomes "Bookmark <n>", numbered in export order.
+//   - Description, notes, website title and website description are blanked.
+//
+// IDs, tags, dates and the unread, shared and archived flags are kept.
+func Anonymize(bookmarks []models.Bookmark) ([]models.Bookmark, error) {
+	a, err := newAnonymizer()
+	if err != nil {
+		return nil, err
+	}
+	return a.apply(bookmarks), nil
+}
+
+// anonymizer applies Anonymize to bookmarks that arrive in batches, as in
+// a streamed export, keeping one salt and numbering titles across batches.
+type anonymizer struct {
+	salt []byte
+	n    int
+}
+
+func newAnonymizer() (*anonymizer, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate anonymization salt: %w", err)
+	}
+	return &anonymizer{salt: salt}, nil
+}
+
+func anonymizeWithSalt(bookmarks []models.Bookmark, salt []byte) []models.Bookmark {
+	return (&anonymizer{salt: salt}).apply(bookmarks)
+}
+
+// apply returns anonymized copies of the next batch of bookmarks.
+func (a *anonymizer) apply(bookmarks []models.Bookmark) []models.Bookmark {
+	anonymized := make([]models.Bookmark, len(bookmarks))
+	for i, b := range bookmarks {
+		a.n++
+		b.URL = placeholderURL(b.URL, a.salt)
+		b.Title = fmt.Sprintf("Bookmark %d", a.n)
+		b.Description = ""
+		b.Notes = ""
+		b.WebsiteTitle = ""
+		b.WebsiteDescription = ""
+		anonymized[i] = b
+	}
+	return anonymized
+}
+
+// placeholderURL derives a stable, non-reversible placeholder for rawURL.
+func placeholderURL(rawURL string, salt []byte) string {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(rawURL))
+	return fmt.Sprintf("https://%s/%s", anonymizedHost, hex.EncodeToString(h.Sum(nil))[:16])
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestExport_Anonymize(t *testing.T) {
+	added := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	bookmarks := []models.Bookmark{
+		{
+			ID:                 1,
+			URL:                "https://secret.example.com/private?token=abc",
+			Title:              "My Secret Page",
+			Description:        "personal description",
+			Notes:              "private notes",
+			WebsiteTitle:       "Secret Site",
+			WebsiteDescription: "site description",
+			TagNames:           []string{"work", "finance"},
+			DateAdded:          added,
+			Unread:             true,
+		},
+		{ID: 2, URL: "https://secret.example.com/private?token=abc", Title: "Duplicate", TagNames: []string{"work"}},
+		{ID: 3, URL: "https://other.example.org/", Title: "Other"},
+	}
+
+	sensitive := []string{
+		"secret.example.com", "token=abc", "other.example.org", "My Secret Page",
+		"personal description", "private notes", "Secret Site", "site description",
+	}
+
+	exporters := map[string]func(*bytes.Buffer) error{
+		"json": func(buf *bytes.Buffer) error {
+			return ExportJSON(newOrgTestClient(t, bookmarks), buf, ExportOptions{Anonymize: true})
+		},
+		"csv": func(buf *bytes.Buffer) error {
+			return ExportCSV(newOrgTestClient(t, bookmarks), buf, ExportOptions{Anonymize: true})
+		},
+		"html": func(buf *bytes.Buffer) error {
+			return ExportHTML(newOrgTestClient(t, bookmarks), buf, ExportOptions{Anonymize: true})
+		},
+		"org": func(buf *bytes.Buffer) error {
+			return ExportOrg(newOrgTestClient(t, bookmarks), buf, ExportOptions{Anonymize: true})
+		},
+	}
+
+	for name, exportFn := range exporters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := exportFn(&buf); err != nil {
+				t.Fatalf("export failed: %v", err)
+			}
+			output := buf.String()
+			for _, s := range sensitive {
+				if strings.Contains(output, s) {
+					t.Errorf("Anonymized output contains %q:\n%s", s, output)
+				}
+			}
+			for _, want := range []string{"work", "finance", anonymizedHost, "Bookmark 3"} {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected anonymized output to keep %q:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAnonymizeWithSalt(t *testing.T) {
+	bookmarks := []models.Bookmark{
+		{ID: 1, URL: "https://a.example.com", Shared: true, TagNames: []string{"x"}},
+		{ID: 2, URL: "https://a.example.com"},
+		{ID: 3, URL: "https://b.example.com"},
+	}
+
+	got := anonymizeWithSalt(bookmarks, []byte("salt"))
+	if len(got) != len(bookmarks) {
+		t.Fatalf("Expected %d bookmarks, got %d", len(bookmarks), len(got))
+	}
+	if got[0].URL != got[1].URL {
+		t.Errorf("Expected identical URLs to share a placeholder, got %q and %q", got[0].URL, got[1].URL)
+	}
+	if got[0].URL == got[2].URL {
+		t.Errorf("Expected different URLs to get different placeholders")
+	}
+	if !got[0].Shared || got[0].ID != 1 || len(got[0].TagNames) != 1 {
+		t.Errorf("Expected flags, IDs and tags to be kept, got %+v", got[0])
+	}
+	if bookmarks[0].URL != "https://a.example.com" {
+		t.Error("Expected the input bookmarks to be left unchanged")
+	}
+
+	// A different salt gives unrelated placeholders
+	if other := anonymizeWithSalt(bookmarks, []byte("pepper")); other[0].URL == got[0].URL {
+		t.Error("Expected placeholders to depend on the salt")
+	}
+}
@@ -0,0 +1,194 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Checkpoint records which entries of an import source have been processed,
+// so an interrupted import can be resumed without repeating them. It is
+// stored as JSON lines: a header naming the source file and its SHA-256,
+// then one line per processed entry with the resulting bookmark ID. A
+// line left incomplete by a crash mid-write is ignored.
+//
+// A nil *Checkpoint records nothing and reports no entries as done. It is
+// safe for concurrent use.
+type Checkpoint struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+	done map[int]int
+}
+
+type checkpointHeader struct {
+	Source string `json:"source"`
+	SHA256 string `json:"sha256"`
+}
+
+type checkpointEntry struct {
+	Entry int `json:"entry"`
+	ID    int `json:"id"`
+}
+
+// OpenCheckpoint opens the checkpoint at path for the import source file.
+// With resume set, entries recorded by an earlier run are loaded, and a
+// checkpoint written for a different source (by content hash) is an error.
+// Without resume, a checkpoint left by an earlier run is replaced by a
+// fresh one.
+func OpenCheckpoint(path, source string, resume bool) (*Checkpoint, error) {
+	hash, err := fileSHA256(source)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &Checkpoint{path: path, done: make(map[int]int)}
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && resume:
+		header, err := cp.load(existing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+		}
+		if header.SHA256 != hash {
+			return nil, fmt.Errorf("checkpoint %s was written for a different file (%s); refusing to resume", path, header.Source)
+		}
+		cp.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+		}
+		// Terminate a partial line so new entries start on their own line
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			if _, err := cp.file.Write([]byte("\n")); err != nil {
+				_ = cp.file.Close()
+				return nil, fmt.Errorf("failed to write checkpoint: %w", err)
+			}
+		}
+	case err == nil || errors.Is(err, os.ErrNotExist):
+		cp.file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create checkpoint: %w", err)
+		}
+		if err := cp.write(checkpointHeader{Source: source, SHA256: hash}); err != nil {
+			_ = cp.file.Close()
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	return cp, nil
+}
+
+// load reads the header and recorded entries from an existing checkpoint.
+// Lines that do not parse, such as one cut short by a crash, are skipped.
+func (c *Checkpoint) load(data []byte) (checkpointHeader, error) {
+	var header checkpointHeader
+	lines := bytes.Split(data, []byte("\n"))
+	if err := json.Unmarshal(lines[0], &header); err != nil || header.SHA256 == "" {
+		return header, fmt.Errorf("invalid checkpoint header")
+	}
+	for _, line := range lines[1:] {
+		var entry checkpointEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		c.done[entry.Entry] = entry.ID
+	}
+	return header, nil
+}
+
+func (c *Checkpoint) write(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Path returns the checkpoint file path.
+func (c *Checkpoint) Path() string {
+	if c == nil {
+		return ""
+	}
+	return c.path
+}
+
+// Len returns the number of entries recorded as processed.
+func (c *Checkpoint) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.done)
+}
+
+// Done reports whether the entry (0-based, in file order) was already
+// processed.
+func (c *Checkpoint) Done(entry int) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.done[entry]
+	return ok
+}
+
+// Record marks the entry as processed, noting the ID of the bookmark it
+// created or updated.
+func (c *Checkpoint) Record(entry, id int) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.write(checkpointEntry{Entry: entry, ID: id}); err != nil {
+		return err
+	}
+	c.done[entry] = id
+	return nil
+}
+
+// Close closes the checkpoint file, keeping it for a later --resume.
+func (c *Checkpoint) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.file.Close()
+}
+
+// Remove closes and deletes the checkpoint file once an import has finished.
+func (c *Checkpoint) Remove() error {
+	if c == nil {
+		return nil
+	}
+	_ = c.file.Close()
+	return os.Remove(c.path)
+}
+
+// fileSHA256 returns the hex SHA-256 of the file's contents.
+func fileSHA256(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,210 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestCheckpoint_InterruptAndResume(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "bookmarks.json")
+	var data ExportData
+	for i := 0; i < 6; i++ {
+		data.Bookmarks = append(data.Bookmarks, ExportBookmark{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(source, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var created []string
+	failAfter := 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		// Simulate the connection dropping partway through the first run
+		if failAfter >= 0 && len(created) == failAfter {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var body models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		created = append(created, body.URL)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 100 + len(created), URL: body.URL})
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+
+	path := filepath.Join(dir, "bookmarks.json.checkpoint")
+
+	// First run stops at the failed entry
+	cp, err := OpenCheckpoint(path, source, false)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() failed: %v", err)
+	}
+	result, err := ImportBookmarks(client, source, ImportOptions{StopOnError: true, Checkpoint: cp})
+	if err == nil {
+		t.Fatal("Expected the first run to stop with an error")
+	}
+	if result.Added != 3 {
+		t.Errorf("Expected 3 added before the interruption, got %d", result.Added)
+	}
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Resumed run processes only the remainder
+	failAfter = -1
+	cp, err = OpenCheckpoint(path, source, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() with resume failed: %v", err)
+	}
+	if cp.Len() != 3 {
+		t.Errorf("Expected 3 entries in the checkpoint, got %d", cp.Len())
+	}
+	result, err = ImportBookmarks(client, source, ImportOptions{StopOnError: true, Checkpoint: cp})
+	if err != nil {
+		t.Fatalf("Resumed import failed: %v", err)
+	}
+	if result.Added != 3 || result.Resumed != 3 {
+		t.Errorf("Expected 3 added and 3 resumed, got %+v", result)
+	}
+	want := "https://example.com/0 https://example.com/1 https://example.com/2 https://example.com/3 https://example.com/4 https://example.com/5"
+	if got := strings.Join(created, " "); got != want {
+		t.Errorf("Expected each entry created exactly once, got %s", got)
+	}
+	if err := cp.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected checkpoint to be removed, got %v", err)
+	}
+}
+
+func TestCheckpoint_WrongSourceFile(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	if err := os.WriteFile(first, []byte(`{"bookmarks":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte(`{"bookmarks":[{"url":"https://example.com"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "import.checkpoint")
+	cp, err := OpenCheckpoint(path, first, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Record(0, 7); err != nil {
+		t.Fatal(err)
+	}
+	_ = cp.Close()
+
+	_, err = OpenCheckpoint(path, second, true)
+	if err == nil || !strings.Contains(err.Error(), "different file") {
+		t.Errorf("Expected resuming with another file to fail, got %v", err)
+	}
+}
+
+func TestCheckpoint_IgnoresTruncatedLine(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "bookmarks.csv")
+	if err := os.WriteFile(source, []byte("url\nhttps://example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "bookmarks.csv.checkpoint")
+	cp, err := OpenCheckpoint(path, source, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Record(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	_ = cp.Close()
+
+	// A crash mid-write leaves a partial line
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"entry":1,"i`)
+	_ = f.Close()
+
+	cp, err = OpenCheckpoint(path, source, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() failed: %v", err)
+	}
+	if !cp.Done(0) || cp.Done(1) || cp.Len() != 1 {
+		t.Errorf("Expected only entry 0 to be done, got %d entries", cp.Len())
+	}
+
+	// Entries recorded after the partial line are read back
+	if err := cp.Record(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	_ = cp.Close()
+	cp, err = OpenCheckpoint(path, source, true)
+	if err != nil {
+		t.Fatalf("OpenCheckpoint() failed: %v", err)
+	}
+	defer func() { _ = cp.Close() }()
+	if !cp.Done(1) || cp.Len() != 2 {
+		t.Errorf("Expected entries 0 and 1 to be done, got %d entries", cp.Len())
+	}
+}
+
+func TestCheckpoint_NilIsNoop(t *testing.T) {
+	var cp *Checkpoint
+	if cp.Done(0) || cp.Len() != 0 || cp.Record(0, 1) != nil || cp.Close() != nil || cp.Remove() != nil {
+		t.Error("Expected nil checkpoint methods to be no-ops")
+	}
+}
+
+func TestCheckpoint_ReplacedWithoutResume(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "bookmarks.json")
+	if err := os.WriteFile(source, []byte(`{"bookmarks":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "bookmarks.json.checkpoint")
+
+	cp, err := OpenCheckpoint(path, source, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Record(0, 7); err != nil {
+		t.Fatal(err)
+	}
+	_ = cp.Close()
+
+	cp, err = OpenCheckpoint(path, source, false)
+	if err != nil {
+		t.Fatalf("Expected a stale checkpoint to be replaced, got %v", err)
+	}
+	_ = cp.Close()
+	cp, err = OpenCheckpoint(path, source, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cp.Close() }()
+	if cp.Len() != 0 {
+		t.Errorf("Expected the replaced checkpoint to be empty, got %d entries", cp.Len())
+	}
+}
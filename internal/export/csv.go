@@ -13,9 +13,9 @@ import (
 // ExportCSV exports bookmarks to CSV format
 func ExportCSV(client *api.Client, writer io.Writer, options ExportOptions) error {
 	// Fetch all bookmarks using the Client's pagination method
-	bookmarks, err := client.FetchAllBookmarks(options.Tags, options.IncludeArchived)
-	if err != nil {
-		return err
+	bookmarks, fetchErr := fetchBookmarks(client, options)
+	if fetchErr != nil && !isPartialFetch(fetchErr) {
+		return fetchErr
 	}
 
 	// Create CSV writer
@@ -68,5 +68,5 @@ func ExportCSV(client *api.Client, writer io.Writer, options ExportOptions) erro
 		}
 	}
 
-	return nil
+	return fetchErr
 }
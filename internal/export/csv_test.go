@@ -348,6 +348,36 @@ func TestExportCSV_WithFilters(t *testing.T) {
 	}
 }
 
+func TestExportCSV_WithQuery(t *testing.T) {
+	var qParam string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qParam = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	var buf bytes.Buffer
+	err := ExportCSV(client, &buf, ExportOptions{Query: "api"})
+	if err != nil {
+		t.Fatalf("ExportCSV() with query failed: %v", err)
+	}
+
+	if qParam != "api" {
+		t.Errorf("Expected q=api, got %q", qParam)
+	}
+
+	// No matches still writes the header
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read exported CSV: %v", err)
+	}
+	if len(records) != 1 || records[0][0] != "url" {
+		t.Errorf("Expected only the header row, got %v", records)
+	}
+}
+
 // TestCSV_RoundTrip tests that CSV export→import preserves all bookmark data
 func TestCSV_RoundTrip(t *testing.T) {
 	testTime := time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC)
@@ -4,17 +4,19 @@ import (
 	"fmt"
 	"html"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
 )
 
 // ExportHTML exports bookmarks to Netscape bookmark format (HTML)
 func ExportHTML(client *api.Client, writer io.Writer, options ExportOptions) error {
 	// Fetch all bookmarks using the Client's pagination method
-	bookmarks, err := client.FetchAllBookmarks(options.Tags, options.IncludeArchived)
-	if err != nil {
-		return err
+	bookmarks, fetchErr := fetchBookmarks(client, options)
+	if fetchErr != nil && !isPartialFetch(fetchErr) {
+		return fetchErr
 	}
 
 	// Write HTML header
@@ -34,49 +36,142 @@ func ExportHTML(client *api.Client, writer io.Writer, options ExportOptions) err
 		return fmt.Errorf("failed to write HTML list start: %w", err)
 	}
 
-	// Write each bookmark
-	for _, b := range bookmarks {
-		// Convert date_added to Unix timestamp
-		addDate := b.DateAdded.Unix()
+	if options.FolderPrefix != "" {
+		if err := writeHTMLFolder(writer, folderTree(bookmarks, options.FolderPrefix), 1); err != nil {
+			return err
+		}
+	} else {
+		for _, b := range bookmarks {
+			if err := writeHTMLBookmark(writer, b, 1); err != nil {
+				return err
+			}
+		}
+	}
 
-		// Escape HTML in title and URL
-		escapedURL := html.EscapeString(b.URL)
-		escapedTitle := html.EscapeString(b.Title)
+	// Write HTML footer
+	if _, err := fmt.Fprintf(writer, "</DL><p>\n"); err != nil {
+		return fmt.Errorf("failed to write HTML list end: %w", err)
+	}
 
-		// Build tags string (comma-separated)
-		tags := strings.Join(b.TagNames, ",")
-		escapedTags := html.EscapeString(tags)
+	return fetchErr
+}
 
-		// Write bookmark entry
-		if _, err := fmt.Fprintf(writer, "    <DT><A HREF=\"%s\" ADD_DATE=\"%d\"", escapedURL, addDate); err != nil {
-			return fmt.Errorf("failed to write bookmark entry: %w", err)
-		}
+// htmlFolder is a folder of an HTML export grouped by tag prefix.
+type htmlFolder struct {
+	folders   map[string]*htmlFolder
+	bookmarks []models.Bookmark
+}
+
+// folderTree places each bookmark in the folder named by its first tag
+// (in sorted order) under prefix: with prefix "browser", a bookmark tagged
+// browser/work/docs goes in work > docs. Bookmarks without such a tag, or
+// tagged with the prefix alone, stay at the top level.
+func folderTree(bookmarks []models.Bookmark, prefix string) *htmlFolder {
+	prefix = strings.Trim(prefix, "/")
+	root := &htmlFolder{}
+	for _, b := range bookmarks {
+		tags := append([]string(nil), b.TagNames...)
+		sort.Strings(tags)
 
-		// Add tags attribute if there are tags
-		if len(b.TagNames) > 0 {
-			if _, err := fmt.Fprintf(writer, " TAGS=\"%s\"", escapedTags); err != nil {
-				return fmt.Errorf("failed to write tags: %w", err)
+		folder := root
+		for _, tag := range tags {
+			// Tags are case-insensitive in LinkDing
+			if len(tag) <= len(prefix)+1 || !strings.EqualFold(tag[:len(prefix)], prefix) || tag[len(prefix)] != '/' {
+				continue
+			}
+			rest := strings.Trim(tag[len(prefix)+1:], "/")
+			if rest == "" {
+				continue
+			}
+			for _, name := range strings.Split(rest, "/") {
+				if name == "" {
+					continue
+				}
+				if folder.folders == nil {
+					folder.folders = make(map[string]*htmlFolder)
+				}
+				if folder.folders[name] == nil {
+					folder.folders[name] = &htmlFolder{}
+				}
+				folder = folder.folders[name]
 			}
+			break
 		}
+		folder.bookmarks = append(folder.bookmarks, b)
+	}
+	return root
+}
+
+// writeHTMLFolder writes a folder's subfolders, sorted by name, and then its
+// bookmarks, indented for the given depth.
+func writeHTMLFolder(writer io.Writer, folder *htmlFolder, depth int) error {
+	indent := strings.Repeat("    ", depth)
+
+	names := make([]string, 0, len(folder.folders))
+	for name := range folder.folders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		// Close the anchor tag and write title
-		if _, err := fmt.Fprintf(writer, ">%s</A>\n", escapedTitle); err != nil {
-			return fmt.Errorf("failed to write bookmark title: %w", err)
+	for _, name := range names {
+		if _, err := fmt.Fprintf(writer, "%s<DT><H3>%s</H3>\n%s<DL><p>\n", indent, html.EscapeString(name), indent); err != nil {
+			return fmt.Errorf("failed to write folder: %w", err)
 		}
+		if err := writeHTMLFolder(writer, folder.folders[name], depth+1); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(writer, "%s</DL><p>\n", indent); err != nil {
+			return fmt.Errorf("failed to write folder end: %w", err)
+		}
+	}
 
-		// Write description if present
-		if b.Description != "" {
-			escapedDesc := html.EscapeString(b.Description)
-			if _, err := fmt.Fprintf(writer, "    <DD>%s\n", escapedDesc); err != nil {
-				return fmt.Errorf("failed to write description: %w", err)
-			}
+	for _, b := range folder.bookmarks {
+		if err := writeHTMLBookmark(writer, b, depth); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// Write HTML footer
-	if _, err := fmt.Fprintf(writer, "</DL><p>\n"); err != nil {
-		return fmt.Errorf("failed to write HTML list end: %w", err)
+// writeHTMLBookmark writes one <DT> entry, and its <DD> description if it
+// has one, indented for the given depth.
+func writeHTMLBookmark(writer io.Writer, b models.Bookmark, depth int) error {
+	indent := strings.Repeat("    ", depth)
+
+	// Convert date_added to Unix timestamp
+	addDate := b.DateAdded.Unix()
+
+	// Escape HTML in title and URL
+	escapedURL := html.EscapeString(b.URL)
+	escapedTitle := html.EscapeString(b.Title)
+
+	// Build tags string (comma-separated)
+	tags := strings.Join(b.TagNames, ",")
+	escapedTags := html.EscapeString(tags)
+
+	// Write bookmark entry
+	if _, err := fmt.Fprintf(writer, "%s<DT><A HREF=\"%s\" ADD_DATE=\"%d\"", indent, escapedURL, addDate); err != nil {
+		return fmt.Errorf("failed to write bookmark entry: %w", err)
+	}
+
+	// Add tags attribute if there are tags
+	if len(b.TagNames) > 0 {
+		if _, err := fmt.Fprintf(writer, " TAGS=\"%s\"", escapedTags); err != nil {
+			return fmt.Errorf("failed to write tags: %w", err)
+		}
+	}
+
+	// Close the anchor tag and write title
+	if _, err := fmt.Fprintf(writer, ">%s</A>\n", escapedTitle); err != nil {
+		return fmt.Errorf("failed to write bookmark title: %w", err)
 	}
 
+	// Write description if present
+	if b.Description != "" {
+		escapedDesc := html.EscapeString(b.Description)
+		if _, err := fmt.Fprintf(writer, "%s<DD>%s\n", indent, escapedDesc); err != nil {
+			return fmt.Errorf("failed to write description: %w", err)
+		}
+	}
 	return nil
 }
@@ -458,3 +458,56 @@ func TestExportHTML_WithFilters(t *testing.T) {
 		}
 	})
 }
+
+func TestExportHTML_FolderPrefix(t *testing.T) {
+	testTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	bookmarks := []models.Bookmark{
+		{ID: 1, URL: "https://docs.example.com", Title: "Docs", TagNames: []string{"browser/work/docs", "reference"}, DateAdded: testTime},
+		{ID: 2, URL: "https://loose.example.com", Title: "Loose", TagNames: []string{"other"}, DateAdded: testTime},
+		{ID: 3, URL: "https://work.example.com", Title: "Work", TagNames: []string{"Browser/work"}, DateAdded: testTime},
+		{ID: 4, URL: "https://root.example.com", Title: "Root", TagNames: []string{"browser"}, DateAdded: testTime},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewEncoder(w).Encode(models.BookmarkList{Count: len(bookmarks), Results: bookmarks}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	var buf bytes.Buffer
+	if err := ExportHTML(client, &buf, ExportOptions{FolderPrefix: "browser/"}); err != nil {
+		t.Fatalf("ExportHTML() failed: %v", err)
+	}
+
+	want := `<DL><p>
+    <DT><H3>work</H3>
+    <DL><p>
+        <DT><H3>docs</H3>
+        <DL><p>
+            <DT><A HREF="https://docs.example.com" ADD_DATE="1704110400" TAGS="browser/work/docs,reference">Docs</A>
+        </DL><p>
+        <DT><A HREF="https://work.example.com" ADD_DATE="1704110400" TAGS="Browser/work">Work</A>
+    </DL><p>
+    <DT><A HREF="https://loose.example.com" ADD_DATE="1704110400" TAGS="other">Loose</A>
+    <DT><A HREF="https://root.example.com" ADD_DATE="1704110400" TAGS="browser">Root</A>
+</DL><p>
+`
+	if output := buf.String(); !strings.HasSuffix(output, want) {
+		t.Errorf("Unexpected folder layout:\n%s\nwant suffix:\n%s", output, want)
+	}
+
+	// The export reads back into the same folders
+	parsed, errs, err := parseNetscapeBookmarks(&buf, true)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("parseNetscapeBookmarks() = %v, %v", errs, err)
+	}
+	folders := make(map[string]string)
+	for _, b := range parsed {
+		folders[b.URL] = folderTag(b.Folders)
+	}
+	if folders["https://docs.example.com"] != "work/docs" || folders["https://work.example.com"] != "work" || folders["https://root.example.com"] != "" {
+		t.Errorf("Folders read back = %v", folders)
+	}
+}
@@ -3,17 +3,24 @@ package export
 
 import (
 	"bufio"
+	"compress/gzip"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/batch"
 	"github.com/rodstewart/linkding-cli/internal/models"
+	"github.com/rodstewart/linkding-cli/internal/urlutil"
 )
 
 // ImportResult tracks the outcome of an import operation
@@ -22,7 +29,16 @@ type ImportResult struct {
 	Updated int
 	Skipped int
 	Failed  int
+	// Resumed counts entries skipped because a checkpoint from an earlier
+	// run had already processed them
+	Resumed int
 	Errors  []ImportError
+	// BundlesAdded and BundlesSkipped count bundles recreated from the file
+	// and those skipped because a bundle with the same name already exists;
+	// BundleErrors reports failures, with Line numbering the file's bundles
+	BundlesAdded   int
+	BundlesSkipped int
+	BundleErrors   []ImportError
 }
 
 // ImportError represents a single import failure
@@ -33,15 +49,145 @@ type ImportError struct {
 
 // ImportOptions configures the import behavior
 type ImportOptions struct {
-	Format         string // json, html, csv, or auto
+	Format         string // json, html (alias netscape), csv, or auto
 	DryRun         bool
 	SkipDuplicates bool
 	AddTags        []string
+	// PreviewExisting lets a DryRun read the bookmarks already on the
+	// server, so entries that would update or skip one are counted as such
+	// instead of as added. Nothing is written either way.
+	PreviewExisting bool
+	// Strict rejects HTML files that are not well-formed Netscape bookmark
+	// files and reports malformed entries as errors instead of skipping them
+	Strict bool
+	// Offset skips this many entries, and Limit (if > 0) processes at most
+	// this many, counting entries in file order
+	Offset int
+	Limit  int
+	// StopOnError ends the import at the first failed entry instead of
+	// continuing with the rest of the file
+	StopOnError bool
+	// AllowedSchemes lists the URL schemes that may be imported; entries with
+	// any other scheme fail. Empty means urlutil.DefaultSchemes.
+	AllowedSchemes []string
+	// Pacer, if set, processes entries in batches with a pause between them
+	Pacer *batch.Pacer
+	// Checkpoint, if set, records each processed entry and skips entries an
+	// earlier run already processed
+	Checkpoint *Checkpoint
+	// Bundles recreates the bundles stored in a JSON file exported with
+	// bundles, after its bookmarks. Bundles whose name already exists are
+	// left alone.
+	Bundles bool
+	// Concurrency is the number of create and update requests sent in
+	// parallel, at most MaxConcurrency; 0 or 1 sends them one at a time.
+	// With more than one, entries are sent in batches of that size, so
+	// StopOnError lets the rest of the failed entry's batch finish. Errors
+	// is sorted by line either way.
+	Concurrency int
+	// RetryFailed is the number of times the create and update requests
+	// that failed are sent again once every entry has been processed, to
+	// get past transient errors. Creates are only sent again if the
+	// bookmarks on the server, read again first, do not already hold the
+	// URL, since a create whose response was lost may have gone through.
+	// Entries rejected before any request, such as those with an invalid
+	// URL, are not retried. It has no effect with StopOnError.
+	RetryFailed int
+	// FoldersAsTags tags each HTML bookmark with the path of the folders it
+	// is nested in, joined by "/", such as "browser/work/docs"
+	FoldersAsTags bool
+	// CSVDialect adjusts CSV parsing for files written by other services:
+	// "" or CSVDialectDefault, or CSVDialectPocket for Pocket's export
+	CSVDialect string
+	// NormalizeURLs matches entries to existing bookmarks by
+	// urlutil.Canonical instead of the exact URL, so http://www.x.com/ and
+	// https://x.com count as duplicates
+	NormalizeURLs bool
+	// Progress, if set, is called as each entry in the Offset/Limit window
+	// is reached, with the number handled before it, and once more when
+	// the import ends. total is 0 for CSV files, which are imported as they
+	// are read. With Concurrency, entries still in flight count as handled.
+	Progress func(done, total int)
 }
 
-// DetectFormat determines the import format from the file extension
+// CSV dialects accepted by ImportOptions.CSVDialect.
+const (
+	CSVDialectDefault = "default"
+	// CSVDialectPocket reads Pocket's title,url,time_added,tags,status export:
+	// tags are separated by "|", and a title that only repeats the URL is
+	// dropped so LinkDing fetches the page's own title.
+	CSVDialectPocket = "pocket"
+)
+
+// csvColumnAliases maps other header names, as written by other services,
+// to the columns importCSV reads. A column named exactly like the target
+// wins over its aliases.
+var csvColumnAliases = map[string]string{
+	"link":        "url",
+	"href":        "url",
+	"address":     "url",
+	"name":        "title",
+	"excerpt":     "description",
+	"labels":      "tags",
+	"tag":         "tags",
+	"to_read":     "unread",
+	"toread":      "unread",
+	"is_archived": "archived",
+}
+
+// checkScheme validates an entry's URL scheme against AllowedSchemes.
+func (o ImportOptions) checkScheme(rawURL string) error {
+	allowed := o.AllowedSchemes
+	if len(allowed) == 0 {
+		allowed = urlutil.DefaultSchemes
+	}
+	return urlutil.CheckScheme(rawURL, allowed)
+}
+
+// stopError returns the error that ends an import under StopOnError once an
+// entry has failed, or nil if the import should continue.
+func (o ImportOptions) stopError(result *ImportResult) error {
+	if !o.StopOnError || len(result.Errors) == 0 {
+		return nil
+	}
+	first := result.Errors[0]
+	return fmt.Errorf("import stopped at line %d: %s", first.Line, first.Message)
+}
+
+// inWindow reports whether the entry at index (0-based, in file order) falls
+// inside the Offset/Limit window.
+func (o ImportOptions) inWindow(index int) bool {
+	return index >= o.Offset && (o.Limit <= 0 || index < o.Offset+o.Limit)
+}
+
+// pastWindow reports whether the entry at index and all later ones fall
+// after the Offset/Limit window.
+func (o ImportOptions) pastWindow(index int) bool {
+	return o.Limit > 0 && index >= o.Offset+o.Limit
+}
+
+// windowSize returns how many of n entries fall inside the Offset/Limit
+// window.
+func (o ImportOptions) windowSize(n int) int {
+	n = max(n-o.Offset, 0)
+	if o.Limit > 0 {
+		n = min(n, o.Limit)
+	}
+	return n
+}
+
+// progress reports done entries out of total to Progress, if set.
+func (o ImportOptions) progress(done, total int) {
+	if o.Progress != nil {
+		o.Progress(done, total)
+	}
+}
+
+// DetectFormat determines the import format from the file extension. A
+// trailing ".gz" is ignored, so "backup.json.gz" is JSON.
 func DetectFormat(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
+	filename = strings.TrimSuffix(strings.ToLower(filename), ".gz")
+	ext := filepath.Ext(filename)
 	switch ext {
 	case ".json":
 		return "json"
@@ -54,6 +200,63 @@ func DetectFormat(filename string) string {
 	}
 }
 
+// gzipFile closes both the gzip reader and the file under it.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	_ = g.Reader.Close()
+	return g.file.Close()
+}
+
+// OpenFile opens a file to import. Gzip-compressed files, such as backups
+// made with --gzip, are recognized by their content and decompressed.
+func OpenFile(filename string) (io.ReadCloser, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	magic, _ := reader.Peek(2)
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return struct {
+			io.Reader
+			io.Closer
+		}{reader, file}, nil
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to decompress %s: %w", filename, err)
+	}
+	return gzipFile{Reader: gz, file: file}, nil
+}
+
+// IncrementalSince returns the IncrementalSince marker of a JSON backup, or
+// nil for a full backup. Files in other formats are never incremental.
+func IncrementalSince(filename string) (*time.Time, error) {
+	if DetectFormat(filename) != "json" {
+		return nil, nil
+	}
+	file, err := OpenFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var header struct {
+		IncrementalSince *time.Time `json:"incremental_since"`
+	}
+	if err := json.NewDecoder(file).Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return header.IncrementalSince, nil
+}
+
 // ImportBookmarks imports bookmarks from a file
 func ImportBookmarks(client *api.Client, filename string, options ImportOptions) (*ImportResult, error) {
 	// Auto-detect format if not specified
@@ -66,9 +269,9 @@ func ImportBookmarks(client *api.Client, filename string, options ImportOptions)
 	}
 
 	// Open file
-	file, err := os.Open(filename)
+	file, err := OpenFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
@@ -76,7 +279,7 @@ func ImportBookmarks(client *api.Client, filename string, options ImportOptions)
 	switch format {
 	case "json":
 		return importJSON(client, file, options)
-	case "html":
+	case "html", "netscape":
 		return importHTML(client, file, options)
 	case "csv":
 		return importCSV(client, file, options)
@@ -95,19 +298,32 @@ func importJSON(client *api.Client, reader io.Reader, options ImportOptions) (*I
 	result := &ImportResult{}
 
 	// Get existing bookmarks to check for duplicates
-	existingURLs := make(map[string]int)
-	if !options.DryRun {
-		existing, err := client.FetchAllBookmarks(nil, true)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch existing bookmarks: %w", err)
-		}
-		for _, b := range existing {
-			existingURLs[b.URL] = b.ID
-		}
+	existingURLs, err := existingBookmarkURLs(client, options)
+	if err != nil {
+		return nil, err
 	}
 
 	// Import each bookmark
+	writer := newBookmarkWriter(client, result, options)
+	var writeErr error
+	handled, total := 0, options.windowSize(len(data.Bookmarks))
 	for i, exportBookmark := range data.Bookmarks {
+		if options.stopError(result) != nil {
+			break
+		}
+		if options.pastWindow(i) {
+			break
+		}
+		if !options.inWindow(i) {
+			continue
+		}
+		options.progress(handled, total)
+		handled++
+		if options.Checkpoint.Done(i) {
+			result.Resumed++
+			continue
+		}
+		options.Pacer.Next(i)
 		lineNum := i + 1
 
 		// Validate required fields
@@ -119,6 +335,11 @@ func importJSON(client *api.Client, reader io.Reader, options ImportOptions) (*I
 			})
 			continue
 		}
+		if err := options.checkScheme(exportBookmark.URL); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, ImportError{Line: lineNum, Message: err.Error()})
+			continue
+		}
 
 		// Prepare bookmark for creation
 		tags := exportBookmark.Tags
@@ -135,12 +356,18 @@ func importJSON(client *api.Client, reader io.Reader, options ImportOptions) (*I
 			Unread:      exportBookmark.Unread,
 			Shared:      exportBookmark.Shared,
 		}
+		if exportBookmark.Notes != nil {
+			bookmarkCreate.Notes = *exportBookmark.Notes
+		}
 
 		// Check for duplicates
-		existingID, exists := existingURLs[exportBookmark.URL]
+		existingID, exists := existingURLs[options.urlKey(exportBookmark.URL)]
 
 		if exists && options.SkipDuplicates {
 			result.Skipped++
+			if writeErr = options.Checkpoint.Record(i, existingID); writeErr != nil {
+				break
+			}
 			continue
 		}
 
@@ -154,124 +381,427 @@ func importJSON(client *api.Client, reader io.Reader, options ImportOptions) (*I
 		}
 
 		// Create or update bookmark
+		job := writeJob{entry: i, line: lineNum, existingID: existingID, exists: exists, create: bookmarkCreate}
 		if exists {
-			// Update existing bookmark
-			update := &models.BookmarkUpdate{
+			job.update = &models.BookmarkUpdate{
 				URL:         &bookmarkCreate.URL,
 				Title:       &bookmarkCreate.Title,
 				Description: &bookmarkCreate.Description,
+				Notes:       exportBookmark.Notes,
 				TagNames:    &bookmarkCreate.TagNames,
 				IsArchived:  &bookmarkCreate.IsArchived,
 				Unread:      &bookmarkCreate.Unread,
 				Shared:      &bookmarkCreate.Shared,
 			}
-			_, err := client.UpdateBookmark(existingID, update)
-			if err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, ImportError{
-					Line:    lineNum,
-					Message: fmt.Sprintf("Failed to update: %v", err),
-				})
-				continue
-			}
-			result.Updated++
-		} else {
-			// Create new bookmark
-			_, err := client.CreateBookmark(bookmarkCreate)
-			if err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, ImportError{
-					Line:    lineNum,
-					Message: fmt.Sprintf("Failed to create: %v", err),
-				})
-				continue
-			}
-			result.Added++
+		}
+		if writeErr = writer.write(job); writeErr != nil {
+			break
+		}
+	}
+
+	if err := writer.wait(); writeErr == nil {
+		writeErr = err
+	}
+	options.progress(handled, total)
+	if writeErr != nil {
+		return result, writeErr
+	}
+	if err := options.stopError(result); err != nil {
+		return result, err
+	}
+	if options.Bundles && len(data.Bundles) > 0 {
+		if err := importBundles(client, data.Bundles, options, result); err != nil {
+			return result, err
 		}
 	}
 
 	return result, nil
 }
 
-// importHTML imports bookmarks from Netscape HTML bookmark format
-func importHTML(client *api.Client, reader io.Reader, options ImportOptions) (*ImportResult, error) {
-	result := &ImportResult{}
+// existingBookmarkURLs maps the URL of every bookmark on the server, as
+// returned by urlKey, to its ID, used to update or skip duplicates. A dry
+// run sends no requests and finds none, unless PreviewExisting is set.
+func existingBookmarkURLs(client *api.Client, options ImportOptions) (map[string]int, error) {
+	if options.DryRun && !options.PreviewExisting {
+		return map[string]int{}, nil
+	}
+	existing, err := client.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing bookmarks: %w", err)
+	}
+	urls := make(map[string]int, len(existing))
+	for _, b := range existing {
+		urls[options.urlKey(b.URL)] = b.ID
+	}
+	return urls, nil
+}
 
-	// Get existing bookmarks to check for duplicates
-	existingURLs := make(map[string]int)
+// urlKey is the form of a URL used to look it up among the existing
+// bookmarks.
+func (o ImportOptions) urlKey(rawURL string) string {
+	if o.NormalizeURLs {
+		return urlutil.Canonical(rawURL)
+	}
+	return rawURL
+}
+
+// importBundles recreates bundles from an export, skipping any whose name
+// already exists on the server.
+func importBundles(client *api.Client, bundles []ExportBundle, options ImportOptions, result *ImportResult) error {
+	existing := make(map[string]bool)
 	if !options.DryRun {
-		existing, err := client.FetchAllBookmarks(nil, true)
+		current, err := client.FetchAllBundles()
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch existing bookmarks: %w", err)
+			return fmt.Errorf("failed to fetch existing bundles: %w", err)
+		}
+		for _, b := range current {
+			existing[b.Name] = true
+		}
+	}
+
+	for i, b := range bundles {
+		if b.Name == "" {
+			result.BundleErrors = append(result.BundleErrors, ImportError{
+				Line:    i + 1,
+				Message: "Missing required field \"name\"",
+			})
+			continue
+		}
+		if existing[b.Name] {
+			result.BundlesSkipped++
+			continue
 		}
-		for _, b := range existing {
-			existingURLs[b.URL] = b.ID
+		if !options.DryRun {
+			create := &models.BundleCreate{
+				Name:         b.Name,
+				Search:       b.Search,
+				AnyTags:      b.AnyTags,
+				AllTags:      b.AllTags,
+				ExcludedTags: b.ExcludedTags,
+				Order:        b.Order,
+			}
+			if _, err := client.CreateBundle(create); err != nil {
+				result.BundleErrors = append(result.BundleErrors, ImportError{
+					Line:    i + 1,
+					Message: fmt.Sprintf("Failed to create bundle %q: %v", b.Name, err),
+				})
+				continue
+			}
 		}
+		existing[b.Name] = true
+		result.BundlesAdded++
 	}
+	return nil
+}
 
-	// Regular expressions for parsing Netscape bookmark format
-	linkPattern := regexp.MustCompile(`<DT><A[^>]+HREF="([^"]+)"([^>]*)>([^<]*)</A>`)
-	tagsPattern := regexp.MustCompile(`TAGS="([^"]+)"`)
-	descPattern := regexp.MustCompile(`<DD>([^\n<]+)`)
+// netscapeBookmark is a bookmark parsed from a Netscape bookmark file.
+type netscapeBookmark struct {
+	URL          string
+	Title        string
+	Description  string
+	Tags         []string
+	AddDate      time.Time
+	LastModified time.Time
+	Shared       *bool    // from PRIVATE (inverted) or TOSHARE
+	Unread       *bool    // from TOREAD
+	Folders      []string // names of the enclosing <H3> folders, outermost first
+	Line         int
+	Entry        int // index among the file's bookmark entries, set by windowNetscape
+}
+
+// Patterns for parsing Netscape bookmark format
+var (
+	netscapeLinkPattern = regexp.MustCompile(`(?i)<DT>\s*<A\s+([^>]*)>(.*?)</A>`)
+	netscapeAttrPattern = regexp.MustCompile(`([A-Za-z_]+)\s*=\s*"([^"]*)"`)
+	netscapeDescPattern = regexp.MustCompile(`(?i)<DD>([^\n<]+)`)
+	// A folder is an <H3> heading followed by the <DL> list it names
+	netscapeFolderPattern    = regexp.MustCompile(`(?i)<DT>\s*<H3\b[^>]*>(.*?)</H3>`)
+	netscapeListOpenPattern  = regexp.MustCompile(`(?i)<DL\b`)
+	netscapeListClosePattern = regexp.MustCompile(`(?i)</DL\s*>`)
+)
+
+// parseNetscapeBookmarks parses a Netscape bookmark file. In strict mode the
+// file must start with the Netscape DOCTYPE and malformed entries (missing
+// HREF, invalid dates or flags) are reported as errors; otherwise malformed
+// attributes are ignored and unparseable entries skipped.
+func parseNetscapeBookmarks(reader io.Reader, strict bool) ([]netscapeBookmark, []ImportError, error) {
+	var bookmarks []netscapeBookmark
+	var errs []ImportError
 
 	scanner := bufio.NewScanner(reader)
 	lineNum := 0
-	var lastURL, lastTitle, lastTags string
+	sawDoctype := false
+	current := -1 // index of the bookmark awaiting a description
+
+	// lists holds the folder name of each open <DL>, "" for lists without
+	// a heading such as the top-level one; heading is the <H3> waiting for
+	// its list
+	var lists []string
+	heading := ""
 
 	for scanner.Scan() {
 		line := scanner.Text()
 		lineNum++
+		trimmed := strings.TrimSpace(line)
 
-		// Check for link tag
-		if matches := linkPattern.FindStringSubmatch(line); matches != nil {
-			// Process previous bookmark if we found a new one
-			if lastURL != "" {
-				processHTMLBookmark(client, result, existingURLs, lastURL, lastTitle, lastTags, "", lineNum-1, options)
+		if !sawDoctype && trimmed != "" {
+			sawDoctype = true
+			if strict && !strings.EqualFold(trimmed, "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+				return nil, nil, fmt.Errorf("line %d: not a Netscape bookmark file (missing <!DOCTYPE NETSCAPE-Bookmark-file-1>)", lineNum)
 			}
+		}
+
+		if matches := netscapeFolderPattern.FindStringSubmatch(line); matches != nil {
+			heading = html.UnescapeString(strings.TrimSpace(matches[1]))
+			current = -1
+		}
+		if netscapeListOpenPattern.MatchString(line) {
+			lists = append(lists, heading)
+			heading = ""
+		}
+
+		if matches := netscapeLinkPattern.FindStringSubmatch(line); matches != nil {
+			bookmark, entryErrs := parseNetscapeEntry(matches[1], matches[2], lineNum, strict)
+			if len(entryErrs) > 0 {
+				errs = append(errs, entryErrs...)
+				current = -1
+				continue
+			}
+			if bookmark.URL == "" {
+				current = -1
+				continue
+			}
+			bookmark.Folders = openFolders(lists)
+			bookmarks = append(bookmarks, bookmark)
+			current = len(bookmarks) - 1
+		} else if strict && strings.Contains(strings.ToUpper(line), "<DT><A") {
+			errs = append(errs, ImportError{Line: lineNum, Message: "malformed bookmark entry"})
+			current = -1
+		} else if matches := netscapeDescPattern.FindStringSubmatch(line); matches != nil && current >= 0 {
+			bookmarks[current].Description = html.UnescapeString(strings.TrimSpace(matches[1]))
+			current = -1
+		}
+
+		if netscapeListClosePattern.MatchString(line) && len(lists) > 0 {
+			lists = lists[:len(lists)-1]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read HTML: %w", err)
+	}
+
+	return bookmarks, errs, nil
+}
+
+// openFolders returns the names of the open folders, outermost first.
+func openFolders(lists []string) []string {
+	var folders []string
+	for _, name := range lists {
+		if name != "" {
+			folders = append(folders, name)
+		}
+	}
+	return folders
+}
+
+// folderTag turns a folder path into a hierarchical tag such as
+// "browser/work/docs". LinkDing tags cannot contain whitespace, so runs of
+// it become "-", as does any "/" within a folder name.
+func folderTag(folders []string) string {
+	parts := make([]string, 0, len(folders))
+	for _, name := range folders {
+		name = strings.Join(strings.Fields(strings.ReplaceAll(name, "/", " ")), "-")
+		if name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// parseNetscapeEntry parses the attributes and title of a single <A> element.
+func parseNetscapeEntry(attrs, title string, lineNum int, strict bool) (netscapeBookmark, []ImportError) {
+	bookmark := netscapeBookmark{
+		Title: html.UnescapeString(strings.TrimSpace(title)),
+		Line:  lineNum,
+	}
+	var errs []ImportError
+	invalid := func(format string, args ...interface{}) {
+		if strict {
+			errs = append(errs, ImportError{Line: lineNum, Message: fmt.Sprintf(format, args...)})
+		}
+	}
 
-			// Extract new bookmark data
-			lastURL = matches[1]
-			attrs := matches[2]
-			lastTitle = matches[3]
+	for _, attr := range netscapeAttrPattern.FindAllStringSubmatch(attrs, -1) {
+		name := strings.ToUpper(attr[1])
+		value := html.UnescapeString(attr[2])
 
-			// Extract tags if present
-			if tagMatches := tagsPattern.FindStringSubmatch(attrs); tagMatches != nil {
-				lastTags = tagMatches[1]
+		switch name {
+		case "HREF":
+			bookmark.URL = strings.TrimSpace(value)
+		case "ADD_DATE", "LAST_MODIFIED":
+			if value == "" {
+				continue
+			}
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				invalid("invalid %s %q: expected a Unix timestamp", name, value)
+				continue
+			}
+			if name == "ADD_DATE" {
+				bookmark.AddDate = time.Unix(seconds, 0).UTC()
 			} else {
-				lastTags = ""
+				bookmark.LastModified = time.Unix(seconds, 0).UTC()
+			}
+		case "PRIVATE", "TOSHARE", "TOREAD":
+			flag, ok := parseNetscapeFlag(value)
+			if !ok {
+				invalid("invalid %s %q: expected 0 or 1", name, value)
+				continue
+			}
+			switch name {
+			case "PRIVATE":
+				shared := !flag
+				bookmark.Shared = &shared
+			case "TOSHARE":
+				bookmark.Shared = &flag
+			case "TOREAD":
+				bookmark.Unread = &flag
+			}
+		case "TAGS":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					bookmark.Tags = append(bookmark.Tags, tag)
+				}
 			}
-		} else if matches := descPattern.FindStringSubmatch(line); matches != nil && lastURL != "" {
-			// Found description for current bookmark
-			description := matches[1]
-			processHTMLBookmark(client, result, existingURLs, lastURL, lastTitle, lastTags, description, lineNum, options)
-			lastURL = ""
-			lastTitle = ""
-			lastTags = ""
 		}
 	}
 
-	// Process last bookmark if it didn't have a description
-	if lastURL != "" {
-		processHTMLBookmark(client, result, existingURLs, lastURL, lastTitle, lastTags, "", lineNum, options)
+	if bookmark.URL == "" {
+		invalid("bookmark entry has no HREF")
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read HTML: %w", err)
+	return bookmark, errs
+}
+
+// parseNetscapeFlag parses a "0"/"1" attribute value.
+func parseNetscapeFlag(value string) (bool, bool) {
+	switch strings.TrimSpace(value) {
+	case "1":
+		return true, true
+	case "0":
+		return false, true
+	default:
+		return false, false
 	}
+}
 
-	return result, nil
+// importHTML imports bookmarks from Netscape HTML bookmark format
+func importHTML(client *api.Client, reader io.Reader, options ImportOptions) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	bookmarks, parseErrs, err := parseNetscapeBookmarks(reader, options.Strict)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, parseErrs = windowNetscape(bookmarks, parseErrs, options)
+	for _, e := range parseErrs {
+		result.Failed++
+		result.Errors = append(result.Errors, e)
+	}
+
+	// Get existing bookmarks to check for duplicates
+	existingURLs, err := existingBookmarkURLs(client, options)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := newBookmarkWriter(client, result, options)
+	var writeErr error
+	handled := 0
+	for _, bookmark := range bookmarks {
+		if options.stopError(result) != nil {
+			break
+		}
+		options.progress(handled, len(bookmarks))
+		handled++
+		if options.Checkpoint.Done(bookmark.Entry) {
+			result.Resumed++
+			continue
+		}
+		options.Pacer.Next(bookmark.Entry)
+		if writeErr = processHTMLBookmark(writer, result, existingURLs, bookmark, options); writeErr != nil {
+			break
+		}
+	}
+
+	if err := writer.wait(); writeErr == nil {
+		writeErr = err
+	}
+	options.progress(handled, len(bookmarks))
+	if writeErr != nil {
+		return result, writeErr
+	}
+	return result, options.stopError(result)
+}
+
+// windowNetscape numbers parsed Netscape entries and applies the
+// Offset/Limit window. Each bookmark line is one entry, whether it parsed or
+// was reported as malformed, so errors outside the window are dropped along
+// with bookmarks.
+func windowNetscape(bookmarks []netscapeBookmark, errs []ImportError, options ImportOptions) ([]netscapeBookmark, []ImportError) {
+	lineSet := make(map[int]bool)
+	for _, b := range bookmarks {
+		lineSet[b.Line] = true
+	}
+	for _, e := range errs {
+		lineSet[e.Line] = true
+	}
+	lines := make([]int, 0, len(lineSet))
+	for line := range lineSet {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+
+	entries := make(map[int]int, len(lines))
+	for i, line := range lines {
+		entries[line] = i
+	}
+
+	var keptBookmarks []netscapeBookmark
+	for _, b := range bookmarks {
+		b.Entry = entries[b.Line]
+		if options.inWindow(b.Entry) {
+			keptBookmarks = append(keptBookmarks, b)
+		}
+	}
+	var keptErrs []ImportError
+	for _, e := range errs {
+		if options.inWindow(entries[e.Line]) {
+			keptErrs = append(keptErrs, e)
+		}
+	}
+	return keptBookmarks, keptErrs
 }
 
-// processHTMLBookmark processes a single bookmark from HTML import
-func processHTMLBookmark(client *api.Client, result *ImportResult, existingURLs map[string]int,
-	url, title, tagsStr, description string, lineNum int, options ImportOptions) {
+// processHTMLBookmark processes a single bookmark from HTML import. Dates are
+// parsed for validation but not sent, since LinkDing assigns its own. Entry
+// failures are recorded in result, or by writer for the request itself; a
+// returned error means the checkpoint could not be written, which ends the
+// import.
+func processHTMLBookmark(writer *bookmarkWriter, result *ImportResult, existingURLs map[string]int,
+	bookmark netscapeBookmark, options ImportOptions) error {
 
-	// Parse tags
-	var tags []string
-	if tagsStr != "" {
-		tags = strings.Split(tagsStr, ",")
-		for i, tag := range tags {
-			tags[i] = strings.TrimSpace(tag)
+	if err := options.checkScheme(bookmark.URL); err != nil {
+		result.Failed++
+		result.Errors = append(result.Errors, ImportError{Line: bookmark.Line, Message: err.Error()})
+		return nil
+	}
+
+	tags := bookmark.Tags
+	if options.FoldersAsTags {
+		if tag := folderTag(bookmark.Folders); tag != "" {
+			tags = append(tags, tag)
 		}
 	}
 
@@ -281,18 +811,24 @@ func processHTMLBookmark(client *api.Client, result *ImportResult, existingURLs
 	}
 
 	bookmarkCreate := &models.BookmarkCreate{
-		URL:         url,
-		Title:       title,
-		Description: description,
+		URL:         bookmark.URL,
+		Title:       bookmark.Title,
+		Description: bookmark.Description,
 		TagNames:    tags,
 	}
+	if bookmark.Shared != nil {
+		bookmarkCreate.Shared = *bookmark.Shared
+	}
+	if bookmark.Unread != nil {
+		bookmarkCreate.Unread = *bookmark.Unread
+	}
 
 	// Check for duplicates
-	existingID, exists := existingURLs[url]
+	existingID, exists := existingURLs[options.urlKey(bookmark.URL)]
 
 	if exists && options.SkipDuplicates {
 		result.Skipped++
-		return
+		return options.Checkpoint.Record(bookmark.Entry, existingID)
 	}
 
 	if options.DryRun {
@@ -301,39 +837,22 @@ func processHTMLBookmark(client *api.Client, result *ImportResult, existingURLs
 		} else {
 			result.Added++
 		}
-		return
+		return nil
 	}
 
 	// Create or update bookmark
+	job := writeJob{entry: bookmark.Entry, line: bookmark.Line, existingID: existingID, exists: exists, create: bookmarkCreate}
 	if exists {
-		update := &models.BookmarkUpdate{
+		job.update = &models.BookmarkUpdate{
 			URL:         &bookmarkCreate.URL,
 			Title:       &bookmarkCreate.Title,
 			Description: &bookmarkCreate.Description,
 			TagNames:    &bookmarkCreate.TagNames,
+			Shared:      bookmark.Shared,
+			Unread:      bookmark.Unread,
 		}
-		_, err := client.UpdateBookmark(existingID, update)
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, ImportError{
-				Line:    lineNum,
-				Message: fmt.Sprintf("Failed to update: %v", err),
-			})
-			return
-		}
-		result.Updated++
-	} else {
-		_, err := client.CreateBookmark(bookmarkCreate)
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, ImportError{
-				Line:    lineNum,
-				Message: fmt.Sprintf("Failed to create: %v", err),
-			})
-			return
-		}
-		result.Added++
 	}
+	return writer.write(job)
 }
 
 // importCSV imports bookmarks from CSV format
@@ -346,32 +865,59 @@ func importCSV(client *api.Client, reader io.Reader, options ImportOptions) (*Im
 		return nil, fmt.Errorf("failed to read CSV header: %w", err)
 	}
 
-	// Map column names to indices
+	// Map column names to indices. Aliases are only used when the column
+	// itself is missing, and unknown columns (such as Pocket's time_added,
+	// which the API cannot set) are ignored
 	colMap := make(map[string]int)
 	for i, name := range header {
 		colMap[strings.ToLower(strings.TrimSpace(name))] = i
 	}
+	for i, name := range header {
+		target, ok := csvColumnAliases[strings.ToLower(strings.TrimSpace(name))]
+		if _, taken := colMap[target]; ok && !taken {
+			colMap[target] = i
+		}
+	}
+	tagSeparator := ","
+	if options.CSVDialect == CSVDialectPocket {
+		tagSeparator = "|"
+	}
 
 	result := &ImportResult{}
 
 	// Get existing bookmarks to check for duplicates
-	existingURLs := make(map[string]int)
-	if !options.DryRun {
-		existing, err := client.FetchAllBookmarks(nil, true)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch existing bookmarks: %w", err)
-		}
-		for _, b := range existing {
-			existingURLs[b.URL] = b.ID
-		}
+	existingURLs, err := existingBookmarkURLs(client, options)
+	if err != nil {
+		return nil, err
 	}
 
+	writer := newBookmarkWriter(client, result, options)
+	var writeErr error
+	handled := 0
 	lineNum := 1 // Start at 1 (header row)
-	for {
+	for entry := 0; ; entry++ {
+		if options.stopError(result) != nil {
+			break
+		}
+		if options.pastWindow(entry) {
+			break
+		}
 		record, err := csvReader.Read()
 		if err == io.EOF {
 			break
 		}
+		if !options.inWindow(entry) {
+			lineNum++
+			continue
+		}
+		options.progress(handled, 0)
+		handled++
+		if options.Checkpoint.Done(entry) {
+			result.Resumed++
+			lineNum++
+			continue
+		}
+		options.Pacer.Next(entry)
 		if err != nil {
 			result.Failed++
 			result.Errors = append(result.Errors, ImportError{
@@ -393,23 +939,33 @@ func importCSV(client *api.Client, reader io.Reader, options ImportOptions) (*Im
 			})
 			continue
 		}
+		if err := options.checkScheme(url); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, ImportError{Line: lineNum, Message: err.Error()})
+			continue
+		}
 
 		title := getCSVField(record, colMap, "title")
+		if options.CSVDialect == CSVDialectPocket && strings.TrimSpace(title) == url {
+			title = ""
+		}
 		description := getCSVField(record, colMap, "description")
 		tagsStr := getCSVField(record, colMap, "tags")
 
 		var tags []string
 		if tagsStr != "" {
-			tags = strings.Split(tagsStr, ",")
+			tags = strings.Split(tagsStr, tagSeparator)
 			for i, tag := range tags {
 				tags[i] = strings.TrimSpace(tag)
 			}
 		}
 
-		// Parse boolean fields
-		unread := parseCSVBool(getCSVField(record, colMap, "unread"))
+		// Parse boolean fields; a status column (Pocket's "unread" or
+		// "archive") can set unread or archived too
+		statusUnread, statusArchived := parseCSVStatus(getCSVField(record, colMap, "status"))
+		unread := parseCSVBool(getCSVField(record, colMap, "unread")) || statusUnread
 		shared := parseCSVBool(getCSVField(record, colMap, "shared"))
-		archived := parseCSVBool(getCSVField(record, colMap, "archived"))
+		archived := parseCSVBool(getCSVField(record, colMap, "archived")) || statusArchived
 
 		// Add custom tags
 		if len(options.AddTags) > 0 {
@@ -427,10 +983,13 @@ func importCSV(client *api.Client, reader io.Reader, options ImportOptions) (*Im
 		}
 
 		// Check for duplicates
-		existingID, exists := existingURLs[url]
+		existingID, exists := existingURLs[options.urlKey(url)]
 
 		if exists && options.SkipDuplicates {
 			result.Skipped++
+			if writeErr = options.Checkpoint.Record(entry, existingID); writeErr != nil {
+				break
+			}
 			continue
 		}
 
@@ -444,8 +1003,9 @@ func importCSV(client *api.Client, reader io.Reader, options ImportOptions) (*Im
 		}
 
 		// Create or update bookmark
+		job := writeJob{entry: entry, line: lineNum, existingID: existingID, exists: exists, create: bookmarkCreate}
 		if exists {
-			update := &models.BookmarkUpdate{
+			job.update = &models.BookmarkUpdate{
 				URL:         &bookmarkCreate.URL,
 				Title:       &bookmarkCreate.Title,
 				Description: &bookmarkCreate.Description,
@@ -454,31 +1014,20 @@ func importCSV(client *api.Client, reader io.Reader, options ImportOptions) (*Im
 				Shared:      &bookmarkCreate.Shared,
 				IsArchived:  &bookmarkCreate.IsArchived,
 			}
-			_, err := client.UpdateBookmark(existingID, update)
-			if err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, ImportError{
-					Line:    lineNum,
-					Message: fmt.Sprintf("Failed to update: %v", err),
-				})
-				continue
-			}
-			result.Updated++
-		} else {
-			_, err := client.CreateBookmark(bookmarkCreate)
-			if err != nil {
-				result.Failed++
-				result.Errors = append(result.Errors, ImportError{
-					Line:    lineNum,
-					Message: fmt.Sprintf("Failed to create: %v", err),
-				})
-				continue
-			}
-			result.Added++
+		}
+		if writeErr = writer.write(job); writeErr != nil {
+			break
 		}
 	}
 
-	return result, nil
+	if err := writer.wait(); writeErr == nil {
+		writeErr = err
+	}
+	options.progress(handled, 0)
+	if writeErr != nil {
+		return result, writeErr
+	}
+	return result, options.stopError(result)
 }
 
 // getCSVField safely retrieves a field from a CSV record
@@ -494,3 +1043,15 @@ func parseCSVBool(value string) bool {
 	value = strings.ToLower(strings.TrimSpace(value))
 	return value == "true" || value == "1" || value == "yes"
 }
+
+// parseCSVStatus maps a status value to unread and archived flags: "unread"
+// is unread, "archive" or "archived" is archived, anything else neither.
+func parseCSVStatus(value string) (unread, archived bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "unread":
+		return true, false
+	case "archive", "archived":
+		return false, true
+	}
+	return false, false
+}
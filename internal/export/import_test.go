@@ -3,13 +3,18 @@ package export
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/batch"
 	"github.com/rodstewart/linkding-cli/internal/models"
 )
 
@@ -27,6 +32,9 @@ func TestDetectFormat(t *testing.T) {
 		{"bookmarks.HTM", "html"},
 		{"bookmarks.csv", "csv"},
 		{"bookmarks.CSV", "csv"},
+		{"linkding-backup-2026-01-22T103000.json.gz", "json"},
+		{"bookmarks.CSV.GZ", "csv"},
+		{"bookmarks.gz", ""},
 		{"bookmarks.txt", ""},
 		{"bookmarks", ""},
 		{"unknown.xyz", ""},
@@ -45,6 +53,7 @@ func TestDetectFormat(t *testing.T) {
 // TestImportJSON_RoundTrip tests JSON export -> import round trip
 func TestImportJSON_RoundTrip(t *testing.T) {
 	// Create test data
+	notes := "Read later"
 	exportData := ExportData{
 		Version: "1.0",
 		Source:  "linkding-cli",
@@ -53,6 +62,7 @@ func TestImportJSON_RoundTrip(t *testing.T) {
 				URL:         "https://example.com",
 				Title:       "Example",
 				Description: "Test bookmark",
+				Notes:       &notes,
 				Tags:        []string{"tag1", "tag2"},
 				Archived:    false,
 				Unread:      false,
@@ -154,6 +164,10 @@ func TestImportJSON_RoundTrip(t *testing.T) {
 	if len(created[0].TagNames) != len(exportData.Bookmarks[0].Tags) {
 		t.Errorf("Tags mismatch: got %v, want %v", created[0].TagNames, exportData.Bookmarks[0].Tags)
 	}
+
+	if created[0].Notes != notes || created[1].Notes != "" {
+		t.Errorf("Notes mismatch: got %q and %q, want %q and none", created[0].Notes, created[1].Notes, notes)
+	}
 }
 
 // TestImportJSON_SkipDuplicates tests duplicate handling with skip option
@@ -209,6 +223,54 @@ func TestImportJSON_SkipDuplicates(t *testing.T) {
 	}
 }
 
+// TestImportJSON_NormalizeURLs tests that NormalizeURLs finds duplicates the
+// exact URL would miss
+func TestImportJSON_NormalizeURLs(t *testing.T) {
+	exportData := ExportData{
+		Bookmarks: []ExportBookmark{
+			{URL: "http://www.example.com/docs/#intro", Title: "Example"},
+			{URL: "https://test.com", Title: "Test"},
+		},
+	}
+	data, err := json.Marshal(exportData)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{
+				Count:   1,
+				Results: []models.Bookmark{{ID: 1, URL: "https://example.com/docs", Title: "Existing"}},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 2, URL: "https://test.com"})
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+
+	tests := []struct {
+		normalize bool
+		skipped   int
+		added     int
+	}{
+		{normalize: false, skipped: 0, added: 2},
+		{normalize: true, skipped: 1, added: 1},
+	}
+	for _, tt := range tests {
+		result, err := importJSON(client, bytes.NewReader(data), ImportOptions{SkipDuplicates: true, NormalizeURLs: tt.normalize})
+		if err != nil {
+			t.Fatalf("importJSON() failed: %v", err)
+		}
+		if result.Skipped != tt.skipped || result.Added != tt.added {
+			t.Errorf("NormalizeURLs=%t: expected %d skipped and %d added, got %d and %d",
+				tt.normalize, tt.skipped, tt.added, result.Skipped, result.Added)
+		}
+	}
+}
+
 // TestImportJSON_UpdateDuplicates tests duplicate handling with update behavior
 func TestImportJSON_UpdateDuplicates(t *testing.T) {
 	exportData := ExportData{
@@ -1032,3 +1094,723 @@ func TestImportBookmarks_FormatOverride(t *testing.T) {
 		t.Errorf("Expected 1 added, got %d", result.Added)
 	}
 }
+
+// TestParseNetscapeBookmarks_Attributes tests each supported Netscape attribute
+func TestParseNetscapeBookmarks_Attributes(t *testing.T) {
+	htmlInput := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://a.com/?x=1&amp;y=2" ADD_DATE="1704110400" LAST_MODIFIED="1704196800" PRIVATE="0" TOREAD="1" TAGS="web, dev">A &amp; B</A>
+    <DD>Desc &lt;tag&gt;
+    <DT><A HREF="https://b.com" PRIVATE="1">Private</A>
+    <DT><A HREF="https://c.com" TOSHARE="1">Shared</A>
+    <DT><A href="https://d.com">Plain</A>
+</DL><p>
+`
+
+	bookmarks, errs, err := parseNetscapeBookmarks(strings.NewReader(htmlInput), true)
+	if err != nil {
+		t.Fatalf("parseNetscapeBookmarks() failed: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors, got: %v", errs)
+	}
+	if len(bookmarks) != 4 {
+		t.Fatalf("Expected 4 bookmarks, got %d", len(bookmarks))
+	}
+
+	a := bookmarks[0]
+	if a.URL != "https://a.com/?x=1&y=2" || a.Title != "A & B" || a.Description != "Desc <tag>" {
+		t.Errorf("Expected unescaped fields, got: %+v", a)
+	}
+	if !a.AddDate.Equal(time.Unix(1704110400, 0)) {
+		t.Errorf("ADD_DATE mismatch: %v", a.AddDate)
+	}
+	if !a.LastModified.Equal(time.Unix(1704196800, 0)) {
+		t.Errorf("LAST_MODIFIED mismatch: %v", a.LastModified)
+	}
+	if a.Shared == nil || !*a.Shared {
+		t.Error("Expected PRIVATE=\"0\" to mark the bookmark as shared")
+	}
+	if a.Unread == nil || !*a.Unread {
+		t.Error("Expected TOREAD=\"1\" to mark the bookmark as unread")
+	}
+	if len(a.Tags) != 2 || a.Tags[0] != "web" || a.Tags[1] != "dev" {
+		t.Errorf("TAGS mismatch: %v", a.Tags)
+	}
+
+	if b := bookmarks[1]; b.Shared == nil || *b.Shared {
+		t.Error("Expected PRIVATE=\"1\" to mark the bookmark as not shared")
+	}
+	if c := bookmarks[2]; c.Shared == nil || !*c.Shared {
+		t.Error("Expected TOSHARE=\"1\" to mark the bookmark as shared")
+	}
+	if d := bookmarks[3]; d.URL != "https://d.com" || d.Shared != nil || d.Unread != nil || !d.AddDate.IsZero() {
+		t.Errorf("Expected lowercase href and no optional attributes, got: %+v", d)
+	}
+}
+
+// TestParseNetscapeBookmarks_Strict tests strict-mode error reporting
+func TestParseNetscapeBookmarks_Strict(t *testing.T) {
+	htmlInput := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://ok.com">OK</A>
+    <DT><A ADD_DATE="1704110400">No href</A>
+    <DT><A HREF="https://bad-date.com" ADD_DATE="yesterday">Bad date</A>
+    <DT><A HREF="https://bad-flag.com" PRIVATE="yes">Bad flag</A>
+    <DT><A HREF="https://broken.com"
+</DL><p>
+`
+
+	t.Run("strict reports malformed entries", func(t *testing.T) {
+		bookmarks, errs, err := parseNetscapeBookmarks(strings.NewReader(htmlInput), true)
+		if err != nil {
+			t.Fatalf("parseNetscapeBookmarks() failed: %v", err)
+		}
+		if len(bookmarks) != 1 || bookmarks[0].URL != "https://ok.com" {
+			t.Errorf("Expected only the valid bookmark, got: %+v", bookmarks)
+		}
+		if len(errs) != 4 {
+			t.Fatalf("Expected 4 errors, got %d: %v", len(errs), errs)
+		}
+		wantLines := []int{4, 5, 6, 7}
+		for i, e := range errs {
+			if e.Line != wantLines[i] {
+				t.Errorf("Error %d: expected line %d, got %d (%s)", i, wantLines[i], e.Line, e.Message)
+			}
+		}
+	})
+
+	t.Run("lenient skips silently", func(t *testing.T) {
+		bookmarks, errs, err := parseNetscapeBookmarks(strings.NewReader(htmlInput), false)
+		if err != nil {
+			t.Fatalf("parseNetscapeBookmarks() failed: %v", err)
+		}
+		if len(errs) != 0 {
+			t.Errorf("Expected no errors in lenient mode, got: %v", errs)
+		}
+		// Bad attributes are ignored, the entry without HREF and the broken line are skipped
+		if len(bookmarks) != 3 {
+			t.Errorf("Expected 3 bookmarks, got %d: %+v", len(bookmarks), bookmarks)
+		}
+	})
+
+	t.Run("strict requires doctype", func(t *testing.T) {
+		_, _, err := parseNetscapeBookmarks(strings.NewReader("<html>\n<DT><A HREF=\"https://a.com\">A</A>\n"), true)
+		if err == nil {
+			t.Error("Expected error for missing DOCTYPE")
+		}
+	})
+}
+
+// TestImportBookmarks_NetscapeAlias tests that "netscape" is accepted as a format
+// and that shared/unread attributes reach the API
+func TestImportBookmarks_NetscapeAlias(t *testing.T) {
+	var created []models.BookmarkCreate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			return
+		}
+		var bookmark models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&bookmark)
+		created = append(created, bookmark)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: len(created)})
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "bookmarks.txt")
+	content := "<!DOCTYPE NETSCAPE-Bookmark-file-1>\n<DT><A HREF=\"https://a.com\" TOSHARE=\"1\" TOREAD=\"1\">A</A>\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := api.NewClient(server.URL, "test-token")
+	result, err := ImportBookmarks(client, file, ImportOptions{Format: "netscape", Strict: true})
+	if err != nil {
+		t.Fatalf("ImportBookmarks() failed: %v", err)
+	}
+	if result.Added != 1 || len(created) != 1 {
+		t.Fatalf("Expected 1 bookmark added, got %+v", result)
+	}
+	if !created[0].Shared || !created[0].Unread {
+		t.Errorf("Expected shared and unread to be sent, got: %+v", created[0])
+	}
+}
+
+func TestImportBookmarks_LimitOffset(t *testing.T) {
+	// Ten entries numbered 1-10 in each format
+	var jsonData ExportData
+	csvContent := "url,title\n"
+	htmlContent := "<!DOCTYPE NETSCAPE-Bookmark-file-1>\n<DL><p>\n"
+	for i := 1; i <= 10; i++ {
+		url := fmt.Sprintf("https://example.com/%d", i)
+		jsonData.Bookmarks = append(jsonData.Bookmarks, ExportBookmark{URL: url, Title: fmt.Sprintf("Entry %d", i)})
+		csvContent += fmt.Sprintf("%s,Entry %d\n", url, i)
+		htmlContent += fmt.Sprintf("<DT><A HREF=\"%s\">Entry %d</A>\n", url, i)
+	}
+	htmlContent += "</DL><p>\n"
+	jsonContent, err := json.Marshal(jsonData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"bookmarks.json": string(jsonContent),
+		"bookmarks.csv":  csvContent,
+		"bookmarks.html": htmlContent,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			var created []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+					return
+				}
+				var bookmark models.BookmarkCreate
+				_ = json.NewDecoder(r.Body).Decode(&bookmark)
+				created = append(created, bookmark.URL)
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(models.Bookmark{ID: len(created)})
+			}))
+			defer server.Close()
+
+			file := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			client := api.NewClient(server.URL, "test-token")
+			result, err := ImportBookmarks(client, file, ImportOptions{Limit: 3, Offset: 5})
+			if err != nil {
+				t.Fatalf("ImportBookmarks() failed: %v", err)
+			}
+			if result.Added != 3 || result.Failed != 0 || result.Skipped != 0 {
+				t.Errorf("Expected exactly 3 added, got %+v", result)
+			}
+			want := []string{"https://example.com/6", "https://example.com/7", "https://example.com/8"}
+			if strings.Join(created, " ") != strings.Join(want, " ") {
+				t.Errorf("Expected entries 6-8 to be imported, got %v", created)
+			}
+		})
+	}
+}
+
+func TestImportHTML_LimitOffsetStrictErrors(t *testing.T) {
+	// The malformed entry is the second one, so an offset of 2 skips its error
+	content := "<!DOCTYPE NETSCAPE-Bookmark-file-1>\n" +
+		"<DT><A HREF=\"https://a.com\">A</A>\n" +
+		"<DT><A HREF=\"https://b.com\" ADD_DATE=\"soon\">B</A>\n" +
+		"<DT><A HREF=\"https://c.com\">C</A>\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	result, err := importHTML(client, strings.NewReader(content), ImportOptions{Strict: true, Offset: 2, DryRun: true})
+	if err != nil {
+		t.Fatalf("importHTML() failed: %v", err)
+	}
+	if result.Added != 1 || result.Failed != 0 {
+		t.Errorf("Expected only entry 3 processed, got %+v", result)
+	}
+}
+
+func TestImportJSON_StopOnError(t *testing.T) {
+	var data ExportData
+	for i := 1; i <= 5; i++ {
+		data.Bookmarks = append(data.Bookmarks, ExportBookmark{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stop := range []bool{true, false} {
+		t.Run(fmt.Sprintf("stop=%v", stop), func(t *testing.T) {
+			posts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+					return
+				}
+				posts++
+				// The token loses access after the first bookmark
+				if posts > 1 {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(models.Bookmark{ID: posts})
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test-token")
+			result, err := importJSON(client, bytes.NewReader(content), ImportOptions{StopOnError: stop})
+
+			if !stop {
+				if err != nil {
+					t.Fatalf("importJSON() failed: %v", err)
+				}
+				if posts != 5 || result.Added != 1 || result.Failed != 4 {
+					t.Errorf("Expected all 5 entries attempted, got %d requests and %+v", posts, result)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("Expected an error with StopOnError")
+			}
+			if !strings.Contains(err.Error(), "line 2") {
+				t.Errorf("Expected error to name line 2, got: %v", err)
+			}
+			if posts != 2 {
+				t.Errorf("Expected import to stop after the 401, got %d create requests", posts)
+			}
+			if result == nil || result.Added != 1 || result.Failed != 1 {
+				t.Errorf("Expected the partial result to be returned, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestImportJSON_RetryFailed(t *testing.T) {
+	data := ExportData{Bookmarks: []ExportBookmark{
+		{URL: "https://example.com/ok"},
+		{URL: "https://example.com/flaky"},
+		{URL: "https://example.com/broken"},
+		{URL: "ftp://example.com/file"},
+	}}
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// newServer fails the first creation of /flaky and every creation of
+	// /broken, and counts the creation requests per URL
+	newServer := func(t *testing.T) (*api.Client, map[string]int) {
+		posts := make(map[string]int)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+				return
+			}
+			var create models.BookmarkCreate
+			_ = json.NewDecoder(r.Body).Decode(&create)
+			posts[create.URL]++
+			if strings.HasSuffix(create.URL, "/broken") || (strings.HasSuffix(create.URL, "/flaky") && posts[create.URL] == 1) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bookmark{ID: len(posts), URL: create.URL})
+		}))
+		t.Cleanup(server.Close)
+		return api.NewClient(server.URL, "test-token"), posts
+	}
+
+	t.Run("recovered", func(t *testing.T) {
+		client, posts := newServer(t)
+		result, err := importJSON(client, bytes.NewReader(content), ImportOptions{RetryFailed: 1, Limit: 2})
+		if err != nil {
+			t.Fatalf("importJSON() failed: %v", err)
+		}
+		if result.Added != 2 || result.Failed != 0 || len(result.Errors) != 0 {
+			t.Errorf("Expected the flaky entry to be added on retry, got %+v", result)
+		}
+		if posts["https://example.com/flaky"] != 2 {
+			t.Errorf("Expected 2 creation requests for the flaky entry, got %d", posts["https://example.com/flaky"])
+		}
+	})
+
+	t.Run("persistent failures", func(t *testing.T) {
+		client, posts := newServer(t)
+		result, err := importJSON(client, bytes.NewReader(content), ImportOptions{RetryFailed: 1})
+		if err != nil {
+			t.Fatalf("importJSON() failed: %v", err)
+		}
+		if result.Added != 2 || result.Failed != 2 {
+			t.Errorf("Expected 2 added and 2 failed, got %+v", result)
+		}
+		var lines []int
+		for _, e := range result.Errors {
+			lines = append(lines, e.Line)
+		}
+		if !reflect.DeepEqual(lines, []int{3, 4}) {
+			t.Errorf("Expected errors for lines 3 and 4 only, got %+v", result.Errors)
+		}
+		if posts["https://example.com/broken"] != 2 {
+			t.Errorf("Expected the broken entry to be sent twice, got %d", posts["https://example.com/broken"])
+		}
+	})
+
+	t.Run("no retry", func(t *testing.T) {
+		client, posts := newServer(t)
+		result, err := importJSON(client, bytes.NewReader(content), ImportOptions{})
+		if err != nil {
+			t.Fatalf("importJSON() failed: %v", err)
+		}
+		if result.Added != 1 || result.Failed != 3 || posts["https://example.com/flaky"] != 1 {
+			t.Errorf("Expected no retries by default, got %+v and %v", result, posts)
+		}
+	})
+}
+
+func TestImportJSON_RetryFailedCommitted(t *testing.T) {
+	content := []byte(`{"bookmarks":[{"url":"https://example.com/lost"},{"url":"https://example.com/flaky"}]}`)
+
+	// The first creation of /lost is saved but answered with an error, as
+	// when the response times out; /flaky is not saved the first time
+	var posts, gets int
+	var saved []models.Bookmark
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			gets++
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(saved), Results: saved})
+			return
+		}
+		var create models.BookmarkCreate
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		posts++
+		if posts <= 2 {
+			if strings.HasSuffix(create.URL, "/lost") {
+				saved = append(saved, models.Bookmark{ID: 7, URL: create.URL})
+			}
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 8, URL: create.URL})
+	}))
+	t.Cleanup(server.Close)
+	client := api.NewClient(server.URL, "test-token")
+
+	result, err := importJSON(client, bytes.NewReader(content), ImportOptions{RetryFailed: 1})
+	if err != nil {
+		t.Fatalf("importJSON() failed: %v", err)
+	}
+	if result.Added != 2 || result.Failed != 0 {
+		t.Errorf("Expected both entries added, got %+v", result)
+	}
+	if posts != 3 {
+		t.Errorf("Expected only the flaky entry to be sent again, got %d creation requests", posts)
+	}
+	if gets != 2 {
+		t.Errorf("Expected the existing bookmarks to be read again before the retry, got %d reads", gets)
+	}
+}
+
+func TestImportJSON_SchemeAllowlist(t *testing.T) {
+	content := `{"bookmarks":[
+		{"url":"javascript:alert(1)"},
+		{"url":"https://ok.example.com"},
+		{"url":"file:///etc/passwd"},
+		{"url":"ftp://ftp.example.com/pub"}
+	]}`
+
+	tests := []struct {
+		name        string
+		allowed     []string
+		wantAdded   int
+		wantFailed  int
+		wantErrLine int
+	}{
+		{name: "default", wantAdded: 1, wantFailed: 3, wantErrLine: 1},
+		{name: "ftp allowed", allowed: []string{"http", "https", "ftp"}, wantAdded: 2, wantFailed: 2, wantErrLine: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("Unexpected request under dry run: %s %s", r.Method, r.URL)
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test-token")
+			result, err := importJSON(client, strings.NewReader(content), ImportOptions{DryRun: true, AllowedSchemes: tt.allowed})
+			if err != nil {
+				t.Fatalf("importJSON() failed: %v", err)
+			}
+			if result.Added != tt.wantAdded || result.Failed != tt.wantFailed {
+				t.Errorf("Expected %d added and %d failed, got %+v", tt.wantAdded, tt.wantFailed, result)
+			}
+			if len(result.Errors) == 0 || result.Errors[0].Line != tt.wantErrLine || !strings.Contains(result.Errors[0].Message, "not allowed") {
+				t.Errorf("Expected a scheme error on line %d, got %+v", tt.wantErrLine, result.Errors)
+			}
+		})
+	}
+}
+
+func TestImportCSVAndHTML_SchemeAllowlist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request under dry run: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+	options := ImportOptions{DryRun: true}
+
+	csvResult, err := importCSV(client, strings.NewReader("url,title\njavascript:void(0),Bad\nhttps://a.com,Good\n"), options)
+	if err != nil {
+		t.Fatalf("importCSV() failed: %v", err)
+	}
+	if csvResult.Added != 1 || csvResult.Failed != 1 {
+		t.Errorf("CSV: expected 1 added and 1 failed, got %+v", csvResult)
+	}
+
+	htmlContent := "<DT><A HREF=\"file:///home/me/notes.txt\">Notes</A>\n<DT><A HREF=\"https://a.com\">A</A>\n"
+	htmlResult, err := importHTML(client, strings.NewReader(htmlContent), options)
+	if err != nil {
+		t.Fatalf("importHTML() failed: %v", err)
+	}
+	if htmlResult.Added != 1 || htmlResult.Failed != 1 {
+		t.Errorf("HTML: expected 1 added and 1 failed, got %+v", htmlResult)
+	}
+}
+
+func TestImportBookmarks_BatchesAndResume(t *testing.T) {
+	var data ExportData
+	csvContent := "url\n"
+	htmlContent := "<!DOCTYPE NETSCAPE-Bookmark-file-1>\n"
+	for i := 0; i < 7; i++ {
+		url := fmt.Sprintf("https://example.com/%d", i)
+		data.Bookmarks = append(data.Bookmarks, ExportBookmark{URL: url})
+		csvContent += url + "\n"
+		htmlContent += fmt.Sprintf("<DT><A HREF=\"%s\">%d</A>\n", url, i)
+	}
+	jsonContent, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request under dry run: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+
+	files := map[string]string{
+		"bookmarks.json": string(jsonContent),
+		"bookmarks.csv":  csvContent,
+		"bookmarks.html": htmlContent,
+	}
+	tests := []struct {
+		name       string
+		offset     int
+		wantStarts []int
+		wantAdded  int
+	}{
+		{name: "from start", offset: 0, wantStarts: []int{0, 3, 6}, wantAdded: 7},
+		{name: "resumed", offset: 2, wantStarts: []int{2, 5}, wantAdded: 5},
+	}
+
+	for name, content := range files {
+		file := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				var starts []int
+				pauses := 0
+				pacer := &batch.Pacer{
+					Size:    3,
+					Pause:   time.Second,
+					OnBatch: func(index int) { starts = append(starts, index) },
+					Sleep:   func(time.Duration) { pauses++ },
+				}
+
+				result, err := ImportBookmarks(client, file, ImportOptions{DryRun: true, Offset: tt.offset, Pacer: pacer})
+				if err != nil {
+					t.Fatalf("ImportBookmarks() failed: %v", err)
+				}
+				if result.Added != tt.wantAdded {
+					t.Errorf("Expected %d added, got %d", tt.wantAdded, result.Added)
+				}
+				if fmt.Sprint(starts) != fmt.Sprint(tt.wantStarts) {
+					t.Errorf("Expected batches to start at %v, got %v", tt.wantStarts, starts)
+				}
+				if pauses != len(tt.wantStarts)-1 {
+					t.Errorf("Expected %d pauses, got %d", len(tt.wantStarts)-1, pauses)
+				}
+			})
+		}
+	}
+}
+
+// csvCreateServer records the bookmarks created by a CSV import.
+func csvCreateServer(t *testing.T, created *[]models.BookmarkCreate) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			if err := json.NewEncoder(w).Encode(models.BookmarkList{Count: 0, Results: []models.Bookmark{}}); err != nil {
+				t.Errorf("Failed to encode response: %v", err)
+			}
+			return
+		}
+		var bookmark models.BookmarkCreate
+		if err := json.NewDecoder(r.Body).Decode(&bookmark); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*created = append(*created, bookmark)
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(models.Bookmark{ID: len(*created)}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestImportCSV_PocketDialect(t *testing.T) {
+	csvInput := `title,url,time_added,tags,status
+Go Blog,https://go.dev/blog,1700000000,go|reading,unread
+https://example.com/raw,https://example.com/raw,1700000001,,archive
+Plain,https://example.com/plain,1700000002,one tag|two,
+`
+
+	var created []models.BookmarkCreate
+	server := csvCreateServer(t, &created)
+
+	client := api.NewClient(server.URL, "test-token")
+	result, err := importCSV(client, strings.NewReader(csvInput), ImportOptions{CSVDialect: CSVDialectPocket})
+	if err != nil {
+		t.Fatalf("importCSV() failed: %v", err)
+	}
+	if result.Added != 3 || len(created) != 3 {
+		t.Fatalf("Expected 3 added, got %d (created %d)", result.Added, len(created))
+	}
+
+	first := created[0]
+	if first.Title != "Go Blog" || first.URL != "https://go.dev/blog" {
+		t.Errorf("First bookmark = %q %q", first.Title, first.URL)
+	}
+	if !reflect.DeepEqual(first.TagNames, []string{"go", "reading"}) {
+		t.Errorf("First tags = %v, want [go reading]", first.TagNames)
+	}
+	if !first.Unread || first.IsArchived {
+		t.Errorf("First: unread=%v archived=%v, want unread only", first.Unread, first.IsArchived)
+	}
+
+	second := created[1]
+	if second.Title != "" {
+		t.Errorf("Title equal to the URL should be dropped, got %q", second.Title)
+	}
+	if second.Unread || !second.IsArchived {
+		t.Errorf("Second: unread=%v archived=%v, want archived only", second.Unread, second.IsArchived)
+	}
+
+	if !reflect.DeepEqual(created[2].TagNames, []string{"one tag", "two"}) {
+		t.Errorf("Third tags = %v, want [one tag two]", created[2].TagNames)
+	}
+}
+
+func TestImportCSV_ColumnAliases(t *testing.T) {
+	csvInput := `link,name,labels,excerpt,added_on
+https://example.com,Example,"a,b",Some text,2024-01-01
+`
+
+	var created []models.BookmarkCreate
+	server := csvCreateServer(t, &created)
+
+	client := api.NewClient(server.URL, "test-token")
+	if _, err := importCSV(client, strings.NewReader(csvInput), ImportOptions{}); err != nil {
+		t.Fatalf("importCSV() failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 created bookmark, got %d", len(created))
+	}
+	got := created[0]
+	if got.URL != "https://example.com" || got.Title != "Example" || got.Description != "Some text" {
+		t.Errorf("Aliased columns not mapped: %+v", got)
+	}
+	if !reflect.DeepEqual(got.TagNames, []string{"a", "b"}) {
+		t.Errorf("Tags = %v, want [a b]", got.TagNames)
+	}
+}
+
+func TestImportHTML_FoldersAsTags(t *testing.T) {
+	htmlInput := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><A HREF="https://top.example.com" TAGS="loose">Top level</A>
+    <DT><H3 ADD_DATE="1704110400">Browser</H3>
+    <DL><p>
+        <DT><H3>Work</H3>
+        <DL><p>
+            <DT><H3>Docs</H3>
+            <DL><p>
+                <DT><A HREF="https://docs.example.com" TAGS="reference">Docs</A>
+                <DD>Nested three deep
+            </DL><p>
+            <DT><A HREF="https://work.example.com">Work</A>
+        </DL><p>
+        <DT><H3>Side Projects</H3>
+        <DL><p>
+            <DT><A HREF="https://side.example.com">Side</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://after.example.com">After</A>
+</DL><p>
+`
+
+	for _, tt := range []struct {
+		name          string
+		foldersAsTags bool
+		want          map[string][]string
+	}{
+		{
+			name: "flat by default",
+			want: map[string][]string{
+				"https://top.example.com":   {"loose"},
+				"https://docs.example.com":  {"reference"},
+				"https://work.example.com":  nil,
+				"https://side.example.com":  nil,
+				"https://after.example.com": nil,
+			},
+		},
+		{
+			name:          "folders as tags",
+			foldersAsTags: true,
+			want: map[string][]string{
+				"https://top.example.com":   {"loose"},
+				"https://docs.example.com":  {"reference", "Browser/Work/Docs"},
+				"https://work.example.com":  {"Browser/Work"},
+				"https://side.example.com":  {"Browser/Side-Projects"},
+				"https://after.example.com": nil,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []models.BookmarkCreate
+			server := csvCreateServer(t, &created)
+
+			client := api.NewClient(server.URL, "test-token")
+			result, err := importHTML(client, strings.NewReader(htmlInput), ImportOptions{FoldersAsTags: tt.foldersAsTags})
+			if err != nil {
+				t.Fatalf("importHTML() failed: %v", err)
+			}
+			if result.Added != len(tt.want) {
+				t.Fatalf("Expected %d added, got %d", len(tt.want), result.Added)
+			}
+
+			got := make(map[string][]string)
+			for _, b := range created {
+				got[b.URL] = b.TagNames
+				if b.URL == "https://docs.example.com" && b.Description != "Nested three deep" {
+					t.Errorf("Description = %q", b.Description)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
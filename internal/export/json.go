@@ -2,20 +2,25 @@ package export
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/rodstewart/linkding-cli/internal/api"
 	"github.com/rodstewart/linkding-cli/internal/models"
 )
 
-// ExportBookmark represents a bookmark in the export format
+// ExportBookmark represents a bookmark in the export format. Notes is nil
+// in files written before notes were exported, so importing or syncing such
+// a file leaves existing notes alone.
 type ExportBookmark struct {
 	ID           int       `json:"id"`
-	URL          string    `json:"url"`
+	URL          string    `json:"url" jsonschema:"required,minLength=1"`
 	Title        string    `json:"title"`
 	Description  string    `json:"description"`
+	Notes        *string   `json:"notes,omitempty"`
 	Tags         []string  `json:"tags"`
 	DateAdded    time.Time `json:"date_added"`
 	DateModified time.Time `json:"date_modified"`
@@ -24,29 +29,141 @@ type ExportBookmark struct {
 	Archived     bool      `json:"archived"`
 }
 
+// ExportBundle represents a bundle in the export format
+type ExportBundle struct {
+	Name         string `json:"name" jsonschema:"required,minLength=1"`
+	Search       string `json:"search"`
+	AnyTags      string `json:"any_tags"`
+	AllTags      string `json:"all_tags"`
+	ExcludedTags string `json:"excluded_tags"`
+	Order        int    `json:"order"`
+}
+
+// Export format versions. Version 2 adds bundles; files without bundles
+// keep version 1 so older readers still accept them.
+const (
+	FormatVersion        = "1"
+	FormatVersionBundles = "2"
+)
+
 // ExportData represents the complete export data structure
 type ExportData struct {
 	Version    string           `json:"version"`
 	ExportedAt time.Time        `json:"exported_at"`
 	Source     string           `json:"source"`
-	Bookmarks  []ExportBookmark `json:"bookmarks"`
+	Bookmarks  []ExportBookmark `json:"bookmarks" jsonschema:"required"`
+	Bundles    []ExportBundle   `json:"bundles,omitempty"`
+	// IncrementalSince marks an incremental backup holding only bookmarks
+	// modified after this time. Bookmarks missing from it are not deleted.
+	IncrementalSince *time.Time `json:"incremental_since,omitempty"`
 }
 
 // ExportOptions configures the export behavior
 type ExportOptions struct {
-	Tags            []string
+	Tags []string
+	// Query keeps only bookmarks matching this LinkDing search, as for
+	// bookmarks list --query
+	Query           string
 	IncludeArchived bool
+	// BestEffort writes whatever pages were fetched when pagination fails
+	// partway; the export function then returns the *api.PartialFetchError.
+	BestEffort bool
+	// ExcludeTags drops bookmarks carrying any of these tags, even if they
+	// match Tags
+	ExcludeTags []string
+	// GroupBy groups entries in formats that support it ("tag" for org;
+	// anything else writes a flat outline)
+	GroupBy string
+	// FolderPrefix nests HTML entries in folders named after their tags
+	// under this prefix, so "browser" puts browser/work/docs in work > docs
+	FolderPrefix string
+	// Anonymize strips personal data from every bookmark before it is
+	// written; see Anonymize for exactly what is redacted
+	Anonymize bool
+	// IncludeBundles adds every bundle to a JSON export, which is then
+	// written as FormatVersionBundles
+	IncludeBundles bool
+	// ModifiedSince keeps only bookmarks modified after it; a JSON export
+	// records it as IncrementalSince. The zero time keeps every bookmark.
+	ModifiedSince time.Time
+	// Count, when set, receives the number of bookmarks written
+	Count *int
+}
+
+// fetchBookmarks retrieves the bookmarks to export. A nil error or a
+// *api.PartialFetchError (best-effort mode only) means the returned
+// bookmarks should be written.
+func fetchBookmarks(client *api.Client, options ExportOptions) ([]models.Bookmark, error) {
+	var bookmarks []models.Bookmark
+	var err error
+	if options.BestEffort {
+		bookmarks, err = client.FetchMatchingBookmarksBestEffort(options.Query, options.Tags, options.IncludeArchived)
+	} else {
+		bookmarks, err = client.FetchMatchingBookmarks(options.Query, options.Tags, options.IncludeArchived)
+	}
+	bookmarks = filterBookmarks(bookmarks, options)
+	if options.Count != nil {
+		*options.Count = len(bookmarks)
+	}
+	if options.Anonymize {
+		anonymized, anonErr := Anonymize(bookmarks)
+		if anonErr != nil {
+			return nil, anonErr
+		}
+		bookmarks = anonymized
+	}
+	return bookmarks, err
+}
+
+// filterBookmarks applies the filters the server cannot: ExcludeTags and
+// ModifiedSince.
+func filterBookmarks(bookmarks []models.Bookmark, options ExportOptions) []models.Bookmark {
+	bookmarks = models.ExcludeTagged(bookmarks, options.ExcludeTags)
+	if !options.ModifiedSince.IsZero() {
+		modified := bookmarks[:0]
+		for _, b := range bookmarks {
+			if b.DateModified.After(options.ModifiedSince) {
+				modified = append(modified, b)
+			}
+		}
+		bookmarks = modified
+	}
+	return bookmarks
+}
+
+// isPartialFetch reports whether err is a best-effort partial fetch error.
+func isPartialFetch(err error) bool {
+	var partial *api.PartialFetchError
+	return errors.As(err, &partial)
+}
+
+// exportSource names the server and, for a filtered export, the filter, so
+// a partial file is not mistaken for a full one.
+func exportSource(options ExportOptions) string {
+	var filters []string
+	if len(options.Tags) > 0 {
+		filters = append(filters, "tags: "+strings.Join(options.Tags, ", "))
+	}
+	if options.Query != "" {
+		filters = append(filters, "query: "+options.Query)
+	}
+	if len(filters) == 0 {
+		return "linkding"
+	}
+	return "linkding (" + strings.Join(filters, "; ") + ")"
 }
 
 // convertToExportFormat converts internal bookmark models to export format
 func convertToExportFormat(bookmarks []models.Bookmark) []ExportBookmark {
 	exported := make([]ExportBookmark, len(bookmarks))
 	for i, b := range bookmarks {
+		notes := b.Notes
 		exported[i] = ExportBookmark{
 			ID:           b.ID,
 			URL:          b.URL,
 			Title:        b.Title,
 			Description:  b.Description,
+			Notes:        &notes,
 			Tags:         b.TagNames,
 			DateAdded:    b.DateAdded,
 			DateModified: b.DateModified,
@@ -58,12 +175,28 @@ func convertToExportFormat(bookmarks []models.Bookmark) []ExportBookmark {
 	return exported
 }
 
+// convertBundlesToExportFormat converts bundle models to export format
+func convertBundlesToExportFormat(bundles []models.Bundle) []ExportBundle {
+	exported := make([]ExportBundle, len(bundles))
+	for i, b := range bundles {
+		exported[i] = ExportBundle{
+			Name:         b.Name,
+			Search:       b.Search,
+			AnyTags:      b.AnyTags,
+			AllTags:      b.AllTags,
+			ExcludedTags: b.ExcludedTags,
+			Order:        b.Order,
+		}
+	}
+	return exported
+}
+
 // ExportJSON exports bookmarks to JSON format
 func ExportJSON(client *api.Client, writer io.Writer, options ExportOptions) error {
 	// Fetch all bookmarks using the Client's pagination method
-	bookmarks, err := client.FetchAllBookmarks(options.Tags, options.IncludeArchived)
-	if err != nil {
-		return err
+	bookmarks, fetchErr := fetchBookmarks(client, options)
+	if fetchErr != nil && !isPartialFetch(fetchErr) {
+		return fetchErr
 	}
 
 	// Convert to export format
@@ -71,11 +204,24 @@ func ExportJSON(client *api.Client, writer io.Writer, options ExportOptions) err
 
 	// Create export data structure
 	data := ExportData{
-		Version:    "1",
+		Version:    FormatVersion,
 		ExportedAt: time.Now().UTC(),
-		Source:     "linkding",
+		Source:     exportSource(options),
 		Bookmarks:  exportBookmarks,
 	}
+	if !options.ModifiedSince.IsZero() {
+		since := options.ModifiedSince.UTC()
+		data.IncrementalSince = &since
+	}
+
+	if options.IncludeBundles {
+		bundles, err := client.FetchAllBundles()
+		if err != nil {
+			return fmt.Errorf("failed to fetch bundles: %w", err)
+		}
+		data.Version = FormatVersionBundles
+		data.Bundles = convertBundlesToExportFormat(bundles)
+	}
 
 	// Encode to JSON with indentation
 	encoder := json.NewEncoder(writer)
@@ -84,5 +230,5 @@ func ExportJSON(client *api.Client, writer io.Writer, options ExportOptions) err
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	return nil
+	return fetchErr
 }
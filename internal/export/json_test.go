@@ -3,6 +3,7 @@ package export
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -21,6 +22,7 @@ func TestConvertToExportFormat(t *testing.T) {
 			URL:          "https://example.com",
 			Title:        "Example",
 			Description:  "Test bookmark",
+			Notes:        "Read later",
 			TagNames:     []string{"tag1", "tag2"},
 			DateAdded:    now,
 			DateModified: now,
@@ -58,6 +60,9 @@ func TestConvertToExportFormat(t *testing.T) {
 	if len(exported[0].Tags) != 2 {
 		t.Errorf("Expected 2 tags, got %d", len(exported[0].Tags))
 	}
+	if exported[0].Notes == nil || *exported[0].Notes != "Read later" {
+		t.Errorf("Expected notes \"Read later\", got %v", exported[0].Notes)
+	}
 	if exported[0].Shared != true {
 		t.Errorf("Expected Shared to be true")
 	}
@@ -282,6 +287,45 @@ func TestExportJSON_WithTags(t *testing.T) {
 	}
 }
 
+func TestExportJSON_WithQuery(t *testing.T) {
+	var qParam string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qParam = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	var buf bytes.Buffer
+	err := ExportJSON(client, &buf, ExportOptions{
+		Tags:  []string{"work"},
+		Query: "api",
+	})
+	if err != nil {
+		t.Fatalf("ExportJSON() with query failed: %v", err)
+	}
+
+	if !strings.Contains(qParam, "api") || !strings.Contains(qParam, "work") {
+		t.Errorf("Expected query and tag in q parameter, got %q", qParam)
+	}
+
+	// No matches still writes a complete file with an empty list
+	var exported ExportData
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to decode exported JSON: %v", err)
+	}
+	if exported.Bookmarks == nil || len(exported.Bookmarks) != 0 {
+		t.Errorf("Expected an empty bookmarks list, got %v", exported.Bookmarks)
+	}
+	if !strings.Contains(buf.String(), `"bookmarks": []`) {
+		t.Errorf("Expected bookmarks written as [], got:\n%s", buf.String())
+	}
+	if exported.Source != "linkding (tags: work; query: api)" {
+		t.Errorf("Expected source to note the filter, got %q", exported.Source)
+	}
+}
+
 // TestJSON_RoundTrip tests that JSON export→import preserves all bookmark data
 func TestJSON_RoundTrip(t *testing.T) {
 	testTime := time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC)
@@ -465,3 +509,170 @@ func TestJSON_RoundTrip(t *testing.T) {
 		}
 	}
 }
+
+// TestExportJSON_BestEffort tests that a mid-pagination failure still writes fetched pages
+func TestExportJSON_BestEffort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "100" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		nextURL := "/api/bookmarks/?offset=100&limit=100"
+		response := models.BookmarkList{
+			Count:   2,
+			Next:    &nextURL,
+			Results: []models.Bookmark{{ID: 1, URL: "https://example.com", Title: "Example"}},
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+
+	t.Run("default mode writes nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ExportJSON(client, &buf, ExportOptions{IncludeArchived: true}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got: %s", buf.String())
+		}
+	})
+
+	t.Run("best-effort writes fetched pages", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportJSON(client, &buf, ExportOptions{IncludeArchived: true, BestEffort: true})
+
+		var partial *api.PartialFetchError
+		if !errors.As(err, &partial) {
+			t.Fatalf("expected PartialFetchError, got %v", err)
+		}
+
+		var exported ExportData
+		if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+			t.Fatalf("Failed to decode exported JSON: %v", err)
+		}
+		if len(exported.Bookmarks) != 1 || exported.Bookmarks[0].URL != "https://example.com" {
+			t.Errorf("expected the first page to be exported, got %+v", exported.Bookmarks)
+		}
+	})
+}
+
+// TestExportJSON_ExcludeTags tests that excluded tags win over included tags
+func TestExportJSON_ExcludeTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := models.BookmarkList{
+			Count: 3,
+			Results: []models.Bookmark{
+				{ID: 1, URL: "https://keep.com", TagNames: []string{"dev"}},
+				{ID: 2, URL: "https://drop.com", TagNames: []string{"dev", "Private"}},
+				{ID: 3, URL: "https://drop2.com", TagNames: []string{"nsfw"}},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL, "test-token")
+	var buf bytes.Buffer
+	err := ExportJSON(client, &buf, ExportOptions{
+		Tags:        []string{"dev"},
+		ExcludeTags: []string{"private", "nsfw"},
+	})
+	if err != nil {
+		t.Fatalf("ExportJSON() failed: %v", err)
+	}
+
+	var exported ExportData
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to decode exported JSON: %v", err)
+	}
+	if len(exported.Bookmarks) != 1 || exported.Bookmarks[0].URL != "https://keep.com" {
+		t.Errorf("Expected only https://keep.com, got %+v", exported.Bookmarks)
+	}
+}
+
+func TestExportJSON_IncludeBundlesRoundTrip(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/bundles/") {
+			_ = json.NewEncoder(w).Encode(models.BundleList{Count: 2, Results: []models.Bundle{
+				{ID: 1, Name: "Reading", AnyTags: "books articles", Order: 1},
+				{ID: 2, Name: "Work", Search: "k8s", ExcludedTags: "old", Order: 2},
+			}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: 1, Results: []models.Bookmark{
+			{ID: 1, URL: "https://example.com", Title: "Example"},
+		}})
+	}))
+	defer source.Close()
+
+	var buf bytes.Buffer
+	if err := ExportJSON(api.NewClient(source.URL, "test-token"), &buf, ExportOptions{IncludeArchived: true, IncludeBundles: true}); err != nil {
+		t.Fatalf("ExportJSON() failed: %v", err)
+	}
+
+	var exported ExportData
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to decode exported JSON: %v", err)
+	}
+	if exported.Version != FormatVersionBundles || len(exported.Bundles) != 2 {
+		t.Fatalf("Expected version %s with 2 bundles, got version %s with %d", FormatVersionBundles, exported.Version, len(exported.Bundles))
+	}
+	if errs, err := ValidateJSON(bytes.NewReader(buf.Bytes())); err != nil || len(errs) > 0 {
+		t.Errorf("Expected the export to match the schema, got %v %v", errs, err)
+	}
+
+	var created []models.BundleCreate
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/bundles/"):
+			_ = json.NewEncoder(w).Encode(models.BundleList{Count: 1, Results: []models.Bundle{{ID: 9, Name: "Work"}}})
+		case r.Method == "GET":
+			_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+		case r.URL.Path == "/api/bundles/":
+			var body models.BundleCreate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bundle{ID: 10, Name: body.Name})
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 5, URL: "https://example.com"})
+		}
+	}))
+	defer destination.Close()
+
+	result, err := importJSON(api.NewClient(destination.URL, "test-token"), bytes.NewReader(buf.Bytes()), ImportOptions{Bundles: true})
+	if err != nil {
+		t.Fatalf("importJSON() failed: %v", err)
+	}
+	if result.Added != 1 || result.BundlesAdded != 1 || result.BundlesSkipped != 1 || len(result.BundleErrors) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	want := models.BundleCreate{Name: "Reading", AnyTags: "books articles", Order: 1}
+	if len(created) != 1 || created[0] != want {
+		t.Errorf("Expected %+v to be recreated, got %+v", want, created)
+	}
+}
+
+func TestExportJSON_WithoutBundlesKeepsVersion1(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/bundles/") {
+			t.Errorf("Expected bundles not to be fetched")
+		}
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := ExportJSON(api.NewClient(server.URL, "test-token"), &buf, ExportOptions{}); err != nil {
+		t.Fatalf("ExportJSON() failed: %v", err)
+	}
+	if strings.Contains(buf.String(), `"bundles"`) || !strings.Contains(buf.String(), `"version": "1"`) {
+		t.Errorf("Expected a version 1 export without bundles, got %s", buf.String())
+	}
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+)
+
+// ExportJSONL exports bookmarks as JSON Lines: one ExportBookmark object per
+// line, with no surrounding document. Each page is written as soon as it is
+// fetched, so memory use does not grow with the collection; pages are
+// therefore always fetched one after another, whatever the client's
+// Parallel setting.
+func ExportJSONL(client *api.Client, writer io.Writer, options ExportOptions) error {
+	var archivedPtr *bool
+	if !options.IncludeArchived {
+		archived := false
+		archivedPtr = &archived
+	}
+
+	var anon *anonymizer
+	if options.Anonymize {
+		var err error
+		if anon, err = newAnonymizer(); err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	written := 0
+	pages := client.BookmarkPages(options.Query, options.Tags, nil, archivedPtr)
+	for {
+		offset := pages.Offset()
+		bookmarks, ok, err := pages.Next()
+		if err != nil {
+			if options.BestEffort && offset > 0 {
+				return &api.PartialFetchError{Offset: offset, Fetched: written, Err: err}
+			}
+			return fmt.Errorf("failed to fetch bookmarks: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		bookmarks = filterBookmarks(bookmarks, options)
+		if anon != nil {
+			bookmarks = anon.apply(bookmarks)
+		}
+		for _, b := range convertToExportFormat(bookmarks) {
+			if err := encoder.Encode(b); err != nil {
+				return fmt.Errorf("failed to write bookmark: %w", err)
+			}
+		}
+		written += len(bookmarks)
+		if options.Count != nil {
+			*options.Count = written
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// lockedBuffer is a bytes.Buffer that a mock server may read while the
+// export writes to it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestExportJSONL(t *testing.T) {
+	var out lockedBuffer
+	next := "next"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var list models.BookmarkList
+		if r.URL.Query().Get("offset") == "" {
+			list = models.BookmarkList{Count: 3, Next: &next, Results: []models.Bookmark{
+				{ID: 1, URL: "https://one.example.com", Title: "One", TagNames: []string{"go"}},
+				{ID: 2, URL: "https://two.example.com", Title: "Two \"quoted\"\nline", TagNames: []string{"private"}},
+			}}
+		} else {
+			// The first page is written before the second is requested
+			if got := strings.Count(out.String(), "\n"); got != 1 {
+				t.Errorf("Expected the first page written before the second request, got %d lines", got)
+			}
+			list = models.BookmarkList{Count: 3, Results: []models.Bookmark{
+				{ID: 3, URL: "https://three.example.com", Title: "Three"},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	var count int
+	client := api.NewClient(server.URL, "test-token")
+	err := ExportJSONL(client, &out, ExportOptions{ExcludeTags: []string{"private"}, Count: &count})
+	if err != nil {
+		t.Fatalf("ExportJSONL() failed: %v", err)
+	}
+
+	var ids []int
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var b ExportBookmark
+		if err := json.Unmarshal(scanner.Bytes(), &b); err != nil {
+			t.Fatalf("Line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		ids = append(ids, b.ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("Expected bookmarks 1 and 3, got %v", ids)
+	}
+	if count != len(ids) {
+		t.Errorf("Expected a count of %d, got %d", len(ids), count)
+	}
+}
+
+func TestExportJSONL_Anonymize(t *testing.T) {
+	client := newOrgTestClient(t, []models.Bookmark{
+		{ID: 1, URL: "https://one.example.com", Title: "One", Description: "secret"},
+		{ID: 2, URL: "https://two.example.com", Title: "Two"},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportJSONL(client, &buf, ExportOptions{Anonymize: true}); err != nil {
+		t.Fatalf("ExportJSONL() failed: %v", err)
+	}
+	output := buf.String()
+	if strings.Contains(output, "example.com") || strings.Contains(output, "secret") {
+		t.Errorf("Expected personal data removed, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"title":"Bookmark 2"`) {
+		t.Errorf("Expected numbered titles, got:\n%s", output)
+	}
+}
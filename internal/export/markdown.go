@@ -0,0 +1,81 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// markdownTextEscaper escapes characters that would end or nest a Markdown
+// link's text.
+var markdownTextEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+// markdownURLEscaper escapes characters that would end a Markdown link's
+// destination. Spaces are percent-encoded since a bare space ends it.
+var markdownURLEscaper = strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, " ", "%20")
+
+// MarkdownLink formats text and url as a Markdown link, [text](url), with
+// runs of whitespace in text collapsed to single spaces.
+func MarkdownLink(text, url string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return fmt.Sprintf("[%s](%s)", markdownTextEscaper.Replace(text), markdownURLEscaper.Replace(url))
+}
+
+// ExportMarkdown exports bookmarks as a Markdown document with a "##" section
+// per tag, sorted by name, and an "Untagged" section last. Each bookmark is a
+// "- [Title](URL) — description" bullet, listed under every one of its tags.
+func ExportMarkdown(client *api.Client, writer io.Writer, options ExportOptions) error {
+	// Fetch all bookmarks using the Client's pagination method
+	bookmarks, fetchErr := fetchBookmarks(client, options)
+	if fetchErr != nil && !isPartialFetch(fetchErr) {
+		return fetchErr
+	}
+
+	if _, err := fmt.Fprint(writer, "# Bookmarks\n"); err != nil {
+		return fmt.Errorf("failed to write Markdown header: %w", err)
+	}
+
+	names, groups, untagged := groupByTag(bookmarks)
+	for _, name := range names {
+		if err := writeMarkdownSection(writer, name, groups[name]); err != nil {
+			return err
+		}
+	}
+	if len(untagged) > 0 {
+		if err := writeMarkdownSection(writer, "Untagged", untagged); err != nil {
+			return err
+		}
+	}
+
+	return fetchErr
+}
+
+// writeMarkdownSection writes a "##" heading followed by a bullet per bookmark.
+func writeMarkdownSection(writer io.Writer, heading string, bookmarks []models.Bookmark) error {
+	if _, err := fmt.Fprintf(writer, "\n## %s\n\n", heading); err != nil {
+		return fmt.Errorf("failed to write Markdown heading: %w", err)
+	}
+	for _, b := range bookmarks {
+		if _, err := fmt.Fprintln(writer, markdownBullet(b)); err != nil {
+			return fmt.Errorf("failed to write bookmark: %w", err)
+		}
+	}
+	return nil
+}
+
+// markdownBullet formats a bookmark as a single-line list item. Bookmarks
+// without a title fall back to the URL as link text.
+func markdownBullet(b models.Bookmark) string {
+	title := strings.Join(strings.Fields(b.Title), " ")
+	if title == "" {
+		title = b.URL
+	}
+	line := "- " + MarkdownLink(title, b.URL)
+	if description := strings.Join(strings.Fields(b.Description), " "); description != "" {
+		line += " — " + description
+	}
+	return line
+}
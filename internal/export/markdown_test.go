@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	client := newOrgTestClient(t, []models.Bookmark{
+		{
+			ID:          1,
+			URL:         "https://go.dev",
+			Title:       "The Go [Programming] Language",
+			Description: "Docs and\ndownloads",
+			TagNames:    []string{"go", "dev"},
+		},
+		{
+			ID:  2,
+			URL: "https://example.com/a (b)",
+		},
+		{
+			ID:       3,
+			URL:      "https://pkg.go.dev",
+			Title:    "Go Packages",
+			TagNames: []string{"go"},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(client, &buf, ExportOptions{}); err != nil {
+		t.Fatalf("ExportMarkdown() failed: %v", err)
+	}
+	output := buf.String()
+
+	expected := "# Bookmarks\n" +
+		"\n## dev\n\n" +
+		"- [The Go \\[Programming\\] Language](https://go.dev) — Docs and downloads\n" +
+		"\n## go\n\n" +
+		"- [The Go \\[Programming\\] Language](https://go.dev) — Docs and downloads\n" +
+		"- [Go Packages](https://pkg.go.dev)\n" +
+		"\n## Untagged\n\n" +
+		"- [https://example.com/a (b)](https://example.com/a%20\\(b\\))\n"
+	if output != expected {
+		t.Errorf("Unexpected Markdown:\n%s\nwant:\n%s", output, expected)
+	}
+	if strings.Count(output, "https://go.dev)") != 2 {
+		t.Errorf("Expected a bookmark with two tags under both headings")
+	}
+}
+
+func TestMarkdownLink(t *testing.T) {
+	got := MarkdownLink("Go  [docs]\n", `https://example.com/a b(1)`)
+	want := `[Go \[docs\]](https://example.com/a%20b\(1\))`
+	if got != want {
+		t.Errorf("MarkdownLink() = %q, want %q", got, want)
+	}
+}
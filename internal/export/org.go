@@ -0,0 +1,159 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// orgUntaggedHeading is the Org heading for bookmarks without tags
+const orgUntaggedHeading = ":Untagged:"
+
+// ExportOrg exports bookmarks to Emacs Org-mode format. Each bookmark is a
+// heading with an [[url][title]] link, its tags as Org :tag: tags and its
+// description as body text. With GroupBy "tag", bookmarks are nested under a
+// top-level heading per tag, followed by an :Untagged: heading for bookmarks
+// without tags.
+func ExportOrg(client *api.Client, writer io.Writer, options ExportOptions) error {
+	// Fetch all bookmarks using the Client's pagination method
+	bookmarks, fetchErr := fetchBookmarks(client, options)
+	if fetchErr != nil && !isPartialFetch(fetchErr) {
+		return fetchErr
+	}
+
+	if _, err := fmt.Fprintf(writer, "#+TITLE: Bookmarks\n\n"); err != nil {
+		return fmt.Errorf("failed to write Org header: %w", err)
+	}
+
+	if options.GroupBy != "tag" {
+		for _, b := range bookmarks {
+			if err := writeOrgBookmark(writer, b, 1); err != nil {
+				return err
+			}
+		}
+		return fetchErr
+	}
+
+	names, groups, untagged := groupByTag(bookmarks)
+	for _, name := range names {
+		if err := writeOrgGroup(writer, name, groups[name]); err != nil {
+			return err
+		}
+	}
+	if len(untagged) > 0 {
+		if err := writeOrgGroup(writer, orgUntaggedHeading, untagged); err != nil {
+			return err
+		}
+	}
+
+	return fetchErr
+}
+
+// writeOrgGroup writes a top-level heading with its bookmarks nested under it.
+func writeOrgGroup(writer io.Writer, heading string, bookmarks []models.Bookmark) error {
+	if _, err := fmt.Fprintf(writer, "* %s\n", heading); err != nil {
+		return fmt.Errorf("failed to write Org heading: %w", err)
+	}
+	for _, b := range bookmarks {
+		if err := writeOrgBookmark(writer, b, 2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupByTag groups bookmarks under each of their tags. It returns the tag
+// names sorted, and the bookmarks without tags separately, so that a tag
+// named like the untagged heading is still a group of its own.
+func groupByTag(bookmarks []models.Bookmark) ([]string, map[string][]models.Bookmark, []models.Bookmark) {
+	groups := make(map[string][]models.Bookmark)
+	var untagged []models.Bookmark
+	for _, b := range bookmarks {
+		if len(b.TagNames) == 0 {
+			untagged = append(untagged, b)
+			continue
+		}
+		for _, tag := range b.TagNames {
+			groups[tag] = append(groups[tag], b)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, groups, untagged
+}
+
+// writeOrgBookmark writes a single bookmark as an Org heading at the given level.
+func writeOrgBookmark(writer io.Writer, b models.Bookmark, level int) error {
+	title := b.Title
+	if title == "" {
+		title = b.URL
+	}
+
+	heading := fmt.Sprintf("%s [[%s][%s]]", strings.Repeat("*", level), orgLinkURL(b.URL), orgLinkText(title))
+	if tags := orgTags(b.TagNames); tags != "" {
+		heading += " " + tags
+	}
+	if _, err := fmt.Fprintln(writer, heading); err != nil {
+		return fmt.Errorf("failed to write bookmark heading: %w", err)
+	}
+
+	indent := strings.Repeat(" ", level+1)
+	if !b.DateAdded.IsZero() {
+		if _, err := fmt.Fprintf(writer, "%s:PROPERTIES:\n%s:ADDED: [%s]\n%s:END:\n",
+			indent, indent, b.DateAdded.Format("2006-01-02 Mon 15:04"), indent); err != nil {
+			return fmt.Errorf("failed to write bookmark properties: %w", err)
+		}
+	}
+
+	// Indent the body so description lines starting with '*' are not read as headings
+	if b.Description != "" {
+		for _, line := range strings.Split(strings.TrimRight(b.Description, "\n"), "\n") {
+			if _, err := fmt.Fprintf(writer, "%s%s\n", indent, line); err != nil {
+				return fmt.Errorf("failed to write description: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// orgLinkURL escapes the characters that would terminate an Org link target.
+func orgLinkURL(url string) string {
+	return strings.NewReplacer("[", "%5B", "]", "%5D").Replace(url)
+}
+
+// orgLinkText replaces square brackets, which Org does not allow in link descriptions.
+func orgLinkText(text string) string {
+	return strings.NewReplacer("[", "{", "]", "}", "\n", " ").Replace(text)
+}
+
+// orgTags formats tags as an Org tag string like ":go:tools:". Characters
+// Org does not allow in tags are replaced with underscores.
+func orgTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	cleaned := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		var sb strings.Builder
+		for _, r := range tag {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+				r == '_', r == '@', r == '#', r == '%', r > 127:
+				sb.WriteRune(r)
+			default:
+				sb.WriteRune('_')
+			}
+		}
+		cleaned = append(cleaned, sb.String())
+	}
+	return ":" + strings.Join(cleaned, ":") + ":"
+}
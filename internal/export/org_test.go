@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// newOrgTestClient serves the given bookmarks from a mock server.
+func newOrgTestClient(t *testing.T, bookmarks []models.Bookmark) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := models.BookmarkList{Count: len(bookmarks), Results: bookmarks}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(server.URL, "test-token")
+}
+
+func TestExportOrg_Headings(t *testing.T) {
+	testTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := newOrgTestClient(t, []models.Bookmark{
+		{
+			ID:          1,
+			URL:         "https://example.com",
+			Title:       "Example Site",
+			Description: "First line\n* not a heading",
+			TagNames:    []string{"go", "dev-tools"},
+			DateAdded:   testTime,
+		},
+		{
+			ID:  2,
+			URL: "https://untitled.example.com/[x]",
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportOrg(client, &buf, ExportOptions{}); err != nil {
+		t.Fatalf("ExportOrg() failed: %v", err)
+	}
+	output := buf.String()
+
+	expected := []string{
+		"#+TITLE: Bookmarks",
+		"* [[https://example.com][Example Site]] :go:dev_tools:",
+		"  :ADDED: [2024-01-01 Mon 12:00]",
+		"  First line\n  * not a heading\n",
+		"* [[https://untitled.example.com/%5Bx%5D][https://untitled.example.com/{x}]]\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestExportOrg_GroupByTag(t *testing.T) {
+	client := newOrgTestClient(t, []models.Bookmark{
+		{ID: 1, URL: "https://a.com", Title: "A", TagNames: []string{"zeta", "alpha"}},
+		{ID: 2, URL: "https://b.com", Title: "B", TagNames: []string{"alpha"}},
+		{ID: 3, URL: "https://c.com", Title: "C"},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportOrg(client, &buf, ExportOptions{GroupBy: "tag"}); err != nil {
+		t.Fatalf("ExportOrg() failed: %v", err)
+	}
+	output := buf.String()
+
+	alpha := strings.Index(output, "* alpha\n")
+	zeta := strings.Index(output, "* zeta\n")
+	untagged := strings.Index(output, "* :Untagged:\n")
+	if alpha < 0 || zeta < 0 || untagged < 0 {
+		t.Fatalf("Expected tag headings, got:\n%s", output)
+	}
+	if !(alpha < zeta && zeta < untagged) {
+		t.Errorf("Expected headings sorted with untagged last, got:\n%s", output)
+	}
+
+	// A bookmark with two tags appears under both
+	if strings.Count(output, "** [[https://a.com][A]]") != 2 {
+		t.Errorf("Expected bookmark A under both of its tags, got:\n%s", output)
+	}
+	if !strings.Contains(output[untagged:], "** [[https://c.com][C]]") {
+		t.Errorf("Expected untagged bookmark under untagged heading, got:\n%s", output)
+	}
+}
+
+func TestExportOrg_TagNamedUntagged(t *testing.T) {
+	client := newOrgTestClient(t, []models.Bookmark{
+		{ID: 1, URL: "https://a.com", Title: "A", TagNames: []string{"untagged"}},
+		{ID: 2, URL: "https://b.com", Title: "B"},
+	})
+
+	var buf bytes.Buffer
+	if err := ExportOrg(client, &buf, ExportOptions{GroupBy: "tag"}); err != nil {
+		t.Fatalf("ExportOrg() failed: %v", err)
+	}
+
+	want := "* untagged\n** [[https://a.com][A]] :untagged:\n* :Untagged:\n** [[https://b.com][B]]\n"
+	if output := buf.String(); !strings.HasSuffix(output, want) {
+		t.Errorf("Expected the untagged tag and the untagged bookmarks apart, got:\n%s", output)
+	}
+}
+
+func TestOrgTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{tags: nil, want: ""},
+		{tags: []string{"go"}, want: ":go:"},
+		{tags: []string{"home lab", "c++", "@work"}, want: ":home_lab:c__:@work:"},
+	}
+	for _, tt := range tests {
+		if got := orgTags(tt.tags); got != tt.want {
+			t.Errorf("orgTags(%v) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
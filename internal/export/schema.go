@@ -0,0 +1,246 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaID identifies the JSON Schema describing the export file format.
+const SchemaID = "https://github.com/rodmhgl/linkdingctl/schemas/export.json"
+
+// ValidationError describes a single schema violation in an export file.
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema returns the JSON Schema for ExportData. It is generated from the
+// struct definitions so it stays in sync with the export format. Fields tagged
+// `jsonschema:"required"` are required; `minLength=N` sets a minimum length.
+func JSONSchema() map[string]interface{} {
+	schema := schemaFor(reflect.TypeOf(ExportData{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = SchemaID
+	schema["title"] = "linkdingctl export"
+	return schema
+}
+
+// schemaFor builds the schema for a single Go type.
+func schemaFor(t reflect.Type) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		// Slices may be encoded as null when empty
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": schemaFor(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			prop := schemaFor(field.Type)
+			for _, opt := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+				switch {
+				case opt == "required":
+					required = append(required, name)
+				case strings.HasPrefix(opt, "minLength="):
+					if n, err := strconv.Atoi(strings.TrimPrefix(opt, "minLength=")); err == nil {
+						prop["minLength"] = n
+					}
+				}
+			}
+			properties[name] = prop
+		}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// jsonFieldName returns the JSON name of a struct field, or false if the
+// field is not serialized.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// ValidateJSON checks a JSON export document against JSONSchema without
+// contacting the server. It returns the violations found; an error is
+// returned only if the input is not valid JSON.
+func ValidateJSON(reader io.Reader) ([]ValidationError, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var errs []ValidationError
+	validateValue(JSONSchema(), doc, "$", &errs)
+	return errs, nil
+}
+
+// validateValue validates value against the subset of JSON Schema produced by schemaFor.
+func validateValue(schema map[string]interface{}, value interface{}, path string, errs *[]ValidationError) {
+	if !matchesType(schema["type"], value) {
+		*errs = append(*errs, ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s, got %s", describeType(schema["type"]), jsonTypeName(value)),
+		})
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if minLen, ok := schema["minLength"].(int); ok && len(v) < minLen {
+			*errs = append(*errs, ValidationError{Path: path, Message: "must not be empty"})
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("invalid date-time %q", v)})
+			}
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range v {
+			validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case map[string]interface{}:
+		required, _ := schema["required"].([]string)
+		for _, name := range required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("missing required field %q", name)})
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := properties[name].(map[string]interface{}); ok {
+				validateValue(prop, v[name], path+"."+name, errs)
+			}
+		}
+	}
+}
+
+// matchesType reports whether value satisfies a schema "type" (string or list of strings).
+func matchesType(schemaType interface{}, value interface{}) bool {
+	switch st := schemaType.(type) {
+	case nil:
+		return true
+	case string:
+		return typeMatches(st, value)
+	case []string:
+		for _, t := range st {
+			if typeMatches(t, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func typeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	default:
+		return true
+	}
+}
+
+func describeType(schemaType interface{}) string {
+	if types, ok := schemaType.([]string); ok {
+		return strings.Join(types, " or ")
+	}
+	return fmt.Sprintf("%v", schemaType)
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+
+	if schema["$id"] != SchemaID {
+		t.Errorf("Expected $id %s, got %v", SchemaID, schema["$id"])
+	}
+
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected top-level properties")
+	}
+	for _, name := range []string{"version", "exported_at", "source", "bookmarks"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("Expected property %q in schema", name)
+		}
+	}
+
+	bookmarks := properties["bookmarks"].(map[string]interface{})
+	items := bookmarks["items"].(map[string]interface{})
+	itemProps := items["properties"].(map[string]interface{})
+	dateAdded := itemProps["date_added"].(map[string]interface{})
+	if dateAdded["format"] != "date-time" {
+		t.Errorf("Expected date_added to be a date-time, got %v", dateAdded)
+	}
+	required, _ := items["required"].([]string)
+	if len(required) != 1 || required[0] != "url" {
+		t.Errorf("Expected bookmark items to require only url, got %v", required)
+	}
+
+	// The schema must be serializable as-is
+	if _, err := json.Marshal(schema); err != nil {
+		t.Errorf("Failed to marshal schema: %v", err)
+	}
+}
+
+func TestValidateJSON_ExportRoundTrip(t *testing.T) {
+	data := ExportData{
+		Version:    "1",
+		ExportedAt: time.Now(),
+		Source:     "https://linkding.example.com",
+		Bookmarks: []ExportBookmark{
+			{ID: 1, URL: "https://example.com", Tags: []string{"a"}, DateAdded: time.Now(), DateModified: time.Now()},
+		},
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Failed to marshal export: %v", err)
+	}
+
+	errs, err := ValidateJSON(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ValidateJSON failed: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("Expected export output to validate, got: %v", errs)
+	}
+}
+
+func TestValidateJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name:     "missing bookmarks",
+			input:    `{"version": "1"}`,
+			wantPath: "$",
+			wantMsg:  `missing required field "bookmarks"`,
+		},
+		{
+			name:     "missing url",
+			input:    `{"bookmarks": [{"title": "No URL"}]}`,
+			wantPath: "$.bookmarks[0]",
+			wantMsg:  `missing required field "url"`,
+		},
+		{
+			name:     "empty url",
+			input:    `{"bookmarks": [{"url": ""}]}`,
+			wantPath: "$.bookmarks[0].url",
+			wantMsg:  "must not be empty",
+		},
+		{
+			name:     "wrong type",
+			input:    `{"bookmarks": [{"url": "https://a.com", "unread": "yes"}]}`,
+			wantPath: "$.bookmarks[0].unread",
+			wantMsg:  "expected boolean, got string",
+		},
+		{
+			name:     "non-integer id",
+			input:    `{"bookmarks": [{"url": "https://a.com", "id": 1.5}]}`,
+			wantPath: "$.bookmarks[0].id",
+			wantMsg:  "expected integer, got number",
+		},
+		{
+			name:     "bad date",
+			input:    `{"bookmarks": [{"url": "https://a.com", "date_added": "yesterday"}]}`,
+			wantPath: "$.bookmarks[0].date_added",
+			wantMsg:  "invalid date-time",
+		},
+		{
+			name:     "tags not an array",
+			input:    `{"bookmarks": [{"url": "https://a.com", "tags": "a,b"}]}`,
+			wantPath: "$.bookmarks[0].tags",
+			wantMsg:  "expected array or null, got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := ValidateJSON(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ValidateJSON failed: %v", err)
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 validation error, got %d: %v", len(errs), errs)
+			}
+			if errs[0].Path != tt.wantPath {
+				t.Errorf("Expected path %q, got %q", tt.wantPath, errs[0].Path)
+			}
+			if !strings.Contains(errs[0].Message, tt.wantMsg) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMsg, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateJSON_InvalidJSON(t *testing.T) {
+	if _, err := ValidateJSON(strings.NewReader(`{"bookmarks": [`)); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+}
@@ -0,0 +1,226 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// MaxConcurrency caps ImportOptions.Concurrency.
+const MaxConcurrency = 16
+
+// writeJob is the create or update request for one import entry.
+type writeJob struct {
+	entry      int // index in file order, for the checkpoint
+	line       int // line reported in errors
+	existingID int
+	exists     bool
+	create     *models.BookmarkCreate
+	update     *models.BookmarkUpdate
+}
+
+// bookmarkWriter sends the create and update requests of an import. Jobs are
+// collected into batches of the import's concurrency, and each batch is sent
+// with the client's CreateBookmarks and UpdateBookmarks before the importer
+// reads on, so no more requests than that are ever in flight. With a
+// concurrency of 1 every job is sent as soon as it is written. The outcomes
+// are counted into the result in entry order.
+type bookmarkWriter struct {
+	client  *api.Client
+	options ImportOptions
+	result  *ImportResult
+
+	size  int // jobs per batch
+	batch []writeJob
+	// failed holds the jobs whose request failed, for RetryFailed
+	failed []failedJob
+}
+
+// failedJob is a job whose request failed, with the index of its entry in
+// the result's Errors.
+type failedJob struct {
+	job      writeJob
+	errIndex int
+}
+
+func newBookmarkWriter(client *api.Client, result *ImportResult, options ImportOptions) *bookmarkWriter {
+	size := max(min(options.Concurrency, MaxConcurrency), 1)
+	return &bookmarkWriter{client: client, options: options, result: result, size: size}
+}
+
+// write creates or updates one bookmark, or queues it until the batch is
+// full. The returned error means the checkpoint could not be written.
+func (w *bookmarkWriter) write(job writeJob) error {
+	w.batch = append(w.batch, job)
+	if len(w.batch) < w.size {
+		return nil
+	}
+	return w.flush()
+}
+
+// flush sends the queued jobs, the creations and updates side by side, and
+// records their outcomes. It returns the first checkpoint failure.
+func (w *bookmarkWriter) flush() error {
+	batch := w.batch
+	w.batch = nil
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var creates []*models.BookmarkCreate
+	var patches []api.BookmarkPatch
+	for _, job := range batch {
+		if job.exists {
+			patches = append(patches, api.BookmarkPatch{ID: job.existingID, Update: job.update})
+		} else {
+			creates = append(creates, job.create)
+		}
+	}
+
+	var created, updated []api.BookmarkResult
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		updated = w.client.UpdateBookmarks(patches, len(patches))
+	}()
+	created = w.client.CreateBookmarks(creates, len(creates))
+	wg.Wait()
+
+	var firstErr error
+	for _, job := range batch {
+		var outcome api.BookmarkResult
+		if job.exists {
+			outcome, updated = updated[0], updated[1:]
+		} else {
+			outcome, created = created[0], created[1:]
+		}
+		if err := w.record(job, outcome); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// record counts the outcome of a request in the result and, on success,
+// marks the entry done in the checkpoint.
+func (w *bookmarkWriter) record(job writeJob, outcome api.BookmarkResult) error {
+	if outcome.Err != nil {
+		action := "create"
+		if job.exists {
+			action = "update"
+		}
+		w.result.Failed++
+		w.failed = append(w.failed, failedJob{job: job, errIndex: len(w.result.Errors)})
+		w.result.Errors = append(w.result.Errors, ImportError{
+			Line:    job.line,
+			Message: fmt.Sprintf("Failed to %s: %v", action, outcome.Err),
+		})
+		return nil
+	}
+	id := job.existingID
+	if job.exists {
+		w.result.Updated++
+	} else {
+		w.result.Added++
+		id = outcome.Bookmark.ID
+	}
+	return w.options.Checkpoint.Record(job.entry, id)
+}
+
+// wait sends the jobs still queued, then resends those that failed up to
+// RetryFailed times, unless StopOnError has ended the import. Entries the
+// importer rejected itself may have been counted before earlier entries of
+// a batch were sent, so the errors are then sorted by line. It returns the
+// first checkpoint failure.
+func (w *bookmarkWriter) wait() error {
+	err := w.flush()
+	for pass := 0; pass < w.options.RetryFailed && len(w.failed) > 0 && !w.options.StopOnError; pass++ {
+		if retryErr := w.retry(); err == nil {
+			err = retryErr
+		}
+	}
+	sort.SliceStable(w.result.Errors, func(i, j int) bool {
+		return w.result.Errors[i].Line < w.result.Errors[j].Line
+	})
+	return err
+}
+
+// retry sends the failed jobs again. Their failures are taken out of the
+// result first, so a job that succeeds now counts as added or updated and
+// only one that fails again is counted as failed.
+//
+// A create that failed may still have been committed by the server, for
+// example when the response timed out, so the existing bookmarks are read
+// again first: a create whose URL is now there counts as added without
+// being sent twice. If they cannot be read, the failed creates stay failed.
+func (w *bookmarkWriter) retry() error {
+	failed := w.failed
+	w.failed = nil
+
+	var existing map[string]int
+	var existingErr error
+	for _, f := range failed {
+		if !f.job.exists {
+			existing, existingErr = existingBookmarkURLs(w.client, w.options)
+			break
+		}
+	}
+	if existingErr != nil {
+		failed = updatesOnly(failed)
+	}
+
+	retried := make(map[int]bool, len(failed))
+	for _, f := range failed {
+		retried[f.errIndex] = true
+	}
+	kept := make([]ImportError, 0, len(w.result.Errors)-len(failed))
+	for i, e := range w.result.Errors {
+		if !retried[i] {
+			kept = append(kept, e)
+		}
+	}
+	w.result.Errors = kept
+	w.result.Failed -= len(failed)
+
+	var firstErr error
+	for _, f := range failed {
+		var err error
+		if id, ok := w.committed(f.job, existing); ok {
+			err = w.record(f.job, api.BookmarkResult{Bookmark: &models.Bookmark{ID: id}})
+		} else {
+			err = w.write(f.job)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := w.flush(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// committed reports whether a failed create's URL is among the existing
+// bookmarks, and its ID if so.
+func (w *bookmarkWriter) committed(job writeJob, existing map[string]int) (int, bool) {
+	if job.exists {
+		return 0, false
+	}
+	id, ok := existing[w.options.urlKey(job.create.URL)]
+	return id, ok
+}
+
+// updatesOnly returns the failed updates, leaving out the failed creates.
+func updatesOnly(failed []failedJob) []failedJob {
+	var updates []failedJob
+	for _, f := range failed {
+		if f.job.exists {
+			updates = append(updates, f)
+		}
+	}
+	return updates
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// concurrentServer serves an import against one existing bookmark, failing
+// creates for URLs containing "fail", and tracks the peak number of
+// requests in flight.
+type concurrentServer struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	created  []string
+	updated  []string
+}
+
+func (s *concurrentServer) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{{ID: 7, URL: "https://example.com/existing"}}})
+		return
+	}
+
+	s.mu.Lock()
+	s.inFlight++
+	s.peak = max(s.peak, s.inFlight)
+	s.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	if r.Method == "PATCH" {
+		s.mu.Lock()
+		s.updated = append(s.updated, r.URL.Path)
+		s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 7})
+		return
+	}
+	var body models.BookmarkCreate
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	if strings.Contains(body.URL, "fail") {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"url":["rejected"]}`))
+		return
+	}
+	s.mu.Lock()
+	s.created = append(s.created, body.URL)
+	id := 100 + len(s.created)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(models.Bookmark{ID: id, URL: body.URL})
+}
+
+func writeConcurrentImportFile(t *testing.T) string {
+	t.Helper()
+	var data ExportData
+	for i := 0; i < 20; i++ {
+		url := fmt.Sprintf("https://example.com/%d", i)
+		if i == 4 || i == 15 {
+			url = fmt.Sprintf("https://example.com/fail-%d", i)
+		}
+		data.Bookmarks = append(data.Bookmarks, ExportBookmark{URL: url})
+	}
+	data.Bookmarks = append(data.Bookmarks, ExportBookmark{URL: "https://example.com/existing"})
+	content, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "bookmarks.json")
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestImportBookmarks_Concurrency(t *testing.T) {
+	srv := &concurrentServer{}
+	server := httptest.NewServer(http.HandlerFunc(srv.handler))
+	defer server.Close()
+	client := api.NewClient(server.URL, "test-token")
+	file := writeConcurrentImportFile(t)
+
+	cp, err := OpenCheckpoint(file+".checkpoint", file, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cp.Remove() }()
+
+	result, err := ImportBookmarks(client, file, ImportOptions{Concurrency: 4, Checkpoint: cp})
+	if err != nil {
+		t.Fatalf("ImportBookmarks() failed: %v", err)
+	}
+
+	if result.Added != 18 || result.Updated != 1 || result.Failed != 2 {
+		t.Errorf("Expected 18 added, 1 updated, 2 failed, got %+v", result)
+	}
+	if len(result.Errors) != 2 || result.Errors[0].Line != 5 || result.Errors[1].Line != 16 {
+		t.Errorf("Expected errors for lines 5 and 16 in order, got %+v", result.Errors)
+	}
+	if len(srv.updated) != 1 || srv.updated[0] != "/api/bookmarks/7/" {
+		t.Errorf("Expected the existing bookmark to be updated, got %v", srv.updated)
+	}
+	if srv.peak < 2 || srv.peak > 4 {
+		t.Errorf("Expected between 2 and 4 requests in flight, got %d", srv.peak)
+	}
+	if cp.Len() != 19 {
+		t.Errorf("Expected 19 entries checkpointed, got %d", cp.Len())
+	}
+}
+
+func TestImportBookmarks_ConcurrencyMatchesSequential(t *testing.T) {
+	for _, format := range []string{"json", "csv", "html"} {
+		t.Run(format, func(t *testing.T) {
+			var file string
+			switch format {
+			case "json":
+				file = writeConcurrentImportFile(t)
+			case "csv":
+				file = filepath.Join(t.TempDir(), "bookmarks.csv")
+				content := "url,title\nhttps://example.com/a,A\nhttps://example.com/fail-b,B\nhttps://example.com/existing,E\nhttps://example.com/c,C\n"
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			case "html":
+				file = filepath.Join(t.TempDir(), "bookmarks.html")
+				content := `<DT><A HREF="https://example.com/a">A</A>
+<DT><A HREF="https://example.com/fail-b">B</A>
+<DT><A HREF="https://example.com/existing">E</A>
+<DT><A HREF="https://example.com/c">C</A>
+`
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			run := func(concurrency int) (*ImportResult, []string) {
+				srv := &concurrentServer{}
+				server := httptest.NewServer(http.HandlerFunc(srv.handler))
+				defer server.Close()
+				result, err := ImportBookmarks(api.NewClient(server.URL, "test-token"), file, ImportOptions{Concurrency: concurrency})
+				if err != nil {
+					t.Fatalf("ImportBookmarks() failed: %v", err)
+				}
+				sort.Strings(srv.created)
+				return result, srv.created
+			}
+
+			sequential, seqCreated := run(1)
+			parallel, parCreated := run(8)
+			if fmt.Sprint(sequential) != fmt.Sprint(parallel) {
+				t.Errorf("Expected the same result, got %+v sequentially and %+v in parallel", sequential, parallel)
+			}
+			if strings.Join(seqCreated, " ") != strings.Join(parCreated, " ") {
+				t.Errorf("Expected the same bookmarks created, got %v and %v", seqCreated, parCreated)
+			}
+		})
+	}
+}
+
+func TestImportBookmarks_ConcurrencyStopOnError(t *testing.T) {
+	srv := &concurrentServer{}
+	server := httptest.NewServer(http.HandlerFunc(srv.handler))
+	defer server.Close()
+	file := writeConcurrentImportFile(t)
+
+	result, err := ImportBookmarks(api.NewClient(server.URL, "test-token"), file, ImportOptions{Concurrency: 2, StopOnError: true})
+	if err == nil || !strings.Contains(err.Error(), "import stopped at line 5") {
+		t.Fatalf("Expected the import to stop at line 5, got %v", err)
+	}
+	// Requests already in flight finish, so a few entries past the failure may be written
+	if result.Failed < 1 || result.Added+result.Failed > 8 {
+		t.Errorf("Expected the import to stop shortly after the failure, got %+v", result)
+	}
+}
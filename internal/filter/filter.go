@@ -0,0 +1,263 @@
+// Package filter parses and evaluates boolean bookmark filter expressions
+// such as `unread AND tag:go AND NOT shared`.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// Expr is a parsed filter expression.
+type Expr interface {
+	// Match reports whether a bookmark satisfies the expression.
+	Match(b *models.Bookmark) bool
+}
+
+// SyntaxError reports a problem with a filter expression and the position
+// (0-based byte offset) of the token that caused it.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// Parse parses a filter expression. Terms are:
+//
+//	tag:NAME      the bookmark has the tag (case-insensitive)
+//	title:~REGEX  the title matches the regular expression
+//	unread        the bookmark is unread
+//	shared        the bookmark is shared
+//	archived      the bookmark is archived
+//
+// Terms combine with NOT, AND and OR, in order of decreasing precedence, and
+// parentheses group them. Keywords are case-insensitive. Double quotes keep
+// spaces and parentheses inside a term, as in title:~"(?i)go (lang|tips)".
+func Parse(input string) (Expr, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q, expected AND or OR", tok.text)}
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokLParen
+	tokRParen
+	tokEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keyword returns the upper-cased word if the token is AND, OR or NOT.
+func (t token) keyword() string {
+	if t.kind != tokWord {
+		return ""
+	}
+	switch upper := strings.ToUpper(t.text); upper {
+	case "AND", "OR", "NOT":
+		return upper
+	}
+	return ""
+}
+
+// tokenize splits the input into parentheses and words. A word ends at
+// whitespace or a parenthesis outside double quotes; the quotes are removed.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		switch c := input[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		default:
+			start := i
+			var word strings.Builder
+			for i < len(input) && !strings.ContainsRune(" \t\n\r()", rune(input[i])) {
+				if input[i] != '"' {
+					word.WriteByte(input[i])
+					i++
+					continue
+				}
+				end := strings.IndexByte(input[i+1:], '"')
+				if end < 0 {
+					return nil, &SyntaxError{Pos: i, Msg: "unterminated quote"}
+				}
+				word.WriteString(input[i+1 : i+1+end])
+				i += end + 2
+			}
+			tokens = append(tokens, token{kind: tokWord, text: word.String(), pos: start})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(input)}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword() == "OR" {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().keyword() == "AND" {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if p.peek().keyword() == "NOT" {
+		p.advance()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokEOF:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected end of filter"}
+	case tokRParen:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: `unexpected ")"`}
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokRParen {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: `unclosed "("`}
+		}
+		return inner, nil
+	}
+	if kw := tok.keyword(); kw != "" {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %s", kw)}
+	}
+	return parseTerm(tok)
+}
+
+// parseTerm turns a word into a term.
+func parseTerm(tok token) (Expr, error) {
+	lower := strings.ToLower(tok.text)
+	switch {
+	case lower == "unread":
+		return unreadExpr{}, nil
+	case lower == "shared":
+		return sharedExpr{}, nil
+	case lower == "archived":
+		return archivedExpr{}, nil
+	case strings.HasPrefix(lower, "tag:"):
+		name := tok.text[len("tag:"):]
+		if name == "" {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: `missing tag name after "tag:"`}
+		}
+		return tagExpr{name}, nil
+	case strings.HasPrefix(lower, "title:~"):
+		re, err := regexp.Compile(tok.text[len("title:~"):])
+		if err != nil {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("invalid regular expression in %q: %v", tok.text, err)}
+		}
+		return titleExpr{re}, nil
+	case strings.HasPrefix(lower, "title:"):
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(`%q: title needs a regular expression, as in title:~pattern`, tok.text)}
+	}
+	return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unknown term %q (expected tag:, title:~, unread, shared or archived)", tok.text)}
+}
+
+type andExpr struct{ left, right Expr }
+
+func (e andExpr) Match(b *models.Bookmark) bool { return e.left.Match(b) && e.right.Match(b) }
+
+type orExpr struct{ left, right Expr }
+
+func (e orExpr) Match(b *models.Bookmark) bool { return e.left.Match(b) || e.right.Match(b) }
+
+type notExpr struct{ inner Expr }
+
+func (e notExpr) Match(b *models.Bookmark) bool { return !e.inner.Match(b) }
+
+type unreadExpr struct{}
+
+func (unreadExpr) Match(b *models.Bookmark) bool { return b.Unread }
+
+type sharedExpr struct{}
+
+func (sharedExpr) Match(b *models.Bookmark) bool { return b.Shared }
+
+type archivedExpr struct{}
+
+func (archivedExpr) Match(b *models.Bookmark) bool { return b.IsArchived }
+
+type tagExpr struct{ name string }
+
+func (e tagExpr) Match(b *models.Bookmark) bool { return b.HasAnyTag([]string{e.name}) }
+
+type titleExpr struct{ re *regexp.Regexp }
+
+func (e titleExpr) Match(b *models.Bookmark) bool { return e.re.MatchString(b.Title) }
@@ -0,0 +1,79 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+func TestParseAndMatch(t *testing.T) {
+	goUnread := &models.Bookmark{Title: "Go Tips", Unread: true, TagNames: []string{"Go", "dev"}}
+	rustShared := &models.Bookmark{Title: "Rust book", Shared: true, TagNames: []string{"rust"}}
+	archivedGo := &models.Bookmark{Title: "Old go notes", IsArchived: true, TagNames: []string{"go"}}
+
+	tests := []struct {
+		expr string
+		want []bool // goUnread, rustShared, archivedGo
+	}{
+		{expr: "unread", want: []bool{true, false, false}},
+		{expr: "tag:go", want: []bool{true, false, true}},
+		{expr: "unread AND tag:go AND NOT shared", want: []bool{true, false, false}},
+		// AND binds tighter than OR
+		{expr: "shared OR tag:go AND archived", want: []bool{false, true, true}},
+		{expr: "(shared OR tag:go) AND archived", want: []bool{false, false, true}},
+		// NOT binds tighter than AND and can repeat
+		{expr: "NOT unread AND tag:go", want: []bool{false, false, true}},
+		{expr: "NOT (unread OR shared)", want: []bool{false, false, true}},
+		{expr: "not not shared", want: []bool{false, true, false}},
+		{expr: "title:~^Go", want: []bool{true, false, false}},
+		{expr: `title:~"(?i)go (tips|notes)"`, want: []bool{true, false, true}},
+		{expr: "TAG:DEV or archived", want: []bool{true, false, true}},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.expr, err)
+			continue
+		}
+		for i, b := range []*models.Bookmark{goUnread, rustShared, archivedGo} {
+			if got := expr.Match(b); got != tt.want[i] {
+				t.Errorf("Parse(%q).Match(%q) = %v, want %v", tt.expr, b.Title, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		pos     int
+		message string
+	}{
+		{expr: "unread AND AND tag:go", pos: 11, message: "unexpected AND"},
+		{expr: "unread tag:go", pos: 7, message: `unexpected "tag:go"`},
+		{expr: "(unread OR shared", pos: 0, message: `unclosed "("`},
+		{expr: "unread)", pos: 6, message: `unexpected "\)"`},
+		{expr: "unread AND", pos: 10, message: "unexpected end of filter"},
+		{expr: "starred", pos: 0, message: `unknown term "starred"`},
+		{expr: "shared OR tag:", pos: 10, message: "missing tag name"},
+		{expr: "title:~[a-", pos: 0, message: "invalid regular expression"},
+		{expr: "title:go", pos: 0, message: "title needs a regular expression"},
+		{expr: `tag:"go`, pos: 4, message: "unterminated quote"},
+		{expr: "", pos: 0, message: "unexpected end of filter"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) error = %v, want a SyntaxError", tt.expr, err)
+			continue
+		}
+		if syntaxErr.Pos != tt.pos || !strings.Contains(syntaxErr.Msg, strings.ReplaceAll(tt.message, `\`, "")) {
+			t.Errorf("Parse(%q) error = %q at %d, want %q at %d", tt.expr, syntaxErr.Msg, syntaxErr.Pos, tt.message, tt.pos)
+		}
+	}
+}
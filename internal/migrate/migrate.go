@@ -0,0 +1,277 @@
+// Package migrate copies bookmarks, tags and bundles from one LinkDing
+// instance to another.
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// DefaultConcurrency is the number of bookmarks written to the destination
+// at the same time.
+const DefaultConcurrency = 4
+
+// MaxConcurrency caps Options.Concurrency.
+const MaxConcurrency = 16
+
+// Options configures a migration.
+type Options struct {
+	// DryRun reads both instances but writes nothing; counts describe what
+	// would happen
+	DryRun bool
+	// SkipDuplicates leaves bookmarks whose URL already exists on the
+	// destination untouched instead of updating them
+	SkipDuplicates bool
+	// Tags also creates tags that no migrated bookmark uses
+	Tags bool
+	// Bundles also copies bundles, skipping names that already exist
+	Bundles bool
+	// Concurrency is the number of parallel bookmark writes, at most
+	// MaxConcurrency (default DefaultConcurrency)
+	Concurrency int
+}
+
+// Result tracks the outcome of a migration.
+type Result struct {
+	Total          int
+	Added          int
+	Updated        int
+	Skipped        int
+	Failed         int
+	TagsCreated    int
+	BundlesCreated int
+	BundlesSkipped int
+	Errors         []Error
+}
+
+// Error is a single item that could not be migrated.
+type Error struct {
+	Item    string // bookmark URL, "tag <name>" or "bundle <name>"
+	Message string
+}
+
+// Run copies every bookmark (including archived ones) from src to dst.
+// Bookmarks are matched by URL; existing ones are updated unless
+// SkipDuplicates is set. Failures of individual items are collected in the
+// result; the returned error is for failures that stop the migration, such
+// as being unable to list either instance.
+func Run(src, dst *api.Client, options Options) (*Result, error) {
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultConcurrency
+	}
+
+	bookmarks, err := src.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source bookmarks: %w", err)
+	}
+	existing, err := dst.FetchAllBookmarks(nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination bookmarks: %w", err)
+	}
+	existingURLs := make(map[string]int, len(existing))
+	for _, b := range existing {
+		existingURLs[b.URL] = b.ID
+	}
+
+	result := &Result{Total: len(bookmarks)}
+	migrateBookmarks(dst, bookmarks, existingURLs, options, result)
+
+	if options.Tags {
+		if err := migrateTags(src, dst, bookmarks, options, result); err != nil {
+			return result, err
+		}
+	}
+	if options.Bundles {
+		if err := migrateBundles(src, dst, options, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// migrateBookmarks writes bookmarks to dst using a pool of workers. Errors
+// are reported in source order regardless of which worker hit them.
+func migrateBookmarks(dst *api.Client, bookmarks []models.Bookmark, existingURLs map[string]int,
+	options Options, result *Result) {
+
+	var mu sync.Mutex
+	failed := make(map[int]Error)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < options.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b := bookmarks[i]
+				existingID, exists := existingURLs[b.URL]
+				outcome, err := migrateBookmark(dst, b, existingID, exists, options)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					result.Failed++
+					failed[i] = Error{Item: b.URL, Message: err.Error()}
+				case outcome == "added":
+					result.Added++
+				case outcome == "updated":
+					result.Updated++
+				default:
+					result.Skipped++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range bookmarks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	indexes := make([]int, 0, len(failed))
+	for i := range failed {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		result.Errors = append(result.Errors, failed[i])
+	}
+}
+
+// migrateBookmark creates or updates one bookmark on dst and reports which
+// it did: "added", "updated" or "skipped".
+func migrateBookmark(dst *api.Client, b models.Bookmark, existingID int, exists bool, options Options) (string, error) {
+	if exists && options.SkipDuplicates {
+		return "skipped", nil
+	}
+	if options.DryRun {
+		if exists {
+			return "updated", nil
+		}
+		return "added", nil
+	}
+
+	tags := b.TagNames
+	if tags == nil {
+		tags = []string{}
+	}
+	if exists {
+		update := &models.BookmarkUpdate{
+			URL:         &b.URL,
+			Title:       &b.Title,
+			Description: &b.Description,
+			Notes:       &b.Notes,
+			TagNames:    &tags,
+			IsArchived:  &b.IsArchived,
+			Unread:      &b.Unread,
+			Shared:      &b.Shared,
+		}
+		if _, err := dst.UpdateBookmark(existingID, update); err != nil {
+			return "", fmt.Errorf("failed to update: %w", err)
+		}
+		return "updated", nil
+	}
+
+	create := &models.BookmarkCreate{
+		URL:         b.URL,
+		Title:       b.Title,
+		Description: b.Description,
+		Notes:       b.Notes,
+		IsArchived:  b.IsArchived,
+		Unread:      b.Unread,
+		Shared:      b.Shared,
+		TagNames:    tags,
+	}
+	if _, err := dst.CreateBookmark(create); err != nil {
+		return "", fmt.Errorf("failed to create: %w", err)
+	}
+	return "added", nil
+}
+
+// migrateTags creates the source's tags that are missing on dst. Tags on
+// migrated bookmarks are created by LinkDing along with the bookmarks, so
+// this only matters for tags no bookmark uses.
+func migrateTags(src, dst *api.Client, bookmarks []models.Bookmark, options Options, result *Result) error {
+	srcTags, err := src.FetchAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to read source tags: %w", err)
+	}
+	dstTags, err := dst.FetchAllTags()
+	if err != nil {
+		return fmt.Errorf("failed to read destination tags: %w", err)
+	}
+
+	have := make(map[string]bool, len(dstTags))
+	for _, t := range dstTags {
+		have[t.Name] = true
+	}
+	// Under dry run the bookmarks' tags have not been created yet
+	if options.DryRun {
+		for _, b := range bookmarks {
+			for _, name := range b.TagNames {
+				have[name] = true
+			}
+		}
+	}
+
+	for _, t := range srcTags {
+		if have[t.Name] {
+			continue
+		}
+		if !options.DryRun {
+			if _, err := dst.CreateTag(t.Name); err != nil {
+				result.Errors = append(result.Errors, Error{Item: "tag " + t.Name, Message: err.Error()})
+				continue
+			}
+		}
+		result.TagsCreated++
+	}
+	return nil
+}
+
+// migrateBundles copies the source's bundles to dst, skipping any whose
+// name already exists there.
+func migrateBundles(src, dst *api.Client, options Options, result *Result) error {
+	srcBundles, err := src.FetchAllBundles()
+	if err != nil {
+		return fmt.Errorf("failed to read source bundles: %w", err)
+	}
+	dstBundles, err := dst.FetchAllBundles()
+	if err != nil {
+		return fmt.Errorf("failed to read destination bundles: %w", err)
+	}
+
+	have := make(map[string]bool, len(dstBundles))
+	for _, b := range dstBundles {
+		have[b.Name] = true
+	}
+
+	for _, b := range srcBundles {
+		if have[b.Name] {
+			result.BundlesSkipped++
+			continue
+		}
+		if !options.DryRun {
+			create := &models.BundleCreate{
+				Name:         b.Name,
+				Search:       b.Search,
+				AnyTags:      b.AnyTags,
+				AllTags:      b.AllTags,
+				ExcludedTags: b.ExcludedTags,
+				Order:        b.Order,
+			}
+			if _, err := dst.CreateBundle(create); err != nil {
+				result.Errors = append(result.Errors, Error{Item: "bundle " + b.Name, Message: err.Error()})
+				continue
+			}
+		}
+		result.BundlesCreated++
+	}
+	return nil
+}
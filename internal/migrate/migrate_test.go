@@ -0,0 +1,173 @@
+package migrate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rodstewart/linkding-cli/internal/api"
+	"github.com/rodstewart/linkding-cli/internal/models"
+)
+
+// fakeInstance is an in-memory LinkDing serving the endpoints a migration
+// uses and recording every write.
+type fakeInstance struct {
+	mu        sync.Mutex
+	bookmarks []models.Bookmark
+	tags      []models.Tag
+	bundles   []models.Bundle
+	writes    []string
+	failURL   string
+}
+
+func (f *fakeInstance) serve(t *testing.T) *api.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == "GET" {
+			switch {
+			case strings.HasPrefix(r.URL.Path, "/api/bookmarks/archived/"):
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Results: []models.Bookmark{}})
+			case strings.HasPrefix(r.URL.Path, "/api/bookmarks/"):
+				_ = json.NewEncoder(w).Encode(models.BookmarkList{Count: len(f.bookmarks), Results: f.bookmarks})
+			case strings.HasPrefix(r.URL.Path, "/api/tags/"):
+				_ = json.NewEncoder(w).Encode(models.TagList{Count: len(f.tags), Results: f.tags})
+			case strings.HasPrefix(r.URL.Path, "/api/bundles/"):
+				_ = json.NewEncoder(w).Encode(models.BundleList{Count: len(f.bundles), Results: f.bundles})
+			default:
+				http.NotFound(w, r)
+			}
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/bookmarks/":
+			var body models.BookmarkCreate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.URL == f.failURL {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"url":["invalid"]}`))
+				return
+			}
+			f.writes = append(f.writes, "create "+body.URL)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bookmark{ID: 100 + len(f.writes), URL: body.URL})
+		case strings.HasPrefix(r.URL.Path, "/api/bookmarks/"):
+			var body models.BookmarkUpdate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.writes = append(f.writes, r.Method+" "+r.URL.Path)
+			_ = json.NewEncoder(w).Encode(models.Bookmark{URL: *body.URL})
+		case r.URL.Path == "/api/tags/":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.writes = append(f.writes, "tag "+body["name"])
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Tag{Name: body["name"]})
+		case r.URL.Path == "/api/bundles/":
+			var body models.BundleCreate
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.writes = append(f.writes, "bundle "+body.Name)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(models.Bundle{Name: body.Name})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(server.URL, "test-token")
+}
+
+func newInstances() (*fakeInstance, *fakeInstance) {
+	src := &fakeInstance{
+		bookmarks: []models.Bookmark{
+			{ID: 1, URL: "https://a.example.com", Title: "A", TagNames: []string{"go"}},
+			{ID: 2, URL: "https://b.example.com", Title: "B"},
+			{ID: 3, URL: "https://c.example.com", Title: "C", Notes: "note"},
+		},
+		tags:    []models.Tag{{ID: 1, Name: "go"}, {ID: 2, Name: "unused"}},
+		bundles: []models.Bundle{{ID: 1, Name: "Reading"}, {ID: 2, Name: "Work"}},
+	}
+	dst := &fakeInstance{
+		bookmarks: []models.Bookmark{{ID: 9, URL: "https://b.example.com"}},
+		tags:      []models.Tag{{ID: 5, Name: "go"}},
+		bundles:   []models.Bundle{{ID: 3, Name: "Work"}},
+	}
+	return src, dst
+}
+
+func TestRun(t *testing.T) {
+	src, dst := newInstances()
+	result, err := Run(src.serve(t), dst.serve(t), Options{Tags: true, Bundles: true, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if result.Total != 3 || result.Added != 2 || result.Updated != 1 || result.Failed != 0 {
+		t.Errorf("Unexpected bookmark counts: %+v", result)
+	}
+	if result.TagsCreated != 1 || result.BundlesCreated != 1 || result.BundlesSkipped != 1 {
+		t.Errorf("Unexpected tag/bundle counts: %+v", result)
+	}
+
+	writes := strings.Join(dst.writes, "\n")
+	for _, want := range []string{"create https://a.example.com", "create https://c.example.com", "PATCH /api/bookmarks/9/", "tag unused", "bundle Reading"} {
+		if !strings.Contains(writes, want) {
+			t.Errorf("Expected destination write %q, got:\n%s", want, writes)
+		}
+	}
+	if len(src.writes) != 0 {
+		t.Errorf("Expected no writes to the source, got %v", src.writes)
+	}
+}
+
+func TestRun_SkipDuplicates(t *testing.T) {
+	src, dst := newInstances()
+	result, err := Run(src.serve(t), dst.serve(t), Options{SkipDuplicates: true})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if result.Added != 2 || result.Skipped != 1 || result.Updated != 0 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+	for _, w := range dst.writes {
+		if strings.HasPrefix(w, "PATCH") {
+			t.Errorf("Expected the duplicate to be left alone, got %s", w)
+		}
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	src, dst := newInstances()
+	result, err := Run(src.serve(t), dst.serve(t), Options{DryRun: true, Tags: true, Bundles: true})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if len(dst.writes) != 0 {
+		t.Errorf("Expected no writes under dry run, got %v", dst.writes)
+	}
+	if result.Added != 2 || result.Updated != 1 || result.TagsCreated != 1 || result.BundlesCreated != 1 {
+		t.Errorf("Expected dry run counts to match a real run, got %+v", result)
+	}
+}
+
+func TestRun_ReportsFailuresInSourceOrder(t *testing.T) {
+	src, dst := newInstances()
+	dst.failURL = "https://c.example.com"
+	src.bookmarks = append(src.bookmarks, models.Bookmark{ID: 4, URL: "https://d.example.com"})
+
+	result, err := Run(src.serve(t), dst.serve(t), Options{Concurrency: 4})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if result.Failed != 1 || result.Added != 2 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Item != "https://c.example.com" {
+		t.Errorf("Expected one error for c.example.com, got %+v", result.Errors)
+	}
+}
@@ -1,7 +1,10 @@
 // Package models defines the data models used in the LinkDing application.
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Bookmark represents a LinkDing bookmark
 type Bookmark struct {
@@ -18,6 +21,57 @@ type Bookmark struct {
 	TagNames           []string  `json:"tag_names"`
 	DateAdded          time.Time `json:"date_added"`
 	DateModified       time.Time `json:"date_modified"`
+	FaviconURL         string    `json:"favicon_url,omitempty"`
+}
+
+// HasAnyTag reports whether the bookmark carries any of the given tags.
+// Tags are compared case-insensitively, as LinkDing does.
+func (b Bookmark) HasAnyTag(tags []string) bool {
+	for _, have := range b.TagNames {
+		for _, want := range tags {
+			if strings.EqualFold(have, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ExcludeTagged returns the bookmarks that carry none of the given tags.
+func ExcludeTagged(bookmarks []Bookmark, tags []string) []Bookmark {
+	if len(tags) == 0 {
+		return bookmarks
+	}
+	kept := make([]Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if !b.HasAnyTag(tags) {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
+
+// TaggedWithAll returns the bookmarks that carry every one of the given
+// tags. LinkDing's search also matches tag names as words in titles, URLs
+// and descriptions, so results fetched by tag are narrowed with this.
+func TaggedWithAll(bookmarks []Bookmark, tags []string) []Bookmark {
+	if len(tags) == 0 {
+		return bookmarks
+	}
+	kept := make([]Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		all := true
+		for _, tag := range tags {
+			if !b.HasAnyTag([]string{tag}) {
+				all = false
+				break
+			}
+		}
+		if all {
+			kept = append(kept, b)
+		}
+	}
+	return kept
 }
 
 // BookmarkCreate represents the request to create a bookmark
@@ -52,6 +106,27 @@ type BookmarkList struct {
 	Results  []Bookmark `json:"results"`
 }
 
+// BookmarkAsset represents a file attached to a bookmark: a web archive
+// snapshot or an upload
+type BookmarkAsset struct {
+	ID          int       `json:"id"`
+	Bookmark    int       `json:"bookmark"`
+	AssetType   string    `json:"asset_type"`
+	DateCreated time.Time `json:"date_created"`
+	ContentType string    `json:"content_type"`
+	DisplayName string    `json:"display_name"`
+	Status      string    `json:"status"`
+}
+
+// BookmarkAssetList represents the paginated response from the bookmark
+// assets API
+type BookmarkAssetList struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []BookmarkAsset `json:"results"`
+}
+
 // Tag represents a LinkDing tag
 type Tag struct {
 	ID        int       `json:"id"`
@@ -94,3 +169,18 @@ type UserProfile struct {
 	PermanentNotes        bool              `json:"permanent_notes"`
 	SearchPreferences     SearchPreferences `json:"search_preferences"`
 }
+
+// UserProfileUpdate represents the request to update profile preferences
+// with PATCH semantics
+type UserProfileUpdate struct {
+	Theme                 *string `json:"theme,omitempty"`
+	BookmarkDateDisplay   *string `json:"bookmark_date_display,omitempty"`
+	BookmarkLinkTarget    *string `json:"bookmark_link_target,omitempty"`
+	WebArchiveIntegration *string `json:"web_archive_integration,omitempty"`
+	TagSearch             *string `json:"tag_search,omitempty"`
+	EnableSharing         *bool   `json:"enable_sharing,omitempty"`
+	EnablePublicSharing   *bool   `json:"enable_public_sharing,omitempty"`
+	EnableFavicons        *bool   `json:"enable_favicons,omitempty"`
+	DisplayURL            *bool   `json:"display_url,omitempty"`
+	PermanentNotes        *bool   `json:"permanent_notes,omitempty"`
+}
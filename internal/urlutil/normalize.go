@@ -0,0 +1,54 @@
+package urlutil
+
+import (
+	"net/url"
+	"strings"
+)
+
+// defaultPorts maps schemes to the port a URL may omit.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// Normalize returns a form of rawURL for comparing bookmarks: the scheme and
+// host are lowercased, a default port is dropped and trailing slashes are
+// stripped from the path. Query and fragment are kept as they are. A URL that
+// cannot be parsed is returned trimmed but otherwise unchanged.
+func Normalize(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port := parsed.Port(); port != "" && port != defaultPorts[parsed.Scheme] {
+		host += ":" + port
+	}
+	parsed.Host = host
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = strings.TrimRight(parsed.RawPath, "/")
+	return parsed.String()
+}
+
+// Canonical returns a looser form of rawURL than Normalize, under which
+// URLs that almost certainly name the same page compare equal: on top of
+// Normalize, http becomes https, a leading "www." is dropped from the host
+// and the fragment is removed. The query is kept. It is meant for matching
+// only, never for storing.
+func Canonical(rawURL string) string {
+	normalized := Normalize(rawURL)
+	parsed, err := url.Parse(normalized)
+	if err != nil || parsed.Host == "" {
+		return normalized
+	}
+
+	if parsed.Scheme == "http" {
+		parsed.Scheme = "https"
+	}
+	parsed.Host = strings.TrimPrefix(parsed.Host, "www.")
+	parsed.Fragment, parsed.RawFragment = "", ""
+	return parsed.String()
+}
@@ -0,0 +1,49 @@
+package urlutil
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://example.com", want: "https://example.com"},
+		{url: "https://Example.COM/", want: "https://example.com"},
+		{url: "HTTPS://example.com:443/docs/", want: "https://example.com/docs"},
+		{url: "http://example.com:80/a//", want: "http://example.com/a"},
+		{url: "http://example.com:8080/", want: "http://example.com:8080"},
+		{url: "https://example.com:80/", want: "https://example.com:80"},
+		{url: "https://example.com/Path/?q=1", want: "https://example.com/Path?q=1"},
+		{url: "https://[::1]:443/", want: "https://[::1]"},
+		{url: "  not a url  ", want: "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.url); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://example.com", want: "https://example.com"},
+		{url: "http://example.com/", want: "https://example.com"},
+		{url: "HTTP://WWW.Example.com/docs/", want: "https://example.com/docs"},
+		{url: "https://www.example.com/a#section-2", want: "https://example.com/a"},
+		{url: "https://example.com/a?q=1#top", want: "https://example.com/a?q=1"},
+		{url: "http://example.com:8080/", want: "https://example.com:8080"},
+		{url: "https://wwwexample.com/", want: "https://wwwexample.com"},
+		{url: "ftp://www.example.com/file", want: "ftp://example.com/file"},
+		{url: "  not a url  ", want: "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := Canonical(tt.url); got != tt.want {
+			t.Errorf("Canonical(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
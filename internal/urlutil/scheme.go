@@ -0,0 +1,51 @@
+// Package urlutil provides checks and normalization for bookmark URLs.
+package urlutil
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultSchemes are the URL schemes accepted when nothing else is allowed.
+var DefaultSchemes = []string{"http", "https"}
+
+// AllowedSchemes returns DefaultSchemes extended with extra, lowercased and
+// without duplicates. A trailing ":" or "://" on an extra scheme is ignored.
+func AllowedSchemes(extra []string) []string {
+	allowed := append([]string{}, DefaultSchemes...)
+	for _, scheme := range extra {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		scheme = strings.TrimSuffix(strings.TrimSuffix(scheme, "://"), ":")
+		if scheme == "" || containsScheme(allowed, scheme) {
+			continue
+		}
+		allowed = append(allowed, scheme)
+	}
+	return allowed
+}
+
+// CheckScheme returns an error if rawURL has no scheme or its scheme is not
+// in allowed. Schemes are compared case-insensitively.
+func CheckScheme(rawURL string, allowed []string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if parsed.Scheme == "" {
+		return fmt.Errorf("URL %q has no scheme (expected one of: %s)", rawURL, strings.Join(allowed, ", "))
+	}
+	if !containsScheme(allowed, strings.ToLower(parsed.Scheme)) {
+		return fmt.Errorf("URL scheme %q is not allowed (allowed: %s)", parsed.Scheme, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func containsScheme(schemes []string, scheme string) bool {
+	for _, s := range schemes {
+		if strings.EqualFold(s, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package urlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllowedSchemes(t *testing.T) {
+	got := AllowedSchemes([]string{"FTP", "https", "gemini://", " "})
+	want := []string{"http", "https", "ftp", "gemini"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AllowedSchemes() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckScheme(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed []string
+		wantErr bool
+	}{
+		{url: "https://example.com", allowed: DefaultSchemes},
+		{url: "HTTP://example.com", allowed: DefaultSchemes},
+		{url: "javascript:alert(1)", allowed: DefaultSchemes, wantErr: true},
+		{url: "file:///etc/passwd", allowed: DefaultSchemes, wantErr: true},
+		{url: "example.com/page", allowed: DefaultSchemes, wantErr: true},
+		{url: "ftp://ftp.example.com/pub", allowed: DefaultSchemes, wantErr: true},
+		{url: "ftp://ftp.example.com/pub", allowed: AllowedSchemes([]string{"ftp"})},
+	}
+
+	for _, tt := range tests {
+		err := CheckScheme(tt.url, tt.allowed)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckScheme(%q, %v) error = %v, wantErr %v", tt.url, tt.allowed, err, tt.wantErr)
+		}
+	}
+}